@@ -0,0 +1,514 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var checksCmd = &cobra.Command{
+	Use:   "checks",
+	Short: "Inspect CI check runs",
+	Long:  `Inspect the check runs of pull requests beyond their pass/fail state.`,
+}
+
+var failuresChecksCmd = NewOperationalCommand(
+	"failures [PR] [flags]",
+	"Extract failing tests from the logs of failed check runs",
+	`Download the logs of the failed GitHub Actions check runs on a PR's head
+commit and extract the failing tests as structured records (check, package,
+test, file, line, message), so the failures can be acted on without
+re-running the tests locally.
+
+Parsers (--parser, default all):
+  go  'go test' output: "--- FAIL" blocks with their output, panics, and
+      build failures, attributed to the package from the "FAIL <package>" line
+
+Checks whose log yields no failures keep the last --tail log lines instead.
+Checks from other CI providers have no downloadable log and are listed with
+their details URL. Logs of expired runs cannot be downloaded.
+
+`+prNumberArgsHelp+`
+
+Examples:
+  # Failing tests of the current branch's PR
+  gh-helper checks failures
+
+  # One line per failure
+  gh-helper checks failures 306 --jq '.checkFailures.failures[] | "\(.package) \(.test): \(.file):\(.line)"'
+
+  # Only the unit test jobs
+  gh-helper checks failures 306 --check 'test*'`,
+	checkFailures,
+)
+
+func init() {
+	failuresChecksCmd.Args = cobra.MaximumNArgs(1)
+	failuresChecksCmd.Flags().StringSlice("check", nil, "Only check runs whose name matches these glob patterns")
+	failuresChecksCmd.Flags().StringSlice("parser", nil, "Log parsers to run (default: all)")
+	failuresChecksCmd.Flags().Int("tail", 20, "Log lines kept for a check without parsed failures (0: none)")
+	failuresChecksCmd.Flags().Int("max-lines", 40, "Maximum message lines per failure")
+
+	checksCmd.AddCommand(failuresChecksCmd)
+	rootCmd.AddCommand(checksCmd)
+}
+
+// TestFailure is one failing test extracted from a check log
+type TestFailure struct {
+	Check   string `json:"check"`
+	Parser  string `json:"parser"`
+	Package string `json:"package,omitempty"`
+	Test    string `json:"test,omitempty"` // empty for package-level failures (build failures, panics outside tests)
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// TestFailureParser extracts failures from the lines of a CI log. Parsers for
+// other languages and test runners are added to testFailureParsers.
+type TestFailureParser interface {
+	Name() string
+	Parse(lines []string) []TestFailure
+}
+
+// testFailureParsers are the available parsers, in the order they run
+var testFailureParsers = []TestFailureParser{goTestFailureParser{}}
+
+// selectTestFailureParsers returns the parsers named by --parser, or all of them
+func selectTestFailureParsers(names []string) ([]TestFailureParser, error) {
+	if len(names) == 0 {
+		return testFailureParsers, nil
+	}
+	available := make([]string, len(testFailureParsers))
+	for i, parser := range testFailureParsers {
+		available[i] = parser.Name()
+	}
+	var selected []TestFailureParser
+	for _, name := range names {
+		i := slices.Index(available, name)
+		if i < 0 {
+			return nil, fmt.Errorf("unknown parser %q (available: %s)", name, strings.Join(available, ", "))
+		}
+		selected = append(selected, testFailureParsers[i])
+	}
+	return selected, nil
+}
+
+var (
+	goTestRunPattern    = regexp.MustCompile(`^=== (?:RUN|CONT|PAUSE|NAME)\s+(\S+)`)
+	goTestEndPattern    = regexp.MustCompile(`^(\s*)--- (FAIL|PASS|SKIP): (\S+) \(`)
+	goPackageEndPattern = regexp.MustCompile(`^(FAIL|ok)\s*\t(\S+)(?:\s+\[([^\]]+)\])?`)
+	goFileLinePattern   = regexp.MustCompile(`^\s*([\w./-]+\.go):(\d+)(?::\d+)?:`)
+)
+
+// goTestFailureParser parses plain 'go test' output, verbose or not
+type goTestFailureParser struct{}
+
+func (goTestFailureParser) Name() string { return "go" }
+
+func (goTestFailureParser) Parse(lines []string) []TestFailure {
+	var failures []TestFailure
+	var messages [][]string // message lines of each failure
+
+	output := map[string][]string{} // verbose output of running tests
+	current := ""                   // test receiving verbose output
+	var pending []int               // failures awaiting their package line
+	capture, captureIndent := -1, 0 // failure receiving non-verbose output indented under "--- FAIL"
+	var orphan []string             // panic or compiler output outside a test
+
+	add := func(failure TestFailure, message []string) int {
+		failures = append(failures, failure)
+		messages = append(messages, message)
+		return len(failures) - 1
+	}
+
+	for _, line := range lines {
+		if m := goTestEndPattern.FindStringSubmatch(line); m != nil {
+			name := m[3]
+			capture = -1
+			if m[2] == "FAIL" {
+				capture = add(TestFailure{Test: name}, output[name])
+				captureIndent = len(m[1])
+				pending = append(pending, capture)
+			}
+			delete(output, name)
+			if current == name {
+				current = ""
+			}
+			continue
+		}
+
+		if m := goPackageEndPattern.FindStringSubmatch(line); m != nil {
+			if m[1] == "FAIL" {
+				pkg := m[2]
+				if len(pending) == 0 {
+					// Build failure or a crash before any test reported
+					if len(orphan) == 0 && m[3] != "" {
+						orphan = []string{m[3]}
+					}
+					pending = append(pending, add(TestFailure{}, orphan))
+				} else if last := pending[len(pending)-1]; len(orphan) > 0 {
+					// A panic trace follows the "--- FAIL" line of the test that panicked
+					messages[last] = append(messages[last], orphan...)
+				}
+				for _, i := range pending {
+					failures[i].Package = pkg
+				}
+			}
+			output = map[string][]string{}
+			current, pending, capture, orphan = "", nil, -1, nil
+			continue
+		}
+
+		if m := goTestRunPattern.FindStringSubmatch(line); m != nil {
+			current, capture = m[1], -1
+			continue
+		}
+		if trimmed := strings.TrimSpace(line); trimmed == "FAIL" || trimmed == "PASS" {
+			capture = -1
+			continue
+		}
+
+		if capture >= 0 && len(line)-len(strings.TrimLeft(line, " \t")) > captureIndent {
+			messages[capture] = append(messages[capture], line)
+			continue
+		}
+		capture = -1
+
+		switch {
+		case current != "":
+			output[current] = append(output[current], line)
+		case len(orphan) > 0 || strings.HasPrefix(line, "panic: ") || strings.HasPrefix(line, "fatal error: ") ||
+			strings.HasPrefix(line, "# ") || goFileLinePattern.MatchString(line):
+			orphan = append(orphan, line)
+		}
+	}
+
+	// A failing parent test only repeats the failure of its subtests
+	failed := map[string]bool{}
+	for _, failure := range failures {
+		failed[failure.Package+" "+failure.Test] = true
+	}
+	result := []TestFailure{}
+	for i, failure := range failures {
+		message := dedentLines(messages[i])
+		if len(message) == 0 && failure.Test != "" && hasFailedSubtest(failed, failure) {
+			continue
+		}
+		for _, line := range message {
+			if m := goFileLinePattern.FindStringSubmatch(line); m != nil {
+				failure.File = m[1]
+				failure.Line, _ = strconv.Atoi(m[2])
+				break
+			}
+		}
+		failure.Message = strings.Join(message, "\n")
+		result = append(result, failure)
+	}
+	return result
+}
+
+// hasFailedSubtest reports whether a subtest of the failure's test also failed
+func hasFailedSubtest(failed map[string]bool, failure TestFailure) bool {
+	prefix := failure.Package + " " + failure.Test + "/"
+	for key := range failed {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// dedentLines drops blank edge lines and the indentation common to all lines
+func dedentLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if n := len(line) - len(strings.TrimLeft(line, " \t")); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	dedented := make([]string, len(lines))
+	for i, line := range lines {
+		if len(line) >= indent && indent > 0 {
+			line = line[indent:]
+		}
+		dedented[i] = strings.TrimRight(line, " \t")
+	}
+	return dedented
+}
+
+// actionsLogTimestamp prefixes each line of a GitHub Actions job log
+var actionsLogTimestamp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?Z ?`)
+
+// splitCheckLog splits a job log into lines without timestamps and workflow
+// commands such as "##[group]"
+func splitCheckLog(log string) []string {
+	var lines []string
+	for _, line := range strings.Split(log, "\n") {
+		line = actionsLogTimestamp.ReplaceAllString(strings.TrimSuffix(line, "\r"), "")
+		line = strings.TrimPrefix(line, "\ufeff")
+		if strings.HasPrefix(line, "##[") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// truncateMessage keeps the first maxLines lines of a failure message
+func truncateMessage(message string, maxLines int) string {
+	lines := strings.Split(message, "\n")
+	if maxLines <= 0 || len(lines) <= maxLines {
+		return message
+	}
+	return strings.Join(lines[:maxLines], "\n") + fmt.Sprintf("\n... (%d more lines)", len(lines)-maxLines)
+}
+
+// failedCheckRun is a failed check run on the PR head commit
+type failedCheckRun struct {
+	trainCheckContext
+	DatabaseID int64  `json:"databaseId"`
+	DetailsURL string `json:"detailsUrl"`
+	CheckSuite struct {
+		App *struct {
+			Slug string `json:"slug"`
+		} `json:"app"`
+		WorkflowRun *struct {
+			Workflow struct {
+				Name string `json:"name"`
+			} `json:"workflow"`
+		} `json:"workflowRun"`
+	} `json:"checkSuite"`
+}
+
+// FailedCheck summarizes a failed check run and what its log yielded
+type FailedCheck struct {
+	Name         string   `json:"name"`
+	Workflow     string   `json:"workflow,omitempty"`
+	Conclusion   string   `json:"conclusion"`
+	URL          string   `json:"url,omitempty"`
+	JobID        int64    `json:"jobId,omitempty"`
+	LogAvailable bool     `json:"logAvailable"`
+	LogError     string   `json:"logError,omitempty"`
+	Failures     int      `json:"failures"`
+	LogTail      []string `json:"logTail,omitempty"` // when no failures were parsed
+}
+
+// getFailedCheckRuns fetches the failed check runs of a PR's head commit
+func (c *GitHubClient) getFailedCheckRuns(prNumber int) (string, []failedCheckRun, error) {
+	query := `
+	query($owner: String!, $repo: String!, $number: Int!) {
+		repository(owner: $owner, name: $repo) {
+			pullRequest(number: $number) {
+				headRefOid
+				commits(last: 1) {
+					nodes {
+						commit {
+							statusCheckRollup {
+								contexts(first: 100) {
+									nodes {
+										__typename
+										... on CheckRun {
+											databaseId name conclusion detailsUrl
+											checkSuite { app { slug } workflowRun { workflow { name } } }
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+
+	responseData, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+		"owner":  c.Owner,
+		"repo":   c.Repo,
+		"number": prNumber,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	var response struct {
+		Data struct {
+			Repository struct {
+				PullRequest *struct {
+					HeadRefOid string `json:"headRefOid"`
+					Commits    struct {
+						Nodes []struct {
+							Commit struct {
+								StatusCheckRollup *struct {
+									Contexts struct {
+										Nodes []failedCheckRun `json:"nodes"`
+									} `json:"contexts"`
+								} `json:"statusCheckRollup"`
+							} `json:"commit"`
+						} `json:"nodes"`
+					} `json:"commits"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return "", nil, fmt.Errorf("failed to parse check runs: %w", err)
+	}
+	pr := response.Data.Repository.PullRequest
+	if pr == nil {
+		return "", nil, fmt.Errorf("PR #%d not found", prNumber)
+	}
+
+	var failed []failedCheckRun
+	for _, node := range pr.Commits.Nodes {
+		if node.Commit.StatusCheckRollup == nil {
+			continue
+		}
+		for _, run := range node.Commit.StatusCheckRollup.Contexts.Nodes {
+			if run.Typename == "CheckRun" && run.failed() {
+				failed = append(failed, run)
+			}
+		}
+	}
+	return pr.HeadRefOid, failed, nil
+}
+
+// getJobLog downloads the log of a GitHub Actions job
+func (c *GitHubClient) getJobLog(jobID int64) (string, error) {
+	data, err := c.RunRESTRequest("GET", fmt.Sprintf("/repos/%s/%s/actions/jobs/%d/logs", c.Owner, c.Repo, jobID), nil)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// matchesCheckPatterns reports whether a check name matches any pattern (all when none)
+func matchesCheckPatterns(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func checkFailures(cmd *cobra.Command, args []string) error {
+	checkPatterns, err := cmd.Flags().GetStringSlice("check")
+	if err != nil {
+		return fmt.Errorf("failed to get 'check' flag: %w", err)
+	}
+	parserNames, err := cmd.Flags().GetStringSlice("parser")
+	if err != nil {
+		return fmt.Errorf("failed to get 'parser' flag: %w", err)
+	}
+	tail, err := cmd.Flags().GetInt("tail")
+	if err != nil {
+		return fmt.Errorf("failed to get 'tail' flag: %w", err)
+	}
+	maxLines, err := cmd.Flags().GetInt("max-lines")
+	if err != nil {
+		return fmt.Errorf("failed to get 'max-lines' flag: %w", err)
+	}
+	parsers, err := selectTestFailureParsers(parserNames)
+	if err != nil {
+		return err
+	}
+
+	client := newCommandClient(cmd)
+	prNumberStr, err := resolvePRNumberFromArgs(args, client)
+	if err != nil {
+		return err
+	}
+	prNumber, err := strconv.Atoi(prNumberStr)
+	if err != nil {
+		return fmt.Errorf("invalid PR number: %w", err)
+	}
+
+	headSHA, runs, err := client.getFailedCheckRuns(prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get check runs: %w", err)
+	}
+	var selected []failedCheckRun
+	for _, run := range runs {
+		if matchesCheckPatterns(checkPatterns, run.Name) {
+			selected = append(selected, run)
+		}
+	}
+
+	type checkResult struct {
+		check    FailedCheck
+		failures []TestFailure
+	}
+	results := ExecuteParallelWithErrors(selected, func(run failedCheckRun) (checkResult, error) {
+		check := FailedCheck{Name: run.Name, Conclusion: run.Conclusion, URL: run.DetailsURL}
+		if run.CheckSuite.WorkflowRun != nil {
+			check.Workflow = run.CheckSuite.WorkflowRun.Workflow.Name
+		}
+		if run.CheckSuite.App == nil || run.CheckSuite.App.Slug != "github-actions" || run.DatabaseID == 0 {
+			return checkResult{check: check}, nil
+		}
+		check.JobID = run.DatabaseID
+
+		log, err := client.getJobLog(run.DatabaseID)
+		if err != nil {
+			check.LogError = err.Error()
+			return checkResult{check: check}, nil
+		}
+		check.LogAvailable = true
+		lines := splitCheckLog(log)
+
+		var failures []TestFailure
+		for _, parser := range parsers {
+			for _, failure := range parser.Parse(lines) {
+				failure.Check = run.Name
+				failure.Parser = parser.Name()
+				failure.Message = truncateMessage(failure.Message, maxLines)
+				failures = append(failures, failure)
+			}
+		}
+		check.Failures = len(failures)
+		if len(failures) == 0 && tail > 0 {
+			for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+				lines = lines[:len(lines)-1]
+			}
+			check.LogTail = lines[max(0, len(lines)-tail):]
+		}
+		return checkResult{check: check, failures: failures}, nil
+	}, true, 4)
+
+	checks := []FailedCheck{}
+	failures := []TestFailure{}
+	for _, result := range results {
+		checks = append(checks, result.Result.check)
+		failures = append(failures, result.Result.failures...)
+	}
+	sort.SliceStable(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+	sort.SliceStable(failures, func(i, j int) bool { return failures[i].Check < failures[j].Check })
+
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{
+		"checkFailures": map[string]interface{}{
+			"pr":       prNumber,
+			"headSha":  headSHA,
+			"checks":   checks,
+			"failures": failures,
+		},
+	})
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGoTestFailureParser(t *testing.T) {
+	log := strings.Join([]string{
+		"2025-01-10T12:00:00.0000000Z ##[group]Run go test ./...",
+		"2025-01-10T12:00:01.0000000Z go: downloading example.com/dep v1.0.0",
+		"2025-01-10T12:00:02.0000000Z ok  \texample.com/app/ok\t0.010s",
+		// Non-verbose output with a subtest
+		"--- FAIL: TestParse (0.00s)",
+		"    --- FAIL: TestParse/empty (0.00s)",
+		"        parse_test.go:21: got 1, want 0",
+		"        diff:",
+		"          - a",
+		"FAIL",
+		"FAIL\texample.com/app/parse\t0.020s",
+		// Verbose output
+		"=== RUN   TestFetch",
+		"    fetch_test.go:40: unexpected status 500",
+		"--- FAIL: TestFetch (0.10s)",
+		"=== RUN   TestOther",
+		"--- PASS: TestOther (0.00s)",
+		"FAIL",
+		"FAIL\texample.com/app/fetch\t0.200s",
+		// Panic in a test
+		"--- FAIL: TestCrash (0.00s)",
+		"panic: boom [recovered]",
+		"\tpanic: boom",
+		"FAIL\texample.com/app/crash\t0.030s",
+		// Build failure
+		"# example.com/app/broken",
+		"broken/broken.go:7:2: undefined: missing",
+		"FAIL\texample.com/app/broken [build failed]",
+	}, "\n")
+
+	failures := goTestFailureParser{}.Parse(splitCheckLog(log))
+	if len(failures) != 4 {
+		t.Fatalf("got %d failures, want 4: %+v", len(failures), failures)
+	}
+
+	want := []TestFailure{
+		{Package: "example.com/app/parse", Test: "TestParse/empty", File: "parse_test.go", Line: 21,
+			Message: "parse_test.go:21: got 1, want 0\ndiff:\n  - a"},
+		{Package: "example.com/app/fetch", Test: "TestFetch", File: "fetch_test.go", Line: 40,
+			Message: "fetch_test.go:40: unexpected status 500"},
+		{Package: "example.com/app/crash", Test: "TestCrash", Message: "panic: boom [recovered]\n\tpanic: boom"},
+		{Package: "example.com/app/broken", File: "broken/broken.go", Line: 7,
+			Message: "# example.com/app/broken\nbroken/broken.go:7:2: undefined: missing"},
+	}
+	for i, w := range want {
+		if failures[i] != w {
+			t.Errorf("failure %d = %+v, want %+v", i, failures[i], w)
+		}
+	}
+}
+
+func TestSelectTestFailureParsers(t *testing.T) {
+	parsers, err := selectTestFailureParsers(nil)
+	if err != nil || len(parsers) != len(testFailureParsers) {
+		t.Fatalf("default parsers = %v, %v; want all", parsers, err)
+	}
+	if _, err := selectTestFailureParsers([]string{"rspec"}); err == nil {
+		t.Error("expected an error for an unknown parser")
+	}
+}
+
+func TestTruncateMessage(t *testing.T) {
+	if got := truncateMessage("a\nb\nc", 2); got != "a\nb\n... (1 more lines)" {
+		t.Errorf("truncateMessage = %q", got)
+	}
+	if got := truncateMessage("a\nb", 2); got != "a\nb" {
+		t.Errorf("truncateMessage = %q", got)
+	}
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 	"os"
-	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -20,18 +19,6 @@ const (
 	UtilityCommand                        // Commands that provide utilities (help, version)
 )
 
-// StandardFlags represents common flag patterns
-type StandardFlags struct {
-	Owner     string
-	Repo      string
-	Timeout   string
-	JSON      bool
-	Message   string
-	Mention   string
-	Verbose   bool
-	DryRun    bool
-}
-
 // CommandConfig holds configuration for command creation
 type CommandConfig struct {
 	Use         string
@@ -94,26 +81,27 @@ func NewCommandWithConfig(config CommandConfig) *cobra.Command {
 	return cmd
 }
 
-// AddFlags adds specified flags to a command
+// AddFlags adds specified flags to a command. Values are read per invocation
+// (see CommandContext), never bound to package-level variables.
 func AddFlags(cmd *cobra.Command, flagNames ...string) {
 	for _, flagName := range flagNames {
 		switch flagName {
 		case "owner":
-			cmd.Flags().StringVar(&GlobalFlags.Owner, "owner", DefaultOwner, "GitHub repository owner")
+			cmd.Flags().String("owner", DefaultOwner, "GitHub repository owner")
 		case "repo":
-			cmd.Flags().StringVar(&GlobalFlags.Repo, "repo", DefaultRepo, "GitHub repository name")
+			cmd.Flags().String("repo", DefaultRepo, "GitHub repository name")
 		case "timeout":
-			cmd.Flags().StringVar(&GlobalFlags.Timeout, "timeout", "5m", "Timeout duration (e.g., 90s, 1.5m, 2m30s, 15m)")
+			cmd.Flags().String("timeout", "5m", "Timeout duration (e.g., 90s, 1.5m, 2m30s, 15m)")
 		case "json":
-			cmd.Flags().BoolVar(&GlobalFlags.JSON, "json", false, "Output structured JSON for programmatic use")
+			cmd.Flags().Bool("json", false, "Output structured JSON for programmatic use")
 		case "message":
-			cmd.Flags().StringVar(&GlobalFlags.Message, "message", "", "Message text (or use stdin)")
+			cmd.Flags().String("message", "", "Message text (or use stdin)")
 		case "mention":
-			cmd.Flags().StringVar(&GlobalFlags.Mention, "mention", "", "Username to mention (without @)")
+			cmd.Flags().String("mention", "", "Username to mention (without @)")
 		case "verbose":
-			cmd.Flags().BoolVarP(&GlobalFlags.Verbose, "verbose", "v", false, "Enable verbose output")
+			cmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
 		case "dry-run":
-			cmd.Flags().BoolVar(&GlobalFlags.DryRun, "dry-run", false, "Show what would be done without executing")
+			cmd.Flags().Bool("dry-run", false, "Show what would be done without executing")
 		default:
 			fmt.Fprintf(os.Stderr, "Warning: Unknown flag type '%s'\n", flagName)
 		}
@@ -125,24 +113,21 @@ func AddPersistentFlags(cmd *cobra.Command, flagNames ...string) {
 	for _, flagName := range flagNames {
 		switch flagName {
 		case "owner":
-			cmd.PersistentFlags().StringVar(&GlobalFlags.Owner, "owner", DefaultOwner, "GitHub repository owner")
+			cmd.PersistentFlags().String("owner", DefaultOwner, "GitHub repository owner")
 		case "repo":
-			cmd.PersistentFlags().StringVar(&GlobalFlags.Repo, "repo", DefaultRepo, "GitHub repository name")
+			cmd.PersistentFlags().String("repo", DefaultRepo, "GitHub repository name")
 		case "timeout":
-			cmd.PersistentFlags().StringVar(&GlobalFlags.Timeout, "timeout", "5m", "Timeout duration (e.g., 90s, 1.5m, 2m30s, 15m)")
+			cmd.PersistentFlags().String("timeout", "5m", "Timeout duration (e.g., 90s, 1.5m, 2m30s, 15m)")
 		case "json":
-			cmd.PersistentFlags().BoolVar(&GlobalFlags.JSON, "json", false, "Output structured JSON for programmatic use")
+			cmd.PersistentFlags().Bool("json", false, "Output structured JSON for programmatic use")
 		case "verbose":
-			cmd.PersistentFlags().BoolVarP(&GlobalFlags.Verbose, "verbose", "v", false, "Enable verbose output")
+			cmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
 		default:
 			fmt.Fprintf(os.Stderr, "Warning: Unknown persistent flag type '%s'\n", flagName)
 		}
 	}
 }
 
-// GlobalFlags holds shared flag values across commands
-var GlobalFlags = &StandardFlags{}
-
 // DefaultOwner and DefaultRepo are dynamically initialized from git remotes
 var (
 	DefaultOwner string
@@ -195,14 +180,6 @@ func InitializeDefaultsWithConfig(config *GitRemoteConfig) {
 }
 
 
-// Timeout parsing helper
-func ParseTimeoutFlag() (time.Duration, error) {
-	if GlobalFlags.Timeout == "" {
-		return 5 * time.Minute, nil // Default timeout
-	}
-	return time.ParseDuration(GlobalFlags.Timeout)
-}
-
 // Command helper functions
 
 // SetupRootCommand configures a root command with standard persistent flags
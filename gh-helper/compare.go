@@ -0,0 +1,291 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var compareCmd = NewOperationalCommand(
+	"compare <base>...<head>",
+	"Compare two refs (commits ahead/behind, changed files, diffstat)",
+	`Compare any two branches, tags, or commits using the GitHub compare API.
+
+Reports how far head is ahead of/behind base, the commits only on head,
+changed files, and aggregate diffstat. Useful for preparing release scopes
+and verifying backports without a PR object.
+
+Commits are fetched page by page, so long ranges are listed in full. The
+compare API reports at most 300 changed files; beyond that the file list and
+diffstat are partial and the result is marked truncated.
+
+Arguments:
+- base...head (or base..head) as a single argument
+- base head as two arguments
+
+Examples:
+  # Release scope between two tags
+  gh-helper compare v0.19.0...v0.20.0-rc1
+
+  # Verify a backport branch against main
+  gh-helper compare main release/v0.19
+
+  # Diffstat only
+  gh-helper compare v0.19.0...main --no-commits --jq '.compare.diffstat'`,
+	compareRefs,
+)
+
+func init() {
+	compareCmd.Args = cobra.RangeArgs(1, 2)
+
+	compareCmd.Flags().Bool("no-commits", false, "Omit the commit list")
+	compareCmd.Flags().Bool("no-files", false, "Omit the changed file list (diffstat is still reported)")
+
+	rootCmd.AddCommand(compareCmd)
+}
+
+// CompareResult represents a comparison between two refs
+type CompareResult struct {
+	Base         string          `json:"base"`
+	Head         string          `json:"head"`
+	Status       string          `json:"status"` // ahead, behind, diverged, identical
+	AheadBy      int             `json:"aheadBy"`
+	BehindBy     int             `json:"behindBy"`
+	TotalCommits int             `json:"totalCommits"`
+	URL          string          `json:"url,omitempty"`
+	Diffstat     CompareDiffstat `json:"diffstat"`
+	Commits      []CompareCommit `json:"commits,omitempty"`
+	Files        []CompareFile   `json:"files,omitempty"`
+	Truncated    bool            `json:"truncated,omitempty"` // Commit or file list is incomplete
+}
+
+// CompareDiffstat is the aggregate change size across all files
+type CompareDiffstat struct {
+	Files     int `json:"files"`
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+}
+
+// CompareCommit is a commit reachable from head but not base
+type CompareCommit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"` // First line only
+	Author  string `json:"author"`
+	Date    string `json:"date"`
+}
+
+// CompareFile is a file changed between base and head
+type CompareFile struct {
+	Filename         string `json:"filename"`
+	Status           string `json:"status"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	PreviousFilename string `json:"previousFilename,omitempty"`
+}
+
+// compareAPIMaxFiles is the maximum number of files returned by the compare API
+const compareAPIMaxFiles = 300
+
+// compareAPIPageSize is the largest per_page the compare API accepts
+const compareAPIPageSize = 100
+
+// parseCompareRange parses "base...head", "base..head", or two separate arguments
+func parseCompareRange(args []string) (base, head string, err error) {
+	if len(args) == 2 {
+		base, head = args[0], args[1]
+	} else {
+		spec := args[0]
+		sep := "..."
+		if !strings.Contains(spec, sep) {
+			sep = ".."
+		}
+		parts := strings.SplitN(spec, sep, 2)
+		if len(parts) != 2 {
+			return "", "", fmt.Errorf("invalid range %q (expected base...head)", spec)
+		}
+		base, head = parts[0], parts[1]
+	}
+
+	if base == "" || head == "" {
+		return "", "", fmt.Errorf("both base and head refs are required")
+	}
+	return base, head, nil
+}
+
+// escapeRef escapes a ref for use in a URL path while keeping slashes in branch names
+func escapeRef(ref string) string {
+	return strings.ReplaceAll(url.PathEscape(ref), "%2F", "/")
+}
+
+// CompareRefs compares two refs using the REST compare API, following its
+// pagination so that every commit is listed. Without withCommits only the
+// first page is fetched and the commit list is left out.
+func (c *GitHubClient) CompareRefs(base, head string, withCommits bool) (*CompareResult, error) {
+	result := &CompareResult{Base: base, Head: head}
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("/repos/%s/%s/compare/%s...%s?per_page=%d&page=%d",
+			c.Owner, c.Repo, escapeRef(base), escapeRef(head), compareAPIPageSize, page)
+
+		responseData, err := c.RunRESTRequest("GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		more, err := result.addComparePage(responseData, page == 1)
+		if err != nil {
+			return nil, err
+		}
+		if !more || !withCommits {
+			break
+		}
+	}
+
+	if !withCommits {
+		result.Commits = nil
+	}
+	result.Diffstat.Files = len(result.Files)
+	result.Truncated = (withCommits && len(result.Commits) < result.TotalCommits) || len(result.Files) >= compareAPIMaxFiles
+
+	return result, nil
+}
+
+// addComparePage adds one page of a compare response to r and reports whether
+// more commits remain. Only the first page carries the changed files, which
+// cover the whole comparison.
+func (r *CompareResult) addComparePage(responseData []byte, first bool) (bool, error) {
+	var response struct {
+		HTMLURL      string `json:"html_url"`
+		Status       string `json:"status"`
+		AheadBy      int    `json:"ahead_by"`
+		BehindBy     int    `json:"behind_by"`
+		TotalCommits int    `json:"total_commits"`
+		Commits      []struct {
+			SHA    string `json:"sha"`
+			Commit struct {
+				Message string `json:"message"`
+				Author  struct {
+					Name string `json:"name"`
+					Date string `json:"date"`
+				} `json:"author"`
+			} `json:"commit"`
+			Author *struct {
+				Login string `json:"login"`
+			} `json:"author"`
+		} `json:"commits"`
+		Files []struct {
+			Filename         string `json:"filename"`
+			Status           string `json:"status"`
+			Additions        int    `json:"additions"`
+			Deletions        int    `json:"deletions"`
+			PreviousFilename string `json:"previous_filename"`
+		} `json:"files"`
+	}
+
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return false, fmt.Errorf("failed to parse compare response: %w", err)
+	}
+
+	if first {
+		r.Status = response.Status
+		r.AheadBy = response.AheadBy
+		r.BehindBy = response.BehindBy
+		r.TotalCommits = response.TotalCommits
+		r.URL = response.HTMLURL
+
+		for _, file := range response.Files {
+			r.Files = append(r.Files, CompareFile{
+				Filename:         file.Filename,
+				Status:           file.Status,
+				Additions:        file.Additions,
+				Deletions:        file.Deletions,
+				PreviousFilename: file.PreviousFilename,
+			})
+			r.Diffstat.Additions += file.Additions
+			r.Diffstat.Deletions += file.Deletions
+		}
+	}
+
+	for _, commit := range response.Commits {
+		author := commit.Commit.Author.Name
+		if commit.Author != nil && commit.Author.Login != "" {
+			author = commit.Author.Login
+		}
+		message, _, _ := strings.Cut(commit.Commit.Message, "\n")
+		r.Commits = append(r.Commits, CompareCommit{
+			SHA:     commit.SHA,
+			Message: message,
+			Author:  author,
+			Date:    commit.Commit.Author.Date,
+		})
+	}
+
+	return len(response.Commits) > 0 && len(r.Commits) < r.TotalCommits, nil
+}
+
+func compareRefs(cmd *cobra.Command, args []string) error {
+	noCommits, err := cmd.Flags().GetBool("no-commits")
+	if err != nil {
+		return fmt.Errorf("failed to get 'no-commits' flag: %w", err)
+	}
+	noFiles, err := cmd.Flags().GetBool("no-files")
+	if err != nil {
+		return fmt.Errorf("failed to get 'no-files' flag: %w", err)
+	}
+
+	base, head, err := parseCompareRange(args)
+	if err != nil {
+		return err
+	}
+
+	client := newCommandClient(cmd)
+	result, err := client.CompareRefs(base, head, !noCommits)
+	if err != nil {
+		return fmt.Errorf("failed to compare %s...%s: %w", base, head, err)
+	}
+
+	if noFiles {
+		result.Files = nil
+	}
+
+	output := map[string]interface{}{
+		"compare": result,
+	}
+
+	return EncodeOutputWithCmd(cmd, output)
+}
+
+// ListCompareCommitSHAs returns the SHAs of all commits reachable from head but not base,
+// following compare API pagination beyond the first page
+func (c *GitHubClient) ListCompareCommitSHAs(base, head string) ([]string, error) {
+	var shas []string
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("/repos/%s/%s/compare/%s...%s?per_page=%d&page=%d",
+			c.Owner, c.Repo, escapeRef(base), escapeRef(head), compareAPIPageSize, page)
+
+		responseData, err := c.RunRESTRequest("GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			TotalCommits int `json:"total_commits"`
+			Commits      []struct {
+				SHA string `json:"sha"`
+			} `json:"commits"`
+		}
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse compare response: %w", err)
+		}
+
+		for _, commit := range response.Commits {
+			shas = append(shas, commit.SHA)
+		}
+
+		if len(response.Commits) == 0 || len(shas) >= response.TotalCommits {
+			return shas, nil
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import "testing"
+
+func TestParseCompareRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantBase string
+		wantHead string
+		wantErr  bool
+	}{
+		{name: "three dots", args: []string{"v0.19.0...v0.20.0-rc1"}, wantBase: "v0.19.0", wantHead: "v0.20.0-rc1"},
+		{name: "two dots", args: []string{"main..release/v0.19"}, wantBase: "main", wantHead: "release/v0.19"},
+		{name: "two arguments", args: []string{"main", "feature/x"}, wantBase: "main", wantHead: "feature/x"},
+		{name: "missing separator", args: []string{"main"}, wantErr: true},
+		{name: "empty head", args: []string{"main..."}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, head, err := parseCompareRange(tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got base=%q head=%q", base, head)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if base != tt.wantBase || head != tt.wantHead {
+				t.Errorf("got (%q, %q), want (%q, %q)", base, head, tt.wantBase, tt.wantHead)
+			}
+		})
+	}
+}
+
+func TestAddComparePage(t *testing.T) {
+	pages := []string{
+		`{"html_url":"https://github.com/o/r/compare/a...b","status":"ahead","ahead_by":3,"behind_by":0,"total_commits":3,
+			"commits":[
+				{"sha":"c1","commit":{"message":"feat: one\n\nbody","author":{"name":"Alice","date":"2025-06-01T00:00:00Z"}},"author":{"login":"alice"}},
+				{"sha":"c2","commit":{"message":"fix: two","author":{"name":"Bob","date":"2025-06-02T00:00:00Z"}},"author":null}
+			],
+			"files":[
+				{"filename":"a.go","status":"modified","additions":10,"deletions":2},
+				{"filename":"b.go","status":"renamed","additions":1,"deletions":1,"previous_filename":"old.go"}
+			]}`,
+		`{"status":"ahead","ahead_by":3,"total_commits":3,
+			"commits":[{"sha":"c3","commit":{"message":"docs: three","author":{"name":"Carol"}},"author":{"login":"carol"}}],
+			"files":[{"filename":"a.go","status":"modified","additions":10,"deletions":2}]}`,
+	}
+
+	result := &CompareResult{Base: "a", Head: "b"}
+	var more []bool
+	for i, page := range pages {
+		m, err := result.addComparePage([]byte(page), i == 0)
+		if err != nil {
+			t.Fatalf("page %d: unexpected error: %v", i+1, err)
+		}
+		more = append(more, m)
+	}
+
+	if !more[0] || more[1] {
+		t.Errorf("more = %v, want [true false]", more)
+	}
+	if result.Status != "ahead" || result.AheadBy != 3 || result.TotalCommits != 3 || result.URL == "" {
+		t.Errorf("unexpected header fields: %+v", result)
+	}
+	if len(result.Commits) != 3 || result.Commits[2].SHA != "c3" {
+		t.Fatalf("expected commits from both pages, got %+v", result.Commits)
+	}
+	if result.Commits[0].Message != "feat: one" || result.Commits[0].Author != "alice" || result.Commits[1].Author != "Bob" {
+		t.Errorf("unexpected commit mapping: %+v", result.Commits[:2])
+	}
+	// Files come from the first page only
+	if len(result.Files) != 2 || result.Files[1].PreviousFilename != "old.go" {
+		t.Errorf("unexpected files: %+v", result.Files)
+	}
+	if result.Diffstat.Additions != 11 || result.Diffstat.Deletions != 3 {
+		t.Errorf("unexpected diffstat: %+v", result.Diffstat)
+	}
+
+	// A page without commits ends pagination even when total_commits is higher
+	short := &CompareResult{}
+	if m, err := short.addComparePage([]byte(`{"total_commits":500,"commits":[]}`), true); err != nil || m {
+		t.Errorf("addComparePage() = %v, %v; want no more pages", m, err)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/goccy/go-yaml"
+)
+
+// repoConfigFileName is the team-shared config file at the repository root
+const repoConfigFileName = ".gh-helper.yaml"
+
+// Config is gh-helper's optional configuration.
+//
+// It is merged from, in increasing priority:
+//   - $XDG_CONFIG_HOME/gh-helper/config.yaml (or ~/.config/gh-helper/config.yaml)
+//   - .gh-helper.yaml at the repository root, shared by the team
+//
+// GH_HELPER_CONFIG replaces both with a single file.
+//
+//	queries:
+//	  triage: "state:open label:needs-triage sort:created-asc"
+//	  mine: "state:open assignee:@me milestone:{milestone}"
+//	scan:
+//	  forbiddenPaths: [".github/workflows/**"]
+//	lint:
+//	  titles:
+//	    rules: [conventional-commits, max-length]
+//	    maxLength: 72
+//	issues:
+//	  defaults:
+//	    labels: [triage]
+//	    assignCreator: true
+type Config struct {
+	// Queries are named issue search queries for 'issues list --saved'
+	Queries map[string]string `yaml:"queries"`
+	// Scan configures 'pr scan' rules; lists are concatenated across files
+	Scan ScanConfig `yaml:"scan"`
+	// Lint configures 'lint' commands; set values override lower-priority files
+	Lint LintConfig `yaml:"lint"`
+	// Issues configures 'issues' commands; lists are concatenated across files
+	Issues IssuesConfig `yaml:"issues"`
+}
+
+// configPaths returns config files to merge, lowest priority first
+func configPaths() []string {
+	if path := os.Getenv("GH_HELPER_CONFIG"); path != "" {
+		return []string{path}
+	}
+
+	var paths []string
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			configHome = filepath.Join(home, ".config")
+		}
+	}
+	if configHome != "" {
+		paths = append(paths, filepath.Join(configHome, "gh-helper", "config.yaml"))
+	}
+	if root, err := GetRepositoryRoot(); err == nil {
+		paths = append(paths, filepath.Join(root, repoConfigFileName))
+	}
+	return paths
+}
+
+// loadConfigFiles merges config files in order; missing files are skipped
+func loadConfigFiles(paths []string) (*Config, error) {
+	config := &Config{Queries: map[string]string{}}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+		}
+
+		var file Config
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+		}
+		for name, query := range file.Queries {
+			config.Queries[name] = query
+		}
+		config.Scan.merge(file.Scan)
+		config.Lint.merge(file.Lint)
+		config.Issues.merge(file.Issues)
+	}
+	return config, nil
+}
+
+// LoadConfig loads and merges gh-helper configuration
+func LoadConfig() (*Config, error) {
+	return loadConfigFiles(configPaths())
+}
+
+// QueryNames returns the saved query names in sorted order
+func (c *Config) QueryNames() []string {
+	names := make([]string, 0, len(c.Queries))
+	for name := range c.Queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigFilesMerge(t *testing.T) {
+	dir := t.TempDir()
+	user := filepath.Join(dir, "user.yaml")
+	shared := filepath.Join(dir, "shared.yaml")
+	if err := os.WriteFile(user, []byte("queries:\n  mine: \"assignee:@me\"\n  triage: \"label:old\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(shared, []byte("queries:\n  triage: \"state:open label:needs-triage\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := loadConfigFiles([]string{user, filepath.Join(dir, "missing.yaml"), shared})
+	if err != nil {
+		t.Fatalf("loadConfigFiles() error = %v", err)
+	}
+	if got := config.Queries["triage"]; got != "state:open label:needs-triage" {
+		t.Errorf("triage = %q, want the repository config to win", got)
+	}
+	if got := config.Queries["mine"]; got != "assignee:@me" {
+		t.Errorf("mine = %q, want it kept from the user config", got)
+	}
+	if names := config.QueryNames(); len(names) != 2 || names[0] != "mine" {
+		t.Errorf("QueryNames() = %v", names)
+	}
+}
+
+func TestLoadConfigFilesInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.yaml")
+	if err := os.WriteFile(path, []byte("queries: [unterminated"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadConfigFiles([]string{path}); err == nil {
+		t.Error("loadConfigFiles() error = nil, want parse error")
+	}
+}
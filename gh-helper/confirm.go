@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultConfirmThreshold is the number of items a destructive bulk operation may
+// affect without confirmation. Overridden by GH_HELPER_CONFIRM_THRESHOLD or --confirm-threshold.
+const defaultConfirmThreshold = 5
+
+// maxConfirmPreviewItems limits how many affected items are listed in the preview
+const maxConfirmPreviewItems = 20
+
+// Confirmation input, replaceable in tests
+var (
+	confirmInput    io.Reader = os.Stdin
+	confirmOutput   io.Writer = os.Stderr
+	stdinIsTerminal           = func() bool {
+		info, err := os.Stdin.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0
+	}
+)
+
+// envConfirmThreshold returns the threshold from GH_HELPER_CONFIRM_THRESHOLD, or the default
+func envConfirmThreshold() int {
+	if value := os.Getenv("GH_HELPER_CONFIRM_THRESHOLD"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+			return n
+		}
+	}
+	return defaultConfirmThreshold
+}
+
+// confirmBulkOperation guards destructive operations affecting many items.
+// When more than --confirm-threshold items are affected (or force is set), it
+// prints a preview and asks for confirmation on a terminal; without a terminal
+// it refuses unless --yes was given.
+func confirmBulkOperation(cmd *cobra.Command, action string, items []string, force bool) error {
+	cc := commandContext(cmd)
+	if cc.AssumeYes || (!force && len(items) <= cc.ConfirmThreshold) {
+		return nil
+	}
+
+	fmt.Fprintf(confirmOutput, "⚠️  About to %s %d item(s):\n", action, len(items))
+	for i, item := range items {
+		if i == maxConfirmPreviewItems {
+			fmt.Fprintf(confirmOutput, "  ... and %d more\n", len(items)-maxConfirmPreviewItems)
+			break
+		}
+		fmt.Fprintf(confirmOutput, "  - %s\n", item)
+	}
+
+	if !stdinIsTerminal() {
+		return fmt.Errorf("refusing to %s %d item(s) without confirmation (threshold: %d); re-run with --yes to proceed", action, len(items), cc.ConfirmThreshold)
+	}
+
+	fmt.Fprint(confirmOutput, "Proceed? [y/N]: ")
+	answer, err := bufio.NewReader(confirmInput).ReadString('\n')
+	if err != nil && answer == "" {
+		return fmt.Errorf("confirmation aborted: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return fmt.Errorf("aborted: %s not confirmed", action)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestConfirmBulkOperation(t *testing.T) {
+	origInput, origOutput, origTerminal := confirmInput, confirmOutput, stdinIsTerminal
+	defer func() {
+		confirmInput, confirmOutput, stdinIsTerminal = origInput, origOutput, origTerminal
+	}()
+
+	items := []string{"#1", "#2", "#3"}
+
+	tests := []struct {
+		name     string
+		items    []string
+		force    bool
+		yes      bool
+		terminal bool
+		input    string
+		wantErr  bool
+	}{
+		{name: "below threshold", items: items[:2]},
+		{name: "above threshold without terminal", items: items, wantErr: true},
+		{name: "above threshold with --yes", items: items, yes: true},
+		{name: "confirmed on terminal", items: items, terminal: true, input: "y\n"},
+		{name: "declined on terminal", items: items, terminal: true, input: "n\n", wantErr: true},
+		{name: "empty answer declines", items: items, terminal: true, input: "\n", wantErr: true},
+		{name: "forced below threshold", items: items[:1], force: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			confirmInput = strings.NewReader(tt.input)
+			confirmOutput = &output
+			terminal := tt.terminal
+			stdinIsTerminal = func() bool { return terminal }
+			cmd := &cobra.Command{}
+			setCommandContext(cmd, &CommandContext{AssumeYes: tt.yes, ConfirmThreshold: 2})
+
+			err := confirmBulkOperation(cmd, "resolve threads", tt.items, tt.force)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("confirmBulkOperation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			prompted := output.Len() > 0
+			wantPrompt := !tt.yes && (tt.force || len(tt.items) > 2)
+			if prompted != wantPrompt {
+				t.Errorf("preview shown = %v, want %v (output: %q)", prompted, wantPrompt, output.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var dispatchCmd = &cobra.Command{
+	Use:   "dispatch",
+	Short: "Trigger GitHub Actions workflows",
+	Long: `Trigger workflow_dispatch and repository_dispatch events and report the
+workflow runs they started, so automation can kick off a pipeline and then
+monitor it by run ID.`,
+}
+
+var workflowDispatchCmd = NewOperationalCommand(
+	"workflow [flags]",
+	"Trigger a workflow_dispatch workflow",
+	`Trigger a workflow with a workflow_dispatch trigger, then look up the run it
+started (runs created for the workflow on --ref after the dispatch).
+
+GitHub does not return the run from the dispatch request, so the run is
+found by polling for up to --find-timeout. If no run appears in time, the
+output has an empty runs list and the dispatch still succeeds.
+
+Examples:
+  # Release workflow on main with an input
+  gh-helper dispatch workflow --name release.yml --ref main --input version=v0.20.0
+
+  # Watch the started run
+  gh run watch $(gh-helper dispatch workflow --name ci.yml --jq '.dispatch.runs[0].id')`,
+	dispatchWorkflow,
+)
+
+var repositoryDispatchCmd = NewOperationalCommand(
+	"repository [flags]",
+	"Send a repository_dispatch event",
+	`Send a repository_dispatch event with an optional JSON client payload, then
+look up the workflow runs it started (repository_dispatch runs created after
+the event). Several workflows may react to one event type.
+
+Examples:
+  # Deploy with a payload from a file
+  gh-helper dispatch repository --event-type deploy --payload-file p.json
+
+  # Inline payload, IDs of the started runs
+  gh-helper dispatch repository --event-type deploy --payload '{"env":"staging"}' --jq '[.dispatch.runs[].id]'`,
+	dispatchRepository,
+)
+
+func init() {
+	workflowDispatchCmd.Flags().String("name", "", "Workflow file name or ID (e.g., release.yml)")
+	workflowDispatchCmd.Flags().String("ref", "", "Branch or tag to run the workflow on (default: repository default branch)")
+	workflowDispatchCmd.Flags().StringArray("input", []string{}, "Workflow input (key=value, repeatable)")
+	workflowDispatchCmd.Flags().Duration("find-timeout", 30*time.Second, "How long to look for the started run (0: don't look)")
+	if err := workflowDispatchCmd.MarkFlagRequired("name"); err != nil {
+		panic(err)
+	}
+
+	repositoryDispatchCmd.Flags().String("event-type", "", "Event type delivered to workflows (on.repository_dispatch.types)")
+	repositoryDispatchCmd.Flags().String("payload", "", "Client payload as a JSON object")
+	repositoryDispatchCmd.Flags().String("payload-file", "", "Read the client payload JSON object from a file")
+	repositoryDispatchCmd.Flags().Duration("find-timeout", 30*time.Second, "How long to look for started runs (0: don't look)")
+	if err := repositoryDispatchCmd.MarkFlagRequired("event-type"); err != nil {
+		panic(err)
+	}
+	repositoryDispatchCmd.MarkFlagsMutuallyExclusive("payload", "payload-file")
+
+	dispatchCmd.AddCommand(workflowDispatchCmd, repositoryDispatchCmd)
+	rootCmd.AddCommand(dispatchCmd)
+}
+
+// DispatchedRun is a workflow run started by a dispatch
+type DispatchedRun struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Event     string `json:"event"`
+	HeadSHA   string `json:"headSha"`
+	URL       string `json:"url"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// dispatchRunSkew tolerates clock differences between this host and GitHub when
+// matching runs created after a dispatch
+const dispatchRunSkew = 5 * time.Second
+
+// dispatchPollInterval is the delay between run lookups
+const dispatchPollInterval = 3 * time.Second
+
+// parseDispatchInputs parses key=value pairs from --input
+func parseDispatchInputs(inputs []string) (map[string]string, error) {
+	values := make(map[string]string, len(inputs))
+	for _, input := range inputs {
+		key, value, ok := strings.Cut(input, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid --input %q (expected key=value)", input)
+		}
+		values[strings.TrimSpace(key)] = value
+	}
+	return values, nil
+}
+
+// parseDispatchPayload parses a repository_dispatch client payload, which must be a JSON object
+func parseDispatchPayload(data []byte) (map[string]interface{}, error) {
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil, nil
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("client payload must be a JSON object: %w", err)
+	}
+	return payload, nil
+}
+
+// getDefaultBranch returns the repository default branch name
+func (c *GitHubClient) getDefaultBranch() (string, error) {
+	result, err := c.RunRESTRequest("GET", fmt.Sprintf("/repos/%s/%s", c.Owner, c.Repo), nil)
+	if err != nil {
+		return "", err
+	}
+	var response struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return "", fmt.Errorf("failed to parse repository response: %w", err)
+	}
+	return response.DefaultBranch, nil
+}
+
+// listDispatchedRuns lists runs of the given event created at or after since.
+// workflow restricts the lookup to one workflow file or ID, ref to one branch.
+func (c *GitHubClient) listDispatchedRuns(workflow, event, ref string, since time.Time) ([]DispatchedRun, error) {
+	query := url.Values{}
+	query.Set("event", event)
+	query.Set("created", ">="+since.Add(-dispatchRunSkew).UTC().Format(time.RFC3339))
+	query.Set("per_page", "20")
+	if ref != "" {
+		query.Set("branch", ref)
+	}
+
+	path := fmt.Sprintf("/repos/%s/%s/actions/runs?%s", c.Owner, c.Repo, query.Encode())
+	if workflow != "" {
+		path = fmt.Sprintf("/repos/%s/%s/actions/workflows/%s/runs?%s", c.Owner, c.Repo, url.PathEscape(workflow), query.Encode())
+	}
+
+	result, err := c.RunRESTRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		WorkflowRuns []struct {
+			ID        int64  `json:"id"`
+			Name      string `json:"name"`
+			Status    string `json:"status"`
+			Event     string `json:"event"`
+			HeadSHA   string `json:"head_sha"`
+			HTMLURL   string `json:"html_url"`
+			CreatedAt string `json:"created_at"`
+		} `json:"workflow_runs"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow runs response: %w", err)
+	}
+
+	runs := make([]DispatchedRun, 0, len(response.WorkflowRuns))
+	for _, run := range response.WorkflowRuns {
+		runs = append(runs, DispatchedRun{
+			ID:        run.ID,
+			Name:      run.Name,
+			Status:    run.Status,
+			Event:     run.Event,
+			HeadSHA:   run.HeadSHA,
+			URL:       run.HTMLURL,
+			CreatedAt: run.CreatedAt,
+		})
+	}
+	// Newest first, so .runs[0] is the run most likely started by this dispatch
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].CreatedAt > runs[j].CreatedAt
+	})
+	return runs, nil
+}
+
+// findDispatchedRuns polls for runs started by a dispatch until one appears or
+// timeout elapses, warning on w when none does
+func (c *GitHubClient) findDispatchedRuns(w io.Writer, workflow, event, ref string, since time.Time, timeout time.Duration) ([]DispatchedRun, error) {
+	runs := []DispatchedRun{}
+	if timeout <= 0 {
+		return runs, nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		found, err := c.listDispatchedRuns(workflow, event, ref, since)
+		if err != nil {
+			return runs, err
+		}
+		if len(found) > 0 {
+			return found, nil
+		}
+		if time.Now().Add(dispatchPollInterval).After(deadline) {
+			fmt.Fprintf(w, "⚠️  No %s run found within %v; it may still be queued\n", event, timeout)
+			return runs, nil
+		}
+		time.Sleep(dispatchPollInterval)
+	}
+}
+
+func dispatchWorkflow(cmd *cobra.Command, args []string) error {
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return fmt.Errorf("failed to get 'name' flag: %w", err)
+	}
+	ref, err := cmd.Flags().GetString("ref")
+	if err != nil {
+		return fmt.Errorf("failed to get 'ref' flag: %w", err)
+	}
+	inputFlags, err := cmd.Flags().GetStringArray("input")
+	if err != nil {
+		return fmt.Errorf("failed to get 'input' flag: %w", err)
+	}
+	findTimeout, err := cmd.Flags().GetDuration("find-timeout")
+	if err != nil {
+		return fmt.Errorf("failed to get 'find-timeout' flag: %w", err)
+	}
+
+	inputs, err := parseDispatchInputs(inputFlags)
+	if err != nil {
+		return err
+	}
+
+	client := newCommandClient(cmd)
+	if ref == "" {
+		ref, err = client.getDefaultBranch()
+		if err != nil {
+			return fmt.Errorf("failed to resolve default branch: %w", err)
+		}
+	}
+
+	body := map[string]interface{}{"ref": ref}
+	if len(inputs) > 0 {
+		body["inputs"] = inputs
+	}
+	dispatchedAt := time.Now()
+	path := fmt.Sprintf("/repos/%s/%s/actions/workflows/%s/dispatches", client.Owner, client.Repo, url.PathEscape(name))
+	if _, err := client.RunRESTRequest("POST", path, body); err != nil {
+		return fmt.Errorf("failed to dispatch workflow %s: %w", name, err)
+	}
+
+	runs, err := client.findDispatchedRuns(messageWriter(cmd), name, "workflow_dispatch", ref, dispatchedAt, findTimeout)
+	if err != nil {
+		return fmt.Errorf("workflow dispatched, but failed to look up its run: %w", err)
+	}
+
+	result := map[string]interface{}{
+		"kind":         "workflow",
+		"workflow":     name,
+		"ref":          ref,
+		"dispatchedAt": dispatchedAt.UTC().Format(time.RFC3339),
+		"runs":         runs,
+	}
+	if len(inputs) > 0 {
+		result["inputs"] = inputs
+	}
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"dispatch": result})
+}
+
+func dispatchRepository(cmd *cobra.Command, args []string) error {
+	eventType, err := cmd.Flags().GetString("event-type")
+	if err != nil {
+		return fmt.Errorf("failed to get 'event-type' flag: %w", err)
+	}
+	payloadText, err := cmd.Flags().GetString("payload")
+	if err != nil {
+		return fmt.Errorf("failed to get 'payload' flag: %w", err)
+	}
+	payloadFile, err := cmd.Flags().GetString("payload-file")
+	if err != nil {
+		return fmt.Errorf("failed to get 'payload-file' flag: %w", err)
+	}
+	findTimeout, err := cmd.Flags().GetDuration("find-timeout")
+	if err != nil {
+		return fmt.Errorf("failed to get 'find-timeout' flag: %w", err)
+	}
+
+	payloadData := []byte(payloadText)
+	if payloadFile != "" {
+		payloadData, err = os.ReadFile(payloadFile)
+		if err != nil {
+			return fmt.Errorf("failed to read payload file: %w", err)
+		}
+	}
+	payload, err := parseDispatchPayload(payloadData)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{"event_type": eventType}
+	if payload != nil {
+		body["client_payload"] = payload
+	}
+
+	client := newCommandClient(cmd)
+	dispatchedAt := time.Now()
+	if _, err := client.RunRESTRequest("POST", fmt.Sprintf("/repos/%s/%s/dispatches", client.Owner, client.Repo), body); err != nil {
+		return fmt.Errorf("failed to send repository_dispatch %s: %w", eventType, err)
+	}
+
+	runs, err := client.findDispatchedRuns(messageWriter(cmd), "", "repository_dispatch", "", dispatchedAt, findTimeout)
+	if err != nil {
+		return fmt.Errorf("event sent, but failed to look up started runs: %w", err)
+	}
+
+	result := map[string]interface{}{
+		"kind":         "repository",
+		"eventType":    eventType,
+		"dispatchedAt": dispatchedAt.UTC().Format(time.RFC3339),
+		"runs":         runs,
+	}
+	if payload != nil {
+		result["payload"] = payload
+	}
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"dispatch": result})
+}
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestParseDispatchInputs(t *testing.T) {
+	got, err := parseDispatchInputs([]string{"version=v0.20.0", " dry_run =true", "notes=a=b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"version": "v0.20.0", "dry_run": "true", "notes": "a=b"}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("input %s = %q, want %q", key, got[key], value)
+		}
+	}
+
+	if _, err := parseDispatchInputs([]string{"version"}); err == nil {
+		t.Error("expected error for input without '='")
+	}
+}
+
+func TestParseDispatchPayload(t *testing.T) {
+	payload, err := parseDispatchPayload([]byte(`{"env": "staging", "replicas": 2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload["env"] != "staging" {
+		t.Errorf("env = %v, want staging", payload["env"])
+	}
+
+	if payload, err := parseDispatchPayload([]byte("  ")); err != nil || payload != nil {
+		t.Errorf("empty payload = %v, %v; want nil, nil", payload, err)
+	}
+	if _, err := parseDispatchPayload([]byte(`["not", "an", "object"]`)); err == nil {
+		t.Error("expected error for non-object payload")
+	}
+}
@@ -0,0 +1,274 @@
+//go:build e2e
+
+package main
+
+// End-to-end tests run the built gh-helper binary against a disposable
+// repository created for the run, so regressions in GraphQL queries and
+// filters show up against the real API. Run with 'make e2e'; the token from
+// 'gh auth token' needs the repo and delete_repo scopes.
+//
+//	GH_HELPER_E2E_OWNER  organization to create the repository in (default: the authenticated user)
+//	GH_HELPER_E2E_KEEP=1 keep the repository after the run for debugging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/apstndb/gh-dev-tools/pkg/ghclient"
+)
+
+// e2eSandbox is the repository shared by the end-to-end tests
+type e2eSandbox struct {
+	api    *ghclient.Client
+	binary string // built gh-helper
+	dir    string // working directory outside any git repository
+	owner  string
+	repo   string
+	pr     int // PR with two review threads on e2e.go
+}
+
+var sandbox e2eSandbox
+
+func TestMain(m *testing.M) {
+	os.Exit(runE2E(m))
+}
+
+func runE2E(m *testing.M) int {
+	dir, err := os.MkdirTemp("", "gh-helper-e2e-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer os.RemoveAll(dir)
+
+	sandbox = e2eSandbox{api: ghclient.New(), binary: filepath.Join(dir, "gh-helper"), dir: dir}
+	if out, err := exec.Command("go", "build", "-o", sandbox.binary, ".").CombinedOutput(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to build gh-helper: %v\n%s", err, out)
+		return 1
+	}
+
+	cleanup, err := sandbox.create()
+	if cleanup != nil {
+		defer cleanup()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to set up the sandbox repository: %v\n", err)
+		return 1
+	}
+	return m.Run()
+}
+
+// rest sends a REST request and decodes the response into out, if given
+func (s *e2eSandbox) rest(method, path string, body, out interface{}) error {
+	data, err := s.api.REST(method, path, body)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// create creates the sandbox repository with labels and a PR with review
+// threads; the returned cleanup deletes the repository
+func (s *e2eSandbox) create() (func(), error) {
+	s.owner = os.Getenv("GH_HELPER_E2E_OWNER")
+	createPath := "/orgs/" + s.owner + "/repos"
+	if s.owner == "" {
+		var user struct {
+			Login string `json:"login"`
+		}
+		if err := s.rest("GET", "/user", nil, &user); err != nil {
+			return nil, err
+		}
+		s.owner, createPath = user.Login, "/user/repos"
+	}
+
+	var repo struct {
+		Name          string `json:"name"`
+		DefaultBranch string `json:"default_branch"`
+	}
+	name := fmt.Sprintf("gh-helper-e2e-%d", time.Now().Unix())
+	if err := s.rest("POST", createPath, map[string]interface{}{
+		"name":        name,
+		"description": "Disposable repository for gh-helper end-to-end tests",
+		"private":     true,
+		"auto_init":   true,
+	}, &repo); err != nil {
+		return nil, err
+	}
+	s.repo = repo.Name
+	base := fmt.Sprintf("/repos/%s/%s", s.owner, s.repo)
+	fmt.Fprintf(os.Stderr, "e2e sandbox: %s/%s\n", s.owner, s.repo)
+
+	cleanup := func() {
+		if os.Getenv("GH_HELPER_E2E_KEEP") == "1" {
+			fmt.Fprintf(os.Stderr, "e2e sandbox kept: https://github.com/%s/%s\n", s.owner, s.repo)
+			return
+		}
+		if err := s.rest("DELETE", base, nil, nil); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to delete the sandbox repository: %v\n", err)
+		}
+	}
+
+	for _, label := range []string{"epic", "e2e-bug"} {
+		if err := s.rest("POST", base+"/labels", map[string]string{"name": label, "color": "ededed"}, nil); err != nil {
+			return cleanup, err
+		}
+	}
+
+	// A branch with one file, opened as a PR with two inline review comments
+	var ref struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}
+	if err := s.rest("GET", base+"/git/ref/heads/"+repo.DefaultBranch, nil, &ref); err != nil {
+		return cleanup, err
+	}
+	if err := s.rest("POST", base+"/git/refs", map[string]string{"ref": "refs/heads/e2e", "sha": ref.Object.SHA}, nil); err != nil {
+		return cleanup, err
+	}
+	content := "package e2e\n\nfunc Fetch() error {\n\treturn nil\n}\n"
+	if err := s.rest("PUT", base+"/contents/e2e.go", map[string]string{
+		"message": "Add e2e.go",
+		"content": base64.StdEncoding.EncodeToString([]byte(content)),
+		"branch":  "e2e",
+	}, nil); err != nil {
+		return cleanup, err
+	}
+	var pr struct {
+		Number int `json:"number"`
+	}
+	if err := s.rest("POST", base+"/pulls", map[string]string{
+		"title": "Add Fetch",
+		"head":  "e2e",
+		"base":  repo.DefaultBranch,
+	}, &pr); err != nil {
+		return cleanup, err
+	}
+	s.pr = pr.Number
+	err := s.rest("POST", fmt.Sprintf("%s/pulls/%d/reviews", base, s.pr), map[string]interface{}{
+		"event": "COMMENT",
+		"body":  "e2e review",
+		"comments": []map[string]interface{}{
+			{"path": "e2e.go", "line": 3, "side": "RIGHT", "body": "TODO: handle retries on timeout"},
+			{"path": "e2e.go", "line": 4, "side": "RIGHT", "body": "Consider wrapping the error"},
+		},
+	}, nil)
+	return cleanup, err
+}
+
+// run executes gh-helper against the sandbox with JSON output and decodes it into out
+func (s *e2eSandbox) run(t *testing.T, out interface{}, args ...string) {
+	t.Helper()
+	args = append(args, "--owner", s.owner, "--repo", s.repo, "--format", "json")
+	cmd := exec.Command(s.binary, args...)
+	cmd.Dir = s.dir
+	// Isolate from the developer's config file
+	cmd.Env = append(os.Environ(), "GH_HELPER_CONFIG="+filepath.Join(s.dir, "config.yaml"))
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("gh-helper %v: %v\n%s", args, err, stderr.String())
+	}
+	if err := json.Unmarshal(stdout.Bytes(), out); err != nil {
+		t.Fatalf("gh-helper %v: invalid JSON output: %v\n%s", args, err, stdout.String())
+	}
+}
+
+// e2eThreads is the output of 'threads list'
+type e2eThreads struct {
+	Threads struct {
+		UnresolvedCount int `json:"unresolvedCount"`
+		Nodes           []struct {
+			ID         string `json:"id"`
+			Path       string `json:"path"`
+			Line       int    `json:"line"`
+			IsResolved bool   `json:"isResolved"`
+			Summary    string `json:"summary"`
+		} `json:"nodes"`
+	} `json:"threads"`
+}
+
+func TestE2EIssues(t *testing.T) {
+	var parent struct {
+		Issue IssueCreationResult `json:"issue"`
+	}
+	sandbox.run(t, &parent, "issues", "create", "--title", "e2e parent", "--label", "epic")
+	if parent.Issue.Number == 0 || !slices.Contains(parent.Issue.Labels, "epic") {
+		t.Fatalf("issues create = %+v", parent.Issue)
+	}
+
+	var child struct {
+		Issue IssueCreationResult `json:"issue"`
+	}
+	sandbox.run(t, &child, "issues", "create", "--title", "e2e child", "--label", "e2e-bug", "--parent", fmt.Sprint(parent.Issue.Number))
+	if child.Issue.Parent == nil || child.Issue.Parent.Number != parent.Issue.Number {
+		t.Fatalf("issues create --parent: parent = %+v, want #%d", child.Issue.Parent, parent.Issue.Number)
+	}
+
+	var show struct {
+		IssueShow IssueShowResult `json:"issueShow"`
+	}
+	sandbox.run(t, &show, "issues", "show", fmt.Sprint(parent.Issue.Number), "--include-sub")
+	if show.IssueShow.SubIssues == nil || show.IssueShow.SubIssues.TotalCount != 1 ||
+		show.IssueShow.SubIssues.Items[0].Number != child.Issue.Number {
+		t.Errorf("issues show --include-sub: subIssues = %+v, want #%d", show.IssueShow.SubIssues, child.Issue.Number)
+	}
+}
+
+func TestE2EThreads(t *testing.T) {
+	pr := fmt.Sprint(sandbox.pr)
+
+	var list e2eThreads
+	sandbox.run(t, &list, "threads", "list", pr, "--unresolved-only")
+	if list.Threads.UnresolvedCount != 2 || len(list.Threads.Nodes) != 2 {
+		t.Fatalf("threads list: unresolvedCount = %d, nodes = %d; want 2, 2", list.Threads.UnresolvedCount, len(list.Threads.Nodes))
+	}
+	first := list.Threads.Nodes[0]
+	if first.Path != "e2e.go" || first.Line != 3 || first.Summary != "TODO: handle retries on timeout" {
+		t.Errorf("first thread = %+v", first)
+	}
+
+	// The search index lags behind newly created PRs
+	var search struct {
+		ThreadSearch struct {
+			Matches []ThreadSearchMatch `json:"matches"`
+		} `json:"threadSearch"`
+	}
+	for attempt := 0; attempt < 12; attempt++ {
+		sandbox.run(t, &search, "threads", "search", "--query", "retr(y|ies)", "--regex", "--since", "1d")
+		if len(search.ThreadSearch.Matches) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Second)
+	}
+	if len(search.ThreadSearch.Matches) != 1 || search.ThreadSearch.Matches[0].ThreadID != first.ID {
+		t.Errorf("threads search matches = %+v, want %s", search.ThreadSearch.Matches, first.ID)
+	}
+
+	var reply struct {
+		ThreadID   string `json:"threadId"`
+		IsResolved bool   `json:"isResolved"`
+	}
+	sandbox.run(t, &reply, "threads", "reply", first.ID, "--message", "Added retries", "--resolve")
+	if reply.ThreadID != first.ID || !reply.IsResolved {
+		t.Fatalf("threads reply --resolve = %+v", reply)
+	}
+
+	var after e2eThreads
+	sandbox.run(t, &after, "threads", "list", pr, "--unresolved-only")
+	if after.Threads.UnresolvedCount != 1 || len(after.Threads.Nodes) != 1 || after.Threads.Nodes[0].ID == first.ID {
+		t.Errorf("threads list after resolve: unresolvedCount = %d, nodes = %+v", after.Threads.UnresolvedCount, after.Threads.Nodes)
+	}
+}
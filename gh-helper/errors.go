@@ -64,4 +64,29 @@ func ArgumentError(argument string, err error) error {
 // NotFoundError creates a "not found" error
 func NotFoundError(resource string, err error) error {
 	return fmt.Errorf(ErrNotFound, resource, err)
-}
\ No newline at end of file
+}
+
+// Process exit codes for conditions that scripts and agent loops need to
+// distinguish from generic failures (exit code 1)
+const (
+	ExitCodeChangesRequested = 3
+	ExitCodeSLAViolation     = 4
+	ExitCodeScanFindings     = 5
+	ExitCodeStaleTarget      = 6 // mutating command refused on a merged or closed PR
+	ExitCodeLintViolations   = 7 // 'lint titles' found violations
+	ExitCodeLinkFailed       = 8 // 'issues import' created issues it could not link to their parent
+)
+
+// ExitError carries a specific process exit code along with the underlying error
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,437 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export repository activity for offline analysis",
+	Long:  `Export pull requests, reviews, threads, issues, and labels into local files that can be queried without hitting the API.`,
+}
+
+var sqliteExportCmd = NewOperationalCommand(
+	"sqlite [flags]",
+	"Load PRs, reviews, threads, issues, and labels into a SQLite database",
+	`Load repository activity updated since --since into a SQLite database for
+ad-hoc SQL analytics.
+
+The database is written with the sqlite3 command-line tool (--sqlite), so no
+SQLite library is linked into gh-helper. With an --output ending in .sql, the
+SQL script is written instead and can be loaded later with
+'sqlite3 repo.db < repo.sql'.
+
+Exports upsert: re-running against the same database refreshes the items
+updated in the window and keeps older rows.
+
+Schema:
+  pull_requests  (number PK, title, state, is_draft, author, base_ref, head_ref,
+                  additions, deletions, changed_files,
+                  created_at, updated_at, merged_at, closed_at)
+  reviews        (id PK, pr_number, author, state, submitted_at)
+  review_threads (id PK, pr_number, path, line, is_resolved, is_outdated,
+                  author, comments, created_at)
+  issues         (number PK, title, state, state_reason, author, milestone,
+                  comments, created_at, updated_at, closed_at)
+  labels         (item_type 'pr'|'issue', item_number, name; PK of all three)
+  export_meta    (key PK, value): repository, since, exported_at
+
+Times are RFC 3339 text in UTC; booleans are 0/1; missing values are NULL.
+
+Examples:
+  # Last 180 days into repo.db
+  gh-helper export sqlite --output repo.db --since 180d
+
+  # Merged PRs per month
+  sqlite3 repo.db "SELECT substr(merged_at, 1, 7) AS month,
+    count(*) FROM pull_requests WHERE merged_at IS NOT NULL GROUP BY month"
+
+  # Write a SQL script instead (no sqlite3 needed)
+  gh-helper export sqlite --output repo.sql --since 30d`,
+	exportSQLite,
+)
+
+func init() {
+	sqliteExportCmd.Flags().StringP("output", "o", "", "Database file, or a .sql file to write the SQL script (required)")
+	sqliteExportCmd.Flags().String("since", "180d", "Export items updated since (days like 180d, YYYY-MM-DD, RFC 3339, or duration)")
+	sqliteExportCmd.Flags().String("sqlite", "sqlite3", "sqlite3 command used to write the database")
+	_ = sqliteExportCmd.MarkFlagRequired("output")
+
+	exportCmd.AddCommand(sqliteExportCmd)
+	rootCmd.AddCommand(exportCmd)
+}
+
+// sqliteSchema is the database schema; see the command help for the columns
+const sqliteSchema = `CREATE TABLE IF NOT EXISTS pull_requests (
+  number INTEGER PRIMARY KEY, title TEXT, state TEXT, is_draft INTEGER, author TEXT,
+  base_ref TEXT, head_ref TEXT, additions INTEGER, deletions INTEGER, changed_files INTEGER,
+  created_at TEXT, updated_at TEXT, merged_at TEXT, closed_at TEXT
+);
+CREATE TABLE IF NOT EXISTS reviews (
+  id TEXT PRIMARY KEY, pr_number INTEGER, author TEXT, state TEXT, submitted_at TEXT
+);
+CREATE TABLE IF NOT EXISTS review_threads (
+  id TEXT PRIMARY KEY, pr_number INTEGER, path TEXT, line INTEGER, is_resolved INTEGER,
+  is_outdated INTEGER, author TEXT, comments INTEGER, created_at TEXT
+);
+CREATE TABLE IF NOT EXISTS issues (
+  number INTEGER PRIMARY KEY, title TEXT, state TEXT, state_reason TEXT, author TEXT,
+  milestone TEXT, comments INTEGER, created_at TEXT, updated_at TEXT, closed_at TEXT
+);
+CREATE TABLE IF NOT EXISTS labels (
+  item_type TEXT, item_number INTEGER, name TEXT, PRIMARY KEY (item_type, item_number, name)
+);
+CREATE TABLE IF NOT EXISTS export_meta (key TEXT PRIMARY KEY, value TEXT);
+CREATE INDEX IF NOT EXISTS reviews_pr ON reviews (pr_number);
+CREATE INDEX IF NOT EXISTS review_threads_pr ON review_threads (pr_number);
+`
+
+type exportLabels struct {
+	Nodes []struct {
+		Name string `json:"name"`
+	} `json:"nodes"`
+}
+
+// exportPR is a pull request with its reviews, threads, and labels
+type exportPR struct {
+	Number       int          `json:"number"`
+	Title        string       `json:"title"`
+	State        string       `json:"state"`
+	IsDraft      bool         `json:"isDraft"`
+	Author       *actorRef    `json:"author"`
+	BaseRefName  string       `json:"baseRefName"`
+	HeadRefName  string       `json:"headRefName"`
+	Additions    int          `json:"additions"`
+	Deletions    int          `json:"deletions"`
+	ChangedFiles int          `json:"changedFiles"`
+	CreatedAt    string       `json:"createdAt"`
+	UpdatedAt    string       `json:"updatedAt"`
+	MergedAt     string       `json:"mergedAt"`
+	ClosedAt     string       `json:"closedAt"`
+	Labels       exportLabels `json:"labels"`
+	Reviews      struct {
+		Nodes []struct {
+			ID          string    `json:"id"`
+			Author      *actorRef `json:"author"`
+			State       string    `json:"state"`
+			SubmittedAt string    `json:"submittedAt"`
+		} `json:"nodes"`
+	} `json:"reviews"`
+	ReviewThreads struct {
+		Nodes []struct {
+			ID         string `json:"id"`
+			Path       string `json:"path"`
+			Line       *int   `json:"line"`
+			IsResolved bool   `json:"isResolved"`
+			IsOutdated bool   `json:"isOutdated"`
+			Comments   struct {
+				TotalCount int `json:"totalCount"`
+				Nodes      []struct {
+					Author    *actorRef `json:"author"`
+					CreatedAt string    `json:"createdAt"`
+				} `json:"nodes"`
+			} `json:"comments"`
+		} `json:"nodes"`
+	} `json:"reviewThreads"`
+}
+
+// exportIssue is an issue with its labels
+type exportIssue struct {
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	State       string    `json:"state"`
+	StateReason string    `json:"stateReason"`
+	Author      *actorRef `json:"author"`
+	Milestone   *struct {
+		Title string `json:"title"`
+	} `json:"milestone"`
+	Comments struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"comments"`
+	CreatedAt string       `json:"createdAt"`
+	UpdatedAt string       `json:"updatedAt"`
+	ClosedAt  string       `json:"closedAt"`
+	Labels    exportLabels `json:"labels"`
+}
+
+const exportPRFields = `
+	number title state isDraft author { login } baseRefName headRefName
+	additions deletions changedFiles createdAt updatedAt mergedAt closedAt
+	labels(first: 20) { nodes { name } }
+	reviews(first: 50) { nodes { id author { login } state submittedAt } }
+	reviewThreads(first: 50) {
+		nodes {
+			id path line isResolved isOutdated
+			comments(first: 1) { totalCount nodes { author { login } createdAt } }
+		}
+	}`
+
+const exportIssueFields = `
+	number title state stateReason author { login } milestone { title }
+	comments { totalCount } createdAt updatedAt closedAt
+	labels(first: 20) { nodes { name } }`
+
+// fetchExportPage pages through a repository connection ordered by update
+// time, newest first, until items predate since. decode appends the page's
+// nodes and returns the updatedAt of its last node.
+func (c *GitHubClient) fetchExportPage(connection, fields string, pageSize int, since time.Time, decode func(nodes json.RawMessage) (string, error)) error {
+	query := fmt.Sprintf(`
+	query($owner: String!, $repo: String!, $first: Int!, $after: String) {
+		repository(owner: $owner, name: $repo) {
+			%s(first: $first, after: $after, orderBy: {field: UPDATED_AT, direction: DESC}) {
+				nodes {%s
+				}
+				pageInfo { hasNextPage endCursor }
+			}
+		}
+	}`, connection, fields)
+
+	var after *string
+	for {
+		responseData, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+			"owner": c.Owner,
+			"repo":  c.Repo,
+			"first": pageSize,
+			"after": after,
+		})
+		if err != nil {
+			return err
+		}
+		var response struct {
+			Data struct {
+				Repository map[string]struct {
+					Nodes    json.RawMessage `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"repository"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", connection, err)
+		}
+		page := response.Data.Repository[connection]
+		lastUpdated, err := decode(page.Nodes)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", connection, err)
+		}
+		if !page.PageInfo.HasNextPage || lastUpdated == "" {
+			return nil
+		}
+		if updatedAt, err := time.Parse(time.RFC3339, lastUpdated); err == nil && updatedAt.Before(since) {
+			return nil
+		}
+		cursor := page.PageInfo.EndCursor
+		after = &cursor
+	}
+}
+
+// fetchExportData fetches the PRs and issues updated since the given time
+func (c *GitHubClient) fetchExportData(since time.Time) ([]exportPR, []exportIssue, error) {
+	updatedSince := func(updatedAt string) bool {
+		t, err := time.Parse(time.RFC3339, updatedAt)
+		return err == nil && !t.Before(since)
+	}
+
+	var prs []exportPR
+	err := c.fetchExportPage("pullRequests", exportPRFields, 25, since, func(nodes json.RawMessage) (string, error) {
+		var page []exportPR
+		if err := json.Unmarshal(nodes, &page); err != nil || len(page) == 0 {
+			return "", err
+		}
+		for _, pr := range page {
+			if updatedSince(pr.UpdatedAt) {
+				prs = append(prs, pr)
+			}
+		}
+		return page[len(page)-1].UpdatedAt, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var issues []exportIssue
+	err = c.fetchExportPage("issues", exportIssueFields, 100, since, func(nodes json.RawMessage) (string, error) {
+		var page []exportIssue
+		if err := json.Unmarshal(nodes, &page); err != nil || len(page) == 0 {
+			return "", err
+		}
+		for _, issue := range page {
+			if updatedSince(issue.UpdatedAt) {
+				issues = append(issues, issue)
+			}
+		}
+		return page[len(page)-1].UpdatedAt, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return prs, issues, nil
+}
+
+// sqlValue renders a Go value as a SQLite literal: strings are quoted, empty
+// strings and nil pointers are NULL, and booleans are 0/1
+func sqlValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		if v == "" {
+			return "NULL"
+		}
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case int:
+		return strconv.Itoa(v)
+	case *int:
+		if v == nil {
+			return "NULL"
+		}
+		return strconv.Itoa(*v)
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	}
+	panic(fmt.Sprintf("sqlValue: unsupported type %T", v))
+}
+
+// writeSQLInsert writes one INSERT OR REPLACE statement
+func writeSQLInsert(w io.Writer, table string, columns []string, values ...interface{}) {
+	literals := make([]string, len(values))
+	for i, v := range values {
+		literals[i] = sqlValue(v)
+	}
+	fmt.Fprintf(w, "INSERT OR REPLACE INTO %s (%s) VALUES (%s);\n", table, strings.Join(columns, ", "), strings.Join(literals, ", "))
+}
+
+// writeExportSQL writes the schema and the exported rows as one transaction.
+// Child rows of each exported PR and issue are replaced, not merged.
+func writeExportSQL(w io.Writer, repository string, since, exportedAt time.Time, prs []exportPR, issues []exportIssue) {
+	fmt.Fprint(w, sqliteSchema)
+	fmt.Fprintln(w, "BEGIN;")
+
+	prColumns := []string{"number", "title", "state", "is_draft", "author", "base_ref", "head_ref", "additions", "deletions", "changed_files", "created_at", "updated_at", "merged_at", "closed_at"}
+	for _, pr := range prs {
+		fmt.Fprintf(w, "DELETE FROM reviews WHERE pr_number = %d;\n", pr.Number)
+		fmt.Fprintf(w, "DELETE FROM review_threads WHERE pr_number = %d;\n", pr.Number)
+		fmt.Fprintf(w, "DELETE FROM labels WHERE item_type = 'pr' AND item_number = %d;\n", pr.Number)
+		writeSQLInsert(w, "pull_requests", prColumns, pr.Number, pr.Title, pr.State, pr.IsDraft, pr.Author.login(),
+			pr.BaseRefName, pr.HeadRefName, pr.Additions, pr.Deletions, pr.ChangedFiles, pr.CreatedAt, pr.UpdatedAt, pr.MergedAt, pr.ClosedAt)
+		for _, review := range pr.Reviews.Nodes {
+			writeSQLInsert(w, "reviews", []string{"id", "pr_number", "author", "state", "submitted_at"},
+				review.ID, pr.Number, review.Author.login(), review.State, review.SubmittedAt)
+		}
+		for _, thread := range pr.ReviewThreads.Nodes {
+			author, createdAt := "", ""
+			if len(thread.Comments.Nodes) > 0 {
+				author, createdAt = thread.Comments.Nodes[0].Author.login(), thread.Comments.Nodes[0].CreatedAt
+			}
+			writeSQLInsert(w, "review_threads", []string{"id", "pr_number", "path", "line", "is_resolved", "is_outdated", "author", "comments", "created_at"},
+				thread.ID, pr.Number, thread.Path, thread.Line, thread.IsResolved, thread.IsOutdated, author, thread.Comments.TotalCount, createdAt)
+		}
+		for _, label := range pr.Labels.Nodes {
+			writeSQLInsert(w, "labels", []string{"item_type", "item_number", "name"}, "pr", pr.Number, label.Name)
+		}
+	}
+
+	issueColumns := []string{"number", "title", "state", "state_reason", "author", "milestone", "comments", "created_at", "updated_at", "closed_at"}
+	for _, issue := range issues {
+		milestone := ""
+		if issue.Milestone != nil {
+			milestone = issue.Milestone.Title
+		}
+		fmt.Fprintf(w, "DELETE FROM labels WHERE item_type = 'issue' AND item_number = %d;\n", issue.Number)
+		writeSQLInsert(w, "issues", issueColumns, issue.Number, issue.Title, issue.State, issue.StateReason, issue.Author.login(),
+			milestone, issue.Comments.TotalCount, issue.CreatedAt, issue.UpdatedAt, issue.ClosedAt)
+		for _, label := range issue.Labels.Nodes {
+			writeSQLInsert(w, "labels", []string{"item_type", "item_number", "name"}, "issue", issue.Number, label.Name)
+		}
+	}
+
+	meta := []string{"key", "value"}
+	writeSQLInsert(w, "export_meta", meta, "repository", repository)
+	writeSQLInsert(w, "export_meta", meta, "since", since.UTC().Format(time.RFC3339))
+	writeSQLInsert(w, "export_meta", meta, "exported_at", exportedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintln(w, "COMMIT;")
+}
+
+func exportSQLite(cmd *cobra.Command, args []string) error {
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("failed to get 'output' flag: %w", err)
+	}
+	sinceValue, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return fmt.Errorf("failed to get 'since' flag: %w", err)
+	}
+	sqliteCommand, err := cmd.Flags().GetString("sqlite")
+	if err != nil {
+		return fmt.Errorf("failed to get 'sqlite' flag: %w", err)
+	}
+
+	now := time.Now()
+	since, err := parseSince(sinceValue, now)
+	if err != nil {
+		return err
+	}
+
+	// Fail before fetching when the database cannot be written
+	scriptOnly := strings.HasSuffix(strings.ToLower(output), ".sql")
+	if !scriptOnly {
+		if sqliteCommand, err = exec.LookPath(sqliteCommand); err != nil {
+			return fmt.Errorf("sqlite3 command not found (%w); install it, or write a SQL script with --output repo.sql", err)
+		}
+	}
+
+	client := newCommandClient(cmd)
+	prs, issues, err := client.fetchExportData(since)
+	if err != nil {
+		return fmt.Errorf("failed to fetch repository activity: %w", err)
+	}
+
+	var script bytes.Buffer
+	writeExportSQL(&script, client.Owner+"/"+client.Repo, since, now, prs, issues)
+
+	if scriptOnly {
+		if err := os.WriteFile(output, script.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write SQL script: %w", err)
+		}
+	} else {
+		sqliteCmd := exec.Command(sqliteCommand, "-bail", output)
+		sqliteCmd.Stdin = &script
+		var stderr bytes.Buffer
+		sqliteCmd.Stderr = &stderr
+		if err := sqliteCmd.Run(); err != nil {
+			return fmt.Errorf("sqlite3 failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+	}
+
+	threads, reviews := 0, 0
+	for _, pr := range prs {
+		threads += len(pr.ReviewThreads.Nodes)
+		reviews += len(pr.Reviews.Nodes)
+	}
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{
+		"sqliteExport": map[string]interface{}{
+			"output":        output,
+			"scriptOnly":    scriptOnly,
+			"since":         since.UTC().Format(time.RFC3339),
+			"pullRequests":  len(prs),
+			"reviews":       reviews,
+			"reviewThreads": threads,
+			"issues":        len(issues),
+		},
+	})
+}
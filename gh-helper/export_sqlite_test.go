@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSQLValue(t *testing.T) {
+	line := 12
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{"it's", "'it''s'"},
+		{"", "NULL"},
+		{42, "42"},
+		{&line, "12"},
+		{(*int)(nil), "NULL"},
+		{true, "1"},
+		{false, "0"},
+	}
+	for _, tt := range tests {
+		if got := sqlValue(tt.value); got != tt.want {
+			t.Errorf("sqlValue(%#v) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func testExportData() ([]exportPR, []exportIssue) {
+	pr := exportPR{Number: 7, Title: "Fix 'quotes'", State: "MERGED", Author: &actorRef{Login: "alice"}, MergedAt: "2025-01-02T00:00:00Z"}
+	pr.Labels.Nodes = append(pr.Labels.Nodes, struct {
+		Name string `json:"name"`
+	}{"bug"})
+	pr.Reviews.Nodes = append(pr.Reviews.Nodes, struct {
+		ID          string    `json:"id"`
+		Author      *actorRef `json:"author"`
+		State       string    `json:"state"`
+		SubmittedAt string    `json:"submittedAt"`
+	}{ID: "PRR_1", State: "APPROVED"})
+	issue := exportIssue{Number: 8, Title: "Crash", State: "OPEN"}
+	return []exportPR{pr}, []exportIssue{issue}
+}
+
+func TestWriteExportSQL(t *testing.T) {
+	prs, issues := testExportData()
+	var script bytes.Buffer
+	writeExportSQL(&script, "o/r", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), prs, issues)
+
+	for _, want := range []string{
+		"CREATE TABLE IF NOT EXISTS pull_requests",
+		"DELETE FROM reviews WHERE pr_number = 7;",
+		"'Fix ''quotes''', 'MERGED', 0, 'alice'",
+		"INSERT OR REPLACE INTO reviews (id, pr_number, author, state, submitted_at) VALUES ('PRR_1', 7, NULL, 'APPROVED', NULL);",
+		"VALUES ('pr', 7, 'bug');",
+		"INSERT OR REPLACE INTO issues",
+		"VALUES ('since', '2025-01-01T00:00:00Z');",
+	} {
+		if !strings.Contains(script.String(), want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+	if !strings.HasSuffix(script.String(), "COMMIT;\n") {
+		t.Error("script does not end with COMMIT")
+	}
+}
+
+func TestWriteExportSQLLoads(t *testing.T) {
+	sqlite, err := exec.LookPath("sqlite3")
+	if err != nil {
+		t.Skip("sqlite3 not installed")
+	}
+	prs, issues := testExportData()
+	db := filepath.Join(t.TempDir(), "repo.db")
+
+	// Loading twice checks that re-exports upsert instead of failing
+	for i := 0; i < 2; i++ {
+		var script bytes.Buffer
+		writeExportSQL(&script, "o/r", time.Now(), time.Now(), prs, issues)
+		load := exec.Command(sqlite, "-bail", db)
+		load.Stdin = &script
+		if out, err := load.CombinedOutput(); err != nil {
+			t.Fatalf("sqlite3 load %d: %v: %s", i, err, out)
+		}
+	}
+
+	out, err := exec.Command(sqlite, db, "SELECT count(*) FROM pull_requests JOIN reviews ON reviews.pr_number = pull_requests.number JOIN labels ON labels.item_number = pull_requests.number").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "1" {
+		t.Errorf("joined rows = %s, want 1", got)
+	}
+}
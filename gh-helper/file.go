@@ -0,0 +1,430 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/apstndb/gh-dev-tools/pkg/reviewwait"
+	"github.com/spf13/cobra"
+)
+
+var fileCmd = &cobra.Command{
+	Use:   "file",
+	Short: "Repository file context",
+	Long: `Show review context for files in the repository: the pull requests that
+changed them, review discussion on them, and their code owners.`,
+}
+
+var fileHistoryCmd = NewOperationalCommand(
+	"history <path> [flags]",
+	"List recent PRs, review threads, and code owners of a file",
+	`List the most recent pull requests that changed a file on the default
+branch, with the review threads each PR had on that file and the file's
+current code owners, for context when reviewing a new change to it.
+
+PRs are found from the default branch's commit history for the path, newest
+first. Code owners come from the CODEOWNERS file on the default branch
+(.github/, root, or docs/, as GitHub resolves it); the last matching rule wins.
+
+Examples:
+  # Recent history of a hot file
+  gh-helper file history gh-helper/main.go
+
+  # Unresolved discussions only
+  gh-helper file history pkg/ghclient/client.go --limit 50 --jq '.fileHistory.pullRequests[].threads[] | select(.resolved | not)'`,
+	fileHistory,
+)
+
+func init() {
+	fileHistoryCmd.Args = cobra.ExactArgs(1)
+	fileHistoryCmd.Flags().Int("limit", 20, "Maximum number of pull requests to list")
+
+	fileCmd.AddCommand(fileHistoryCmd)
+	rootCmd.AddCommand(fileCmd)
+}
+
+// codeownersLocations are the CODEOWNERS paths in GitHub's lookup order
+var codeownersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// FileHistory is the output of 'file history'
+type FileHistory struct {
+	Path           string          `json:"path"`
+	DefaultBranch  string          `json:"defaultBranch"`
+	CodeOwners     []string        `json:"codeOwners"`
+	CodeOwnersRule string          `json:"codeOwnersRule,omitempty"` // matching CODEOWNERS pattern
+	CodeOwnersFile string          `json:"codeOwnersFile,omitempty"`
+	PullRequests   []FileHistoryPR `json:"pullRequests"`
+}
+
+// FileHistoryPR is a pull request that changed the file
+type FileHistoryPR struct {
+	Number   int                 `json:"number"`
+	Title    string              `json:"title"`
+	Author   string              `json:"author"`
+	MergedAt string              `json:"mergedAt,omitempty"`
+	URL      string              `json:"url"`
+	Commits  []string            `json:"commits"` // short SHAs touching the file
+	Threads  []FileHistoryThread `json:"threads"`
+}
+
+// FileHistoryThread is a review thread on the file in a pull request
+type FileHistoryThread struct {
+	ID       string `json:"id"`
+	Line     int    `json:"line,omitempty"`
+	Resolved bool   `json:"resolved"`
+	Outdated bool   `json:"outdated,omitempty"`
+	Author   string `json:"author"`
+	Comment  string `json:"comment"` // first line of the opening comment
+	Comments int    `json:"comments"`
+	URL      string `json:"url"`
+}
+
+// fileHistoryCommit is a default branch commit touching the file
+type fileHistoryCommit struct {
+	OID                    string `json:"oid"`
+	AssociatedPullRequests struct {
+		Nodes []struct {
+			Number      int       `json:"number"`
+			Title       string    `json:"title"`
+			URL         string    `json:"url"`
+			MergedAt    string    `json:"mergedAt"`
+			BaseRefName string    `json:"baseRefName"`
+			Author      *actorRef `json:"author"`
+		} `json:"nodes"`
+	} `json:"associatedPullRequests"`
+}
+
+// codeownersRule is one parsed CODEOWNERS line
+type codeownersRule struct {
+	Pattern string
+	Owners  []string
+	regexp  *regexp.Regexp
+}
+
+// parseCodeowners parses CODEOWNERS content, skipping comments and invalid lines
+func parseCodeowners(text string) []codeownersRule {
+	var rules []codeownersRule
+	for _, line := range strings.Split(text, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 && (i == 0 || line[i-1] != '\\') {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		re, err := codeownersPatternRegexp(fields[0])
+		if err != nil {
+			continue
+		}
+		rules = append(rules, codeownersRule{Pattern: fields[0], Owners: fields[1:], regexp: re})
+	}
+	return rules
+}
+
+// codeownersPatternRegexp converts a gitignore-style CODEOWNERS pattern to a
+// regexp. Patterns with a leading or inner slash are anchored to the root;
+// a pattern matching a directory also matches everything below it.
+func codeownersPatternRegexp(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.Trim(pattern, "/")
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	if dirOnly {
+		expr.WriteString("/.*$")
+	} else {
+		expr.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(expr.String())
+}
+
+// matchCodeowners returns the owners of path from the last matching rule
+func matchCodeowners(rules []codeownersRule, path string) (owners []string, pattern string) {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].regexp.MatchString(path) {
+			return rules[i].Owners, rules[i].Pattern
+		}
+	}
+	return nil, ""
+}
+
+// collectHistoryPRs groups commits (newest first) by the PR merged into the
+// default branch that introduced them, keeping the first limit PRs
+func collectHistoryPRs(commits []fileHistoryCommit, defaultBranch string, limit int) []FileHistoryPR {
+	var prs []FileHistoryPR
+	index := map[int]int{}
+	for _, commit := range commits {
+		for _, pr := range commit.AssociatedPullRequests.Nodes {
+			if pr.MergedAt == "" || (defaultBranch != "" && pr.BaseRefName != defaultBranch) {
+				continue
+			}
+			if i, seen := index[pr.Number]; seen {
+				prs[i].Commits = append(prs[i].Commits, reviewwait.ShortSHA(commit.OID))
+				break
+			}
+			if len(prs) == limit {
+				break
+			}
+			index[pr.Number] = len(prs)
+			prs = append(prs, FileHistoryPR{
+				Number:   pr.Number,
+				Title:    pr.Title,
+				Author:   pr.Author.login(),
+				MergedAt: pr.MergedAt,
+				URL:      pr.URL,
+				Commits:  []string{reviewwait.ShortSHA(commit.OID)},
+				Threads:  []FileHistoryThread{},
+			})
+			break
+		}
+	}
+	return prs
+}
+
+// firstLine returns the first non-empty line of s
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// getFileCommits fetches the default branch history of path and the CODEOWNERS files
+func (c *GitHubClient) getFileCommits(path string, first int) (defaultBranch string, commits []fileHistoryCommit, codeownersFile, codeowners string, err error) {
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString(`
+	query($owner: String!, $repo: String!, $path: String!, $first: Int!) {
+	  repository(owner: $owner, name: $repo) {
+	    defaultBranchRef {
+	      name
+	      target {
+	        ... on Commit {
+	          history(first: $first, path: $path) {
+	            nodes {
+	              oid
+	              associatedPullRequests(first: 5) {
+	                nodes { number title url mergedAt baseRefName author { login } }
+	              }
+	            }
+	          }
+	        }
+	      }
+	    }
+`)
+	for i, location := range codeownersLocations {
+		fmt.Fprintf(&queryBuilder, "    codeowners%d: object(expression: %q) { ... on Blob { text } }\n", i, "HEAD:"+location)
+	}
+	queryBuilder.WriteString("  }\n}")
+
+	result, err := c.RunGraphQLQueryWithVariables(queryBuilder.String(), map[string]interface{}{
+		"owner": c.Owner,
+		"repo":  c.Repo,
+		"path":  path,
+		"first": first,
+	})
+	if err != nil {
+		return "", nil, "", "", err
+	}
+
+	var response struct {
+		Data struct {
+			Repository map[string]json.RawMessage `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return "", nil, "", "", fmt.Errorf("failed to parse history response: %w", err)
+	}
+
+	var branch *struct {
+		Name   string `json:"name"`
+		Target struct {
+			History struct {
+				Nodes []fileHistoryCommit `json:"nodes"`
+			} `json:"history"`
+		} `json:"target"`
+	}
+	if raw, ok := response.Data.Repository["defaultBranchRef"]; ok {
+		if err := json.Unmarshal(raw, &branch); err != nil {
+			return "", nil, "", "", fmt.Errorf("failed to parse history response: %w", err)
+		}
+	}
+	if branch == nil {
+		return "", nil, "", "", fmt.Errorf("repository %s/%s has no default branch", c.Owner, c.Repo)
+	}
+
+	for i, location := range codeownersLocations {
+		var blob *struct {
+			Text string `json:"text"`
+		}
+		if raw, ok := response.Data.Repository[fmt.Sprintf("codeowners%d", i)]; ok {
+			if err := json.Unmarshal(raw, &blob); err == nil && blob != nil {
+				codeownersFile, codeowners = location, blob.Text
+				break
+			}
+		}
+	}
+	return branch.Name, branch.Target.History.Nodes, codeownersFile, codeowners, nil
+}
+
+// getFileThreads fetches the review threads on path for each PR in one aliased query
+func (c *GitHubClient) getFileThreads(path string, prs []FileHistoryPR) (map[int][]FileHistoryThread, error) {
+	threads := map[int][]FileHistoryThread{}
+	if len(prs) == 0 {
+		return threads, nil
+	}
+
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("query($owner: String!, $repo: String!) {\n  repository(owner: $owner, name: $repo) {\n")
+	for i, pr := range prs {
+		fmt.Fprintf(&queryBuilder, `    pr%d: pullRequest(number: %d) {
+      reviewThreads(first: 100) {
+        nodes {
+          id path line isResolved isOutdated
+          comments(first: 1) { totalCount nodes { author { login } body url } }
+        }
+      }
+    }
+`, i, pr.Number)
+	}
+	queryBuilder.WriteString("  }\n}")
+
+	result, err := c.RunGraphQLQueryWithVariables(queryBuilder.String(), map[string]interface{}{
+		"owner": c.Owner,
+		"repo":  c.Repo,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository map[string]*struct {
+				ReviewThreads struct {
+					Nodes []struct {
+						ID         string `json:"id"`
+						Path       string `json:"path"`
+						Line       int    `json:"line"`
+						IsResolved bool   `json:"isResolved"`
+						IsOutdated bool   `json:"isOutdated"`
+						Comments   struct {
+							TotalCount int `json:"totalCount"`
+							Nodes      []struct {
+								Author *actorRef `json:"author"`
+								Body   string    `json:"body"`
+								URL    string    `json:"url"`
+							} `json:"nodes"`
+						} `json:"comments"`
+					} `json:"nodes"`
+				} `json:"reviewThreads"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse review threads response: %w", err)
+	}
+
+	for i, pr := range prs {
+		node := response.Data.Repository[fmt.Sprintf("pr%d", i)]
+		if node == nil {
+			continue
+		}
+		for _, thread := range node.ReviewThreads.Nodes {
+			if thread.Path != path {
+				continue
+			}
+			item := FileHistoryThread{
+				ID:       thread.ID,
+				Line:     thread.Line,
+				Resolved: thread.IsResolved,
+				Outdated: thread.IsOutdated,
+				Comments: thread.Comments.TotalCount,
+			}
+			if len(thread.Comments.Nodes) > 0 {
+				first := thread.Comments.Nodes[0]
+				item.Author = first.Author.login()
+				item.Comment = firstLine(first.Body)
+				item.URL = first.URL
+			}
+			threads[pr.Number] = append(threads[pr.Number], item)
+		}
+	}
+	return threads, nil
+}
+
+func fileHistory(cmd *cobra.Command, args []string) error {
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return fmt.Errorf("failed to get 'limit' flag: %w", err)
+	}
+	if limit <= 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+
+	path := strings.TrimPrefix(strings.TrimPrefix(args[0], "./"), "/")
+	if path == "" {
+		return fmt.Errorf("path is required")
+	}
+
+	client := newCommandClient(cmd)
+	// A PR usually lands several commits on a file; fetch extra history to fill --limit PRs
+	defaultBranch, commits, codeownersFile, codeowners, err := client.getFileCommits(path, min(limit*3, 100))
+	if err != nil {
+		return fmt.Errorf("failed to fetch history of %s: %w", path, err)
+	}
+
+	prs := collectHistoryPRs(commits, defaultBranch, limit)
+	threads, err := client.getFileThreads(path, prs)
+	if err != nil {
+		return fmt.Errorf("failed to fetch review threads: %w", err)
+	}
+	for i := range prs {
+		if prThreads, ok := threads[prs[i].Number]; ok {
+			prs[i].Threads = prThreads
+		}
+	}
+
+	history := FileHistory{
+		Path:          path,
+		DefaultBranch: defaultBranch,
+		CodeOwners:    []string{},
+		PullRequests:  prs,
+	}
+	if history.PullRequests == nil {
+		history.PullRequests = []FileHistoryPR{}
+	}
+	if codeowners != "" {
+		owners, pattern := matchCodeowners(parseCodeowners(codeowners), path)
+		history.CodeOwnersFile = codeownersFile
+		history.CodeOwnersRule = pattern
+		if owners != nil {
+			history.CodeOwners = owners
+		}
+	}
+
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"fileHistory": history})
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/apstndb/gh-dev-tools/pkg/reviewwait"
+)
+
+func TestMatchCodeowners(t *testing.T) {
+	rules := parseCodeowners(`# Default owners
+*                @org/core
+*.md             @docs-team
+/gh-helper/      @alice
+docs/            @docs-team
+pkg/**/client.go @bob @carol # clients
+`)
+
+	tests := []struct {
+		path        string
+		wantOwners  []string
+		wantPattern string
+	}{
+		{"go.mod", []string{"@org/core"}, "*"},
+		{"README.md", []string{"@docs-team"}, "*.md"},
+		{"gh-helper/README.md", []string{"@alice"}, "/gh-helper/"},
+		{"gh-helper/main.go", []string{"@alice"}, "/gh-helper/"},
+		{"docs/guide/intro.txt", []string{"@docs-team"}, "docs/"},
+		{"pkg/ghclient/client.go", []string{"@bob", "@carol"}, "pkg/**/client.go"},
+		{"pkg/client.go", []string{"@bob", "@carol"}, "pkg/**/client.go"},
+		{"pkg/ghclient/other.go", []string{"@org/core"}, "*"},
+	}
+	for _, tt := range tests {
+		owners, pattern := matchCodeowners(rules, tt.path)
+		if !reflect.DeepEqual(owners, tt.wantOwners) || pattern != tt.wantPattern {
+			t.Errorf("matchCodeowners(%q) = %v, %q; want %v, %q", tt.path, owners, pattern, tt.wantOwners, tt.wantPattern)
+		}
+	}
+
+	if owners, pattern := matchCodeowners(parseCodeowners("/src/ @x"), "lib/src/a.go"); owners != nil || pattern != "" {
+		t.Errorf("anchored pattern matched nested path: %v, %q", owners, pattern)
+	}
+}
+
+func TestCollectHistoryPRs(t *testing.T) {
+	var commits []fileHistoryCommit
+	data := `[
+	  {"oid": "aaaaaaaaaa", "associatedPullRequests": {"nodes": [{"number": 3, "mergedAt": "2026-03-01T00:00:00Z", "baseRefName": "main", "author": {"login": "alice"}}]}},
+	  {"oid": "bbbbbbbbbb", "associatedPullRequests": {"nodes": [{"number": 3, "mergedAt": "2026-03-01T00:00:00Z", "baseRefName": "main"}]}},
+	  {"oid": "cccccccccc", "associatedPullRequests": {"nodes": [
+	    {"number": 9, "mergedAt": "", "baseRefName": "main"},
+	    {"number": 2, "mergedAt": "2026-02-01T00:00:00Z", "baseRefName": "main"}]}},
+	  {"oid": "dddddddddd", "associatedPullRequests": {"nodes": [{"number": 5, "mergedAt": "2026-01-15T00:00:00Z", "baseRefName": "release"}]}},
+	  {"oid": "eeeeeeeeee", "associatedPullRequests": {"nodes": [{"number": 1, "mergedAt": "2026-01-01T00:00:00Z", "baseRefName": "main"}]}},
+	  {"oid": "ffffffffff", "associatedPullRequests": {"nodes": []}}
+	]`
+	if err := json.Unmarshal([]byte(data), &commits); err != nil {
+		t.Fatal(err)
+	}
+
+	prs := collectHistoryPRs(commits, "main", 2)
+	if len(prs) != 2 || prs[0].Number != 3 || prs[1].Number != 2 {
+		t.Fatalf("collectHistoryPRs() = %+v, want PRs 3 and 2", prs)
+	}
+	if want := []string{reviewwait.ShortSHA("aaaaaaaaaa"), reviewwait.ShortSHA("bbbbbbbbbb")}; !reflect.DeepEqual(prs[0].Commits, want) {
+		t.Errorf("PR 3 commits = %v, want %v", prs[0].Commits, want)
+	}
+	if prs[0].Author != "alice" {
+		t.Errorf("PR 3 author = %q, want alice", prs[0].Author)
+	}
+
+	if prs := collectHistoryPRs(commits, "main", 10); len(prs) != 3 {
+		t.Errorf("collectHistoryPRs() without limit returned %d PRs, want 3 (unmerged and non-default base skipped)", len(prs))
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// prTarget is a PR a mutating command is about to touch, directly or through
+// one of its review threads
+type prTarget struct {
+	Ref    string `json:"ref"` // what the command was given: thread ID or PR number
+	Number int    `json:"number"`
+	State  string `json:"state"` // OPEN, CLOSED, or MERGED
+}
+
+// StaleTargetError reports PRs that are no longer open
+type StaleTargetError struct {
+	Targets []prTarget
+}
+
+func (e *StaleTargetError) Error() string {
+	refs := make([]string, len(e.Targets))
+	for i, target := range e.Targets {
+		if target.Ref == fmt.Sprint(target.Number) {
+			refs[i] = fmt.Sprintf("PR #%d is %s", target.Number, strings.ToLower(target.State))
+		} else {
+			refs[i] = fmt.Sprintf("%s (PR #%d) is %s", target.Ref, target.Number, strings.ToLower(target.State))
+		}
+	}
+	return fmt.Sprintf("stale target: %s; use --force to operate on it anyway", strings.Join(refs, ", "))
+}
+
+// ErrorDetails adds the stale targets to the JSON error envelope
+func (e *StaleTargetError) ErrorDetails() map[string]interface{} {
+	return map[string]interface{}{"staleTargets": e.Targets}
+}
+
+// staleTargets returns the targets whose PR is not open
+func staleTargets(targets []prTarget) []prTarget {
+	var stale []prTarget
+	for _, target := range targets {
+		if target.State != "OPEN" {
+			stale = append(stale, target)
+		}
+	}
+	return stale
+}
+
+// addForceFlag adds the --force flag that bypasses the stale target check
+func addForceFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("force", false, "Operate even if the PR is already merged or closed")
+}
+
+// checkFreshTargets fails with exit code 6 when a target PR is merged or
+// closed, unless --force is given. lookup is only called without --force.
+func checkFreshTargets(cmd *cobra.Command, lookup func() ([]prTarget, error)) error {
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return fmt.Errorf("failed to get 'force' flag: %w", err)
+	}
+	if force {
+		return nil
+	}
+	targets, err := lookup()
+	if err != nil {
+		return fmt.Errorf("failed to check PR state: %w", err)
+	}
+	if stale := staleTargets(targets); len(stale) > 0 {
+		return &ExitError{Code: ExitCodeStaleTarget, Err: &StaleTargetError{Targets: stale}}
+	}
+	return nil
+}
+
+// getPRTargetsForNodes looks up the PR state of review threads and pull
+// requests by node ID. Other node types (e.g., issues) are skipped.
+func (c *GitHubClient) getPRTargetsForNodes(ids []string) ([]prTarget, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	query := `
+	query($ids: [ID!]!) {
+		nodes(ids: $ids) {
+			... on PullRequest { id number state }
+			... on PullRequestReviewThread {
+				id
+				pullRequest { number state }
+			}
+		}
+	}`
+	responseData, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{"ids": ids})
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Nodes []*struct {
+				ID          string `json:"id"`
+				Number      int    `json:"number"`
+				State       string `json:"state"`
+				PullRequest *struct {
+					Number int    `json:"number"`
+					State  string `json:"state"`
+				} `json:"pullRequest"`
+			} `json:"nodes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse PR state: %w", err)
+	}
+
+	var targets []prTarget
+	for _, node := range response.Data.Nodes {
+		switch {
+		case node == nil:
+		case node.PullRequest != nil:
+			targets = append(targets, prTarget{Ref: node.ID, Number: node.PullRequest.Number, State: node.PullRequest.State})
+		case node.State != "":
+			targets = append(targets, prTarget{Ref: fmt.Sprint(node.Number), Number: node.Number, State: node.State})
+		}
+	}
+	return targets, nil
+}
+
+// getPRTarget looks up the state of a PR by number
+func (c *GitHubClient) getPRTarget(prNumber string) ([]prTarget, error) {
+	number, err := strconv.Atoi(prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PR number %q: %w", prNumber, err)
+	}
+	query := `
+	query($owner: String!, $repo: String!, $number: Int!) {
+		repository(owner: $owner, name: $repo) {
+			pullRequest(number: $number) { number state }
+		}
+	}`
+	responseData, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+		"owner":  c.Owner,
+		"repo":   c.Repo,
+		"number": number,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository struct {
+				PullRequest *prTarget `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse PR state: %w", err)
+	}
+	if response.Data.Repository.PullRequest == nil {
+		return nil, fmt.Errorf("PR #%d not found", number)
+	}
+	target := *response.Data.Repository.PullRequest
+	target.Ref = prNumber
+	return []prTarget{target}, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestStaleTargets(t *testing.T) {
+	targets := []prTarget{
+		{Ref: "PRRT_open", Number: 1, State: "OPEN"},
+		{Ref: "PRRT_merged", Number: 2, State: "MERGED"},
+		{Ref: "3", Number: 3, State: "CLOSED"},
+	}
+	stale := staleTargets(targets)
+	if len(stale) != 2 || stale[0].Number != 2 || stale[1].Number != 3 {
+		t.Fatalf("staleTargets() = %+v, want PRs 2 and 3", stale)
+	}
+
+	err := &StaleTargetError{Targets: stale}
+	want := "stale target: PRRT_merged (PR #2) is merged, PR #3 is closed; use --force to operate on it anyway"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestCheckFreshTargets(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "reply"}
+		addForceFlag(cmd)
+		return cmd
+	}
+	merged := func() ([]prTarget, error) {
+		return []prTarget{{Ref: "42", Number: 42, State: "MERGED"}}, nil
+	}
+
+	err := checkFreshTargets(newCmd(), merged)
+	var staleErr *StaleTargetError
+	if !errors.As(err, &staleErr) || exitCodeOf(err) != ExitCodeStaleTarget {
+		t.Fatalf("checkFreshTargets() = %v, want StaleTargetError with exit code %d", err, ExitCodeStaleTarget)
+	}
+
+	var out bytes.Buffer
+	writeCommandError(&out, &CommandError{Command: "gh-helper threads reply", Format: FormatJSON, Err: err})
+	if want := `"staleTargets":[{"ref":"42","number":42,"state":"MERGED"}]`; !strings.Contains(out.String(), want) {
+		t.Errorf("writeCommandError() = %q, want it to contain %q", out.String(), want)
+	}
+
+	forced := newCmd()
+	if err := forced.Flags().Set("force", "true"); err != nil {
+		t.Fatal(err)
+	}
+	called := false
+	if err := checkFreshTargets(forced, func() ([]prTarget, error) { called = true; return merged() }); err != nil || called {
+		t.Errorf("checkFreshTargets(--force) = %v (lookup called: %v), want nil without lookup", err, called)
+	}
+}
@@ -1,70 +1,37 @@
 package main
 
 import (
-	"bytes"
-	"crypto/tls"
-	"encoding/json" // Still needed for json.RawMessage type
 	"fmt"
-	"log/slog"
-	"net/http"
 	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
-	"sync"
-	"time"
-	
-	"golang.org/x/net/http2"
+
+	"github.com/apstndb/gh-dev-tools/pkg/ghclient"
 )
 
-// GitHubClient provides common GitHub operations with token caching
+// GitHubClient provides common GitHub operations with token caching.
+// Requests go through the shared transport in pkg/ghclient.
 type GitHubClient struct {
 	Owner        string
 	Repo         string
-	httpClient   *http.Client
+	api          *ghclient.Client // API transport shared with other tools
 	repositoryID string // Cached repository ID (immutable for repo lifetime)
+	resolver     *Resolver // Name-to-ID resolver with per-client cache
+	orgScoped    bool      // Organization-wide client without a repository
 }
 
-// Global shared client for HTTP/2 connection reuse and keep-alive optimization
-var (
-	sharedHTTPClient *http.Client
-	clientOnce       sync.Once
-)
-
-
 // Repository IDs are immutable for the lifetime of a repository
 // - Renaming a repo preserves the ID
 // - Only deletion+recreation generates a new ID (extremely rare in practice)
 // - No TTL needed for single command execution lifecycle
 
-// getOptimizedHTTPClient returns a shared HTTP client optimized for GitHub API
-// Features: HTTP/2, connection pooling, keep-alive, proper timeouts
-func getOptimizedHTTPClient() *http.Client {
-	clientOnce.Do(func() {
-		// Create transport with HTTP/2 support and connection pooling
-		transport := &http.Transport{
-			MaxIdleConns:        100,
-			MaxIdleConnsPerHost: 10,
-			IdleConnTimeout:     90 * time.Second,
-			TLSHandshakeTimeout: 10 * time.Second,
-			TLSClientConfig: &tls.Config{
-				NextProtos: []string{"h2", "http/1.1"}, // Prefer HTTP/2
-			},
-		}
-
-		// Configure HTTP/2 (ignore errors - falls back to HTTP/1.1 gracefully)
-		_ = http2.ConfigureTransport(transport)
-
-		sharedHTTPClient = &http.Client{
-			Transport: transport,
-			Timeout:   30 * time.Second,
-		}
-	})
-	return sharedHTTPClient
-}
+// GraphQLError is a single entry of a GraphQL "errors" array
+type GraphQLError = ghclient.GraphQLError
 
 // PRInfo represents basic PR information  
 type PRInfo struct {
+	ID     string `json:"id,omitempty"`
 	Number int    `json:"number"`
 	Title  string `json:"title"`
 	State  string `json:"state"`
@@ -80,9 +47,18 @@ func NewGitHubClient(owner, repo string) *GitHubClient {
 		repo = DefaultRepo
 	}
 	return &GitHubClient{
-		Owner:      owner,
-		Repo:       repo,
-		httpClient: getOptimizedHTTPClient(), // Use shared optimized client
+		Owner: owner,
+		Repo:  repo,
+		api:   ghclient.New(), // Uses the shared optimized HTTP client
+	}
+}
+
+// NewOrgClient creates a client for organization-wide queries not tied to a repository
+func NewOrgClient(org string) *GitHubClient {
+	return &GitHubClient{
+		Owner:     org,
+		api:       ghclient.New(),
+		orgScoped: true,
 	}
 }
 
@@ -95,6 +71,9 @@ func (c *GitHubClient) ValidateClient() error {
 			"  2. Use --owner and --repo flags\n" +
 			"  3. Ensure git remote origin points to a GitHub repository")
 	}
+	if c.orgScoped && c.Owner != "" {
+		return nil
+	}
 	if c.Owner == "" {
 		return fmt.Errorf("GitHub owner not configured. Solutions:\n" +
 			"  1. Run from a git repository with configured remotes\n" +
@@ -108,37 +87,6 @@ func (c *GitHubClient) ValidateClient() error {
 	return nil
 }
 
-// getToken retrieves GitHub token from gh CLI  
-// No caching needed - auth tokens don't invalidate during single command execution
-func getToken() (string, error) {
-	cmd := exec.Command("gh", "auth", "token")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", fmt.Errorf("failed to get GitHub token: %w\n💡 Tip: Run 'gh auth login' to authenticate", err)
-	}
-
-	token := strings.TrimSpace(string(output))
-	if token == "" {
-		return "", fmt.Errorf("empty token returned from gh auth token")
-	}
-
-	return token, nil
-}
-
-// GraphQLRequest represents a GraphQL request payload
-type GraphQLRequest struct {
-	Query     string                 `json:"query"`
-	Variables map[string]interface{} `json:"variables,omitempty"`
-}
-
-// GraphQLResponse represents a GraphQL response
-type GraphQLResponse struct {
-	Data   json.RawMessage `json:"data"`
-	Errors []struct {
-		Message string `json:"message"`
-	} `json:"errors"`
-}
-
 // RunGraphQLQuery executes a GraphQL query using HTTP client (legacy compatibility)
 func (c *GitHubClient) RunGraphQLQuery(query string) ([]byte, error) {
 	return c.RunGraphQLQueryWithVariables(query, nil)
@@ -146,71 +94,39 @@ func (c *GitHubClient) RunGraphQLQuery(query string) ([]byte, error) {
 
 // RunGraphQLQueryWithVariables executes a GraphQL query with variables using optimized HTTP client
 // Optimization details documented in dev-docs/lessons-learned/shell-to-go-migration.md
+// Any GraphQL error fails the whole call; use RunGraphQLQueryPartial to keep partial data.
 func (c *GitHubClient) RunGraphQLQueryWithVariables(query string, variables map[string]interface{}) ([]byte, error) {
-	// Validate client configuration before making API calls
-	if err := c.ValidateClient(); err != nil {
-		return nil, err
-	}
-
-	token, err := getToken()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get GitHub token: %w", err)
-	}
-
-	// Prepare GraphQL request
-	reqPayload := GraphQLRequest{
-		Query:     query,
-		Variables: variables,
-	}
-
-	// Use unified JSON marshaling for GitHub API
-	jsonData, err := FormatJSON.Marshal(reqPayload)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal GraphQL request: %w", err)
-	}
-
-	// Create HTTP request
-	req, err := http.NewRequest("POST", "https://api.github.com/graphql", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "spanner-mycli-dev-tools/1.0")
-
-	// Execute request
-	resp, err := c.httpClient.Do(req)
+	data, graphqlErrors, err := c.RunGraphQLQueryPartial(query, variables)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute GraphQL request: %w", err)
+		return nil, err
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			slog.Warn("failed to close response body", "url", resp.Request.URL.String(), "error", err)
-		}
-	}()
-
-	// Read response
-	var buf bytes.Buffer
-	_, err = buf.ReadFrom(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	if len(graphqlErrors) > 0 {
+		return nil, fmt.Errorf("GraphQL error: %s", graphqlErrors[0].Message)
 	}
+	return data, nil
+}
 
-	// Check HTTP status
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GraphQL request failed with status %d: %s", resp.StatusCode, buf.String())
+// RunGraphQLQueryPartial executes a GraphQL query and returns the response body together
+// with any GraphQL errors instead of failing on the first one. Batched alias queries and
+// mutations use this to report per-alias success and failure from partial data.
+// Transport, HTTP status, and SSO authorization failures are still returned as err.
+func (c *GitHubClient) RunGraphQLQueryPartial(query string, variables map[string]interface{}) ([]byte, []GraphQLError, error) {
+	// Validate client configuration before making API calls
+	if err := c.ValidateClient(); err != nil {
+		return nil, nil, err
 	}
+	return c.api.GraphQLPartial(query, variables)
+}
 
-	// Parse GraphQL response for errors (with JSON marshaler support for json.RawMessage)
-	var graphqlResp GraphQLResponse
-	if err := Unmarshal(buf.Bytes(), &graphqlResp); err == nil {
-		if len(graphqlResp.Errors) > 0 {
-			return nil, fmt.Errorf("GraphQL error: %s", graphqlResp.Errors[0].Message)
-		}
+// RunRESTRequest executes a GitHub REST API request using the shared HTTP client.
+// path is relative to https://api.github.com (e.g., "/repos/owner/repo/compare/a...b").
+// body is marshaled as JSON when non-nil.
+func (c *GitHubClient) RunRESTRequest(method, path string, body interface{}) ([]byte, error) {
+	// Validate client configuration before making API calls
+	if err := c.ValidateClient(); err != nil {
+		return nil, err
 	}
-
-	return buf.Bytes(), nil
+	return c.api.REST(method, path, body)
 }
 
 // CreatePRComment creates a comment on a pull request using GraphQL mutation
@@ -368,6 +284,7 @@ func (c *GitHubClient) CreatePR(opts PRCreateOptions) (*PRInfo, error) {
 	    draft: $draft
 	  }) {
 	    pullRequest {
+	      id
 	      number
 	      title
 	      state
@@ -293,6 +293,19 @@ type TimelineItem struct {
 
 // PRInfo and PRCreateOptions are defined in github.go
 
+// actorRef is an optional GraphQL actor; deleted accounts come back as null
+type actorRef struct {
+	Login string `json:"login"`
+}
+
+// login returns the actor's login, or "" for a null actor
+func (a *actorRef) login() string {
+	if a == nil {
+		return ""
+	}
+	return a.Login
+}
+
 // =============================================================================
 // Review Monitor Types
 // =============================================================================
@@ -13,22 +13,69 @@ type IssueInfo struct {
 	PRs    []PRInfo `json:"prs"`
 }
 
+// LinkedPullRequest is a PR that closes or references an issue
+type LinkedPullRequest struct {
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	IsDraft    bool   `json:"isDraft"`
+	URL        string `json:"url"`
+	Repository string `json:"repository"`
+	WillClose  bool   `json:"willClose"`           // Merging the PR closes the issue
+	Checks     string `json:"checks,omitempty"`    // statusCheckRollup state of the head commit
+	Mergeable  string `json:"mergeable,omitempty"` // MERGEABLE, CONFLICTING, or UNKNOWN
+}
+
+// linkedPRFields selects the PullRequest fields of LinkedPullRequest
+const linkedPRFields = `
+	number
+	title
+	state
+	isDraft
+	url
+	mergeable
+	repository { nameWithOwner }
+	commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }`
+
 // FindPRsForIssue finds all PRs associated with an issue number
 func (c *GitHubClient) FindPRsForIssue(issueNumber int) ([]PRInfo, error) {
+	linked, err := c.FindLinkedPRsForIssue(issueNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var prs []PRInfo
+	for _, pr := range linked {
+		prs = append(prs, PRInfo{
+			Number: pr.Number,
+			Title:  pr.Title,
+			State:  pr.State,
+		})
+	}
+
+	return prs, nil
+}
+
+// FindLinkedPRsForIssue returns all PRs that close the issue (closing keywords or
+// the Development sidebar) or reference it (timeline cross-references), with
+// their state, checks, and mergeability
+func (c *GitHubClient) FindLinkedPRsForIssue(issueNumber int) ([]LinkedPullRequest, error) {
 	query := `
 	query($owner: String!, $repo: String!, $issueNumber: Int!) {
 	  repository(owner: $owner, name: $repo) {
 	    issue(number: $issueNumber) {
 	      number
 	      title
-	      timelineItems(itemTypes: CROSS_REFERENCED_EVENT, last: 20) {
+	      closedByPullRequestsReferences(first: 20, includeClosedPrs: true) {
+	        nodes {` + linkedPRFields + `
+	        }
+	      }
+	      timelineItems(itemTypes: CROSS_REFERENCED_EVENT, last: 50) {
 	        nodes {
 	          ... on CrossReferencedEvent {
+	            willCloseTarget
 	            source {
-	              ... on PullRequest {
-	                number
-	                title
-	                state
+	              ... on PullRequest {` + linkedPRFields + `
 	              }
 	            }
 	          }
@@ -49,19 +96,40 @@ func (c *GitHubClient) FindPRsForIssue(issueNumber int) ([]PRInfo, error) {
 		return nil, fmt.Errorf("failed to fetch issue info: %w", err)
 	}
 
+	type prNode struct {
+		Number     int    `json:"number"`
+		Title      string `json:"title"`
+		State      string `json:"state"`
+		IsDraft    bool   `json:"isDraft"`
+		URL        string `json:"url"`
+		Mergeable  string `json:"mergeable"`
+		Repository struct {
+			NameWithOwner string `json:"nameWithOwner"`
+		} `json:"repository"`
+		Commits struct {
+			Nodes []struct {
+				Commit struct {
+					StatusCheckRollup *struct {
+						State string `json:"state"`
+					} `json:"statusCheckRollup"`
+				} `json:"commit"`
+			} `json:"nodes"`
+		} `json:"commits"`
+	}
+
 	var response struct {
 		Data struct {
 			Repository struct {
 				Issue struct {
-					Number        int    `json:"number"`
-					Title         string `json:"title"`
+					Number                         int    `json:"number"`
+					Title                          string `json:"title"`
+					ClosedByPullRequestsReferences struct {
+						Nodes []prNode `json:"nodes"`
+					} `json:"closedByPullRequestsReferences"`
 					TimelineItems struct {
 						Nodes []struct {
-							Source struct {
-								Number int    `json:"number"`
-								Title  string `json:"title"`
-								State  string `json:"state"`
-							} `json:"source"`
+							WillCloseTarget bool   `json:"willCloseTarget"`
+							Source          prNode `json:"source"`
 						} `json:"nodes"`
 					} `json:"timelineItems"`
 				} `json:"issue"`
@@ -73,15 +141,44 @@ func (c *GitHubClient) FindPRsForIssue(issueNumber int) ([]PRInfo, error) {
 		return nil, fmt.Errorf("failed to parse issue response: %w", err)
 	}
 
-	var prs []PRInfo
-	for _, node := range response.Data.Repository.Issue.TimelineItems.Nodes {
-		if node.Source.Number > 0 {
-			prs = append(prs, PRInfo{
-				Number: node.Source.Number,
-				Title:  node.Source.Title,
-				State:  node.Source.State,
-			})
+	var prs []LinkedPullRequest
+	index := make(map[string]int)
+	add := func(node prNode, willClose bool) {
+		// Cross-reference sources that are issues decode as empty nodes
+		if node.Number == 0 {
+			return
+		}
+		key := fmt.Sprintf("%s#%d", node.Repository.NameWithOwner, node.Number)
+		if i, ok := index[key]; ok {
+			prs[i].WillClose = prs[i].WillClose || willClose
+			return
 		}
+		pr := LinkedPullRequest{
+			Number:     node.Number,
+			Title:      node.Title,
+			State:      node.State,
+			IsDraft:    node.IsDraft,
+			URL:        node.URL,
+			Repository: node.Repository.NameWithOwner,
+			WillClose:  willClose,
+		}
+		// Mergeability is only meaningful while the PR is open
+		if node.State == "OPEN" {
+			pr.Mergeable = node.Mergeable
+		}
+		if len(node.Commits.Nodes) > 0 && node.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+			pr.Checks = node.Commits.Nodes[0].Commit.StatusCheckRollup.State
+		}
+		index[key] = len(prs)
+		prs = append(prs, pr)
+	}
+
+	issue := response.Data.Repository.Issue
+	for _, node := range issue.ClosedByPullRequestsReferences.Nodes {
+		add(node, true)
+	}
+	for _, node := range issue.TimelineItems.Nodes {
+		add(node.Source, node.WillCloseTarget)
 	}
 
 	return prs, nil
@@ -3,9 +3,11 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -37,9 +39,27 @@ Examples:
     --title "Write cache tests" \
     --body "Add unit tests for cache implementation" \
     --parent 123
+
+  # Land in the current sprint's "Todo" column of a project
+  gh-helper issues create --title "Fix flaky test" --project Roadmap --iteration current --status Todo
   
   # Create from file with template
-  gh-helper issues create --body-file issue-template.md --title "Release v2.0"`,
+  gh-helper issues create --body-file issue-template.md --title "Release v2.0"
+
+  # Without the configured defaults
+  gh-helper issues create --title "Spike: new parser" --no-defaults
+
+Defaults from the "issues.defaults" section of the config file (see 'issues
+list --help') are applied unless --no-defaults is given, and the output lists
+what they added under appliedDefaults:
+
+  issues:
+    defaults:
+      labels: [triage]                  # added to every created issue
+      assignCreator: true               # assign yourself when --assignee is not given
+      assignees: [alice]                # or these users (takes precedence over assignCreator)
+      inheritParentLabels: true         # with --parent, add the parent's labels
+      inheritParentLabelsExcept: [epic]`,
 	createIssue,
 )
 
@@ -57,7 +77,13 @@ Examples:
   gh-helper issues show 248 --include-sub
   
   # Include detailed information for each sub-issue (requires additional queries)
-  gh-helper issues show 248 --include-sub --detailed`,
+  gh-helper issues show 248 --include-sub --detailed
+  
+  # Is the issue being worked on? List linked PRs with checks and mergeability
+  gh-helper issues show 248 --include-prs
+
+  # Include the conversation (newest 20 comments by alice in the last week)
+  gh-helper issues show 248 --comments --author alice --since 168h --limit 20`,
 	showIssue,
 )
 
@@ -101,6 +127,9 @@ func init() {
 	createIssueCmd.Flags().StringP("milestone", "m", "", "Assign to milestone")
 	createIssueCmd.Flags().StringP("project", "p", "", "Add to project")
 	createIssueCmd.Flags().Int("parent", 0, "Parent issue number for sub-issue creation")
+	createIssueCmd.Flags().String("iteration", "", "Set the project iteration: current, next, or an iteration title (requires --project)")
+	createIssueCmd.Flags().String("status", "", "Set the project Status column, e.g. Todo (requires --project)")
+	createIssueCmd.Flags().Bool("no-defaults", false, "Do not apply the issues.defaults labels and assignees from the config file")
 
 	// Mark title as required
 	if err := createIssueCmd.MarkFlagRequired("title"); err != nil {
@@ -111,6 +140,11 @@ func init() {
 	// Configure flags for show command
 	showIssueCmd.Flags().Bool("include-sub", false, "Include sub-issues list and statistics")
 	showIssueCmd.Flags().Bool("detailed", false, "Include detailed information for each sub-issue (requires --include-sub)")
+	showIssueCmd.Flags().Bool("include-prs", false, "Include PRs closing or referencing the issue with state, checks, and mergeability")
+	showIssueCmd.Flags().Bool("comments", false, "Include conversation comments (oldest first)")
+	showIssueCmd.Flags().String("author", "", "Only comments by this user (requires --comments)")
+	showIssueCmd.Flags().String("since", "", "Only comments created since RFC 3339 time, YYYY-MM-DD, or duration ago like 48h (requires --comments)")
+	showIssueCmd.Flags().Int("limit", 0, "Only the most recent N matching comments (requires --comments, 0: all)")
 
 	// Configure flags for edit command
 	editIssueCmd.Flags().Int("parent", 0, "Set parent issue number")
@@ -137,7 +171,10 @@ type IssueCreationResult struct {
 	Labels    []string           `json:"labels,omitempty"`
 	Assignees []string           `json:"assignees,omitempty"`
 	Parent    *ParentIssueInfo   `json:"parent,omitempty"`
+	Project   *ProjectItemInfo   `json:"project,omitempty"`
 	CreatedAt string             `json:"createdAt"`
+	// AppliedDefaults lists what the config defaults added
+	AppliedDefaults []AppliedIssueDefault `json:"appliedDefaults,omitempty"`
 }
 
 // ParentIssueInfo represents parent issue information
@@ -180,6 +217,21 @@ func createIssue(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get 'parent' flag: %w", err)
 	}
+	iteration, err := cmd.Flags().GetString("iteration")
+	if err != nil {
+		return fmt.Errorf("failed to get 'iteration' flag: %w", err)
+	}
+	status, err := cmd.Flags().GetString("status")
+	if err != nil {
+		return fmt.Errorf("failed to get 'status' flag: %w", err)
+	}
+	noDefaults, err := cmd.Flags().GetBool("no-defaults")
+	if err != nil {
+		return fmt.Errorf("failed to get 'no-defaults' flag: %w", err)
+	}
+	if (iteration != "" || status != "") && project == "" {
+		return fmt.Errorf("--iteration and --status require --project")
+	}
 
 	// Handle body from file
 	if bodyFile != "" {
@@ -194,7 +246,7 @@ func createIssue(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create GitHub client
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
 
 	// Get repository ID
 	repoID, err := client.GetRepositoryID()
@@ -202,51 +254,116 @@ func createIssue(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get repository ID: %w", err)
 	}
 
-	// Get label IDs if labels are specified
-	var labelIDs []string
-	if len(labels) > 0 {
-		labelMap, err := client.GetLabelIDs(labels)
+	// Apply the config defaults before resolving, so default labels and users are validated too
+	var appliedDefaults []AppliedIssueDefault
+	if !noDefaults {
+		config, err := LoadConfig()
 		if err != nil {
-			return fmt.Errorf("failed to get label IDs: %w", err)
+			return err
+		}
+		defaults := config.Issues.Defaults
+		input := issueDefaultsInput{Labels: labels, Assignees: assignees}
+		if parentNumber > 0 && defaults.needsParentLabels() {
+			parent, err := client.GetLabelableInfo(repoID, "Issue", parentNumber)
+			if err != nil {
+				return fmt.Errorf("failed to get parent issue labels: %w", err)
+			}
+			for _, label := range parent.Labels.Nodes {
+				input.ParentLabels = append(input.ParentLabels, label.Name)
+			}
 		}
-		// Extract the IDs from the map
-		for _, labelName := range labels {
-			if id, ok := labelMap[labelName]; ok {
-				labelIDs = append(labelIDs, id)
-			} else {
-				return fmt.Errorf("label not found: %s", labelName)
+		if defaults.needsCreator(assignees) {
+			if input.Creator, err = client.GetCurrentUser(); err != nil {
+				return fmt.Errorf("failed to get current user: %w", err)
 			}
 		}
+		labels, assignees, appliedDefaults = defaults.apply(input)
 	}
 
-	// Get assignee IDs if assignees are specified
-	var assigneeIDs []string
-	if len(assignees) > 0 {
-		assigneeIDs, err = client.GetUserIDs(assignees)
+	// Resolve all names in one query so every typo is reported before creating anything
+	req := ResolveRequest{Labels: labels, Users: assignees}
+	if milestone != "" {
+		req.Milestones = []string{milestone}
+	}
+	if project != "" {
+		req.Projects = []string{project}
+	}
+	resolved, err := client.Resolver().Resolve(req)
+	if err != nil {
+		return err
+	}
+	if err := resolved.Err(); err != nil {
+		return err
+	}
+	milestoneID, _ := resolved.ID(ResolveMilestones, milestone)
+	projectID, _ := resolved.ID(ResolveProjects, project)
+
+	var fieldUpdates *projectFieldUpdates
+	if iteration != "" || status != "" {
+		fieldUpdates, err = client.resolveProjectFieldUpdates(projectID, iteration, status, time.Now())
 		if err != nil {
-			return fmt.Errorf("failed to get assignee IDs: %w", err)
+			return fmt.Errorf("failed to resolve project fields: %w", err)
 		}
 	}
 
-	// Get milestone ID if specified
-	var milestoneID string
-	if milestone != "" {
-		milestoneID, err = client.GetMilestoneID(milestone)
+	// Create the issue
+	params := IssueCreateParams{
+		RepositoryID: repoID,
+		Title:        title,
+		Body:         body,
+		LabelIDs:     resolved.IDsOf(ResolveLabels, labels),
+		AssigneeIDs:  resolved.IDsOf(ResolveUsers, assignees),
+		MilestoneID:  milestoneID,
+	}
+	if projectID != "" {
+		params.ProjectIDs = []string{projectID}
+	}
+
+	issueID, result, err := client.CreateIssue(params)
+	if err != nil {
+		return err
+	}
+	result.AppliedDefaults = appliedDefaults
+
+	// If parent is specified, create sub-issue relationship
+	if parentNumber > 0 {
+		result.Parent, err = client.AddSubIssue(issueID, parentNumber)
 		if err != nil {
-			return fmt.Errorf("failed to get milestone ID: %w", err)
+			// Don't fail the entire operation, just warn
+			WarningMsg("Failed to create sub-issue relationship: %v", err).Print(cmd)
 		}
 	}
 
-	// Get project ID if specified
-	var projectID string
-	if project != "" {
-		projectID, err = client.GetProjectID(project)
+	// Set the iteration and status of the project item created with the issue
+	if fieldUpdates != nil {
+		result.Project, err = client.applyProjectFieldUpdates(projectID, project, issueID, fieldUpdates)
 		if err != nil {
-			return fmt.Errorf("failed to get project ID: %w", err)
+			// The issue exists; report the field failure without failing the command
+			WarningMsg("Failed to set project fields: %v", err).Print(cmd)
 		}
 	}
 
-	// Create the issue
+	// Output result
+	output := map[string]interface{}{
+		"issue": result,
+	}
+
+	return EncodeOutputWithCmd(cmd, output)
+}
+
+// IssueCreateParams holds the resolved node IDs used by the createIssue mutation
+type IssueCreateParams struct {
+	RepositoryID string
+	Title        string
+	Body         string
+	LabelIDs     []string
+	AssigneeIDs  []string
+	MilestoneID  string
+	ProjectIDs   []string
+}
+
+// CreateIssue creates an issue and returns its node ID along with the creation result
+func (c *GitHubClient) CreateIssue(params IssueCreateParams) (string, *IssueCreationResult, error) {
 	mutation := `
 	mutation CreateIssue($repositoryId: ID!, $title: String!, $body: String, $labelIds: [ID!], $assigneeIds: [ID!], $milestoneId: ID, $projectIds: [ID!]) {
 		createIssue(input: {
@@ -280,60 +397,48 @@ func createIssue(cmd *cobra.Command, args []string) error {
 	}`
 
 	variables := map[string]interface{}{
-		"repositoryId": repoID,
-		"title":        title,
+		"repositoryId": params.RepositoryID,
+		"title":        params.Title,
 	}
 
-	if body != "" {
-		variables["body"] = body
+	if params.Body != "" {
+		variables["body"] = params.Body
 	}
-	if len(labelIDs) > 0 {
-		variables["labelIds"] = labelIDs
+	if len(params.LabelIDs) > 0 {
+		variables["labelIds"] = params.LabelIDs
 	}
-	if len(assigneeIDs) > 0 {
-		variables["assigneeIds"] = assigneeIDs
+	if len(params.AssigneeIDs) > 0 {
+		variables["assigneeIds"] = params.AssigneeIDs
 	}
-	if milestoneID != "" {
-		variables["milestoneId"] = milestoneID
+	if params.MilestoneID != "" {
+		variables["milestoneId"] = params.MilestoneID
 	}
-	if projectID != "" {
-		variables["projectIds"] = []string{projectID}
+	if len(params.ProjectIDs) > 0 {
+		variables["projectIds"] = params.ProjectIDs
 	}
 
-	responseData, err := client.RunGraphQLQueryWithVariables(mutation, variables)
+	responseData, err := c.RunGraphQLQueryWithVariables(mutation, variables)
 	if err != nil {
-		return fmt.Errorf("failed to create issue: %w", err)
+		return "", nil, fmt.Errorf("failed to create issue: %w", err)
 	}
 
 	var response CreateIssueResponse
 
 	if err := json.Unmarshal(responseData, &response); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+		return "", nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	issue := response.Data.CreateIssue.Issue
 	if issue.Number == 0 {
-		return fmt.Errorf("issue creation failed: empty response")
-	}
-
-	// If parent is specified, create sub-issue relationship
-	var parentInfo *ParentIssueInfo
-	if parentNumber > 0 {
-		parentInfo, err = client.AddSubIssue(issue.ID, parentNumber)
-		if err != nil {
-			// Don't fail the entire operation, just warn
-			WarningMsg("Failed to create sub-issue relationship: %v", err).Print()
-		}
+		return "", nil, fmt.Errorf("issue creation failed: empty response")
 	}
 
-	// Build result
-	result := IssueCreationResult{
+	result := &IssueCreationResult{
 		Number:    issue.Number,
 		Title:     issue.Title,
 		URL:       issue.URL,
 		State:     issue.State,
 		CreatedAt: issue.CreatedAt,
-		Parent:    parentInfo,
 	}
 
 	// Extract labels
@@ -346,12 +451,7 @@ func createIssue(cmd *cobra.Command, args []string) error {
 		result.Assignees = append(result.Assignees, assignee.Login)
 	}
 
-	// Output result
-	output := map[string]interface{}{
-		"issue": result,
-	}
-
-	return EncodeOutputWithCmd(cmd, output)
+	return issue.ID, result, nil
 }
 
 // Helper methods that need to be added to GitHubClient
@@ -605,7 +705,7 @@ func (c *GitHubClient) GetIssueWithSubIssues(number int, includeSub bool, detail
 				detailResult, err := c.GetIssueWithSubIssues(subIssuesInfo.Items[i].Number, false, false)
 				if err != nil {
 					// Log error but continue
-					WarningMsg("Failed to fetch details for sub-issue #%d: %v", subIssuesInfo.Items[i].Number, err).Print()
+					slog.Warn("failed to fetch sub-issue details", "issue", subIssuesInfo.Items[i].Number, "error", err)
 					continue
 				}
 				subIssuesInfo.Items[i].Details = &detailResult.Issue
@@ -945,8 +1045,40 @@ type BasicIssueInfo struct {
 
 // IssueShowResult represents the result of showing issue details
 type IssueShowResult struct {
-	Issue     DetailedIssueInfo `json:"issue"`
-	SubIssues *SubIssuesInfo    `json:"subIssues,omitempty"`
+	Issue        DetailedIssueInfo  `json:"issue"`
+	SubIssues    *SubIssuesInfo     `json:"subIssues,omitempty"`
+	PullRequests *LinkedPRsInfo     `json:"pullRequests,omitempty"`
+	Comments     *IssueCommentsInfo `json:"comments,omitempty"`
+}
+
+// LinkedPRsInfo summarizes the PRs closing or referencing an issue
+type LinkedPRsInfo struct {
+	TotalCount int                 `json:"totalCount"`
+	OpenCount  int                 `json:"openCount"`
+	Merged     bool                `json:"merged"` // A closing PR has been merged
+	Nodes      []LinkedPullRequest `json:"nodes"`
+}
+
+// newLinkedPRsInfo builds the summary for a list of linked PRs
+func newLinkedPRsInfo(prs []LinkedPullRequest) *LinkedPRsInfo {
+	info := &LinkedPRsInfo{
+		TotalCount: len(prs),
+		Nodes:      prs,
+	}
+	if info.Nodes == nil {
+		info.Nodes = []LinkedPullRequest{}
+	}
+	for _, pr := range prs {
+		switch pr.State {
+		case "OPEN":
+			info.OpenCount++
+		case "MERGED":
+			if pr.WillClose {
+				info.Merged = true
+			}
+		}
+	}
+	return info
 }
 
 // DetailedIssueInfo represents detailed issue information
@@ -981,9 +1113,16 @@ type SubIssueItem struct {
 
 // EditIssueResult represents the result of issue editing
 type EditIssueResult struct {
-	Issue        BasicIssueInfo    `json:"issue"`
-	Changes      []ChangeInfo      `json:"changes"`
-	ParentChange *ParentChangeInfo `json:"parentChange,omitempty"`
+	Issue        BasicIssueInfo     `json:"issue"`
+	Changes      []ChangeInfo       `json:"changes"`
+	ParentChange *ParentChangeInfo  `json:"parentChange,omitempty"`
+	Failed       []BatchItemFailure `json:"failed,omitempty"`
+}
+
+// BatchItemFailure records an item that failed within an otherwise successful batch operation
+type BatchItemFailure struct {
+	Number int    `json:"number"`
+	Error  string `json:"error"`
 }
 
 // ChangeInfo represents a single change made to an issue
@@ -1032,14 +1171,44 @@ func showIssue(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get 'detailed' flag: %w", err)
 	}
+	includePRs, err := cmd.Flags().GetBool("include-prs")
+	if err != nil {
+		return fmt.Errorf("failed to get 'include-prs' flag: %w", err)
+	}
+	includeComments, err := cmd.Flags().GetBool("comments")
+	if err != nil {
+		return fmt.Errorf("failed to get 'comments' flag: %w", err)
+	}
+	commentAuthor, err := cmd.Flags().GetString("author")
+	if err != nil {
+		return fmt.Errorf("failed to get 'author' flag: %w", err)
+	}
+	commentSince, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return fmt.Errorf("failed to get 'since' flag: %w", err)
+	}
+	commentLimit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return fmt.Errorf("failed to get 'limit' flag: %w", err)
+	}
 	
 	// Validate flag combination
 	if detailed && !includeSub {
 		return fmt.Errorf("--detailed requires --include-sub")
 	}
+	if !includeComments && (commentAuthor != "" || commentSince != "" || commentLimit != 0) {
+		return fmt.Errorf("--author, --since, and --limit require --comments")
+	}
+	if commentLimit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+	since, err := parseCommentsSince(commentSince, time.Now())
+	if err != nil {
+		return err
+	}
 	
 	// Create GitHub client
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
 	
 	// Fetch issue information
 	result, err := client.GetIssueWithSubIssues(issueNumber, includeSub, detailed)
@@ -1047,6 +1216,25 @@ func showIssue(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to fetch issue: %w", err)
 	}
 	
+	if includePRs {
+		prs, err := client.FindLinkedPRsForIssue(issueNumber)
+		if err != nil {
+			return fmt.Errorf("failed to fetch linked PRs: %w", err)
+		}
+		result.PullRequests = newLinkedPRsInfo(prs)
+	}
+	
+	if includeComments {
+		result.Comments, err = client.GetIssueComments(issueNumber, IssueCommentFilter{
+			Author: strings.TrimPrefix(commentAuthor, "@"),
+			Since:  since,
+			Limit:  commentLimit,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fetch comments: %w", err)
+		}
+	}
+	
 	// Output result
 	output := map[string]interface{}{
 		"issueShow": result,
@@ -1274,6 +1462,92 @@ func (c *GitHubClient) ReorderSubIssue(subIssueNumber int, afterNumber int, befo
 	}, nil
 }
 
+// runSubIssueBatch runs one aliased addSubIssue/removeSubIssue mutation for all sub-issues
+// and reports per-item results, so one bad sub-issue does not fail the whole batch
+func (c *GitHubClient) runSubIssueBatch(parentID, mutationName, aliasPrefix string, subIssueNumbers []int) ([]int, []BatchItemFailure, error) {
+	var failed []BatchItemFailure
+
+	// Resolve sub-issue IDs; aliases keep the index into subIssueNumbers
+	subIssueIDs := make(map[int]string, len(subIssueNumbers))
+	for i, num := range subIssueNumbers {
+		subQuery := `
+		query($owner: String!, $repo: String!, $number: Int!) {
+			repository(owner: $owner, name: $repo) {
+				issue(number: $number) { id }
+			}
+		}`
+		subVars := map[string]interface{}{"owner": c.Owner, "repo": c.Repo, "number": num}
+		subData, err := c.RunGraphQLQueryWithVariables(subQuery, subVars)
+		if err != nil {
+			failed = append(failed, BatchItemFailure{Number: num, Error: err.Error()})
+			continue
+		}
+
+		var subResp GetRepositoryIssueResponse
+		if err := json.Unmarshal(subData, &subResp); err != nil {
+			return nil, nil, err
+		}
+		if subResp.Data.Repository.Issue == nil {
+			failed = append(failed, BatchItemFailure{Number: num, Error: "sub-issue not found"})
+			continue
+		}
+		subIssueIDs[i] = subResp.Data.Repository.Issue.ID
+	}
+
+	if len(subIssueIDs) == 0 {
+		return nil, failed, nil
+	}
+
+	// Use GraphQL aliases to batch the operations
+	var mutationBuilder strings.Builder
+	mutationBuilder.WriteString("mutation {")
+	for i := range subIssueNumbers {
+		subID, ok := subIssueIDs[i]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&mutationBuilder, `
+		%s%d: %s(input: {
+			issueId: "%s"
+			subIssueId: "%s"
+		}) {
+			issue { id }
+		}`, aliasPrefix, i, mutationName, parentID, subID)
+	}
+	mutationBuilder.WriteString("\n}")
+
+	_, graphqlErrors, err := c.RunGraphQLQueryPartial(mutationBuilder.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	aliasErrors := make(map[string]string)
+	for _, graphqlErr := range graphqlErrors {
+		alias := graphqlErr.Alias()
+		if alias == "" {
+			// An error without a path (e.g., a syntax error) applies to the whole batch
+			return nil, nil, fmt.Errorf("GraphQL error: %s", graphqlErr.Message)
+		}
+		if _, exists := aliasErrors[alias]; !exists {
+			aliasErrors[alias] = graphqlErr.Message
+		}
+	}
+
+	var succeeded []int
+	for i, num := range subIssueNumbers {
+		if _, ok := subIssueIDs[i]; !ok {
+			continue
+		}
+		if message, ok := aliasErrors[fmt.Sprintf("%s%d", aliasPrefix, i)]; ok {
+			failed = append(failed, BatchItemFailure{Number: num, Error: message})
+			continue
+		}
+		succeeded = append(succeeded, num)
+	}
+
+	return succeeded, failed, nil
+}
+
 // BatchAddSubIssues adds multiple sub-issues to a parent issue
 func (c *GitHubClient) BatchAddSubIssues(parentNumber int, subIssueNumbers []int) (*EditIssueResult, error) {
 	// Get parent issue ID
@@ -1312,61 +1586,12 @@ func (c *GitHubClient) BatchAddSubIssues(parentNumber int, subIssueNumbers []int
 
 	parentIssue := parentResp.Data.Repository.Issue
 
-	// Get sub-issue IDs
-	subIssueIDs := make([]string, 0, len(subIssueNumbers))
-	for _, num := range subIssueNumbers {
-		subQuery := `
-		query($owner: String!, $repo: String!, $number: Int!) {
-			repository(owner: $owner, name: $repo) {
-				issue(number: $number) { id }
-			}
-		}`
-		subVars := map[string]interface{}{"owner": c.Owner, "repo": c.Repo, "number": num}
-		subData, err := c.RunGraphQLQueryWithVariables(subQuery, subVars)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch sub-issue #%d: %w", num, err)
-		}
-		
-		var subResp GetRepositoryIssueResponse
-		
-		if err := json.Unmarshal(subData, &subResp); err != nil {
-			return nil, err
-		}
-		
-		if subResp.Data.Repository.Issue == nil {
-			return nil, fmt.Errorf("sub-issue not found: #%d", num)
-		}
-		
-		subIssueIDs = append(subIssueIDs, subResp.Data.Repository.Issue.ID)
-	}
-
-	// Use GraphQL aliases to batch the operations
-	var mutationBuilder strings.Builder
-	mutationBuilder.WriteString("mutation {")
-	
-	for i, subID := range subIssueIDs {
-		fmt.Fprintf(&mutationBuilder, `
-		add%d: addSubIssue(input: {
-			issueId: "%s"
-			subIssueId: "%s"
-		}) {
-			issue { id }
-		}`, i, parentIssue.ID, subID)
-	}
-	
-	mutationBuilder.WriteString("\n}")
-
-	mutationData, err := c.RunGraphQLQuery(mutationBuilder.String())
+	succeeded, failed, err := c.runSubIssueBatch(parentIssue.ID, "addSubIssue", "add", subIssueNumbers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add sub-issues: %w", err)
 	}
-
-	// We don't need to parse the full response, just check for errors
-	var errorCheck struct {
-		Errors []interface{} `json:"errors"`
-	}
-	if err := json.Unmarshal(mutationData, &errorCheck); err == nil && len(errorCheck.Errors) > 0 {
-		return nil, fmt.Errorf("GraphQL errors occurred during batch operation")
+	if len(succeeded) == 0 && len(failed) > 0 {
+		return nil, fmt.Errorf("failed to add any sub-issues: #%d: %s", failed[0].Number, failed[0].Error)
 	}
 
 	return &EditIssueResult{
@@ -1379,10 +1604,11 @@ func (c *GitHubClient) BatchAddSubIssues(parentNumber int, subIssueNumbers []int
 		Changes: []ChangeInfo{
 			{
 				Field:    "sub-issues",
-				NewValue: fmt.Sprintf("added %d sub-issues: %v", len(subIssueNumbers), subIssueNumbers),
+				NewValue: fmt.Sprintf("added %d sub-issues: %v", len(succeeded), succeeded),
 				Action:   "add",
 			},
 		},
+		Failed: failed,
 	}, nil
 }
 
@@ -1424,61 +1650,12 @@ func (c *GitHubClient) BatchRemoveSubIssues(parentNumber int, subIssueNumbers []
 
 	parentIssue := parentResp.Data.Repository.Issue
 
-	// Get sub-issue IDs
-	subIssueIDs := make([]string, 0, len(subIssueNumbers))
-	for _, num := range subIssueNumbers {
-		subQuery := `
-		query($owner: String!, $repo: String!, $number: Int!) {
-			repository(owner: $owner, name: $repo) {
-				issue(number: $number) { id }
-			}
-		}`
-		subVars := map[string]interface{}{"owner": c.Owner, "repo": c.Repo, "number": num}
-		subData, err := c.RunGraphQLQueryWithVariables(subQuery, subVars)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch sub-issue #%d: %w", num, err)
-		}
-		
-		var subResp GetRepositoryIssueResponse
-		
-		if err := json.Unmarshal(subData, &subResp); err != nil {
-			return nil, err
-		}
-		
-		if subResp.Data.Repository.Issue == nil {
-			return nil, fmt.Errorf("sub-issue not found: #%d", num)
-		}
-		
-		subIssueIDs = append(subIssueIDs, subResp.Data.Repository.Issue.ID)
-	}
-
-	// Use GraphQL aliases to batch the operations
-	var mutationBuilder strings.Builder
-	mutationBuilder.WriteString("mutation {")
-	
-	for i, subID := range subIssueIDs {
-		fmt.Fprintf(&mutationBuilder, `
-		remove%d: removeSubIssue(input: {
-			issueId: "%s"
-			subIssueId: "%s"
-		}) {
-			issue { id }
-		}`, i, parentIssue.ID, subID)
-	}
-	
-	mutationBuilder.WriteString("\n}")
-
-	mutationData, err := c.RunGraphQLQuery(mutationBuilder.String())
+	succeeded, failed, err := c.runSubIssueBatch(parentIssue.ID, "removeSubIssue", "remove", subIssueNumbers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to remove sub-issues: %w", err)
 	}
-
-	// We don't need to parse the full response, just check for errors
-	var errorCheck struct {
-		Errors []interface{} `json:"errors"`
-	}
-	if err := json.Unmarshal(mutationData, &errorCheck); err == nil && len(errorCheck.Errors) > 0 {
-		return nil, fmt.Errorf("GraphQL errors occurred during batch operation")
+	if len(succeeded) == 0 && len(failed) > 0 {
+		return nil, fmt.Errorf("failed to remove any sub-issues: #%d: %s", failed[0].Number, failed[0].Error)
 	}
 
 	return &EditIssueResult{
@@ -1491,10 +1668,11 @@ func (c *GitHubClient) BatchRemoveSubIssues(parentNumber int, subIssueNumbers []
 		Changes: []ChangeInfo{
 			{
 				Field:    "sub-issues",
-				NewValue: fmt.Sprintf("removed %d sub-issues: %v", len(subIssueNumbers), subIssueNumbers),
+				NewValue: fmt.Sprintf("removed %d sub-issues: %v", len(succeeded), succeeded),
 				Action:   "remove",
 			},
 		},
+		Failed: failed,
 	}, nil
 }
 
@@ -1587,7 +1765,7 @@ func editIssue(cmd *cobra.Command, args []string) error {
 	}
 	
 	// Create GitHub client
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
 	
 	// Execute the appropriate operation
 	var result *EditIssueResult
@@ -1602,6 +1780,13 @@ func editIssue(cmd *cobra.Command, args []string) error {
 	case len(addSubs) > 0:
 		result, err = client.BatchAddSubIssues(issueNumber, addSubs)
 	case len(removeSubs) > 0:
+		items := make([]string, 0, len(removeSubs))
+		for _, number := range removeSubs {
+			items = append(items, fmt.Sprintf("#%d (sub-issue of #%d)", number, issueNumber))
+		}
+		if err := confirmBulkOperation(cmd, "remove sub-issues", items, false); err != nil {
+			return err
+		}
 		result, err = client.BatchRemoveSubIssues(issueNumber, removeSubs)
 	}
 	
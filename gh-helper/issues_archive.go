@@ -0,0 +1,520 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var archiveIssuesCmd = NewOperationalCommand(
+	"archive [flags]",
+	"Archive full issue content to a directory for offline retention",
+	`Archive issues as one JSON file per issue (body, comments, labels, and
+timeline events) plus an index.json, for offline retention before a
+repository cleanup.
+
+--before keeps issues closed before the date; open issues are kept when they
+were created before it.
+
+The index records the pagination cursor after every page of issues, so an
+interrupted archive resumes where it stopped when run again with the same
+directory and filters. A completed archive is not fetched again.
+
+Examples:
+  # Archive issues closed before 2023
+  gh-helper issues archive --state closed --before 2023-01-01 --output archive/
+
+  # Everything, open and closed
+  gh-helper issues archive --state all --output archive/`,
+	archiveIssues,
+)
+
+func init() {
+	archiveIssuesCmd.Flags().String("state", "closed", "Issue state to archive (open|closed|all)")
+	archiveIssuesCmd.Flags().String("before", "", "Only archive issues closed (open: created) before this date (YYYY-MM-DD)")
+	archiveIssuesCmd.Flags().StringP("output", "o", "", "Directory to write the archive to (required)")
+	_ = archiveIssuesCmd.MarkFlagRequired("output")
+
+	issuesCmd.AddCommand(archiveIssuesCmd)
+}
+
+// archiveIndexFile is the index written next to the per-issue files
+const archiveIndexFile = "index.json"
+
+// ArchivedIssue is the full content of an archived issue
+type ArchivedIssue struct {
+	Number    int               `json:"number"`
+	Title     string            `json:"title"`
+	State     string            `json:"state"`
+	Author    string            `json:"author,omitempty"`
+	URL       string            `json:"url"`
+	CreatedAt string            `json:"createdAt"`
+	ClosedAt  string            `json:"closedAt,omitempty"`
+	Milestone string            `json:"milestone,omitempty"`
+	Labels    []string          `json:"labels"`
+	Assignees []string          `json:"assignees"`
+	Body      string            `json:"body"`
+	Comments  []ArchivedComment `json:"comments"`
+	Events    []ArchivedEvent   `json:"events"`
+}
+
+// ArchivedComment is an issue comment
+type ArchivedComment struct {
+	Author    string `json:"author,omitempty"`
+	CreatedAt string `json:"createdAt"`
+	Body      string `json:"body"`
+	URL       string `json:"url"`
+}
+
+// ArchivedEvent is a timeline event of an issue
+type ArchivedEvent struct {
+	Type          string `json:"type"`
+	Actor         string `json:"actor,omitempty"`
+	CreatedAt     string `json:"createdAt"`
+	Label         string `json:"label,omitempty"`
+	Assignee      string `json:"assignee,omitempty"`
+	Milestone     string `json:"milestone,omitempty"`
+	PreviousTitle string `json:"previousTitle,omitempty"`
+	Title         string `json:"title,omitempty"`
+	StateReason   string `json:"stateReason,omitempty"`
+	Source        int    `json:"source,omitempty"` // referencing issue or PR number
+}
+
+// ArchiveIndex lists the archived issues and the resume point of the archive
+type ArchiveIndex struct {
+	Repository string              `json:"repository"`
+	State      string              `json:"state"`
+	Before     string              `json:"before,omitempty"`
+	Cursor     string              `json:"cursor,omitempty"` // end cursor of the last archived page
+	Complete   bool                `json:"complete"`
+	UpdatedAt  string              `json:"updatedAt"`
+	Issues     []ArchiveIndexEntry `json:"issues"`
+}
+
+// ArchiveIndexEntry is an archived issue in the index
+type ArchiveIndexEntry struct {
+	Number   int    `json:"number"`
+	Title    string `json:"title"`
+	State    string `json:"state"`
+	ClosedAt string `json:"closedAt,omitempty"`
+	File     string `json:"file"`
+}
+
+// archiveIssueFile is the file name of an archived issue
+func archiveIssueFile(number int) string {
+	return fmt.Sprintf("issue-%d.json", number)
+}
+
+// archiveIncludes reports whether an issue falls before the --before date:
+// closed issues by closedAt, open issues by createdAt
+func archiveIncludes(issue ArchivedIssue, before time.Time) bool {
+	if before.IsZero() {
+		return true
+	}
+	at := issue.ClosedAt
+	if at == "" {
+		at = issue.CreatedAt
+	}
+	t, err := time.Parse(time.RFC3339, at)
+	return err == nil && t.Before(before)
+}
+
+// loadArchiveIndex reads the index of an archive directory; a missing index
+// starts a new archive. An index written with other filters is rejected, since
+// its cursor does not apply.
+func loadArchiveIndex(dir, repository, state, before string) (*ArchiveIndex, error) {
+	path := filepath.Join(dir, archiveIndexFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ArchiveIndex{Repository: repository, State: state, Before: before, Issues: []ArchiveIndexEntry{}}, nil
+		}
+		return nil, fmt.Errorf("failed to read archive index: %w", err)
+	}
+	var index ArchiveIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse archive index %s: %w", path, err)
+	}
+	if index.Repository != repository || index.State != state || index.Before != before {
+		return nil, fmt.Errorf("%s archives %s (state %s, before %q); use another directory for %s (state %s, before %q)",
+			path, index.Repository, index.State, index.Before, repository, state, before)
+	}
+	if index.Issues == nil {
+		index.Issues = []ArchiveIndexEntry{}
+	}
+	return &index, nil
+}
+
+// add records an archived issue, replacing an entry left by an interrupted page
+func (index *ArchiveIndex) add(issue ArchivedIssue) {
+	entry := ArchiveIndexEntry{
+		Number:   issue.Number,
+		Title:    issue.Title,
+		State:    issue.State,
+		ClosedAt: issue.ClosedAt,
+		File:     archiveIssueFile(issue.Number),
+	}
+	for i := range index.Issues {
+		if index.Issues[i].Number == issue.Number {
+			index.Issues[i] = entry
+			return
+		}
+	}
+	index.Issues = append(index.Issues, entry)
+}
+
+// writeArchiveFile writes v as indented JSON with writeFileAtomic
+func writeArchiveFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", filepath.Base(path), err)
+	}
+	return writeFileAtomic(path, append(data, '\n'))
+}
+
+// archiveTimelineItemTypes are the timeline events kept in the archive
+const archiveTimelineItemTypes = "[LABELED_EVENT, UNLABELED_EVENT, CLOSED_EVENT, REOPENED_EVENT, ASSIGNED_EVENT, UNASSIGNED_EVENT, RENAMED_TITLE_EVENT, MILESTONED_EVENT, DEMILESTONED_EVENT, CROSS_REFERENCED_EVENT]"
+
+// archiveConnectionFields selects a page of comments and timeline events
+const archiveConnectionFields = `
+		comments(first: 100, after: $commentsAfter) @include(if: $withComments) {
+			nodes { author { login } createdAt body url }
+			pageInfo { hasNextPage endCursor }
+		}
+		timelineItems(first: 100, after: $eventsAfter, itemTypes: ` + archiveTimelineItemTypes + `) @include(if: $withEvents) {
+			nodes {
+				__typename
+				... on LabeledEvent { actor { login } createdAt label { name } }
+				... on UnlabeledEvent { actor { login } createdAt label { name } }
+				... on ClosedEvent { actor { login } createdAt stateReason }
+				... on ReopenedEvent { actor { login } createdAt }
+				... on AssignedEvent { actor { login } createdAt assignee { ... on Actor { login } } }
+				... on UnassignedEvent { actor { login } createdAt assignee { ... on Actor { login } } }
+				... on RenamedTitleEvent { actor { login } createdAt previousTitle currentTitle }
+				... on MilestonedEvent { actor { login } createdAt milestoneTitle }
+				... on DemilestonedEvent { actor { login } createdAt milestoneTitle }
+				... on CrossReferencedEvent {
+					actor { login }
+					createdAt
+					source { ... on Issue { number } ... on PullRequest { number } }
+				}
+			}
+			pageInfo { hasNextPage endCursor }
+		}`
+
+// archiveConnections is the decoded form of archiveConnectionFields
+type archiveConnections struct {
+	Comments *struct {
+		Nodes []struct {
+			Author    *actorRef `json:"author"`
+			CreatedAt string    `json:"createdAt"`
+			Body      string    `json:"body"`
+			URL       string    `json:"url"`
+		} `json:"nodes"`
+		PageInfo archivePageInfo `json:"pageInfo"`
+	} `json:"comments"`
+	TimelineItems *struct {
+		Nodes []struct {
+			TypeName  string    `json:"__typename"`
+			Actor     *actorRef `json:"actor"`
+			CreatedAt string    `json:"createdAt"`
+			Label     *struct {
+				Name string `json:"name"`
+			} `json:"label"`
+			Assignee       *actorRef `json:"assignee"`
+			StateReason    string    `json:"stateReason"`
+			PreviousTitle  string    `json:"previousTitle"`
+			CurrentTitle   string    `json:"currentTitle"`
+			MilestoneTitle string    `json:"milestoneTitle"`
+			Source         *struct {
+				Number int `json:"number"`
+			} `json:"source"`
+		} `json:"nodes"`
+		PageInfo archivePageInfo `json:"pageInfo"`
+	} `json:"timelineItems"`
+}
+
+type archivePageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// appendTo adds the decoded comments and events to issue and returns the
+// cursors of the connections that have more pages ("" when done)
+func (conn archiveConnections) appendTo(issue *ArchivedIssue) (commentsAfter, eventsAfter string) {
+	if conn.Comments != nil {
+		for _, node := range conn.Comments.Nodes {
+			issue.Comments = append(issue.Comments, ArchivedComment{
+				Author:    node.Author.login(),
+				CreatedAt: node.CreatedAt,
+				Body:      node.Body,
+				URL:       node.URL,
+			})
+		}
+		if conn.Comments.PageInfo.HasNextPage {
+			commentsAfter = conn.Comments.PageInfo.EndCursor
+		}
+	}
+	if conn.TimelineItems != nil {
+		for _, node := range conn.TimelineItems.Nodes {
+			event := ArchivedEvent{
+				Type:          node.TypeName,
+				Actor:         node.Actor.login(),
+				CreatedAt:     node.CreatedAt,
+				Assignee:      node.Assignee.login(),
+				Milestone:     node.MilestoneTitle,
+				PreviousTitle: node.PreviousTitle,
+				Title:         node.CurrentTitle,
+				StateReason:   node.StateReason,
+			}
+			if node.Label != nil {
+				event.Label = node.Label.Name
+			}
+			if node.Source != nil {
+				event.Source = node.Source.Number
+			}
+			issue.Events = append(issue.Events, event)
+		}
+		if conn.TimelineItems.PageInfo.HasNextPage {
+			eventsAfter = conn.TimelineItems.PageInfo.EndCursor
+		}
+	}
+	return commentsAfter, eventsAfter
+}
+
+// archivePage is one page of issues with their first comments and events
+type archivePage struct {
+	Issues    []ArchivedIssue
+	EndCursor string
+	HasNext   bool
+}
+
+// getArchivePage fetches a page of issues after cursor, with the first pages
+// of their comments and events; remaining pages are fetched per issue
+func (c *GitHubClient) getArchivePage(states []string, after string) (*archivePage, error) {
+	query := `
+	query($owner: String!, $repo: String!, $states: [IssueState!], $after: String, $commentsAfter: String, $eventsAfter: String, $withComments: Boolean!, $withEvents: Boolean!) {
+		repository(owner: $owner, name: $repo) {
+			issues(first: 25, after: $after, states: $states, orderBy: {field: CREATED_AT, direction: ASC}) {
+				nodes {
+					id number title state url createdAt closedAt body
+					author { login }
+					milestone { title }
+					labels(first: 100) { nodes { name } }
+					assignees(first: 20) { nodes { login } }` + archiveConnectionFields + `
+				}
+				pageInfo { hasNextPage endCursor }
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"owner":        c.Owner,
+		"repo":         c.Repo,
+		"states":       states,
+		"withComments": true,
+		"withEvents":   true,
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+	responseData, err := c.RunGraphQLQueryWithVariables(query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository struct {
+				Issues struct {
+					Nodes []struct {
+						ID        string    `json:"id"`
+						Number    int       `json:"number"`
+						Title     string    `json:"title"`
+						State     string    `json:"state"`
+						URL       string    `json:"url"`
+						CreatedAt string    `json:"createdAt"`
+						ClosedAt  string    `json:"closedAt"`
+						Body      string    `json:"body"`
+						Author    *actorRef `json:"author"`
+						Milestone *struct {
+							Title string `json:"title"`
+						} `json:"milestone"`
+						Labels struct {
+							Nodes []struct {
+								Name string `json:"name"`
+							} `json:"nodes"`
+						} `json:"labels"`
+						Assignees struct {
+							Nodes []actorRef `json:"nodes"`
+						} `json:"assignees"`
+						archiveConnections
+					} `json:"nodes"`
+					PageInfo archivePageInfo `json:"pageInfo"`
+				} `json:"issues"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse issues response: %w", err)
+	}
+
+	page := &archivePage{
+		EndCursor: response.Data.Repository.Issues.PageInfo.EndCursor,
+		HasNext:   response.Data.Repository.Issues.PageInfo.HasNextPage,
+	}
+	for _, node := range response.Data.Repository.Issues.Nodes {
+		issue := ArchivedIssue{
+			Number:    node.Number,
+			Title:     node.Title,
+			State:     node.State,
+			Author:    node.Author.login(),
+			URL:       node.URL,
+			CreatedAt: node.CreatedAt,
+			ClosedAt:  node.ClosedAt,
+			Body:      node.Body,
+			Labels:    []string{},
+			Assignees: []string{},
+			Comments:  []ArchivedComment{},
+			Events:    []ArchivedEvent{},
+		}
+		if node.Milestone != nil {
+			issue.Milestone = node.Milestone.Title
+		}
+		for _, label := range node.Labels.Nodes {
+			issue.Labels = append(issue.Labels, label.Name)
+		}
+		for _, assignee := range node.Assignees.Nodes {
+			issue.Assignees = append(issue.Assignees, assignee.Login)
+		}
+		commentsAfter, eventsAfter := node.archiveConnections.appendTo(&issue)
+		if err := c.getRemainingArchiveConnections(node.ID, &issue, commentsAfter, eventsAfter); err != nil {
+			return nil, fmt.Errorf("failed to fetch issue #%d: %w", node.Number, err)
+		}
+		page.Issues = append(page.Issues, issue)
+	}
+	return page, nil
+}
+
+// getRemainingArchiveConnections follows the comment and event pages of an
+// issue beyond the first
+func (c *GitHubClient) getRemainingArchiveConnections(issueID string, issue *ArchivedIssue, commentsAfter, eventsAfter string) error {
+	query := `
+	query($id: ID!, $commentsAfter: String, $eventsAfter: String, $withComments: Boolean!, $withEvents: Boolean!) {
+		node(id: $id) {
+			... on Issue {` + archiveConnectionFields + `
+			}
+		}
+	}`
+
+	for commentsAfter != "" || eventsAfter != "" {
+		responseData, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+			"id":            issueID,
+			"commentsAfter": commentsAfter,
+			"eventsAfter":   eventsAfter,
+			"withComments":  commentsAfter != "",
+			"withEvents":    eventsAfter != "",
+		})
+		if err != nil {
+			return err
+		}
+		var response struct {
+			Data struct {
+				Node archiveConnections `json:"node"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return fmt.Errorf("failed to parse issue response: %w", err)
+		}
+		commentsAfter, eventsAfter = response.Data.Node.appendTo(issue)
+	}
+	return nil
+}
+
+func archiveIssues(cmd *cobra.Command, args []string) error {
+	stateFlag, err := cmd.Flags().GetString("state")
+	if err != nil {
+		return fmt.Errorf("failed to get 'state' flag: %w", err)
+	}
+	beforeFlag, err := cmd.Flags().GetString("before")
+	if err != nil {
+		return fmt.Errorf("failed to get 'before' flag: %w", err)
+	}
+	dir, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("failed to get 'output' flag: %w", err)
+	}
+
+	var states []string
+	switch strings.ToLower(stateFlag) {
+	case "open":
+		states = []string{"OPEN"}
+	case "closed":
+		states = []string{"CLOSED"}
+	case "all":
+		states = []string{"OPEN", "CLOSED"}
+	default:
+		return fmt.Errorf("invalid --state %q (expected open, closed, or all)", stateFlag)
+	}
+	var before time.Time
+	if beforeFlag != "" {
+		if before, err = time.Parse("2006-01-02", beforeFlag); err != nil {
+			return fmt.Errorf("invalid --before %q (expected YYYY-MM-DD): %w", beforeFlag, err)
+		}
+	}
+
+	client := newCommandClient(cmd)
+	repository := client.Owner + "/" + client.Repo
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	index, err := loadArchiveIndex(dir, repository, strings.ToLower(stateFlag), beforeFlag)
+	if err != nil {
+		return err
+	}
+
+	archived := 0
+	resumed := index.Cursor != "" && !index.Complete
+	for !index.Complete {
+		page, err := client.getArchivePage(states, index.Cursor)
+		if err != nil {
+			return fmt.Errorf("failed to fetch issues (archived %d so far; run again to resume): %w", archived, err)
+		}
+		for _, issue := range page.Issues {
+			if !archiveIncludes(issue, before) {
+				continue
+			}
+			if err := writeArchiveFile(filepath.Join(dir, archiveIssueFile(issue.Number)), issue); err != nil {
+				return err
+			}
+			index.add(issue)
+			archived++
+		}
+
+		if page.EndCursor != "" {
+			index.Cursor = page.EndCursor
+		}
+		index.Complete = !page.HasNext
+		index.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+		if err := writeArchiveFile(filepath.Join(dir, archiveIndexFile), index); err != nil {
+			return err
+		}
+		InfoMsg("Archived %d issues", len(index.Issues)).Print(cmd)
+	}
+
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{
+		"archive": map[string]interface{}{
+			"directory":  dir,
+			"repository": repository,
+			"archived":   archived,
+			"total":      len(index.Issues),
+			"resumed":    resumed,
+			"complete":   index.Complete,
+		},
+	})
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArchiveIncludes(t *testing.T) {
+	before := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		issue ArchivedIssue
+		want  bool
+	}{
+		{"closed before", ArchivedIssue{CreatedAt: "2021-05-01T00:00:00Z", ClosedAt: "2022-12-31T23:59:59Z"}, true},
+		{"closed after", ArchivedIssue{CreatedAt: "2021-05-01T00:00:00Z", ClosedAt: "2023-01-01T00:00:00Z"}, false},
+		{"open created before", ArchivedIssue{CreatedAt: "2022-06-01T00:00:00Z"}, true},
+		{"open created after", ArchivedIssue{CreatedAt: "2023-06-01T00:00:00Z"}, false},
+	}
+	for _, tt := range tests {
+		if got := archiveIncludes(tt.issue, before); got != tt.want {
+			t.Errorf("%s: archiveIncludes() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if !archiveIncludes(ArchivedIssue{CreatedAt: "2030-01-01T00:00:00Z"}, time.Time{}) {
+		t.Error("archiveIncludes() without --before = false, want true")
+	}
+}
+
+func TestArchiveIndexResume(t *testing.T) {
+	dir := t.TempDir()
+	index, err := loadArchiveIndex(dir, "o/r", "closed", "2023-01-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	index.add(ArchivedIssue{Number: 1, Title: "old", State: "CLOSED"})
+	index.add(ArchivedIssue{Number: 2, Title: "two", State: "CLOSED"})
+	index.add(ArchivedIssue{Number: 1, Title: "renamed", State: "CLOSED"}) // page retried after an interruption
+	index.Cursor = "cursor1"
+	if err := writeArchiveFile(filepath.Join(dir, archiveIndexFile), index); err != nil {
+		t.Fatal(err)
+	}
+
+	resumed, err := loadArchiveIndex(dir, "o/r", "closed", "2023-01-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resumed.Cursor != "cursor1" || len(resumed.Issues) != 2 || resumed.Issues[0].Title != "renamed" || resumed.Issues[1].File != "issue-2.json" {
+		t.Errorf("resumed index = %+v", resumed)
+	}
+
+	if _, err := loadArchiveIndex(dir, "o/r", "all", "2023-01-01"); err == nil || !strings.Contains(err.Error(), "another directory") {
+		t.Errorf("loadArchiveIndex() with other filters = %v, want a directory error", err)
+	}
+}
+
+func TestArchiveConnectionsAppendTo(t *testing.T) {
+	var conn archiveConnections
+	data := `{
+		"comments": {"nodes": [{"author": {"login": "alice"}, "createdAt": "2022-01-01T00:00:00Z", "body": "hi", "url": "u"}],
+			"pageInfo": {"hasNextPage": true, "endCursor": "c1"}},
+		"timelineItems": {"nodes": [
+			{"__typename": "LabeledEvent", "actor": {"login": "bob"}, "createdAt": "2022-01-02T00:00:00Z", "label": {"name": "bug"}},
+			{"__typename": "CrossReferencedEvent", "actor": null, "createdAt": "2022-01-03T00:00:00Z", "source": {"number": 7}}],
+			"pageInfo": {"hasNextPage": false, "endCursor": "e1"}}
+	}`
+	if err := json.Unmarshal([]byte(data), &conn); err != nil {
+		t.Fatal(err)
+	}
+
+	var issue ArchivedIssue
+	commentsAfter, eventsAfter := conn.appendTo(&issue)
+	if commentsAfter != "c1" || eventsAfter != "" {
+		t.Errorf("cursors = %q, %q; want c1 and none", commentsAfter, eventsAfter)
+	}
+	if len(issue.Comments) != 1 || issue.Comments[0].Author != "alice" {
+		t.Errorf("comments = %+v", issue.Comments)
+	}
+	if len(issue.Events) != 2 || issue.Events[0].Label != "bug" || issue.Events[1].Source != 7 || issue.Events[1].Actor != "" {
+		t.Errorf("events = %+v", issue.Events)
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IssueComment is a conversation comment included by 'issues show --comments'
+type IssueComment struct {
+	ID        string `json:"id"`
+	Author    string `json:"author"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"createdAt"`
+	URL       string `json:"url"`
+}
+
+// IssueCommentsInfo holds the filtered conversation of an issue, oldest first
+type IssueCommentsInfo struct {
+	TotalCount int            `json:"totalCount"`          // all comments on the issue
+	Shown      int            `json:"shown"`               // comments matching the filters, up to --limit
+	Truncated  bool           `json:"truncated,omitempty"` // older matching comments were omitted by --limit
+	Author     string         `json:"author,omitempty"`
+	Since      string         `json:"since,omitempty"`
+	Comments   []IssueComment `json:"comments"`
+}
+
+// IssueCommentFilter selects the comments shown with --comments
+type IssueCommentFilter struct {
+	Author string    // login, case-insensitive; empty for all
+	Since  time.Time // zero for no lower bound
+	Limit  int       // most recent N matching comments; 0 for all
+}
+
+// parseCommentsSince parses --since as RFC 3339, YYYY-MM-DD, or a duration ago (e.g., 48h)
+func parseCommentsSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q (expected RFC 3339 time, YYYY-MM-DD, or duration like 48h)", value)
+}
+
+var sinceDaysPattern = regexp.MustCompile(`^(\d+)d$`)
+
+// parseSince parses --since as days (90d), or as parseCommentsSince does
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if m := sinceDaysPattern.FindStringSubmatch(value); m != nil {
+		days, _ := strconv.Atoi(m[1])
+		return now.AddDate(0, 0, -days), nil
+	}
+	return parseCommentsSince(value, now)
+}
+
+// matches reports whether a comment passes the author and since filters
+func (f IssueCommentFilter) matches(comment IssueComment) bool {
+	if f.Author != "" && !strings.EqualFold(comment.Author, f.Author) {
+		return false
+	}
+	if !f.Since.IsZero() {
+		createdAt, err := time.Parse(time.RFC3339, comment.CreatedAt)
+		if err != nil || createdAt.Before(f.Since) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetIssueComments fetches the conversation comments of an issue matching the filter.
+// Pages are read newest first, so --since and --limit stop paging early.
+func (c *GitHubClient) GetIssueComments(issueNumber int, filter IssueCommentFilter) (*IssueCommentsInfo, error) {
+	query := `
+	query($owner: String!, $repo: String!, $number: Int!, $before: String) {
+	  repository(owner: $owner, name: $repo) {
+	    issue(number: $number) {
+	      comments(last: 100, before: $before) {
+	        totalCount
+	        pageInfo { hasPreviousPage startCursor }
+	        nodes { id author { login } body createdAt url }
+	      }
+	    }
+	  }
+	}`
+
+	info := &IssueCommentsInfo{Author: filter.Author}
+	if !filter.Since.IsZero() {
+		info.Since = filter.Since.UTC().Format(time.RFC3339)
+	}
+
+	var newestFirst []IssueComment
+	var before interface{}
+	for {
+		result, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+			"owner":  c.Owner,
+			"repo":   c.Repo,
+			"number": issueNumber,
+			"before": before,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Data struct {
+				Repository struct {
+					Issue *struct {
+						Comments struct {
+							TotalCount int `json:"totalCount"`
+							PageInfo   struct {
+								HasPreviousPage bool   `json:"hasPreviousPage"`
+								StartCursor     string `json:"startCursor"`
+							} `json:"pageInfo"`
+							Nodes []struct {
+								ID        string    `json:"id"`
+								Author    *actorRef `json:"author"`
+								Body      string    `json:"body"`
+								CreatedAt string    `json:"createdAt"`
+								URL       string    `json:"url"`
+							} `json:"nodes"`
+						} `json:"comments"`
+					} `json:"issue"`
+				} `json:"repository"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(result, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse comments response: %w", err)
+		}
+		issue := response.Data.Repository.Issue
+		if issue == nil {
+			return nil, fmt.Errorf("issue #%d not found", issueNumber)
+		}
+		info.TotalCount = issue.Comments.TotalCount
+
+		nodes := issue.Comments.Nodes
+		reachedSince := false
+		for i := len(nodes) - 1; i >= 0; i-- {
+			node := nodes[i]
+			comment := IssueComment{
+				ID:        node.ID,
+				Author:    node.Author.login(),
+				Body:      node.Body,
+				CreatedAt: node.CreatedAt,
+				URL:       node.URL,
+			}
+			if !filter.Since.IsZero() {
+				if createdAt, err := time.Parse(time.RFC3339, comment.CreatedAt); err == nil && createdAt.Before(filter.Since) {
+					reachedSince = true
+					break
+				}
+			}
+			if !filter.matches(comment) {
+				continue
+			}
+			if filter.Limit > 0 && len(newestFirst) == filter.Limit {
+				info.Truncated = true
+				break
+			}
+			newestFirst = append(newestFirst, comment)
+		}
+
+		if reachedSince || info.Truncated || !issue.Comments.PageInfo.HasPreviousPage {
+			break
+		}
+		before = issue.Comments.PageInfo.StartCursor
+	}
+
+	info.Comments = make([]IssueComment, 0, len(newestFirst))
+	for i := len(newestFirst) - 1; i >= 0; i-- {
+		info.Comments = append(info.Comments, newestFirst[i])
+	}
+	info.Shown = len(info.Comments)
+	return info, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCommentsSince(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"", time.Time{}, false},
+		{"2025-03-01T09:30:00Z", time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC), false},
+		{"2025-03-01", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"48h", time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC), false},
+		{"-1h", time.Time{}, true},
+		{"last week", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseCommentsSince(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("since = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	got, err := parseSince("90d", now)
+	if err != nil || !got.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("parseSince(90d) = %v, %v", got, err)
+	}
+	if got, err := parseSince("2025-02-01", now); err != nil || got.Format("2006-01-02") != "2025-02-01" {
+		t.Errorf("parseSince(date) = %v, %v", got, err)
+	}
+	if _, err := parseSince("soon", now); err == nil {
+		t.Error("parseSince(soon) succeeded, want error")
+	}
+}
+
+func TestIssueCommentFilterMatches(t *testing.T) {
+	comment := IssueComment{Author: "Alice", CreatedAt: "2025-03-05T00:00:00Z"}
+	tests := []struct {
+		name   string
+		filter IssueCommentFilter
+		want   bool
+	}{
+		{"no filter", IssueCommentFilter{}, true},
+		{"author case-insensitive", IssueCommentFilter{Author: "alice"}, true},
+		{"other author", IssueCommentFilter{Author: "bob"}, false},
+		{"since before", IssueCommentFilter{Since: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)}, true},
+		{"since after", IssueCommentFilter{Since: time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matches(comment); got != tt.want {
+				t.Errorf("matches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,495 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+)
+
+var dedupeIssuesCmd = NewOperationalCommand(
+	"dedupe [flags]",
+	"Report likely duplicate open issues",
+	`Cluster open issues by title/body similarity and report likely duplicate
+groups with confidence scores and a suggested canonical issue.
+
+Similarity (0-1) between two issues is, by default:
+  0.6 × word overlap of titles + 0.4 × overlap of 3-word body shingles
+(titles only when either body is empty). With --embed-command, it is the cosine
+similarity of embeddings computed by the command: it receives a JSON array of
+"title\n\nbody" strings on stdin and must print a JSON array of vectors in the
+same order.
+
+Issues with similarity ≥ --threshold are grouped. The canonical issue of a
+group is the one with the most comments (oldest on ties); each duplicate's
+score is its similarity to the canonical issue. Grouping is transitive, so a
+duplicate that only resembles another duplicate is marked "chained".
+
+--close-duplicates comments "Duplicate of #N" on each duplicate scoring at least
+--threshold against the canonical issue and closes it as a duplicate; chained
+issues are left open. Combine with --dry-run to preview.
+
+Examples:
+  # Duplicate candidates among open bugs
+  gh-helper issues dedupe --label bug
+
+  # Stricter matching, using an embedding model
+  gh-helper issues dedupe --threshold 0.85 --embed-command "python3 embed.py"
+
+  # Preview, then close duplicates
+  gh-helper issues dedupe --label bug --close-duplicates --dry-run
+  gh-helper issues dedupe --label bug --close-duplicates`,
+	dedupeIssues,
+)
+
+func init() {
+	dedupeIssuesCmd.Flags().String("label", "", "Only consider open issues with this label")
+	dedupeIssuesCmd.Flags().Float64("threshold", 0.6, "Minimum similarity (0-1) to group issues")
+	dedupeIssuesCmd.Flags().Int("limit", 300, "Maximum open issues to compare (most recently created first)")
+	dedupeIssuesCmd.Flags().String("embed-command", "", "Shell command computing embeddings (default: $GH_HELPER_EMBED_CMD, else text shingling)")
+	dedupeIssuesCmd.Flags().Bool("close-duplicates", false, "Close duplicates with a \"Duplicate of #N\" comment")
+	dedupeIssuesCmd.Flags().Bool("dry-run", false, "With --close-duplicates, show what would be closed")
+
+	issuesCmd.AddCommand(dedupeIssuesCmd)
+}
+
+// dedupeIssue is an open issue considered for deduplication
+type dedupeIssue struct {
+	ID        string `json:"id"`
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"createdAt"`
+	URL       string `json:"url"`
+	Comments  struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"comments"`
+}
+
+// DuplicateIssue is a likely duplicate of a group's canonical issue
+type DuplicateIssue struct {
+	Number int     `json:"number"`
+	Title  string  `json:"title"`
+	URL    string  `json:"url"`
+	Score  float64 `json:"score"`
+	Closed bool    `json:"closed,omitempty"`
+	Error  string  `json:"error,omitempty"`
+	// Chained is set when the issue joined the group through another
+	// duplicate and its score is below the threshold; it is never closed
+	Chained bool `json:"chained,omitempty"`
+}
+
+// DuplicateGroup is a cluster of likely duplicate issues
+type DuplicateGroup struct {
+	Canonical  DuplicateIssue   `json:"canonical"`
+	Duplicates []DuplicateIssue `json:"duplicates"`
+	Confidence float64          `json:"confidence"` // mean pairwise similarity within the group
+}
+
+// similarityFunc scores two issues by index
+type similarityFunc func(i, j int) float64
+
+// normalizeWords lowercases text and splits it into words
+func normalizeWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// wordSet returns the set of words in text
+func wordSet(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range normalizeWords(text) {
+		set[word] = true
+	}
+	return set
+}
+
+// shingleSet returns the set of k-word shingles in text (the whole text when shorter)
+func shingleSet(text string, k int) map[string]bool {
+	words := normalizeWords(text)
+	set := make(map[string]bool)
+	if len(words) > 0 && len(words) < k {
+		set[strings.Join(words, " ")] = true
+	}
+	for i := 0; i+k <= len(words); i++ {
+		set[strings.Join(words[i:i+k], " ")] = true
+	}
+	return set
+}
+
+// jaccard returns |a ∩ b| / |a ∪ b|, or 0 when both are empty
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	intersection := 0
+	for key := range a {
+		if b[key] {
+			intersection++
+		}
+	}
+	return float64(intersection) / float64(len(a)+len(b)-intersection)
+}
+
+// shingleSimilarity scores issues by title word overlap and body shingle overlap
+func shingleSimilarity(issues []dedupeIssue) similarityFunc {
+	titles := make([]map[string]bool, len(issues))
+	bodies := make([]map[string]bool, len(issues))
+	for i, issue := range issues {
+		titles[i] = wordSet(issue.Title)
+		bodies[i] = shingleSet(issue.Body, 3)
+	}
+	return func(i, j int) float64 {
+		title := jaccard(titles[i], titles[j])
+		if len(bodies[i]) == 0 || len(bodies[j]) == 0 {
+			return title
+		}
+		return 0.6*title + 0.4*jaccard(bodies[i], bodies[j])
+	}
+}
+
+// cosineSimilarity scores issues by the cosine of their embeddings
+func cosineSimilarity(vectors [][]float64) similarityFunc {
+	return func(i, j int) float64 {
+		a, b := vectors[i], vectors[j]
+		if len(a) != len(b) || len(a) == 0 {
+			return 0
+		}
+		var dot, normA, normB float64
+		for k := range a {
+			dot += a[k] * b[k]
+			normA += a[k] * a[k]
+			normB += b[k] * b[k]
+		}
+		if normA == 0 || normB == 0 {
+			return 0
+		}
+		return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+	}
+}
+
+// embedIssues runs the embedding command over all issues in one invocation
+func embedIssues(command string, issues []dedupeIssue) ([][]float64, error) {
+	texts := make([]string, len(issues))
+	for i, issue := range issues {
+		texts[i] = issue.Title + "\n\n" + issue.Body
+	}
+	input, err := json.Marshal(texts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal embedding input: %w", err)
+	}
+
+	embedCmd := exec.Command("sh", "-c", command)
+	embedCmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	embedCmd.Stdout = &stdout
+	embedCmd.Stderr = &stderr
+	if err := embedCmd.Run(); err != nil {
+		return nil, fmt.Errorf("embedding command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var vectors [][]float64
+	if err := json.Unmarshal(stdout.Bytes(), &vectors); err != nil {
+		return nil, fmt.Errorf("embedding command output is not a JSON array of vectors: %w", err)
+	}
+	if len(vectors) != len(issues) {
+		return nil, fmt.Errorf("embedding command returned %d vectors for %d issues", len(vectors), len(issues))
+	}
+	return vectors, nil
+}
+
+// clusterDuplicates groups issues whose similarity reaches the threshold
+// (single linkage), largest and most confident groups first
+func clusterDuplicates(issues []dedupeIssue, similarity similarityFunc, threshold float64) []DuplicateGroup {
+	parent := make([]int, len(issues))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	scores := make(map[[2]int]float64)
+	for i := range issues {
+		for j := i + 1; j < len(issues); j++ {
+			score := similarity(i, j)
+			scores[[2]int{i, j}] = score
+			if score >= threshold {
+				parent[find(i)] = find(j)
+			}
+		}
+	}
+	pairScore := func(i, j int) float64 {
+		if i > j {
+			i, j = j, i
+		}
+		return scores[[2]int{i, j}]
+	}
+
+	members := make(map[int][]int)
+	for i := range issues {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+
+	groups := []DuplicateGroup{}
+	for _, indexes := range members {
+		if len(indexes) < 2 {
+			continue
+		}
+
+		// Most discussed issue is canonical; the oldest wins ties
+		canonical := indexes[0]
+		for _, i := range indexes[1:] {
+			if issues[i].Comments.TotalCount > issues[canonical].Comments.TotalCount ||
+				(issues[i].Comments.TotalCount == issues[canonical].Comments.TotalCount && issues[i].Number < issues[canonical].Number) {
+				canonical = i
+			}
+		}
+
+		group := DuplicateGroup{Canonical: DuplicateIssue{
+			Number: issues[canonical].Number,
+			Title:  issues[canonical].Title,
+			URL:    issues[canonical].URL,
+		}}
+		total, pairs := 0.0, 0
+		for a, i := range indexes {
+			for _, j := range indexes[a+1:] {
+				total += pairScore(i, j)
+				pairs++
+			}
+			if i == canonical {
+				continue
+			}
+			score := pairScore(i, canonical)
+			group.Duplicates = append(group.Duplicates, DuplicateIssue{
+				Number:  issues[i].Number,
+				Title:   issues[i].Title,
+				URL:     issues[i].URL,
+				Score:   roundScore(score),
+				Chained: score < threshold,
+			})
+		}
+		sort.Slice(group.Duplicates, func(a, b int) bool {
+			return group.Duplicates[a].Score > group.Duplicates[b].Score
+		})
+		group.Confidence = roundScore(total / float64(pairs))
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(a, b int) bool {
+		if groups[a].Confidence != groups[b].Confidence {
+			return groups[a].Confidence > groups[b].Confidence
+		}
+		return groups[a].Canonical.Number < groups[b].Canonical.Number
+	})
+	return groups
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*100) / 100
+}
+
+// listDedupeIssues fetches open issues, most recently created first
+func (c *GitHubClient) listDedupeIssues(label string, limit int) ([]dedupeIssue, error) {
+	query := `
+	query($owner: String!, $repo: String!, $labels: [String!], $first: Int!, $after: String) {
+	  repository(owner: $owner, name: $repo) {
+	    issues(states: [OPEN], labels: $labels, first: $first, after: $after, orderBy: {field: CREATED_AT, direction: DESC}) {
+	      pageInfo { hasNextPage endCursor }
+	      nodes { id number title body createdAt url comments { totalCount } }
+	    }
+	  }
+	}`
+
+	var labels interface{}
+	if label != "" {
+		labels = []string{label}
+	}
+
+	var issues []dedupeIssue
+	var after interface{}
+	for len(issues) < limit {
+		result, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+			"owner":  c.Owner,
+			"repo":   c.Repo,
+			"labels": labels,
+			"first":  min(100, limit-len(issues)),
+			"after":  after,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Data struct {
+				Repository struct {
+					Issues struct {
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+						Nodes []dedupeIssue `json:"nodes"`
+					} `json:"issues"`
+				} `json:"repository"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(result, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse issues response: %w", err)
+		}
+
+		page := response.Data.Repository.Issues
+		issues = append(issues, page.Nodes...)
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		after = page.PageInfo.EndCursor
+	}
+	return issues, nil
+}
+
+// AddComment adds a comment to an issue or pull request by node ID
+func (c *GitHubClient) AddComment(subjectID, body string) error {
+	mutation := `
+	mutation($subjectId: ID!, $body: String!) {
+	  addComment(input: {subjectId: $subjectId, body: $body}) {
+	    commentEdge { node { id } }
+	  }
+	}`
+	_, err := c.RunGraphQLQueryWithVariables(mutation, map[string]interface{}{
+		"subjectId": subjectID,
+		"body":      body,
+	})
+	return err
+}
+
+func dedupeIssues(cmd *cobra.Command, args []string) error {
+	label, err := cmd.Flags().GetString("label")
+	if err != nil {
+		return fmt.Errorf("failed to get 'label' flag: %w", err)
+	}
+	threshold, err := cmd.Flags().GetFloat64("threshold")
+	if err != nil {
+		return fmt.Errorf("failed to get 'threshold' flag: %w", err)
+	}
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return fmt.Errorf("failed to get 'limit' flag: %w", err)
+	}
+	embedCommand, err := cmd.Flags().GetString("embed-command")
+	if err != nil {
+		return fmt.Errorf("failed to get 'embed-command' flag: %w", err)
+	}
+	closeDuplicates, err := cmd.Flags().GetBool("close-duplicates")
+	if err != nil {
+		return fmt.Errorf("failed to get 'close-duplicates' flag: %w", err)
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get 'dry-run' flag: %w", err)
+	}
+
+	if threshold <= 0 || threshold > 1 {
+		return fmt.Errorf("--threshold must be in (0, 1]")
+	}
+	if limit <= 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+	if embedCommand == "" {
+		embedCommand = os.Getenv("GH_HELPER_EMBED_CMD")
+	}
+
+	client := newCommandClient(cmd)
+	issues, err := client.listDedupeIssues(label, limit)
+	if err != nil {
+		return fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	method := "shingles"
+	similarity := shingleSimilarity(issues)
+	if embedCommand != "" {
+		vectors, err := embedIssues(embedCommand, issues)
+		if err != nil {
+			return err
+		}
+		method = "embeddings"
+		similarity = cosineSimilarity(vectors)
+	}
+	groups := clusterDuplicates(issues, similarity, threshold)
+
+	failed := 0
+	if closeDuplicates && !dryRun {
+		var items []string
+		for _, group := range groups {
+			for _, duplicate := range group.Duplicates {
+				if duplicate.Chained {
+					continue
+				}
+				items = append(items, fmt.Sprintf("#%d (duplicate of #%d, score %.2f)", duplicate.Number, group.Canonical.Number, duplicate.Score))
+			}
+		}
+		// Similarity is heuristic, so always confirm closing (like label changes on queries)
+		if len(items) > 0 {
+			if err := confirmBulkOperation(cmd, "close duplicate issues", items, true); err != nil {
+				return err
+			}
+		}
+
+		ids := make(map[int]string, len(issues))
+		for _, issue := range issues {
+			ids[issue.Number] = issue.ID
+		}
+		for g := range groups {
+			group := &groups[g]
+			for d := range group.Duplicates {
+				duplicate := &group.Duplicates[d]
+				if duplicate.Chained {
+					continue
+				}
+				id := ids[duplicate.Number]
+				if err := client.AddComment(id, fmt.Sprintf("Duplicate of #%d", group.Canonical.Number)); err != nil {
+					duplicate.Error = fmt.Sprintf("comment: %v", err)
+				} else if err := client.CloseIssue(id, "DUPLICATE"); err != nil {
+					duplicate.Error = fmt.Sprintf("close: %v", err)
+				} else {
+					duplicate.Closed = true
+				}
+				if duplicate.Error != "" {
+					failed++
+				}
+			}
+		}
+	}
+
+	result := map[string]interface{}{
+		"compared":  len(issues),
+		"method":    method,
+		"threshold": threshold,
+		"groups":    groups,
+	}
+	if label != "" {
+		result["label"] = label
+	}
+	if closeDuplicates && dryRun {
+		result["dryRun"] = true
+	}
+	if err := EncodeOutputWithCmd(cmd, map[string]interface{}{"dedupe": result}); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to close %d duplicate issue(s)", failed)
+	}
+	return nil
+}
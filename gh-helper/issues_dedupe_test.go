@@ -0,0 +1,83 @@
+package main
+
+import "testing"
+
+func TestClusterDuplicates(t *testing.T) {
+	issue := func(number int, title, body string, comments int) dedupeIssue {
+		i := dedupeIssue{Number: number, Title: title, Body: body}
+		i.Comments.TotalCount = comments
+		return i
+	}
+	issues := []dedupeIssue{
+		issue(10, "Panic when config file is empty", "Running with an empty config file panics in LoadConfig", 0),
+		issue(12, "Panic when config file is empty", "An empty config file panics in LoadConfig on startup", 3),
+		issue(15, "Support dark mode in markdown output", "", 0),
+		issue(20, "panic: config file is empty", "", 0),
+	}
+
+	groups := clusterDuplicates(issues, shingleSimilarity(issues), 0.6)
+	if len(groups) != 1 {
+		t.Fatalf("got %d groups, want 1: %+v", len(groups), groups)
+	}
+	group := groups[0]
+	if group.Canonical.Number != 12 {
+		t.Errorf("canonical = #%d, want #12 (most comments)", group.Canonical.Number)
+	}
+	if len(group.Duplicates) != 2 {
+		t.Fatalf("got %d duplicates, want 2: %+v", len(group.Duplicates), group.Duplicates)
+	}
+	for _, duplicate := range group.Duplicates {
+		if duplicate.Number == 15 {
+			t.Errorf("unrelated issue #15 grouped as duplicate")
+		}
+		if duplicate.Score < 0.6 && duplicate.Number == 10 {
+			t.Errorf("#10 score = %v, want >= 0.6", duplicate.Score)
+		}
+	}
+	if group.Confidence <= 0 || group.Confidence > 1 {
+		t.Errorf("confidence = %v, want (0, 1]", group.Confidence)
+	}
+}
+
+func TestClusterDuplicatesChain(t *testing.T) {
+	// A~B and B~C, but A and C are dissimilar: C is grouped but chained
+	issues := make([]dedupeIssue, 3)
+	for i := range issues {
+		issues[i].Number = i + 1
+	}
+	issues[0].Comments.TotalCount = 5 // A is canonical
+	scores := map[[2]int]float64{{0, 1}: 0.9, {1, 2}: 0.9, {0, 2}: 0.2}
+	similarity := func(i, j int) float64 { return scores[[2]int{i, j}] }
+
+	groups := clusterDuplicates(issues, similarity, 0.6)
+	if len(groups) != 1 || groups[0].Canonical.Number != 1 || len(groups[0].Duplicates) != 2 {
+		t.Fatalf("groups = %+v, want one group with canonical #1 and two duplicates", groups)
+	}
+	for _, duplicate := range groups[0].Duplicates {
+		if want := duplicate.Number == 3; duplicate.Chained != want {
+			t.Errorf("#%d chained = %v, want %v", duplicate.Number, duplicate.Chained, want)
+		}
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	similarity := cosineSimilarity([][]float64{{1, 0}, {2, 0}, {0, 1}, {}})
+	if got := similarity(0, 1); got != 1 {
+		t.Errorf("parallel = %v, want 1", got)
+	}
+	if got := similarity(0, 2); got != 0 {
+		t.Errorf("orthogonal = %v, want 0", got)
+	}
+	if got := similarity(0, 3); got != 0 {
+		t.Errorf("mismatched dimensions = %v, want 0", got)
+	}
+}
+
+func TestShingleSet(t *testing.T) {
+	if got := shingleSet("Too short", 3); len(got) != 1 || !got["too short"] {
+		t.Errorf("short text = %v, want whole text as one shingle", got)
+	}
+	if got := shingleSet("a b c d", 3); len(got) != 2 || !got["a b c"] || !got["b c d"] {
+		t.Errorf("shingles = %v", got)
+	}
+}
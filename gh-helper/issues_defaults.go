@@ -0,0 +1,108 @@
+package main
+
+import (
+	"slices"
+)
+
+// IssuesConfig configures 'issues' commands
+type IssuesConfig struct {
+	// Defaults are applied by 'issues create' unless --no-defaults is given
+	Defaults IssueDefaultsConfig `yaml:"defaults"`
+}
+
+// IssueDefaultsConfig holds the defaults of created issues
+type IssueDefaultsConfig struct {
+	// Labels are added to every created issue
+	Labels []string `yaml:"labels"`
+	// Assignees are assigned when --assignee is not given
+	Assignees []string `yaml:"assignees"`
+	// AssignCreator assigns the authenticated user when --assignee is not given
+	AssignCreator *bool `yaml:"assignCreator"`
+	// InheritParentLabels adds the parent's labels to issues created with --parent
+	InheritParentLabels *bool `yaml:"inheritParentLabels"`
+	// InheritParentLabelsExcept are parent labels never inherited (e.g. "epic")
+	InheritParentLabelsExcept []string `yaml:"inheritParentLabelsExcept"`
+}
+
+// merge overlays a higher-priority config file's issue defaults: lists are
+// concatenated, and set booleans override
+func (i *IssuesConfig) merge(other IssuesConfig) {
+	d, o := &i.Defaults, other.Defaults
+	d.Labels = append(d.Labels, o.Labels...)
+	d.Assignees = append(d.Assignees, o.Assignees...)
+	d.InheritParentLabelsExcept = append(d.InheritParentLabelsExcept, o.InheritParentLabelsExcept...)
+	if o.AssignCreator != nil {
+		d.AssignCreator = o.AssignCreator
+	}
+	if o.InheritParentLabels != nil {
+		d.InheritParentLabels = o.InheritParentLabels
+	}
+}
+
+// AppliedIssueDefault reports what one default rule added to a created issue
+type AppliedIssueDefault struct {
+	Rule      string   `json:"rule"` // labels, assignees, assign-creator, or inherit-parent-labels
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+}
+
+// issueDefaultsInput is what the defaults are applied to
+type issueDefaultsInput struct {
+	Labels       []string
+	Assignees    []string
+	ParentLabels []string // labels of the --parent issue, if any
+	Creator      string   // authenticated user, when assignCreator is set
+}
+
+// needsParentLabels reports whether applying the defaults needs the parent's labels
+func (d IssueDefaultsConfig) needsParentLabels() bool {
+	return d.InheritParentLabels != nil && *d.InheritParentLabels
+}
+
+// needsCreator reports whether applying the defaults needs the authenticated user
+func (d IssueDefaultsConfig) needsCreator(assignees []string) bool {
+	return len(assignees) == 0 && len(d.Assignees) == 0 && d.AssignCreator != nil && *d.AssignCreator
+}
+
+// apply returns the labels and assignees with the defaults added, and the
+// defaults that added something. Explicit --assignee values replace the
+// assignee defaults; labels are always added to.
+func (d IssueDefaultsConfig) apply(input issueDefaultsInput) (labels, assignees []string, applied []AppliedIssueDefault) {
+	labels = slices.Clone(input.Labels)
+	assignees = slices.Clone(input.Assignees)
+
+	addLabels := func(rule string, candidates []string) {
+		var added []string
+		for _, label := range candidates {
+			if !slices.Contains(labels, label) {
+				labels = append(labels, label)
+				added = append(added, label)
+			}
+		}
+		if len(added) > 0 {
+			applied = append(applied, AppliedIssueDefault{Rule: rule, Labels: added})
+		}
+	}
+	addLabels("labels", d.Labels)
+	if d.needsParentLabels() {
+		var inherited []string
+		for _, label := range input.ParentLabels {
+			if !slices.Contains(d.InheritParentLabelsExcept, label) {
+				inherited = append(inherited, label)
+			}
+		}
+		addLabels("inherit-parent-labels", inherited)
+	}
+
+	if len(assignees) == 0 {
+		switch {
+		case len(d.Assignees) > 0:
+			assignees = slices.Compact(slices.Clone(d.Assignees))
+			applied = append(applied, AppliedIssueDefault{Rule: "assignees", Assignees: assignees})
+		case d.needsCreator(nil) && input.Creator != "":
+			assignees = []string{input.Creator}
+			applied = append(applied, AppliedIssueDefault{Rule: "assign-creator", Assignees: assignees})
+		}
+	}
+	return labels, assignees, applied
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIssueDefaultsApply(t *testing.T) {
+	yes := true
+	defaults := IssueDefaultsConfig{
+		Labels:                    []string{"triage", "bug"},
+		AssignCreator:             &yes,
+		InheritParentLabels:       &yes,
+		InheritParentLabelsExcept: []string{"epic"},
+	}
+
+	labels, assignees, applied := defaults.apply(issueDefaultsInput{
+		Labels:       []string{"bug"},
+		ParentLabels: []string{"epic", "area/cli", "triage"},
+		Creator:      "alice",
+	})
+	if want := []string{"bug", "triage", "area/cli"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("labels = %v, want %v", labels, want)
+	}
+	if want := []string{"alice"}; !reflect.DeepEqual(assignees, want) {
+		t.Errorf("assignees = %v, want %v", assignees, want)
+	}
+	wantApplied := []AppliedIssueDefault{
+		{Rule: "labels", Labels: []string{"triage"}},
+		{Rule: "inherit-parent-labels", Labels: []string{"area/cli"}},
+		{Rule: "assign-creator", Assignees: []string{"alice"}},
+	}
+	if !reflect.DeepEqual(applied, wantApplied) {
+		t.Errorf("applied = %+v, want %+v", applied, wantApplied)
+	}
+
+	// Explicit assignees replace the assignee defaults
+	_, assignees, applied = defaults.apply(issueDefaultsInput{Assignees: []string{"bob"}, Creator: "alice"})
+	if !reflect.DeepEqual(assignees, []string{"bob"}) || len(applied) != 1 {
+		t.Errorf("explicit assignee: assignees = %v, applied = %+v", assignees, applied)
+	}
+	if defaults.needsCreator([]string{"bob"}) {
+		t.Error("needsCreator() = true with an explicit assignee")
+	}
+}
+
+func TestIssuesConfigMerge(t *testing.T) {
+	yes, no := true, false
+	config := IssuesConfig{Defaults: IssueDefaultsConfig{Labels: []string{"triage"}, AssignCreator: &yes}}
+	config.merge(IssuesConfig{Defaults: IssueDefaultsConfig{Labels: []string{"team/cli"}, AssignCreator: &no}})
+	config.merge(IssuesConfig{})
+
+	if want := []string{"triage", "team/cli"}; !reflect.DeepEqual(config.Defaults.Labels, want) {
+		t.Errorf("labels = %v, want %v", config.Defaults.Labels, want)
+	}
+	if config.Defaults.AssignCreator == nil || *config.Defaults.AssignCreator {
+		t.Error("a higher-priority assignCreator: false should override")
+	}
+}
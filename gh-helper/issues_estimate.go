@@ -0,0 +1,690 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var estimateIssueCmd = NewOperationalCommand(
+	"estimate <issue-number> [flags]",
+	"Show or set an issue's story-point estimate",
+	`Show or set an issue's estimate for sprint planning.
+
+Estimates are stored in one of two places:
+  - Labels (default): a label named <label-prefix><points>, e.g. 'points/5'.
+    Other labels with the same prefix are removed; missing labels are created.
+  - Project field: a number field of a ProjectV2 linked to the repository,
+    selected with --project and --field. The issue is added to the project
+    if it is not already an item.
+
+Examples:
+  # Set an estimate using the 'points/5' label
+  gh-helper issues estimate 123 --points 5
+
+  # Show the current estimate
+  gh-helper issues estimate 123
+
+  # Store estimates in the "Estimate" number field of a project
+  gh-helper issues estimate 123 --points 3 --project "Roadmap" --field Estimate
+
+  # Clear an estimate
+  gh-helper issues estimate 123 --clear`,
+	estimateIssue,
+)
+
+var reportEstimateCmd = NewOperationalCommand(
+	"report [flags]",
+	"Sum issue estimates in a milestone by state",
+	`Sum estimates of the issues in a milestone, grouped by state, for sprint
+planning. Issues without an estimate are listed separately.
+
+Uses the same --label-prefix / --project / --field settings as 'issues estimate'.
+
+Examples:
+  # Label-based report
+  gh-helper issues estimate report --milestone v0.20.0
+
+  # Project-field-based report, as markdown
+  gh-helper issues estimate report --milestone v0.20.0 --project "Roadmap" --format markdown`,
+	reportEstimates,
+)
+
+func init() {
+	estimateIssueCmd.Args = cobra.ExactArgs(1)
+
+	estimateIssueCmd.PersistentFlags().String("label-prefix", "points/", "Label prefix used for label-based estimates")
+	estimateIssueCmd.PersistentFlags().String("project", "", "Store estimates in a project number field instead of labels (project title)")
+	estimateIssueCmd.PersistentFlags().String("field", "Estimate", "Project number field name (with --project)")
+	estimateIssueCmd.Flags().Float64("points", 0, "Estimate to set")
+	estimateIssueCmd.Flags().Bool("clear", false, "Remove the estimate")
+
+	addOutputFormats(reportEstimateCmd, FormatMarkdown)
+	reportEstimateCmd.Flags().StringP("milestone", "m", "", "Milestone title (required)")
+	if err := reportEstimateCmd.MarkFlagRequired("milestone"); err != nil {
+		panic(fmt.Sprintf("failed to mark milestone flag as required: %v", err))
+	}
+
+	estimateIssueCmd.AddCommand(reportEstimateCmd)
+	issuesCmd.AddCommand(estimateIssueCmd)
+}
+
+// EstimateSettings selects where estimates are stored
+type EstimateSettings struct {
+	LabelPrefix string
+	Project     string // Project title; empty means label-based
+	Field       string
+}
+
+func getEstimateSettings(cmd *cobra.Command) (EstimateSettings, error) {
+	var settings EstimateSettings
+	var err error
+	if settings.LabelPrefix, err = cmd.Flags().GetString("label-prefix"); err != nil {
+		return settings, fmt.Errorf("failed to get 'label-prefix' flag: %w", err)
+	}
+	if settings.Project, err = cmd.Flags().GetString("project"); err != nil {
+		return settings, fmt.Errorf("failed to get 'project' flag: %w", err)
+	}
+	if settings.Field, err = cmd.Flags().GetString("field"); err != nil {
+		return settings, fmt.Errorf("failed to get 'field' flag: %w", err)
+	}
+	if settings.Project == "" && settings.LabelPrefix == "" {
+		return settings, fmt.Errorf("--label-prefix must not be empty for label-based estimates")
+	}
+	return settings, nil
+}
+
+// formatPoints formats an estimate without trailing zeros (5, 0.5)
+func formatPoints(points float64) string {
+	return strconv.FormatFloat(points, 'f', -1, 64)
+}
+
+// estimateFromLabels returns the estimate encoded in labels with the given prefix.
+// If several estimate labels exist, the last one wins.
+func estimateFromLabels(labels []string, prefix string) (float64, bool) {
+	var points float64
+	found := false
+	for _, label := range labels {
+		value, ok := strings.CutPrefix(label, prefix)
+		if !ok {
+			continue
+		}
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			points = parsed
+			found = true
+		}
+	}
+	return points, found
+}
+
+// EstimatedIssue is an issue with its optional estimate
+type EstimatedIssue struct {
+	Number int      `json:"number"`
+	Title  string   `json:"title"`
+	State  string   `json:"state"`
+	Points *float64 `json:"points,omitempty"`
+}
+
+// EstimateStateSummary sums estimates for one issue state
+type EstimateStateSummary struct {
+	Issues    int     `json:"issues"`
+	Estimated int     `json:"estimated"`
+	Points    float64 `json:"points"`
+}
+
+// EstimateReport summarizes estimates in a milestone
+type EstimateReport struct {
+	Milestone   string                          `json:"milestone"`
+	Source      string                          `json:"source"`
+	TotalPoints float64                         `json:"totalPoints"`
+	ByState     map[string]EstimateStateSummary `json:"byState"`
+	Unestimated []EstimatedIssue                `json:"unestimated"`
+}
+
+// summarizeEstimates groups issues by state and sums their estimates
+func summarizeEstimates(issues []EstimatedIssue) EstimateReport {
+	report := EstimateReport{
+		ByState:     map[string]EstimateStateSummary{},
+		Unestimated: []EstimatedIssue{},
+	}
+	for _, issue := range issues {
+		summary := report.ByState[issue.State]
+		summary.Issues++
+		if issue.Points != nil {
+			summary.Estimated++
+			summary.Points += *issue.Points
+			report.TotalPoints += *issue.Points
+		} else {
+			report.Unestimated = append(report.Unestimated, issue)
+		}
+		report.ByState[issue.State] = summary
+	}
+	return report
+}
+
+func estimateIssue(cmd *cobra.Command, args []string) error {
+	issueNumber, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid issue number: %s", args[0])
+	}
+
+	settings, err := getEstimateSettings(cmd)
+	if err != nil {
+		return err
+	}
+	clearEstimate, err := cmd.Flags().GetBool("clear")
+	if err != nil {
+		return fmt.Errorf("failed to get 'clear' flag: %w", err)
+	}
+	points, err := cmd.Flags().GetFloat64("points")
+	if err != nil {
+		return fmt.Errorf("failed to get 'points' flag: %w", err)
+	}
+	setPoints := cmd.Flags().Changed("points")
+	if setPoints && clearEstimate {
+		return fmt.Errorf("--points and --clear are mutually exclusive")
+	}
+	if setPoints && points < 0 {
+		return fmt.Errorf("--points must not be negative")
+	}
+
+	client := newCommandClient(cmd)
+
+	var current *float64
+	if settings.Project != "" {
+		current, err = client.updateProjectEstimate(issueNumber, settings, setPoints, clearEstimate, points)
+	} else {
+		current, err = client.updateLabelEstimate(issueNumber, settings.LabelPrefix, setPoints, clearEstimate, points)
+	}
+	if err != nil {
+		return err
+	}
+
+	source := "labels"
+	if settings.Project != "" {
+		source = fmt.Sprintf("project %q field %q", settings.Project, settings.Field)
+	}
+
+	result := map[string]interface{}{
+		"issue":   issueNumber,
+		"source":  source,
+		"updated": setPoints || clearEstimate,
+	}
+	if current != nil {
+		result["points"] = *current
+	} else {
+		result["points"] = nil
+	}
+
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"estimate": result})
+}
+
+// updateLabelEstimate reads and optionally replaces the estimate label of an issue,
+// returning the resulting estimate
+func (c *GitHubClient) updateLabelEstimate(issueNumber int, prefix string, set, clearValue bool, points float64) (*float64, error) {
+	repoID, err := c.GetRepositoryID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository ID: %w", err)
+	}
+	issue, err := c.GetLabelableInfo(repoID, "Issue", issueNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issue #%d: %w", issueNumber, err)
+	}
+
+	var labels []string
+	for _, label := range issue.Labels.Nodes {
+		labels = append(labels, label.Name)
+	}
+
+	if !set && !clearValue {
+		if current, ok := estimateFromLabels(labels, prefix); ok {
+			return &current, nil
+		}
+		return nil, nil
+	}
+
+	removeIDs, add := estimateLabelChange(issue.Labels.Nodes, prefix, clearValue, points)
+	if len(removeIDs) > 0 {
+		if _, err := c.RemoveLabelsFromItem(issue.ID, removeIDs); err != nil {
+			return nil, err
+		}
+	}
+	if clearValue {
+		return nil, nil
+	}
+	if !add {
+		return &points, nil
+	}
+
+	labelName := prefix + formatPoints(points)
+	labelIDs, err := c.GetLabelIDs([]string{labelName})
+	if err != nil {
+		return nil, err
+	}
+	labelID, ok := labelIDs[labelName]
+	if !ok {
+		labelID, err = c.CreateLabel(repoID, labelName, "ededed", "Story-point estimate")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create label %q: %w", labelName, err)
+		}
+	}
+	if _, err := c.AddLabelsToItem(issue.ID, []string{labelID}); err != nil {
+		return nil, err
+	}
+
+	return &points, nil
+}
+
+// estimateLabelChange returns the IDs of the estimate labels to remove and
+// whether the label for points must be added. Only labels estimateFromLabels
+// parses count as estimates, and one already carrying points is kept.
+func estimateLabelChange(labels []Label, prefix string, clearValue bool, points float64) (removeIDs []string, add bool) {
+	kept := false
+	for _, label := range labels {
+		value, ok := estimateFromLabels([]string{label.Name}, prefix)
+		if !ok {
+			continue
+		}
+		if !clearValue && !kept && value == points {
+			kept = true
+			continue
+		}
+		removeIDs = append(removeIDs, label.ID)
+	}
+	return removeIDs, !clearValue && !kept
+}
+
+// CreateLabel creates a repository label and returns its node ID
+func (c *GitHubClient) CreateLabel(repoID, name, color, description string) (string, error) {
+	mutation := `
+	mutation($input: CreateLabelInput!) {
+		createLabel(input: $input) {
+			label {
+				id
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"repositoryId": repoID,
+			"name":         name,
+			"color":        color,
+			"description":  description,
+		},
+	}
+
+	responseData, err := c.RunGraphQLQueryWithVariables(mutation, variables)
+	if err != nil {
+		return "", err
+	}
+
+	var response struct {
+		Data struct {
+			CreateLabel struct {
+				Label struct {
+					ID string `json:"id"`
+				} `json:"label"`
+			} `json:"createLabel"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return "", err
+	}
+
+	return response.Data.CreateLabel.Label.ID, nil
+}
+
+// getProjectNumberFieldID returns the ID of a number field in a ProjectV2
+func (c *GitHubClient) getProjectNumberFieldID(projectID, fieldName string) (string, error) {
+	query := `
+	query($projectId: ID!, $field: String!) {
+		node(id: $projectId) {
+			... on ProjectV2 {
+				field(name: $field) {
+					... on ProjectV2Field {
+						id
+						dataType
+					}
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"projectId": projectID,
+		"field":     fieldName,
+	}
+
+	responseData, err := c.RunGraphQLQueryWithVariables(query, variables)
+	if err != nil {
+		return "", err
+	}
+
+	var response struct {
+		Data struct {
+			Node struct {
+				Field *struct {
+					ID       string `json:"id"`
+					DataType string `json:"dataType"`
+				} `json:"field"`
+			} `json:"node"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return "", err
+	}
+
+	field := response.Data.Node.Field
+	if field == nil || field.ID == "" {
+		return "", fmt.Errorf("project field not found: %s", fieldName)
+	}
+	if field.DataType != "NUMBER" {
+		return "", fmt.Errorf("project field %q is %s, not NUMBER", fieldName, field.DataType)
+	}
+	return field.ID, nil
+}
+
+// updateProjectEstimate reads and optionally updates the estimate field of an issue's
+// project item, returning the resulting estimate
+func (c *GitHubClient) updateProjectEstimate(issueNumber int, settings EstimateSettings, set, clearValue bool, points float64) (*float64, error) {
+	projectID, err := c.GetProjectID(settings.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	if !set && !clearValue {
+		issues, err := c.listEstimatedIssues("", settings, &issueNumber)
+		if err != nil {
+			return nil, err
+		}
+		if len(issues) == 0 {
+			return nil, fmt.Errorf("issue #%d not found", issueNumber)
+		}
+		return issues[0].Points, nil
+	}
+
+	fieldID, err := c.getProjectNumberFieldID(projectID, settings.Field)
+	if err != nil {
+		return nil, err
+	}
+
+	repoID, err := c.GetRepositoryID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository ID: %w", err)
+	}
+	issue, err := c.GetLabelableInfo(repoID, "Issue", issueNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issue #%d: %w", issueNumber, err)
+	}
+
+	itemID, err := c.addProjectItem(projectID, issue.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if clearValue {
+		clearMutation := `
+		mutation($projectId: ID!, $itemId: ID!, $fieldId: ID!) {
+			clearProjectV2ItemFieldValue(input: {projectId: $projectId, itemId: $itemId, fieldId: $fieldId}) {
+				projectV2Item { id }
+			}
+		}`
+		if _, err := c.RunGraphQLQueryWithVariables(clearMutation, map[string]interface{}{
+			"projectId": projectID,
+			"itemId":    itemID,
+			"fieldId":   fieldID,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to clear project field: %w", err)
+		}
+		return nil, nil
+	}
+
+	updateMutation := `
+	mutation($projectId: ID!, $itemId: ID!, $fieldId: ID!, $value: Float!) {
+		updateProjectV2ItemFieldValue(input: {projectId: $projectId, itemId: $itemId, fieldId: $fieldId, value: {number: $value}}) {
+			projectV2Item { id }
+		}
+	}`
+	if _, err := c.RunGraphQLQueryWithVariables(updateMutation, map[string]interface{}{
+		"projectId": projectID,
+		"itemId":    itemID,
+		"fieldId":   fieldID,
+		"value":     points,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update project field: %w", err)
+	}
+
+	return &points, nil
+}
+
+// listEstimatedIssues fetches issues in a milestone (or a single issue) with their estimates
+func (c *GitHubClient) listEstimatedIssues(milestoneNumber string, settings EstimateSettings, single *int) ([]EstimatedIssue, error) {
+	const issueFields = `
+					number
+					title
+					state
+					labels(first: 50) {
+						nodes {
+							name
+						}
+					}
+					projectItems(first: 20) {
+						nodes {
+							project {
+								title
+							}
+							fieldValueByName(name: $field) {
+								... on ProjectV2ItemFieldNumberValue {
+									number
+								}
+							}
+						}
+					}`
+
+	type issueNode struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		State  string `json:"state"`
+		Labels struct {
+			Nodes []struct {
+				Name string `json:"name"`
+			} `json:"nodes"`
+		} `json:"labels"`
+		ProjectItems struct {
+			Nodes []struct {
+				Project struct {
+					Title string `json:"title"`
+				} `json:"project"`
+				FieldValueByName *struct {
+					Number *float64 `json:"number"`
+				} `json:"fieldValueByName"`
+			} `json:"nodes"`
+		} `json:"projectItems"`
+	}
+
+	toEstimated := func(node issueNode) EstimatedIssue {
+		issue := EstimatedIssue{Number: node.Number, Title: node.Title, State: node.State}
+		if settings.Project != "" {
+			for _, item := range node.ProjectItems.Nodes {
+				if item.Project.Title == settings.Project && item.FieldValueByName != nil && item.FieldValueByName.Number != nil {
+					points := *item.FieldValueByName.Number
+					issue.Points = &points
+				}
+			}
+			return issue
+		}
+		var labels []string
+		for _, label := range node.Labels.Nodes {
+			labels = append(labels, label.Name)
+		}
+		if points, ok := estimateFromLabels(labels, settings.LabelPrefix); ok {
+			issue.Points = &points
+		}
+		return issue
+	}
+
+	if single != nil {
+		query := `
+		query($owner: String!, $repo: String!, $number: Int!, $field: String!) {
+			repository(owner: $owner, name: $repo) {
+				issue(number: $number) {` + issueFields + `
+				}
+			}
+		}`
+		responseData, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+			"owner":  c.Owner,
+			"repo":   c.Repo,
+			"number": *single,
+			"field":  settings.Field,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var response struct {
+			Data struct {
+				Repository struct {
+					Issue *issueNode `json:"issue"`
+				} `json:"repository"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return nil, err
+		}
+		if response.Data.Repository.Issue == nil {
+			return nil, nil
+		}
+		return []EstimatedIssue{toEstimated(*response.Data.Repository.Issue)}, nil
+	}
+
+	query := `
+	query($owner: String!, $repo: String!, $filterBy: IssueFilters, $after: String, $field: String!) {
+		repository(owner: $owner, name: $repo) {
+			issues(first: 100, after: $after, filterBy: $filterBy, orderBy: {field: CREATED_AT, direction: ASC}) {
+				nodes {` + issueFields + `
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	}`
+
+	var issues []EstimatedIssue
+	var after *string
+	for {
+		variables := map[string]interface{}{
+			"owner":    c.Owner,
+			"repo":     c.Repo,
+			"filterBy": map[string]interface{}{"milestoneNumber": milestoneNumber},
+			"after":    after,
+			"field":    settings.Field,
+		}
+
+		responseData, err := c.RunGraphQLQueryWithVariables(query, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Data struct {
+				Repository struct {
+					Issues struct {
+						Nodes    []issueNode `json:"nodes"`
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+					} `json:"issues"`
+				} `json:"repository"`
+			} `json:"data"`
+		}
+
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return nil, err
+		}
+
+		for _, node := range response.Data.Repository.Issues.Nodes {
+			issues = append(issues, toEstimated(node))
+		}
+
+		pageInfo := response.Data.Repository.Issues.PageInfo
+		if !pageInfo.HasNextPage {
+			break
+		}
+		cursor := pageInfo.EndCursor
+		after = &cursor
+	}
+
+	return issues, nil
+}
+
+func reportEstimates(cmd *cobra.Command, args []string) error {
+	milestone, err := cmd.Flags().GetString("milestone")
+	if err != nil {
+		return fmt.Errorf("failed to get 'milestone' flag: %w", err)
+	}
+	settings, err := getEstimateSettings(cmd)
+	if err != nil {
+		return err
+	}
+
+	client := newCommandClient(cmd)
+
+	milestoneNumber, err := client.GetMilestoneNumber(milestone)
+	if err != nil {
+		return err
+	}
+
+	issues, err := client.listEstimatedIssues(strconv.Itoa(milestoneNumber), settings, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	report := summarizeEstimates(issues)
+	report.Milestone = milestone
+	report.Source = "labels (" + settings.LabelPrefix + "N)"
+	if settings.Project != "" {
+		report.Source = fmt.Sprintf("project %q field %q", settings.Project, settings.Field)
+	}
+
+	if ResolveFormat(cmd) == FormatMarkdown {
+		outputMarkdownEstimateReport(cmd.OutOrStdout(), report)
+		return nil
+	}
+
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"estimateReport": report})
+}
+
+func outputMarkdownEstimateReport(w io.Writer, report EstimateReport) {
+	fmt.Fprintf(w, "# Estimates: %s\n\n", report.Milestone)
+	fmt.Fprintf(w, "Source: %s\n\n", report.Source)
+	fmt.Fprintln(w, "| State | Issues | Estimated | Points |")
+	fmt.Fprintln(w, "|-------|--------|-----------|--------|")
+
+	states := make([]string, 0, len(report.ByState))
+	for state := range report.ByState {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+	for _, state := range states {
+		summary := report.ByState[state]
+		fmt.Fprintf(w, "| %s | %d | %d | %s |\n", state, summary.Issues, summary.Estimated, formatPoints(summary.Points))
+	}
+	fmt.Fprintf(w, "\n**Total points:** %s\n", formatPoints(report.TotalPoints))
+
+	if len(report.Unestimated) > 0 {
+		fmt.Fprintf(w, "\n## Unestimated (%d)\n\n", len(report.Unestimated))
+		for _, issue := range report.Unestimated {
+			fmt.Fprintf(w, "- #%d %s (%s)\n", issue.Number, issue.Title, issue.State)
+		}
+	}
+}
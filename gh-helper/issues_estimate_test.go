@@ -0,0 +1,86 @@
+package main
+
+import "testing"
+
+func TestEstimateFromLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		labels    []string
+		prefix    string
+		want      float64
+		wantFound bool
+	}{
+		{name: "integer", labels: []string{"bug", "points/5"}, prefix: "points/", want: 5, wantFound: true},
+		{name: "fraction", labels: []string{"points/0.5"}, prefix: "points/", want: 0.5, wantFound: true},
+		{name: "none", labels: []string{"bug"}, prefix: "points/", wantFound: false},
+		{name: "non-numeric ignored", labels: []string{"points/large"}, prefix: "points/", wantFound: false},
+		{name: "custom prefix", labels: []string{"sp:3"}, prefix: "sp:", want: 3, wantFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := estimateFromLabels(tt.labels, tt.prefix)
+			if found != tt.wantFound || got != tt.want {
+				t.Errorf("estimateFromLabels() = (%v, %v), want (%v, %v)", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestEstimateLabelChange(t *testing.T) {
+	labels := []Label{{ID: "L1", Name: "bug"}, {ID: "L2", Name: "points/3"}, {ID: "L3", Name: "points/large"}}
+
+	tests := []struct {
+		name       string
+		labels     []Label
+		clear      bool
+		points     float64
+		wantRemove []string
+		wantAdd    bool
+	}{
+		{name: "replace estimate", labels: labels, points: 5, wantRemove: []string{"L2"}, wantAdd: true},
+		{name: "unchanged estimate", labels: labels, points: 3},
+		{name: "clear estimate", labels: labels, clear: true, wantRemove: []string{"L2"}},
+		{name: "clear without estimate", labels: labels[:1], clear: true},
+		{name: "set first estimate", labels: labels[:1], points: 1, wantAdd: true},
+		{name: "duplicate estimates", labels: []Label{{ID: "L2", Name: "points/3"}, {ID: "L4", Name: "points/3.0"}}, points: 3, wantRemove: []string{"L4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remove, add := estimateLabelChange(tt.labels, "points/", tt.clear, tt.points)
+			if len(remove) != len(tt.wantRemove) || add != tt.wantAdd {
+				t.Fatalf("estimateLabelChange() = (%v, %v), want (%v, %v)", remove, add, tt.wantRemove, tt.wantAdd)
+			}
+			for i := range remove {
+				if remove[i] != tt.wantRemove[i] {
+					t.Errorf("estimateLabelChange() removes %v, want %v", remove, tt.wantRemove)
+				}
+			}
+		})
+	}
+}
+
+func TestSummarizeEstimates(t *testing.T) {
+	three, five := 3.0, 5.0
+	issues := []EstimatedIssue{
+		{Number: 1, State: "OPEN", Points: &three},
+		{Number: 2, State: "OPEN"},
+		{Number: 3, State: "CLOSED", Points: &five},
+	}
+
+	report := summarizeEstimates(issues)
+
+	if report.TotalPoints != 8 {
+		t.Errorf("TotalPoints = %v, want 8", report.TotalPoints)
+	}
+	if got := report.ByState["OPEN"]; got.Issues != 2 || got.Estimated != 1 || got.Points != 3 {
+		t.Errorf("OPEN summary = %+v", got)
+	}
+	if got := report.ByState["CLOSED"]; got.Issues != 1 || got.Points != 5 {
+		t.Errorf("CLOSED summary = %+v", got)
+	}
+	if len(report.Unestimated) != 1 || report.Unestimated[0].Number != 2 {
+		t.Errorf("Unestimated = %+v, want [#2]", report.Unestimated)
+	}
+}
@@ -0,0 +1,438 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var exportIssuesCmd = NewOperationalCommand(
+	"export [flags]",
+	"Export issues as flat rows for spreadsheets",
+	`Export issues as flat rows (CSV/TSV) including parent issue numbers
+derived from the sub-issue hierarchy.
+
+Available fields:
+  number, title, state, parent, parentTitle, subIssues, assignees,
+  labels, milestone, author, url, createdAt, closedAt
+
+Multi-valued fields (assignees, labels) are joined with ", ".
+
+Examples:
+  # Export a milestone as CSV
+  gh-helper issues export --milestone v0.20.0 --format csv
+
+  # Choose columns and write to a file
+  gh-helper issues export --milestone v0.20.0 --format csv \
+    --fields number,title,state,parent,assignees,labels --output v0.20.0.csv
+
+  # Open issues only, as TSV for pasting into a spreadsheet
+  gh-helper issues export --state open --format tsv
+
+  # Structured output (YAML/JSON) of the same rows
+  gh-helper issues export --milestone v0.20.0 --json`,
+	exportIssues,
+)
+
+// defaultExportFields is the column set used when --fields is not given
+var defaultExportFields = []string{"number", "title", "state", "parent", "assignees", "labels"}
+
+func init() {
+	addOutputFormats(exportIssuesCmd, FormatCSV, FormatTSV)
+	exportIssuesCmd.Flags().String("milestone", "", "Only export issues in this milestone (title)")
+	exportIssuesCmd.Flags().String("state", "all", "Issue state to export (open|closed|all)")
+	exportIssuesCmd.Flags().StringSlice("fields", defaultExportFields, "Columns to export (comma-separated)")
+	exportIssuesCmd.Flags().StringP("output", "o", "", "Write to file instead of stdout")
+
+	issuesCmd.AddCommand(exportIssuesCmd)
+}
+
+// ExportIssue is a flattened issue row used for CSV/TSV export
+type ExportIssue struct {
+	Number      int      `json:"number"`
+	Title       string   `json:"title"`
+	State       string   `json:"state"`
+	Parent      int      `json:"parent,omitempty"`
+	ParentTitle string   `json:"parentTitle,omitempty"`
+	SubIssues   int      `json:"subIssues"`
+	Assignees   []string `json:"assignees"`
+	Labels      []string `json:"labels"`
+	Milestone   string   `json:"milestone,omitempty"`
+	Author      string   `json:"author,omitempty"`
+	URL         string   `json:"url"`
+	CreatedAt   string   `json:"createdAt"`
+	ClosedAt    string   `json:"closedAt,omitempty"`
+}
+
+// exportFieldValue returns the cell value of the named field for an issue
+func exportFieldValue(issue ExportIssue, field string) (string, error) {
+	switch field {
+	case "number":
+		return strconv.Itoa(issue.Number), nil
+	case "title":
+		return issue.Title, nil
+	case "state":
+		return issue.State, nil
+	case "parent":
+		if issue.Parent == 0 {
+			return "", nil
+		}
+		return strconv.Itoa(issue.Parent), nil
+	case "parentTitle":
+		return issue.ParentTitle, nil
+	case "subIssues":
+		return strconv.Itoa(issue.SubIssues), nil
+	case "assignees":
+		return strings.Join(issue.Assignees, ", "), nil
+	case "labels":
+		return strings.Join(issue.Labels, ", "), nil
+	case "milestone":
+		return issue.Milestone, nil
+	case "author":
+		return issue.Author, nil
+	case "url":
+		return issue.URL, nil
+	case "createdAt":
+		return issue.CreatedAt, nil
+	case "closedAt":
+		return issue.ClosedAt, nil
+	default:
+		return "", fmt.Errorf("unknown field: %s", field)
+	}
+}
+
+// writeIssuesDelimited writes issues as CSV (or TSV when comma is '\t') with a header row
+func writeIssuesDelimited(w io.Writer, issues []ExportIssue, fields []string, comma rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+
+	if err := writer.Write(fields); err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		record := make([]string, len(fields))
+		for i, field := range fields {
+			value, err := exportFieldValue(issue, field)
+			if err != nil {
+				return err
+			}
+			record[i] = value
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func exportIssues(cmd *cobra.Command, args []string) error {
+	milestone, err := cmd.Flags().GetString("milestone")
+	if err != nil {
+		return fmt.Errorf("failed to get 'milestone' flag: %w", err)
+	}
+	state, err := cmd.Flags().GetString("state")
+	if err != nil {
+		return fmt.Errorf("failed to get 'state' flag: %w", err)
+	}
+	fields, err := cmd.Flags().GetStringSlice("fields")
+	if err != nil {
+		return fmt.Errorf("failed to get 'fields' flag: %w", err)
+	}
+	outputPath, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("failed to get 'output' flag: %w", err)
+	}
+
+	// Validate field names before hitting the API
+	for _, field := range fields {
+		if _, err := exportFieldValue(ExportIssue{}, field); err != nil {
+			return err
+		}
+	}
+
+	var states []string
+	switch strings.ToLower(state) {
+	case "open":
+		states = []string{"OPEN"}
+	case "closed":
+		states = []string{"CLOSED"}
+	case "all", "":
+		states = []string{"OPEN", "CLOSED"}
+	default:
+		return fmt.Errorf("invalid state: %s (must be open, closed, or all)", state)
+	}
+
+	client := newCommandClient(cmd)
+
+	var milestoneNumber string
+	if milestone != "" {
+		number, err := client.GetMilestoneNumber(milestone)
+		if err != nil {
+			return fmt.Errorf("failed to resolve milestone: %w", err)
+		}
+		milestoneNumber = strconv.Itoa(number)
+	}
+
+	issues, err := client.ListIssuesForExport(states, milestoneNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch issues: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	if outputPath != "" {
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	switch ResolveFormat(cmd) {
+	case FormatCSV:
+		err = writeIssuesDelimited(out, issues, fields, ',')
+	case FormatTSV:
+		err = writeIssuesDelimited(out, issues, fields, '\t')
+	default:
+		output := map[string]interface{}{
+			"issuesExport": map[string]interface{}{
+				"milestone": milestone,
+				"count":     len(issues),
+				"issues":    issues,
+			},
+		}
+		if outputPath != "" {
+			err = EncodeOutput(out, ResolveFormat(cmd), output)
+		} else {
+			err = EncodeOutputWithCmd(cmd, output)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	if outputPath != "" {
+		fmt.Fprintf(messageWriter(cmd), "Exported %d issue(s) to %s\n", len(issues), outputPath)
+	}
+	return nil
+}
+
+// GetMilestoneNumber returns the number of the milestone with the given title (open or closed)
+func (c *GitHubClient) GetMilestoneNumber(title string) (int, error) {
+	query := `
+	query($owner: String!, $repo: String!, $title: String!) {
+		repository(owner: $owner, name: $repo) {
+			milestones(query: $title, first: 20, states: [OPEN, CLOSED]) {
+				nodes {
+					number
+					title
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"owner": c.Owner,
+		"repo":  c.Repo,
+		"title": title,
+	}
+
+	responseData, err := c.RunGraphQLQueryWithVariables(query, variables)
+	if err != nil {
+		return 0, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository struct {
+				Milestones struct {
+					Nodes []struct {
+						Number int    `json:"number"`
+						Title  string `json:"title"`
+					} `json:"nodes"`
+				} `json:"milestones"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return 0, err
+	}
+
+	// The milestones query is a substring match, so require an exact title
+	for _, node := range response.Data.Repository.Milestones.Nodes {
+		if node.Title == title {
+			return node.Number, nil
+		}
+	}
+
+	return 0, fmt.Errorf("milestone not found: %s", title)
+}
+
+// ListIssuesForExport fetches all issues matching the given states and optional
+// milestone number, following pagination
+func (c *GitHubClient) ListIssuesForExport(states []string, milestoneNumber string) ([]ExportIssue, error) {
+	query := `
+	query($owner: String!, $repo: String!, $states: [IssueState!], $filterBy: IssueFilters, $after: String) {
+		repository(owner: $owner, name: $repo) {
+			issues(first: 100, after: $after, states: $states, filterBy: $filterBy, orderBy: {field: CREATED_AT, direction: ASC}) {
+				nodes {
+					number
+					title
+					state
+					url
+					createdAt
+					closedAt
+					author {
+						login
+					}
+					milestone {
+						title
+					}
+					parent {
+						number
+						title
+					}
+					subIssues {
+						totalCount
+					}
+					assignees(first: 20) {
+						nodes {
+							login
+						}
+					}
+					labels(first: 20) {
+						nodes {
+							name
+						}
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	}`
+
+	var filterBy map[string]interface{}
+	if milestoneNumber != "" {
+		filterBy = map[string]interface{}{"milestoneNumber": milestoneNumber}
+	}
+
+	var issues []ExportIssue
+	var after *string
+	for {
+		variables := map[string]interface{}{
+			"owner":    c.Owner,
+			"repo":     c.Repo,
+			"states":   states,
+			"filterBy": filterBy,
+			"after":    after,
+		}
+
+		responseData, err := c.RunGraphQLQueryWithVariables(query, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Data struct {
+				Repository struct {
+					Issues struct {
+						Nodes []struct {
+							Number    int     `json:"number"`
+							Title     string  `json:"title"`
+							State     string  `json:"state"`
+							URL       string  `json:"url"`
+							CreatedAt string  `json:"createdAt"`
+							ClosedAt  *string `json:"closedAt"`
+							Author    *struct {
+								Login string `json:"login"`
+							} `json:"author"`
+							Milestone *struct {
+								Title string `json:"title"`
+							} `json:"milestone"`
+							Parent *struct {
+								Number int    `json:"number"`
+								Title  string `json:"title"`
+							} `json:"parent"`
+							SubIssues struct {
+								TotalCount int `json:"totalCount"`
+							} `json:"subIssues"`
+							Assignees struct {
+								Nodes []struct {
+									Login string `json:"login"`
+								} `json:"nodes"`
+							} `json:"assignees"`
+							Labels struct {
+								Nodes []struct {
+									Name string `json:"name"`
+								} `json:"nodes"`
+							} `json:"labels"`
+						} `json:"nodes"`
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+					} `json:"issues"`
+				} `json:"repository"`
+			} `json:"data"`
+		}
+
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return nil, err
+		}
+
+		for _, node := range response.Data.Repository.Issues.Nodes {
+			issue := ExportIssue{
+				Number:    node.Number,
+				Title:     node.Title,
+				State:     node.State,
+				SubIssues: node.SubIssues.TotalCount,
+				Assignees: []string{},
+				Labels:    []string{},
+				URL:       node.URL,
+				CreatedAt: node.CreatedAt,
+			}
+			if node.ClosedAt != nil {
+				issue.ClosedAt = *node.ClosedAt
+			}
+			if node.Author != nil {
+				issue.Author = node.Author.Login
+			}
+			if node.Milestone != nil {
+				issue.Milestone = node.Milestone.Title
+			}
+			if node.Parent != nil {
+				issue.Parent = node.Parent.Number
+				issue.ParentTitle = node.Parent.Title
+			}
+			for _, assignee := range node.Assignees.Nodes {
+				issue.Assignees = append(issue.Assignees, assignee.Login)
+			}
+			for _, label := range node.Labels.Nodes {
+				issue.Labels = append(issue.Labels, label.Name)
+			}
+			issues = append(issues, issue)
+		}
+
+		pageInfo := response.Data.Repository.Issues.PageInfo
+		if !pageInfo.HasNextPage {
+			break
+		}
+		cursor := pageInfo.EndCursor
+		after = &cursor
+	}
+
+	return issues, nil
+}
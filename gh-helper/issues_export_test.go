@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteIssuesDelimited(t *testing.T) {
+	issues := []ExportIssue{
+		{
+			Number:    10,
+			Title:     "Epic: caching",
+			State:     "OPEN",
+			SubIssues: 2,
+			Assignees: []string{"alice"},
+			Labels:    []string{"enhancement"},
+		},
+		{
+			Number:    11,
+			Title:     "Write cache tests, part 1",
+			State:     "CLOSED",
+			Parent:    10,
+			Assignees: []string{"alice", "bob"},
+			Labels:    []string{},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		fields   []string
+		comma    rune
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:   "CSV with default fields",
+			fields: defaultExportFields,
+			comma:  ',',
+			expected: "number,title,state,parent,assignees,labels\n" +
+				"10,Epic: caching,OPEN,,alice,enhancement\n" +
+				"11,\"Write cache tests, part 1\",CLOSED,10,\"alice, bob\",\n",
+		},
+		{
+			name:   "TSV with selected fields",
+			fields: []string{"number", "parent", "subIssues"},
+			comma:  '\t',
+			expected: "number\tparent\tsubIssues\n" +
+				"10\t\t2\n" +
+				"11\t10\t0\n",
+		},
+		{
+			name:    "unknown field",
+			fields:  []string{"number", "priority"},
+			comma:   ',',
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := writeIssuesDelimited(&buf, issues, tt.fields, tt.comma)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := buf.String(); got != tt.expected {
+				t.Errorf("output mismatch\ngot:\n%s\nwant:\n%s", got, tt.expected)
+			}
+		})
+	}
+}
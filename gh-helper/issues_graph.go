@@ -0,0 +1,452 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var graphIssuesCmd = NewOperationalCommand(
+	"graph [flags]",
+	"Export parent/sub-issue and blocked-by relationships as a graph",
+	`Export the relationships between the issues of a milestone as a graph for
+planning and architecture docs:
+
+  parent -> sub-issue      solid edge
+  blocker -> blocked issue dashed edge labeled "blocks"
+
+Nodes are colored by state (open: green, closed: purple). Related issues
+outside the milestone are included with a dashed border. Cycles in the
+relationships are reported and drawn in red.
+
+--format dot writes Graphviz, --format mermaid a Mermaid flowchart; YAML/JSON
+output lists the nodes, edges, and cycles.
+
+Blocked-by relationships need a GitHub API with issue dependencies; when it is
+unavailable, only parent/sub-issue edges are exported with a warning.
+
+Examples:
+  # Graphviz SVG of a milestone
+  gh-helper issues graph --milestone v0.20.0 --format dot | dot -Tsvg > v0.20.0.svg
+
+  # Mermaid block for a design doc
+  gh-helper issues graph --milestone v0.20.0 --format mermaid --output docs/plan.mmd`,
+	graphIssues,
+)
+
+func init() {
+	addOutputFormats(graphIssuesCmd, FormatDOT, FormatMermaid)
+	graphIssuesCmd.Flags().String("milestone", "", "Milestone whose issues to graph (required)")
+	graphIssuesCmd.Flags().StringP("output", "o", "", "Write to file instead of stdout")
+	_ = graphIssuesCmd.MarkFlagRequired("milestone")
+
+	issuesCmd.AddCommand(graphIssuesCmd)
+}
+
+// Edge kinds of IssueGraphEdge
+const (
+	issueEdgeParent = "parent" // From is the parent of To
+	issueEdgeBlocks = "blocks" // From blocks To
+)
+
+// IssueGraph is the relationship graph of a milestone's issues
+type IssueGraph struct {
+	Milestone string           `json:"milestone"`
+	Nodes     []IssueGraphNode `json:"nodes"`
+	Edges     []IssueGraphEdge `json:"edges"`
+	Cycles    [][]int          `json:"cycles,omitempty"` // issue numbers along each cycle
+}
+
+// IssueGraphNode is an issue in the graph
+type IssueGraphNode struct {
+	Number   int    `json:"number"`
+	Title    string `json:"title"`
+	State    string `json:"state"`
+	External bool   `json:"external,omitempty"` // related issue outside the milestone
+}
+
+// IssueGraphEdge is a relationship between two issues
+type IssueGraphEdge struct {
+	From    int    `json:"from"`
+	To      int    `json:"to"`
+	Kind    string `json:"kind"`
+	InCycle bool   `json:"inCycle,omitempty"`
+}
+
+// graphIssue is a milestone issue with its relationships as returned by the API
+type graphIssue struct {
+	Number    int             `json:"number"`
+	Title     string          `json:"title"`
+	State     string          `json:"state"`
+	Parent    *IssueGraphNode `json:"parent"`
+	SubIssues struct {
+		Nodes []IssueGraphNode `json:"nodes"`
+	} `json:"subIssues"`
+	BlockedBy struct {
+		Nodes []IssueGraphNode `json:"nodes"`
+	} `json:"blockedBy"`
+}
+
+// buildIssueGraph collects nodes and deduplicated edges from the milestone
+// issues and marks the edges on cycles
+func buildIssueGraph(milestone string, issues []graphIssue) *IssueGraph {
+	graph := &IssueGraph{Milestone: milestone, Nodes: []IssueGraphNode{}, Edges: []IssueGraphEdge{}}
+	nodes := map[int]int{} // number -> index in graph.Nodes
+	addNode := func(node IssueGraphNode, external bool) {
+		if i, ok := nodes[node.Number]; ok {
+			if !external {
+				graph.Nodes[i].External = false
+			}
+			return
+		}
+		node.External = external
+		nodes[node.Number] = len(graph.Nodes)
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	edges := map[IssueGraphEdge]bool{}
+	addEdge := func(from, to int, kind string) {
+		edge := IssueGraphEdge{From: from, To: to, Kind: kind}
+		if !edges[edge] {
+			edges[edge] = true
+			graph.Edges = append(graph.Edges, edge)
+		}
+	}
+
+	for _, issue := range issues {
+		addNode(IssueGraphNode{Number: issue.Number, Title: issue.Title, State: issue.State}, false)
+	}
+	for _, issue := range issues {
+		if issue.Parent != nil {
+			addNode(*issue.Parent, true)
+			addEdge(issue.Parent.Number, issue.Number, issueEdgeParent)
+		}
+		for _, sub := range issue.SubIssues.Nodes {
+			addNode(sub, true)
+			addEdge(issue.Number, sub.Number, issueEdgeParent)
+		}
+		for _, blocker := range issue.BlockedBy.Nodes {
+			addNode(blocker, true)
+			addEdge(blocker.Number, issue.Number, issueEdgeBlocks)
+		}
+	}
+
+	graph.Cycles = findIssueCycles(graph.Edges)
+	onCycle := map[[2]int]bool{}
+	for _, cycle := range graph.Cycles {
+		for i := range cycle {
+			onCycle[[2]int{cycle[i], cycle[(i+1)%len(cycle)]}] = true
+		}
+	}
+	for i := range graph.Edges {
+		graph.Edges[i].InCycle = onCycle[[2]int{graph.Edges[i].From, graph.Edges[i].To}]
+	}
+	return graph
+}
+
+// findIssueCycles walks the edges depth-first and returns each cycle found
+// through a back edge, as the issue numbers along it
+func findIssueCycles(edges []IssueGraphEdge) [][]int {
+	adjacency := map[int][]int{}
+	var starts []int
+	for _, edge := range edges {
+		if _, ok := adjacency[edge.From]; !ok {
+			starts = append(starts, edge.From)
+		}
+		adjacency[edge.From] = append(adjacency[edge.From], edge.To)
+	}
+	sort.Ints(starts)
+
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := map[int]int{}
+	var path []int
+	var cycles [][]int
+	var visit func(number int)
+	visit = func(number int) {
+		state[number] = onPath
+		path = append(path, number)
+		for _, next := range adjacency[number] {
+			switch state[next] {
+			case onPath:
+				for i := len(path) - 1; i >= 0; i-- {
+					if path[i] == next {
+						cycles = append(cycles, append([]int(nil), path[i:]...))
+						break
+					}
+				}
+			case unvisited:
+				visit(next)
+			}
+		}
+		path = path[:len(path)-1]
+		state[number] = done
+	}
+	for _, start := range starts {
+		if state[start] == unvisited {
+			visit(start)
+		}
+	}
+	return cycles
+}
+
+// issueGraphLabel is the node label "#N title", shortened for readability
+func issueGraphLabel(node IssueGraphNode) string {
+	title := node.Title
+	if runes := []rune(title); len(runes) > 50 {
+		title = string(runes[:49]) + "…"
+	}
+	return fmt.Sprintf("#%d %s", node.Number, title)
+}
+
+// issueGraphColor is the fill color of a node state
+func issueGraphColor(state string) string {
+	if state == "CLOSED" {
+		return "#e2d5f7"
+	}
+	return "#c6efce"
+}
+
+// writeIssueGraphDOT writes the graph in Graphviz DOT
+func writeIssueGraphDOT(w io.Writer, graph *IssueGraph) {
+	fmt.Fprintf(w, "digraph %s {\n", strconv.Quote("issues "+graph.Milestone))
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=box, style=filled];")
+	for _, node := range graph.Nodes {
+		style := ""
+		if node.External {
+			style = `, style="filled,dashed"`
+		}
+		fmt.Fprintf(w, "  i%d [label=%s, fillcolor=%q%s];\n", node.Number, strconv.Quote(issueGraphLabel(node)), issueGraphColor(node.State), style)
+	}
+	for _, edge := range graph.Edges {
+		var attrs []string
+		if edge.Kind == issueEdgeBlocks {
+			attrs = append(attrs, "style=dashed", `label="blocks"`)
+		}
+		if edge.InCycle {
+			attrs = append(attrs, "color=red")
+		}
+		if len(attrs) > 0 {
+			fmt.Fprintf(w, "  i%d -> i%d [%s];\n", edge.From, edge.To, strings.Join(attrs, ", "))
+		} else {
+			fmt.Fprintf(w, "  i%d -> i%d;\n", edge.From, edge.To)
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// writeIssueGraphMermaid writes the graph as a Mermaid flowchart
+func writeIssueGraphMermaid(w io.Writer, graph *IssueGraph) {
+	fmt.Fprintln(w, "flowchart LR")
+	classes := map[string][]string{}
+	for _, node := range graph.Nodes {
+		label := strings.ReplaceAll(issueGraphLabel(node), `"`, "#quot;")
+		fmt.Fprintf(w, "  i%d[\"%s\"]\n", node.Number, label)
+		class := strings.ToLower(node.State)
+		if node.External {
+			class += "External"
+		}
+		classes[class] = append(classes[class], fmt.Sprintf("i%d", node.Number))
+	}
+	var cycleLinks []string
+	for i, edge := range graph.Edges {
+		if edge.Kind == issueEdgeBlocks {
+			fmt.Fprintf(w, "  i%d -. blocks .-> i%d\n", edge.From, edge.To)
+		} else {
+			fmt.Fprintf(w, "  i%d --> i%d\n", edge.From, edge.To)
+		}
+		if edge.InCycle {
+			cycleLinks = append(cycleLinks, strconv.Itoa(i))
+		}
+	}
+	for _, state := range []string{"open", "closed"} {
+		color := issueGraphColor(strings.ToUpper(state))
+		fmt.Fprintf(w, "  classDef %s fill:%s\n", state, color)
+		fmt.Fprintf(w, "  classDef %sExternal fill:%s,stroke-dasharray:4\n", state, color)
+	}
+	names := make([]string, 0, len(classes))
+	for class := range classes {
+		names = append(names, class)
+	}
+	sort.Strings(names)
+	for _, class := range names {
+		fmt.Fprintf(w, "  class %s %s\n", strings.Join(classes[class], ","), class)
+	}
+	if len(cycleLinks) > 0 {
+		fmt.Fprintf(w, "  linkStyle %s stroke:red\n", strings.Join(cycleLinks, ","))
+	}
+}
+
+// listGraphIssues fetches the issues of a milestone with their parent and
+// sub-issues, following pagination
+func (c *GitHubClient) listGraphIssues(milestoneNumber int) ([]graphIssue, error) {
+	query := `
+	query($owner: String!, $repo: String!, $milestone: Int!, $after: String) {
+		repository(owner: $owner, name: $repo) {
+			milestone(number: $milestone) {
+				issues(first: 100, after: $after) {
+					nodes {
+						number title state
+						parent { number title state }
+						subIssues(first: 50) { nodes { number title state } }
+					}
+					pageInfo { hasNextPage endCursor }
+				}
+			}
+		}
+	}`
+
+	var issues []graphIssue
+	var after *string
+	for {
+		responseData, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+			"owner":     c.Owner,
+			"repo":      c.Repo,
+			"milestone": milestoneNumber,
+			"after":     after,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var response struct {
+			Data struct {
+				Repository struct {
+					Milestone *struct {
+						Issues struct {
+							Nodes    []graphIssue `json:"nodes"`
+							PageInfo struct {
+								HasNextPage bool   `json:"hasNextPage"`
+								EndCursor   string `json:"endCursor"`
+							} `json:"pageInfo"`
+						} `json:"issues"`
+					} `json:"milestone"`
+				} `json:"repository"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse milestone issues: %w", err)
+		}
+		if response.Data.Repository.Milestone == nil {
+			return nil, fmt.Errorf("milestone #%d not found", milestoneNumber)
+		}
+		page := response.Data.Repository.Milestone.Issues
+		issues = append(issues, page.Nodes...)
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		cursor := page.PageInfo.EndCursor
+		after = &cursor
+	}
+	return issues, nil
+}
+
+// getBlockedBy fetches the blocking issues of each issue in one aliased query
+func (c *GitHubClient) getBlockedBy(numbers []int) (map[int][]IssueGraphNode, error) {
+	blockedBy := map[int][]IssueGraphNode{}
+	for start := 0; start < len(numbers); start += 50 {
+		batch := numbers[start:min(start+50, len(numbers))]
+
+		var queryBuilder strings.Builder
+		queryBuilder.WriteString("query($owner: String!, $repo: String!) {\n  repository(owner: $owner, name: $repo) {\n")
+		for i, number := range batch {
+			fmt.Fprintf(&queryBuilder, "    issue%d: issue(number: %d) { blockedBy(first: 20) { nodes { number title state } } }\n", i, number)
+		}
+		queryBuilder.WriteString("  }\n}")
+
+		responseData, err := c.RunGraphQLQueryWithVariables(queryBuilder.String(), map[string]interface{}{
+			"owner": c.Owner,
+			"repo":  c.Repo,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var response struct {
+			Data struct {
+				Repository map[string]*struct {
+					BlockedBy struct {
+						Nodes []IssueGraphNode `json:"nodes"`
+					} `json:"blockedBy"`
+				} `json:"repository"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse blocked-by response: %w", err)
+		}
+		for i, number := range batch {
+			if issue := response.Data.Repository[fmt.Sprintf("issue%d", i)]; issue != nil && len(issue.BlockedBy.Nodes) > 0 {
+				blockedBy[number] = issue.BlockedBy.Nodes
+			}
+		}
+	}
+	return blockedBy, nil
+}
+
+func graphIssues(cmd *cobra.Command, args []string) error {
+	milestone, err := cmd.Flags().GetString("milestone")
+	if err != nil {
+		return fmt.Errorf("failed to get 'milestone' flag: %w", err)
+	}
+	outputFile, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("failed to get 'output' flag: %w", err)
+	}
+
+	client := newCommandClient(cmd)
+	milestoneNumber, err := client.GetMilestoneNumber(milestone)
+	if err != nil {
+		return err
+	}
+	issues, err := client.listGraphIssues(milestoneNumber)
+	if err != nil {
+		return fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	numbers := make([]int, len(issues))
+	for i, issue := range issues {
+		numbers[i] = issue.Number
+	}
+	if blockedBy, err := client.getBlockedBy(numbers); err != nil {
+		WarningMsg("Blocked-by relationships unavailable, exporting parent/sub-issue edges only: %v", err).Fprint(cmd.ErrOrStderr())
+	} else {
+		for i := range issues {
+			issues[i].BlockedBy.Nodes = blockedBy[issues[i].Number]
+		}
+	}
+
+	graph := buildIssueGraph(milestone, issues)
+	if len(graph.Cycles) > 0 {
+		WarningMsg("%d cycle(s) in issue relationships (drawn in red)", len(graph.Cycles)).Fprint(cmd.ErrOrStderr())
+	}
+
+	out := cmd.OutOrStdout()
+	if outputFile != "" {
+		file, err := os.Create(outputFile)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	switch ResolveFormat(cmd) {
+	case FormatDOT:
+		writeIssueGraphDOT(out, graph)
+		return nil
+	case FormatMermaid:
+		writeIssueGraphMermaid(out, graph)
+		return nil
+	}
+	if outputFile != "" {
+		return EncodeOutput(out, ResolveFormat(cmd), map[string]interface{}{"issueGraph": graph})
+	}
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"issueGraph": graph})
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindIssueCycles(t *testing.T) {
+	edges := []IssueGraphEdge{
+		{From: 1, To: 2, Kind: issueEdgeParent},
+		{From: 2, To: 3, Kind: issueEdgeBlocks},
+		{From: 3, To: 1, Kind: issueEdgeBlocks},
+		{From: 1, To: 4, Kind: issueEdgeParent},
+	}
+	want := [][]int{{1, 2, 3}}
+	if got := findIssueCycles(edges); !reflect.DeepEqual(got, want) {
+		t.Errorf("findIssueCycles() = %v, want %v", got, want)
+	}
+	if got := findIssueCycles(edges[:2]); got != nil {
+		t.Errorf("findIssueCycles() on a DAG = %v, want nil", got)
+	}
+}
+
+func TestBuildIssueGraph(t *testing.T) {
+	issues := []graphIssue{
+		{Number: 10, Title: "Epic", State: "OPEN"},
+		{Number: 11, Title: "Task", State: "CLOSED", Parent: &IssueGraphNode{Number: 10, Title: "Epic", State: "OPEN"}},
+	}
+	issues[0].SubIssues.Nodes = []IssueGraphNode{{Number: 11, Title: "Task", State: "CLOSED"}}
+	issues[0].BlockedBy.Nodes = []IssueGraphNode{{Number: 99, Title: "Elsewhere", State: "OPEN"}}
+
+	graph := buildIssueGraph("v1", issues)
+	if len(graph.Nodes) != 3 {
+		t.Fatalf("nodes = %+v, want 3", graph.Nodes)
+	}
+	if graph.Nodes[2].Number != 99 || !graph.Nodes[2].External {
+		t.Errorf("node %+v, want external #99", graph.Nodes[2])
+	}
+	want := []IssueGraphEdge{{From: 10, To: 11, Kind: issueEdgeParent}, {From: 99, To: 10, Kind: issueEdgeBlocks}}
+	if !reflect.DeepEqual(graph.Edges, want) {
+		t.Errorf("edges = %+v, want %+v (parent edge deduplicated)", graph.Edges, want)
+	}
+}
+
+func TestWriteIssueGraph(t *testing.T) {
+	graph := &IssueGraph{
+		Milestone: "v1",
+		Nodes: []IssueGraphNode{
+			{Number: 1, Title: `Say "hi"`, State: "OPEN"},
+			{Number: 2, Title: "Done", State: "CLOSED", External: true},
+		},
+		Edges: []IssueGraphEdge{{From: 2, To: 1, Kind: issueEdgeBlocks, InCycle: true}},
+	}
+
+	var dot bytes.Buffer
+	writeIssueGraphDOT(&dot, graph)
+	for _, want := range []string{
+		`i1 [label="#1 Say \"hi\"", fillcolor="#c6efce"];`,
+		`i2 [label="#2 Done", fillcolor="#e2d5f7", style="filled,dashed"];`,
+		`i2 -> i1 [style=dashed, label="blocks", color=red];`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("DOT output missing %q:\n%s", want, dot.String())
+		}
+	}
+
+	var mermaid bytes.Buffer
+	writeIssueGraphMermaid(&mermaid, graph)
+	for _, want := range []string{
+		`i1["#1 Say #quot;hi#quot;"]`,
+		"i2 -. blocks .-> i1",
+		"class i2 closedExternal",
+		"linkStyle 0 stroke:red",
+	} {
+		if !strings.Contains(mermaid.String(), want) {
+			t.Errorf("Mermaid output missing %q:\n%s", want, mermaid.String())
+		}
+	}
+}
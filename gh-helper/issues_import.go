@@ -0,0 +1,485 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var importIssuesCmd = NewOperationalCommand(
+	"import [flags]",
+	"Create issues in bulk from a CSV/TSV plan",
+	`Create issues in bulk from a CSV/TSV file, including parent relationships,
+labels, assignees, and milestones resolved from columns.
+
+Columns are mapped to issue fields with --map as field=Column pairs.
+Unmapped fields default to a column with the same name (case-insensitive).
+
+Fields:
+  title      Issue title (required)
+  body       Issue body
+  labels     Labels (comma or semicolon separated)
+  assignees  Assignee logins (comma or semicolon separated)
+  milestone  Milestone title
+  parent     Parent issue: an existing issue number (123 or #123), or the
+             id/title of another row in the same file
+  id         Row key used by other rows' parent column
+
+Issues are created first, then linked to their parents, so rows may appear
+in any order. A result mapping file (row, title, number, url, parent, status)
+is written next to the input unless --result-file is given. Rows whose issue
+was created but could not be linked to its parent get status link-failed, and
+the command exits with code 8.
+
+Examples:
+  # Preview what would be created
+  gh-helper issues import --file plan.csv --map "title=Title,parent=Epic" --dry-run
+
+  # Create issues with one second between mutations
+  gh-helper issues import --file plan.csv --map "title=Title,parent=Epic,labels=Labels,assignees=Owner"
+
+  # TSV input with an explicit result file
+  gh-helper issues import --file plan.tsv --result-file plan-created.csv`,
+	importIssues,
+)
+
+func init() {
+	importIssuesCmd.Flags().String("file", "", "CSV/TSV file to import (required; .tsv is tab-separated)")
+	importIssuesCmd.Flags().String("map", "", "Column mapping as field=Column pairs (e.g., \"title=Title,parent=Epic\")")
+	importIssuesCmd.Flags().Bool("dry-run", false, "Validate and show the plan without creating issues")
+	importIssuesCmd.Flags().Duration("delay", time.Second, "Delay between mutations to avoid secondary rate limits")
+	importIssuesCmd.Flags().String("result-file", "", "Result mapping file (default: <file>.result.csv)")
+
+	if err := importIssuesCmd.MarkFlagRequired("file"); err != nil {
+		panic(fmt.Sprintf("failed to mark file flag as required: %v", err))
+	}
+
+	issuesCmd.AddCommand(importIssuesCmd)
+}
+
+// importFields lists the issue fields that can be mapped from columns
+var importFields = []string{"title", "body", "labels", "assignees", "milestone", "parent", "id"}
+
+// IssuePlanRow is a single issue to create, parsed from an import file
+type IssuePlanRow struct {
+	Row       int      `json:"row"` // 1-based data row number (excluding header)
+	ID        string   `json:"id,omitempty"`
+	Title     string   `json:"title"`
+	Body      string   `json:"body,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Milestone string   `json:"milestone,omitempty"`
+	Parent    string   `json:"parent,omitempty"`
+
+	// Resolved parent: either another row index or an existing issue number
+	parentRow    int
+	parentNumber int
+}
+
+// ImportRowResult records the outcome for a single imported row
+type ImportRowResult struct {
+	Row    int    `json:"row"`
+	Title  string `json:"title"`
+	Number int    `json:"number,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Parent int    `json:"parent,omitempty"`
+	Status string `json:"status"` // planned, created, linked, link-failed, failed
+	Error  string `json:"error,omitempty"`
+}
+
+// parseImportMapping parses "field=Column,..." into a field to column map
+func parseImportMapping(spec string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	if strings.TrimSpace(spec) == "" {
+		return mapping, nil
+	}
+
+	for _, pair := range strings.Split(spec, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid mapping %q (expected field=Column)", pair)
+		}
+		field := strings.ToLower(strings.TrimSpace(parts[0]))
+		valid := false
+		for _, f := range importFields {
+			if f == field {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown field in mapping: %s (valid: %s)", field, strings.Join(importFields, ", "))
+		}
+		mapping[field] = strings.TrimSpace(parts[1])
+	}
+
+	return mapping, nil
+}
+
+// splitImportList splits a multi-valued cell on commas or semicolons
+func splitImportList(value string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseIssuePlan reads an import file and resolves in-file parent references
+func parseIssuePlan(r io.Reader, comma rune, mapping map[string]string) ([]IssuePlanRow, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	if comma == '\t' {
+		reader.LazyQuotes = true
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("import file is empty")
+	}
+
+	// Resolve each field to a column index
+	header := records[0]
+	columns := make(map[string]int)
+	for _, field := range importFields {
+		name, explicit := mapping[field]
+		if !explicit {
+			name = field
+		}
+		index := -1
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), name) {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			if explicit {
+				return nil, fmt.Errorf("column %q (mapped to %s) not found in header", name, field)
+			}
+			continue
+		}
+		columns[field] = index
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("no title column found (use --map title=Column)")
+	}
+
+	cell := func(record []string, field string) string {
+		index, ok := columns[field]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	var rows []IssuePlanRow
+	for i, record := range records[1:] {
+		row := IssuePlanRow{
+			Row:       i + 1,
+			ID:        cell(record, "id"),
+			Title:     cell(record, "title"),
+			Body:      cell(record, "body"),
+			Labels:    splitImportList(cell(record, "labels")),
+			Assignees: splitImportList(cell(record, "assignees")),
+			Milestone: cell(record, "milestone"),
+			Parent:    cell(record, "parent"),
+			parentRow: -1,
+		}
+		if row.Title == "" {
+			if strings.Join(record, "") == "" {
+				continue // Skip blank lines
+			}
+			return nil, fmt.Errorf("row %d: title is empty", row.Row)
+		}
+		rows = append(rows, row)
+	}
+
+	// Index rows by id and title for in-file parent references
+	byKey := make(map[string]int)
+	for i, row := range rows {
+		if row.ID != "" {
+			if _, dup := byKey[row.ID]; dup {
+				return nil, fmt.Errorf("row %d: duplicate id %q", row.Row, row.ID)
+			}
+			byKey[row.ID] = i
+		}
+	}
+	for i, row := range rows {
+		if _, exists := byKey[row.Title]; !exists {
+			byKey[row.Title] = i
+		}
+	}
+
+	for i := range rows {
+		parent := rows[i].Parent
+		if parent == "" {
+			continue
+		}
+		if index, ok := byKey[parent]; ok {
+			if index == i {
+				return nil, fmt.Errorf("row %d: issue cannot be its own parent", rows[i].Row)
+			}
+			rows[i].parentRow = index
+			continue
+		}
+		number, err := strconv.Atoi(strings.TrimPrefix(parent, "#"))
+		if err != nil || number <= 0 {
+			return nil, fmt.Errorf("row %d: parent %q is neither an issue number nor a row in this file", rows[i].Row, parent)
+		}
+		rows[i].parentNumber = number
+	}
+
+	// Detect cycles among in-file parent references
+	for i := range rows {
+		seen := map[int]bool{i: true}
+		for next := rows[i].parentRow; next >= 0; next = rows[next].parentRow {
+			if seen[next] {
+				return nil, fmt.Errorf("row %d: parent references form a cycle", rows[i].Row)
+			}
+			seen[next] = true
+		}
+	}
+
+	return rows, nil
+}
+
+func importIssues(cmd *cobra.Command, args []string) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("failed to get 'file' flag: %w", err)
+	}
+	mapSpec, err := cmd.Flags().GetString("map")
+	if err != nil {
+		return fmt.Errorf("failed to get 'map' flag: %w", err)
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get 'dry-run' flag: %w", err)
+	}
+	delay, err := cmd.Flags().GetDuration("delay")
+	if err != nil {
+		return fmt.Errorf("failed to get 'delay' flag: %w", err)
+	}
+	resultFile, err := cmd.Flags().GetString("result-file")
+	if err != nil {
+		return fmt.Errorf("failed to get 'result-file' flag: %w", err)
+	}
+
+	mapping, err := parseImportMapping(mapSpec)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer f.Close()
+
+	comma := ','
+	if strings.EqualFold(filepath.Ext(file), ".tsv") {
+		comma = '\t'
+	}
+
+	rows, err := parseIssuePlan(f, comma, mapping)
+	if err != nil {
+		return err
+	}
+
+	client := newCommandClient(cmd)
+
+	// Resolve names to IDs up front so that a typo fails before anything is created
+	repoID, err := client.GetRepositoryID()
+	if err != nil {
+		return fmt.Errorf("failed to get repository ID: %w", err)
+	}
+
+	var req ResolveRequest
+	for _, row := range rows {
+		req.Labels = append(req.Labels, row.Labels...)
+		req.Users = append(req.Users, row.Assignees...)
+		if row.Milestone != "" {
+			req.Milestones = append(req.Milestones, row.Milestone)
+		}
+	}
+	resolved, err := client.Resolver().Resolve(req)
+	if err != nil {
+		return err
+	}
+	if err := resolved.Err(); err != nil {
+		return err
+	}
+
+	results := make([]ImportRowResult, len(rows))
+	for i, row := range rows {
+		results[i] = ImportRowResult{Row: row.Row, Title: row.Title, Parent: row.parentNumber, Status: "planned"}
+	}
+
+	if !dryRun {
+		issueIDs := make([]string, len(rows))
+		mutations := 0
+		throttle := func() {
+			if mutations > 0 && delay > 0 {
+				time.Sleep(delay)
+			}
+			mutations++
+		}
+
+		// Phase 1: create every issue
+		for i, row := range rows {
+			params := IssueCreateParams{
+				RepositoryID: repoID,
+				Title:        row.Title,
+				Body:         row.Body,
+				LabelIDs:     resolved.IDsOf(ResolveLabels, row.Labels),
+				AssigneeIDs:  resolved.IDsOf(ResolveUsers, row.Assignees),
+			}
+			params.MilestoneID, _ = resolved.ID(ResolveMilestones, row.Milestone)
+
+			throttle()
+			id, created, err := client.CreateIssue(params)
+			if err != nil {
+				results[i].Status = "failed"
+				results[i].Error = err.Error()
+				WarningMsg("Row %d: %v", row.Row, err).Print(cmd)
+				continue
+			}
+			issueIDs[i] = id
+			results[i].Number = created.Number
+			results[i].URL = created.URL
+			results[i].Status = "created"
+		}
+
+		// Phase 2: link sub-issues to their parents
+		for i, row := range rows {
+			if issueIDs[i] == "" || (row.parentRow < 0 && row.parentNumber == 0) {
+				continue
+			}
+			parentNumber := row.parentNumber
+			if row.parentRow >= 0 {
+				parentNumber = results[row.parentRow].Number
+				if parentNumber == 0 {
+					results[i].Status = "link-failed"
+					results[i].Error = fmt.Sprintf("parent row %d was not created", rows[row.parentRow].Row)
+					continue
+				}
+			}
+			results[i].Parent = parentNumber
+
+			throttle()
+			if _, err := client.AddSubIssue(issueIDs[i], parentNumber); err != nil {
+				results[i].Status = "link-failed"
+				results[i].Error = fmt.Sprintf("failed to link parent #%d: %v", parentNumber, err)
+				WarningMsg("Row %d: %s", row.Row, results[i].Error).Print(cmd)
+				continue
+			}
+			results[i].Status = "linked"
+		}
+
+		if resultFile == "" {
+			resultFile = strings.TrimSuffix(file, filepath.Ext(file)) + ".result.csv"
+		}
+		if err := writeImportResults(resultFile, results); err != nil {
+			return fmt.Errorf("failed to write result file: %w", err)
+		}
+	}
+
+	created, linkFailed, failed := tallyImportResults(results)
+
+	summary := map[string]interface{}{
+		"file":       file,
+		"dryRun":     dryRun,
+		"total":      len(rows),
+		"created":    created,
+		"linkFailed": linkFailed,
+		"failed":     failed,
+		"results":    results,
+	}
+	if dryRun {
+		summary["plan"] = rows
+		delete(summary, "results")
+	} else {
+		summary["resultFile"] = resultFile
+	}
+
+	if err := EncodeOutputWithCmd(cmd, map[string]interface{}{"issuesImport": summary}); err != nil {
+		return err
+	}
+
+	return importResultError(len(rows), linkFailed, failed)
+}
+
+// tallyImportResults counts created issues (linked or not), issues created
+// without their requested parent, and rows that could not be created
+func tallyImportResults(results []ImportRowResult) (created, linkFailed, failed int) {
+	for _, result := range results {
+		switch result.Status {
+		case "failed":
+			failed++
+		case "link-failed":
+			linkFailed++
+			created++
+		case "created", "linked":
+			created++
+		}
+	}
+	return created, linkFailed, failed
+}
+
+// importResultError fails the import when a row was not created, or exits with
+// ExitCodeLinkFailed when issues exist but lack their parent relationship
+func importResultError(total, linkFailed, failed int) error {
+	if failed > 0 {
+		return fmt.Errorf("%d of %d issue(s) failed to import", failed, total)
+	}
+	if linkFailed > 0 {
+		return &ExitError{
+			Code: ExitCodeLinkFailed,
+			Err:  fmt.Errorf("%d of %d issue(s) created but not linked to their parent", linkFailed, total),
+		}
+	}
+	return nil
+}
+
+// writeImportResults writes the row to issue mapping as CSV for traceability
+func writeImportResults(path string, results []ImportRowResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	writer := csv.NewWriter(f)
+	if err := writer.Write([]string{"row", "title", "number", "url", "parent", "status", "error"}); err != nil {
+		return err
+	}
+	for _, result := range results {
+		number, parent := "", ""
+		if result.Number > 0 {
+			number = strconv.Itoa(result.Number)
+		}
+		if result.Parent > 0 {
+			parent = strconv.Itoa(result.Parent)
+		}
+		record := []string{strconv.Itoa(result.Row), result.Title, number, result.URL, parent, result.Status, result.Error}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
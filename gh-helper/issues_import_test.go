@@ -0,0 +1,138 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseIssuePlan(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		comma   rune
+		mapping string
+		check   func(t *testing.T, rows []IssuePlanRow)
+		wantErr string
+	}{
+		{
+			name: "mapped columns with in-file and existing parents",
+			input: "Title,Epic,Labels,Owner\n" +
+				"Caching epic,,enhancement,\n" +
+				"Write cache tests,Caching epic,\"test; enhancement\",alice\n" +
+				"Fix flaky test,#42,,\"alice,bob\"\n",
+			comma:   ',',
+			mapping: "title=Title,parent=Epic,labels=Labels,assignees=Owner",
+			check: func(t *testing.T, rows []IssuePlanRow) {
+				if len(rows) != 3 {
+					t.Fatalf("expected 3 rows, got %d", len(rows))
+				}
+				if rows[1].parentRow != 0 || rows[1].parentNumber != 0 {
+					t.Errorf("row 2 should reference row index 0, got row=%d number=%d", rows[1].parentRow, rows[1].parentNumber)
+				}
+				if strings.Join(rows[1].Labels, "|") != "test|enhancement" {
+					t.Errorf("unexpected labels: %v", rows[1].Labels)
+				}
+				if rows[2].parentRow != -1 || rows[2].parentNumber != 42 {
+					t.Errorf("row 3 should reference issue #42, got row=%d number=%d", rows[2].parentRow, rows[2].parentNumber)
+				}
+				if strings.Join(rows[2].Assignees, "|") != "alice|bob" {
+					t.Errorf("unexpected assignees: %v", rows[2].Assignees)
+				}
+			},
+		},
+		{
+			name:  "default column names and id references in TSV",
+			input: "id\ttitle\tparent\nE1\tEpic\t\nT1\tTask\tE1\n",
+			comma: '\t',
+			check: func(t *testing.T, rows []IssuePlanRow) {
+				if len(rows) != 2 || rows[1].parentRow != 0 {
+					t.Errorf("expected task to reference epic row, got %+v", rows)
+				}
+			},
+		},
+		{
+			name:    "missing mapped column",
+			input:   "Name\nfoo\n",
+			comma:   ',',
+			mapping: "title=Title",
+			wantErr: "not found in header",
+		},
+		{
+			name:    "unresolvable parent",
+			input:   "title,parent\nTask,Unknown epic\n",
+			comma:   ',',
+			wantErr: "neither an issue number nor a row",
+		},
+		{
+			name:    "parent cycle",
+			input:   "id,title,parent\nA,First,B\nB,Second,A\n",
+			comma:   ',',
+			wantErr: "cycle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping, err := parseImportMapping(tt.mapping)
+			if err != nil {
+				t.Fatalf("unexpected mapping error: %v", err)
+			}
+			rows, err := parseIssuePlan(strings.NewReader(tt.input), tt.comma, mapping)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tt.check(t, rows)
+		})
+	}
+}
+
+func TestParseImportMapping(t *testing.T) {
+	if _, err := parseImportMapping("title=Title,priority=P"); err == nil {
+		t.Error("expected error for unknown field")
+	}
+	if _, err := parseImportMapping("title"); err == nil {
+		t.Error("expected error for malformed pair")
+	}
+	mapping, err := parseImportMapping("Title=Summary, parent = Epic")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mapping["title"] != "Summary" || mapping["parent"] != "Epic" {
+		t.Errorf("unexpected mapping: %v", mapping)
+	}
+}
+
+func TestImportResultError(t *testing.T) {
+	results := []ImportRowResult{
+		{Row: 1, Title: "Epic", Number: 10, Status: "created"},
+		{Row: 2, Title: "Task", Number: 11, Parent: 10, Status: "linked"},
+		{Row: 3, Title: "Other task", Number: 12, Parent: 10, Status: "link-failed", Error: "failed to link parent #10: boom"},
+	}
+	created, linkFailed, failed := tallyImportResults(results)
+	if created != 3 || linkFailed != 1 || failed != 0 {
+		t.Fatalf("tallyImportResults() = %d, %d, %d; want 3, 1, 0", created, linkFailed, failed)
+	}
+	err := importResultError(len(results), linkFailed, failed)
+	if code := exitCodeOf(err); code != ExitCodeLinkFailed {
+		t.Errorf("exitCodeOf(%v) = %d, want %d", err, code, ExitCodeLinkFailed)
+	}
+
+	results = append(results, ImportRowResult{Row: 4, Title: "Broken", Status: "failed", Error: "boom"})
+	created, linkFailed, failed = tallyImportResults(results)
+	if err := importResultError(len(results), linkFailed, failed); err == nil || exitCodeOf(err) == ExitCodeLinkFailed {
+		t.Errorf("importResultError() = %v, want a generic failure when a row was not created", err)
+	}
+	if created != 3 {
+		t.Errorf("created = %d, want 3", created)
+	}
+
+	if err := importResultError(2, 0, 0); err != nil {
+		t.Errorf("importResultError() = %v, want nil", err)
+	}
+}
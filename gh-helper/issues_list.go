@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var listIssuesCmd = NewOperationalCommand(
+	"list [flags]",
+	"List issues with search qualifiers or saved queries",
+	`List issues of the repository using GitHub search syntax, or a named query
+from the config file so teams share the same triage views.
+
+Saved queries live under "queries:" in .gh-helper.yaml at the repository root
+(shared) or ~/.config/gh-helper/config.yaml (personal). Placeholders like
+{milestone} are filled from --param; "repo:" and "is:issue" are added automatically.
+
+  # .gh-helper.yaml
+  queries:
+    triage: "state:open label:needs-triage sort:created-asc"
+    release: "milestone:{milestone} state:open sort:updated-desc"
+
+Examples:
+  # Ad-hoc search
+  gh-helper issues list --query "state:open label:bug sort:updated-desc"
+
+  # Saved queries
+  gh-helper issues list --saved triage
+  gh-helper issues list --saved release --param milestone=v0.20.0
+
+  # Saved query with extra qualifiers
+  gh-helper issues list --saved triage --query "assignee:@me"`,
+	listIssues,
+)
+
+func init() {
+	listIssuesCmd.Flags().String("saved", "", "Run a named query from the config file")
+	listIssuesCmd.Flags().StringArray("param", []string{}, "Placeholder value for a saved query (key=value, repeatable)")
+	listIssuesCmd.Flags().String("query", "", "Search qualifiers (appended to a saved query)")
+	listIssuesCmd.Flags().Int("limit", 30, "Maximum issues to list (max 100)")
+
+	issuesCmd.AddCommand(listIssuesCmd)
+}
+
+// queryPlaceholderPattern matches {name} placeholders in saved queries
+var queryPlaceholderPattern = regexp.MustCompile(`\{([A-Za-z][A-Za-z0-9_-]*)\}`)
+
+// parseQueryParams parses key=value pairs from --param
+func parseQueryParams(params []string) (map[string]string, error) {
+	values := make(map[string]string, len(params))
+	for _, param := range params {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid --param %q (expected key=value)", param)
+		}
+		values[strings.TrimSpace(key)] = value
+	}
+	return values, nil
+}
+
+// expandSavedQuery substitutes {name} placeholders, reporting every missing parameter
+func expandSavedQuery(query string, params map[string]string) (string, error) {
+	var missing []string
+	expanded := queryPlaceholderPattern.ReplaceAllStringFunc(query, func(match string) string {
+		name := match[1 : len(match)-1]
+		value, ok := params[name]
+		if !ok {
+			missing = append(missing, name)
+			return match
+		}
+		// Quote values with spaces so they stay a single qualifier value
+		if strings.ContainsAny(value, " \t") && !strings.HasPrefix(value, `"`) {
+			return `"` + value + `"`
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing --param for: %s", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+// buildIssueSearchQuery scopes search qualifiers to the repository's issues
+func buildIssueSearchQuery(owner, repo, qualifiers string) string {
+	parts := []string{fmt.Sprintf("repo:%s/%s", owner, repo), "is:issue"}
+	if q := strings.TrimSpace(qualifiers); q != "" {
+		parts = append(parts, q)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ListedIssue is an issue in 'issues list' output
+type ListedIssue struct {
+	Number    int      `json:"number"`
+	Title     string   `json:"title"`
+	State     string   `json:"state"`
+	Author    string   `json:"author,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Milestone string   `json:"milestone,omitempty"`
+	CreatedAt string   `json:"createdAt"`
+	UpdatedAt string   `json:"updatedAt"`
+	URL       string   `json:"url"`
+}
+
+// SearchIssues runs an issue search and returns matching issues with the total count
+func (c *GitHubClient) SearchIssues(searchQuery string, limit int) ([]ListedIssue, int, error) {
+	query := `
+	query($query: String!, $limit: Int!) {
+	  search(query: $query, type: ISSUE, first: $limit) {
+	    issueCount
+	    nodes {
+	      ... on Issue {
+	        number
+	        title
+	        state
+	        author { login }
+	        labels(first: 20) { nodes { name } }
+	        assignees(first: 10) { nodes { login } }
+	        milestone { title }
+	        createdAt
+	        updatedAt
+	        url
+	      }
+	    }
+	  }
+	}`
+
+	variables := map[string]interface{}{
+		"query": searchQuery,
+		"limit": limit,
+	}
+
+	result, err := c.RunGraphQLQueryWithVariables(query, variables)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var response struct {
+		Data struct {
+			Search struct {
+				IssueCount int `json:"issueCount"`
+				Nodes      []struct {
+					Number int    `json:"number"`
+					Title  string `json:"title"`
+					State  string `json:"state"`
+					Author *struct {
+						Login string `json:"login"`
+					} `json:"author"`
+					Labels struct {
+						Nodes []struct {
+							Name string `json:"name"`
+						} `json:"nodes"`
+					} `json:"labels"`
+					Assignees struct {
+						Nodes []struct {
+							Login string `json:"login"`
+						} `json:"nodes"`
+					} `json:"assignees"`
+					Milestone *struct {
+						Title string `json:"title"`
+					} `json:"milestone"`
+					CreatedAt string `json:"createdAt"`
+					UpdatedAt string `json:"updatedAt"`
+					URL       string `json:"url"`
+				} `json:"nodes"`
+			} `json:"search"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse search response: %w", err)
+	}
+
+	issues := []ListedIssue{}
+	for _, node := range response.Data.Search.Nodes {
+		if node.Number == 0 {
+			continue
+		}
+		issue := ListedIssue{
+			Number:    node.Number,
+			Title:     node.Title,
+			State:     node.State,
+			CreatedAt: node.CreatedAt,
+			UpdatedAt: node.UpdatedAt,
+			URL:       node.URL,
+		}
+		if node.Author != nil {
+			issue.Author = node.Author.Login
+		}
+		for _, label := range node.Labels.Nodes {
+			issue.Labels = append(issue.Labels, label.Name)
+		}
+		for _, assignee := range node.Assignees.Nodes {
+			issue.Assignees = append(issue.Assignees, assignee.Login)
+		}
+		if node.Milestone != nil {
+			issue.Milestone = node.Milestone.Title
+		}
+		issues = append(issues, issue)
+	}
+	return issues, response.Data.Search.IssueCount, nil
+}
+
+func listIssues(cmd *cobra.Command, args []string) error {
+	saved, err := cmd.Flags().GetString("saved")
+	if err != nil {
+		return fmt.Errorf("failed to get 'saved' flag: %w", err)
+	}
+	paramFlags, err := cmd.Flags().GetStringArray("param")
+	if err != nil {
+		return fmt.Errorf("failed to get 'param' flag: %w", err)
+	}
+	extra, err := cmd.Flags().GetString("query")
+	if err != nil {
+		return fmt.Errorf("failed to get 'query' flag: %w", err)
+	}
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return fmt.Errorf("failed to get 'limit' flag: %w", err)
+	}
+	if limit <= 0 || limit > 100 {
+		return fmt.Errorf("--limit must be between 1 and 100")
+	}
+
+	qualifiers := extra
+	if saved != "" {
+		config, err := LoadConfig()
+		if err != nil {
+			return err
+		}
+		savedQuery, ok := config.Queries[saved]
+		if !ok {
+			if len(config.Queries) == 0 {
+				return fmt.Errorf("saved query %q not found: no queries defined in %s or the user config", saved, repoConfigFileName)
+			}
+			return fmt.Errorf("saved query %q not found (available: %s)", saved, strings.Join(config.QueryNames(), ", "))
+		}
+		params, err := parseQueryParams(paramFlags)
+		if err != nil {
+			return err
+		}
+		qualifiers, err = expandSavedQuery(savedQuery, params)
+		if err != nil {
+			return fmt.Errorf("saved query %q: %w", saved, err)
+		}
+		if extra != "" {
+			qualifiers += " " + extra
+		}
+	} else if len(paramFlags) > 0 {
+		return fmt.Errorf("--param requires --saved")
+	}
+
+	client := newCommandClient(cmd)
+	searchQuery := buildIssueSearchQuery(client.Owner, client.Repo, qualifiers)
+	issues, total, err := client.SearchIssues(searchQuery, limit)
+	if err != nil {
+		return fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	result := map[string]interface{}{
+		"query":      searchQuery,
+		"totalCount": total,
+		"nodes":      issues,
+	}
+	if saved != "" {
+		result["saved"] = saved
+	}
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"issues": result})
+}
@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+func TestExpandSavedQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		params  map[string]string
+		want    string
+		wantErr bool
+	}{
+		{"no placeholders", "state:open label:needs-triage", nil, "state:open label:needs-triage", false},
+		{"substituted", "milestone:{milestone} assignee:{user}", map[string]string{"milestone": "v0.20.0", "user": "alice"}, "milestone:v0.20.0 assignee:alice", false},
+		{"value with spaces quoted", "label:{label}", map[string]string{"label": "good first issue"}, `label:"good first issue"`, false},
+		{"missing parameter", "milestone:{milestone} label:{label}", map[string]string{"label": "bug"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandSavedQuery(tt.query, tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expandSavedQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("expandSavedQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryParams(t *testing.T) {
+	params, err := parseQueryParams([]string{"milestone=v0.20.0", "expr=a=b"})
+	if err != nil {
+		t.Fatalf("parseQueryParams() error = %v", err)
+	}
+	if params["milestone"] != "v0.20.0" || params["expr"] != "a=b" {
+		t.Errorf("parseQueryParams() = %v", params)
+	}
+	if _, err := parseQueryParams([]string{"novalue"}); err == nil {
+		t.Error("parseQueryParams() error = nil for missing '='")
+	}
+}
+
+func TestBuildIssueSearchQuery(t *testing.T) {
+	if got, want := buildIssueSearchQuery("o", "r", " state:open "), "repo:o/r is:issue state:open"; got != want {
+		t.Errorf("buildIssueSearchQuery() = %q, want %q", got, want)
+	}
+	if got, want := buildIssueSearchQuery("o", "r", ""), "repo:o/r is:issue"; got != want {
+		t.Errorf("buildIssueSearchQuery() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ProjectItemInfo describes the project item of a created issue
+type ProjectItemInfo struct {
+	Project   string `json:"project"`
+	ItemID    string `json:"itemId"`
+	Iteration string `json:"iteration,omitempty"`
+	Status    string `json:"status,omitempty"`
+}
+
+// projectIteration is an active or upcoming iteration of an iteration field
+type projectIteration struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	StartDate string `json:"startDate"` // YYYY-MM-DD
+	Duration  int    `json:"duration"`  // days
+}
+
+// projectFieldUpdate is a resolved field value to set on a project item
+type projectFieldUpdate struct {
+	FieldID string
+	Value   string // GraphQL ProjectV2FieldValue input, e.g. {iterationId: "..."}
+	Display string
+}
+
+// projectFieldUpdates holds the resolved --iteration and --status values
+type projectFieldUpdates struct {
+	Iteration *projectFieldUpdate
+	Status    *projectFieldUpdate
+}
+
+// selectIteration resolves "current", "next", or an iteration title (case-insensitive).
+// Iterations are ordered by start date; "next" is the first one starting after now.
+func selectIteration(iterations []projectIteration, value string, now time.Time) (*projectIteration, error) {
+	today := now.Format("2006-01-02")
+	switch strings.ToLower(value) {
+	case "current":
+		for i, iteration := range iterations {
+			start, err := time.Parse("2006-01-02", iteration.StartDate)
+			if err != nil {
+				continue
+			}
+			end := start.AddDate(0, 0, iteration.Duration).Format("2006-01-02")
+			if iteration.StartDate <= today && today < end {
+				return &iterations[i], nil
+			}
+		}
+		return nil, fmt.Errorf("no iteration is in progress on %s", today)
+	case "next":
+		for i, iteration := range iterations {
+			if iteration.StartDate > today {
+				return &iterations[i], nil
+			}
+		}
+		return nil, fmt.Errorf("no iteration starts after %s", today)
+	}
+
+	titles := make([]string, len(iterations))
+	for i, iteration := range iterations {
+		if strings.EqualFold(iteration.Title, value) {
+			return &iterations[i], nil
+		}
+		titles[i] = iteration.Title
+	}
+	return nil, fmt.Errorf("iteration not found: %s (available: %s)", value, strings.Join(titles, ", "))
+}
+
+// resolveProjectFieldUpdates looks up the iteration field and the Status
+// single-select field of a project and resolves the requested values, so
+// typos are reported before the issue is created
+func (c *GitHubClient) resolveProjectFieldUpdates(projectID, iteration, status string, now time.Time) (*projectFieldUpdates, error) {
+	query := `
+	query($projectId: ID!) {
+		node(id: $projectId) {
+			... on ProjectV2 {
+				fields(first: 50) {
+					nodes {
+						... on ProjectV2IterationField {
+							id
+							name
+							configuration {
+								iterations { id title startDate duration }
+							}
+						}
+						... on ProjectV2SingleSelectField {
+							id
+							name
+							options { id name }
+						}
+					}
+				}
+			}
+		}
+	}`
+
+	responseData, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{"projectId": projectID})
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Node struct {
+				Fields struct {
+					Nodes []struct {
+						ID            string `json:"id"`
+						Name          string `json:"name"`
+						Configuration *struct {
+							Iterations []projectIteration `json:"iterations"`
+						} `json:"configuration"`
+						Options []struct {
+							ID   string `json:"id"`
+							Name string `json:"name"`
+						} `json:"options"`
+					} `json:"nodes"`
+				} `json:"fields"`
+			} `json:"node"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse project fields: %w", err)
+	}
+
+	updates := &projectFieldUpdates{}
+	for _, field := range response.Data.Node.Fields.Nodes {
+		switch {
+		case iteration != "" && updates.Iteration == nil && field.Configuration != nil:
+			selected, err := selectIteration(field.Configuration.Iterations, iteration, now)
+			if err != nil {
+				return nil, fmt.Errorf("project field %q: %w", field.Name, err)
+			}
+			updates.Iteration = &projectFieldUpdate{
+				FieldID: field.ID,
+				Value:   fmt.Sprintf("{iterationId: %q}", selected.ID),
+				Display: selected.Title,
+			}
+		case status != "" && strings.EqualFold(field.Name, "Status") && field.Options != nil:
+			names := make([]string, len(field.Options))
+			for i, option := range field.Options {
+				if strings.EqualFold(option.Name, status) {
+					updates.Status = &projectFieldUpdate{
+						FieldID: field.ID,
+						Value:   fmt.Sprintf("{singleSelectOptionId: %q}", option.ID),
+						Display: option.Name,
+					}
+					break
+				}
+				names[i] = option.Name
+			}
+			if updates.Status == nil {
+				return nil, fmt.Errorf("status not found: %s (available: %s)", status, strings.Join(names, ", "))
+			}
+		}
+	}
+
+	if iteration != "" && updates.Iteration == nil {
+		return nil, fmt.Errorf("project has no iteration field")
+	}
+	if status != "" && updates.Status == nil {
+		return nil, fmt.Errorf("project has no Status field")
+	}
+	return updates, nil
+}
+
+// addProjectItem adds content to a project and returns the item ID.
+// addProjectV2ItemById returns the existing item if the content is already in the project.
+func (c *GitHubClient) addProjectItem(projectID, contentID string) (string, error) {
+	mutation := `
+	mutation($projectId: ID!, $contentId: ID!) {
+		addProjectV2ItemById(input: {projectId: $projectId, contentId: $contentId}) {
+			item {
+				id
+			}
+		}
+	}`
+	responseData, err := c.RunGraphQLQueryWithVariables(mutation, map[string]interface{}{
+		"projectId": projectID,
+		"contentId": contentID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to add issue to project: %w", err)
+	}
+	var response struct {
+		Data struct {
+			AddProjectV2ItemById struct {
+				Item struct {
+					ID string `json:"id"`
+				} `json:"item"`
+			} `json:"addProjectV2ItemById"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return "", err
+	}
+	return response.Data.AddProjectV2ItemById.Item.ID, nil
+}
+
+// applyProjectFieldUpdates adds an issue to the project and sets the resolved
+// iteration and status in one aliased mutation
+func (c *GitHubClient) applyProjectFieldUpdates(projectID, projectName, issueID string, updates *projectFieldUpdates) (*ProjectItemInfo, error) {
+	itemID, err := c.addProjectItem(projectID, issueID)
+	if err != nil {
+		return nil, err
+	}
+	info := &ProjectItemInfo{Project: projectName, ItemID: itemID}
+
+	var mutationBuilder strings.Builder
+	mutationBuilder.WriteString("mutation($projectId: ID!, $itemId: ID!) {")
+	for _, update := range []struct {
+		alias  string
+		update *projectFieldUpdate
+	}{{"iteration", updates.Iteration}, {"status", updates.Status}} {
+		if update.update == nil {
+			continue
+		}
+		fmt.Fprintf(&mutationBuilder, `
+		%s: updateProjectV2ItemFieldValue(input: {projectId: $projectId, itemId: $itemId, fieldId: %q, value: %s}) {
+			projectV2Item { id }
+		}`, update.alias, update.update.FieldID, update.update.Value)
+	}
+	mutationBuilder.WriteString("\n}")
+
+	if updates.Iteration == nil && updates.Status == nil {
+		return info, nil
+	}
+	if _, err := c.RunGraphQLQueryWithVariables(mutationBuilder.String(), map[string]interface{}{
+		"projectId": projectID,
+		"itemId":    itemID,
+	}); err != nil {
+		return info, fmt.Errorf("failed to update project fields: %w", err)
+	}
+	if updates.Iteration != nil {
+		info.Iteration = updates.Iteration.Display
+	}
+	if updates.Status != nil {
+		info.Status = updates.Status.Display
+	}
+	return info, nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSelectIteration(t *testing.T) {
+	iterations := []projectIteration{
+		{ID: "I1", Title: "Sprint 1", StartDate: "2026-03-02", Duration: 14},
+		{ID: "I2", Title: "Sprint 2", StartDate: "2026-03-16", Duration: 14},
+		{ID: "I3", Title: "Sprint 3", StartDate: "2026-03-30", Duration: 14},
+	}
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		now     time.Time
+		wantID  string
+		wantErr bool
+	}{
+		{value: "current", now: now, wantID: "I1"},
+		{value: "next", now: now, wantID: "I2"},
+		{value: "Current", now: now.AddDate(0, 0, 1), wantID: "I2"},
+		{value: "sprint 3", now: now, wantID: "I3"},
+		{value: "Sprint 9", now: now, wantErr: true},
+		{value: "current", now: now.AddDate(0, 1, 0), wantErr: true},
+		{value: "next", now: now.AddDate(0, 0, 16), wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := selectIteration(iterations, tt.value, tt.now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("selectIteration(%q, %s) error = %v, wantErr %v", tt.value, tt.now.Format("2006-01-02"), err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.ID != tt.wantID {
+			t.Errorf("selectIteration(%q, %s) = %s, want %s", tt.value, tt.now.Format("2006-01-02"), got.ID, tt.wantID)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var reparentIssuesCmd = NewOperationalCommand(
+	"reparent [flags]",
+	"Move sub-issues from one parent issue to another",
+	`Move all (or filtered) sub-issues of --from under --to in batched
+mutations, reporting the result of each sub-issue.
+
+Moved sub-issues are appended to the end of --to in their order under
+--from (mutations in one request run in order), so the relative order is
+preserved; they are placed after --to's existing sub-issues.
+
+Filters (repeatable, all must match):
+  label=<name>      Sub-issue has the label
+  state=open|closed Sub-issue state
+  assignee=<login>  Sub-issue is assigned to the user
+
+Examples:
+  # Preview moving backend work to a new epic
+  gh-helper issues reparent --from 100 --to 200 --filter label=backend --dry-run
+
+  # Move all open sub-issues
+  gh-helper issues reparent --from 100 --to 200 --filter state=open`,
+	reparentIssues,
+)
+
+func init() {
+	reparentIssuesCmd.Flags().Int("from", 0, "Current parent issue number (required)")
+	reparentIssuesCmd.Flags().Int("to", 0, "New parent issue number (required)")
+	reparentIssuesCmd.Flags().StringArray("filter", []string{}, "Only move sub-issues matching key=value (label, state, assignee; repeatable)")
+	reparentIssuesCmd.Flags().Bool("dry-run", false, "Show what would be moved without changing anything")
+	for _, name := range []string{"from", "to"} {
+		if err := reparentIssuesCmd.MarkFlagRequired(name); err != nil {
+			panic(fmt.Sprintf("failed to mark %s flag as required: %v", name, err))
+		}
+	}
+
+	issuesCmd.AddCommand(reparentIssuesCmd)
+}
+
+// reparentBatchSize limits aliased mutations per request
+const reparentBatchSize = 20
+
+// reparentSubIssue is a sub-issue of the current parent
+type reparentSubIssue struct {
+	ID     string `json:"id"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Assignees struct {
+		Nodes []struct {
+			Login string `json:"login"`
+		} `json:"nodes"`
+	} `json:"assignees"`
+}
+
+// reparentFilter is a parsed --filter key=value
+type reparentFilter struct {
+	Key   string
+	Value string
+}
+
+// ReparentItem is the result for one sub-issue
+type ReparentItem struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Status string `json:"status"` // moved, would-move, failed
+	Error  string `json:"error,omitempty"`
+}
+
+// parseReparentFilters parses --filter values
+func parseReparentFilters(values []string) ([]reparentFilter, error) {
+	var filters []reparentFilter
+	for _, value := range values {
+		key, filterValue, ok := strings.Cut(value, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if !ok || filterValue == "" {
+			return nil, fmt.Errorf("invalid --filter %q (expected key=value)", value)
+		}
+		switch key {
+		case "label", "assignee":
+		case "state":
+			filterValue = strings.ToLower(filterValue)
+			if filterValue != "open" && filterValue != "closed" {
+				return nil, fmt.Errorf("invalid --filter %q (state must be open or closed)", value)
+			}
+		default:
+			return nil, fmt.Errorf("unknown --filter key %q (supported: label, state, assignee)", key)
+		}
+		filters = append(filters, reparentFilter{Key: key, Value: filterValue})
+	}
+	return filters, nil
+}
+
+// matchesReparentFilters reports whether a sub-issue matches all filters
+func matchesReparentFilters(issue reparentSubIssue, filters []reparentFilter) bool {
+	for _, filter := range filters {
+		matched := false
+		switch filter.Key {
+		case "label":
+			for _, label := range issue.Labels.Nodes {
+				if strings.EqualFold(label.Name, filter.Value) {
+					matched = true
+					break
+				}
+			}
+		case "assignee":
+			for _, assignee := range issue.Assignees.Nodes {
+				if strings.EqualFold(assignee.Login, strings.TrimPrefix(filter.Value, "@")) {
+					matched = true
+					break
+				}
+			}
+		case "state":
+			matched = strings.EqualFold(issue.State, filter.Value)
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// getReparentIssues fetches both parents and all sub-issues of the current parent in order
+func (c *GitHubClient) getReparentIssues(from, to int) (fromInfo, toInfo BasicIssueInfo, toID string, subIssues []reparentSubIssue, err error) {
+	query := `
+	query($owner: String!, $repo: String!, $from: Int!, $to: Int!, $after: String) {
+	  repository(owner: $owner, name: $repo) {
+	    from: issue(number: $from) {
+	      number title url state
+	      subIssues(first: 100, after: $after) {
+	        pageInfo { hasNextPage endCursor }
+	        nodes {
+	          id number title state
+	          labels(first: 20) { nodes { name } }
+	          assignees(first: 10) { nodes { login } }
+	        }
+	      }
+	    }
+	    to: issue(number: $to) { id number title url state }
+	  }
+	}`
+
+	var after interface{}
+	for {
+		result, queryErr := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+			"owner": c.Owner,
+			"repo":  c.Repo,
+			"from":  from,
+			"to":    to,
+			"after": after,
+		})
+		if queryErr != nil {
+			return fromInfo, toInfo, "", nil, queryErr
+		}
+
+		var response struct {
+			Data struct {
+				Repository struct {
+					From *struct {
+						BasicIssueInfo
+						SubIssues struct {
+							PageInfo struct {
+								HasNextPage bool   `json:"hasNextPage"`
+								EndCursor   string `json:"endCursor"`
+							} `json:"pageInfo"`
+							Nodes []reparentSubIssue `json:"nodes"`
+						} `json:"subIssues"`
+					} `json:"from"`
+					To *struct {
+						ID string `json:"id"`
+						BasicIssueInfo
+					} `json:"to"`
+				} `json:"repository"`
+			} `json:"data"`
+		}
+		if jsonErr := json.Unmarshal(result, &response); jsonErr != nil {
+			return fromInfo, toInfo, "", nil, fmt.Errorf("failed to parse issues response: %w", jsonErr)
+		}
+		if response.Data.Repository.From == nil {
+			return fromInfo, toInfo, "", nil, fmt.Errorf("parent issue not found: #%d", from)
+		}
+		if response.Data.Repository.To == nil {
+			return fromInfo, toInfo, "", nil, fmt.Errorf("new parent issue not found: #%d", to)
+		}
+
+		fromInfo = response.Data.Repository.From.BasicIssueInfo
+		toInfo = response.Data.Repository.To.BasicIssueInfo
+		toID = response.Data.Repository.To.ID
+		page := response.Data.Repository.From.SubIssues
+		subIssues = append(subIssues, page.Nodes...)
+		if !page.PageInfo.HasNextPage {
+			return fromInfo, toInfo, toID, subIssues, nil
+		}
+		after = page.PageInfo.EndCursor
+	}
+}
+
+// moveSubIssues re-parents sub-issues under parentID with aliased addSubIssue
+// (replaceParent) mutations. Top-level mutation fields execute serially, so
+// sub-issues are appended in the given order. Returns the error per index.
+func (c *GitHubClient) moveSubIssues(parentID string, subIssues []reparentSubIssue) (map[int]string, error) {
+	failures := map[int]string{}
+	for start := 0; start < len(subIssues); start += reparentBatchSize {
+		end := min(start+reparentBatchSize, len(subIssues))
+
+		var mutationBuilder strings.Builder
+		mutationBuilder.WriteString("mutation {")
+		for i := start; i < end; i++ {
+			fmt.Fprintf(&mutationBuilder, `
+		move%d: addSubIssue(input: {
+			issueId: "%s"
+			subIssueId: "%s"
+			replaceParent: true
+		}) {
+			issue { id }
+		}`, i, parentID, subIssues[i].ID)
+		}
+		mutationBuilder.WriteString("\n}")
+
+		_, graphqlErrors, err := c.RunGraphQLQueryPartial(mutationBuilder.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, graphqlErr := range graphqlErrors {
+			alias := graphqlErr.Alias()
+			if alias == "" {
+				// An error without a path (e.g., a syntax error) applies to the whole batch
+				return nil, fmt.Errorf("GraphQL error: %s", graphqlErr.Message)
+			}
+			var index int
+			if _, scanErr := fmt.Sscanf(alias, "move%d", &index); scanErr == nil {
+				if _, exists := failures[index]; !exists {
+					failures[index] = graphqlErr.Message
+				}
+			}
+		}
+	}
+	return failures, nil
+}
+
+func reparentIssues(cmd *cobra.Command, args []string) error {
+	from, err := cmd.Flags().GetInt("from")
+	if err != nil {
+		return fmt.Errorf("failed to get 'from' flag: %w", err)
+	}
+	to, err := cmd.Flags().GetInt("to")
+	if err != nil {
+		return fmt.Errorf("failed to get 'to' flag: %w", err)
+	}
+	filterValues, err := cmd.Flags().GetStringArray("filter")
+	if err != nil {
+		return fmt.Errorf("failed to get 'filter' flag: %w", err)
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get 'dry-run' flag: %w", err)
+	}
+
+	if from == to {
+		return fmt.Errorf("--from and --to must be different issues")
+	}
+	filters, err := parseReparentFilters(filterValues)
+	if err != nil {
+		return err
+	}
+
+	client := newCommandClient(cmd)
+	fromInfo, toInfo, toID, subIssues, err := client.getReparentIssues(from, to)
+	if err != nil {
+		return fmt.Errorf("failed to fetch issues: %w", err)
+	}
+
+	var selected []reparentSubIssue
+	for _, subIssue := range subIssues {
+		if subIssue.Number == to {
+			// An issue cannot become its own parent
+			continue
+		}
+		if matchesReparentFilters(subIssue, filters) {
+			selected = append(selected, subIssue)
+		}
+	}
+
+	items := make([]ReparentItem, len(selected))
+	for i, subIssue := range selected {
+		items[i] = ReparentItem{Number: subIssue.Number, Title: subIssue.Title, Status: "would-move"}
+	}
+
+	failed := 0
+	if !dryRun && len(selected) > 0 {
+		descriptions := make([]string, len(selected))
+		for i, subIssue := range selected {
+			descriptions[i] = fmt.Sprintf("#%d %s", subIssue.Number, subIssue.Title)
+		}
+		if err := confirmBulkOperation(cmd, fmt.Sprintf("move from #%d to #%d", from, to), descriptions, false); err != nil {
+			return err
+		}
+
+		failures, err := client.moveSubIssues(toID, selected)
+		if err != nil {
+			return fmt.Errorf("failed to move sub-issues: %w", err)
+		}
+		for i := range items {
+			if message, ok := failures[i]; ok {
+				items[i].Status = "failed"
+				items[i].Error = message
+				failed++
+				continue
+			}
+			items[i].Status = "moved"
+		}
+	}
+
+	result := map[string]interface{}{
+		"from":      fromInfo,
+		"to":        toInfo,
+		"matched":   len(selected),
+		"subIssues": len(subIssues),
+		"items":     items,
+	}
+	if dryRun {
+		result["dryRun"] = true
+	}
+	if err := EncodeOutputWithCmd(cmd, map[string]interface{}{"reparent": result}); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to move %d of %d sub-issue(s)", failed, len(selected))
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestReparentFilters(t *testing.T) {
+	var issue reparentSubIssue
+	data := `{"number": 5, "state": "OPEN",
+	  "labels": {"nodes": [{"name": "Backend"}]},
+	  "assignees": {"nodes": [{"login": "alice"}]}}`
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		filters []string
+		want    bool
+	}{
+		{nil, true},
+		{[]string{"label=backend"}, true},
+		{[]string{"label=frontend"}, false},
+		{[]string{"state=open", "assignee=@alice"}, true},
+		{[]string{"state=closed"}, false},
+		{[]string{"label=backend", "assignee=bob"}, false},
+	}
+	for _, tt := range tests {
+		filters, err := parseReparentFilters(tt.filters)
+		if err != nil {
+			t.Fatalf("parseReparentFilters(%v) error = %v", tt.filters, err)
+		}
+		if got := matchesReparentFilters(issue, filters); got != tt.want {
+			t.Errorf("filters %v: match = %v, want %v", tt.filters, got, tt.want)
+		}
+	}
+
+	for _, invalid := range []string{"label", "milestone=v1", "state=draft", "label="} {
+		if _, err := parseReparentFilters([]string{invalid}); err == nil {
+			t.Errorf("parseReparentFilters(%q) error = nil, want error", invalid)
+		}
+	}
+}
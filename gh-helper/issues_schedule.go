@@ -0,0 +1,379 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var scheduleIssueCmd = NewOperationalCommand(
+	"schedule [issue-number] [flags]",
+	"Assign issues to the milestone covering a due date",
+	`Assign issues to the milestone whose due date covers the requested date:
+the open milestone with the earliest due date on or after --due. Milestones
+without a due date are ignored.
+
+If no open milestone covers the date, one is created with the due date and
+the --new-title title (default: the date itself), unless --no-create is given.
+Due dates are compared and created as UTC dates, so a created milestone may
+show the previous day in the web UI west of UTC.
+
+The decision (chosen or created milestone and why) is reported with each
+issue's previous milestone. Issues already in the milestone are left as is.
+
+Examples:
+  # Schedule one issue
+  gh-helper issues schedule 123 --due 2025-02-01
+
+  # Bulk, previewing the decision first
+  gh-helper issues schedule --items 123,124,130 --due 2025-02-01 --dry-run
+
+  # Name the milestone if one has to be created
+  gh-helper issues schedule 123 --due 2025-03-31 --new-title "v0.21.0"`,
+	scheduleIssues,
+)
+
+func init() {
+	scheduleIssueCmd.Args = cobra.MaximumNArgs(1)
+
+	scheduleIssueCmd.Flags().String("due", "", "Date the work is due (YYYY-MM-DD, required)")
+	scheduleIssueCmd.Flags().String("items", "", "Comma-separated issue numbers for bulk scheduling")
+	scheduleIssueCmd.Flags().String("new-title", "", "Title for a milestone created when none covers the date (default: the date)")
+	scheduleIssueCmd.Flags().Bool("no-create", false, "Fail instead of creating a milestone when none covers the date")
+	scheduleIssueCmd.Flags().Bool("dry-run", false, "Report the decision without changing anything")
+	if err := scheduleIssueCmd.MarkFlagRequired("due"); err != nil {
+		panic(fmt.Sprintf("failed to mark due flag as required: %v", err))
+	}
+
+	issuesCmd.AddCommand(scheduleIssueCmd)
+}
+
+// ScheduleMilestone is an open milestone candidate for scheduling
+type ScheduleMilestone struct {
+	ID     string `json:"id,omitempty"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	DueOn  string `json:"dueOn,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// dueDate returns the milestone due date as YYYY-MM-DD, or "" if unset.
+// GitHub stores due dates as timestamps; only the date part is meaningful.
+func (m ScheduleMilestone) dueDate() string {
+	if len(m.DueOn) < 10 {
+		return ""
+	}
+	return m.DueOn[:10]
+}
+
+// pickMilestoneForDate returns the open milestone with the earliest due date
+// on or after due (YYYY-MM-DD); ties are broken by title
+func pickMilestoneForDate(milestones []ScheduleMilestone, due string) (*ScheduleMilestone, bool) {
+	var candidates []ScheduleMilestone
+	for _, milestone := range milestones {
+		if d := milestone.dueDate(); d != "" && d >= due {
+			candidates = append(candidates, milestone)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, false
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].dueDate() != candidates[j].dueDate() {
+			return candidates[i].dueDate() < candidates[j].dueDate()
+		}
+		return candidates[i].Title < candidates[j].Title
+	})
+	return &candidates[0], true
+}
+
+// parseIssueNumberList parses comma-separated issue numbers ("123,#124")
+func parseIssueNumberList(list string) ([]int, error) {
+	var numbers []int
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimPrefix(strings.TrimSpace(part), "#")
+		if part == "" {
+			continue
+		}
+		number, err := strconv.Atoi(part)
+		if err != nil || number <= 0 {
+			return nil, fmt.Errorf("invalid issue number %q", part)
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+// GetOpenMilestones lists the repository's open milestones
+func (c *GitHubClient) GetOpenMilestones() ([]ScheduleMilestone, error) {
+	query := `
+	query($owner: String!, $repo: String!) {
+	  repository(owner: $owner, name: $repo) {
+	    milestones(first: 100, states: [OPEN], orderBy: {field: DUE_DATE, direction: ASC}) {
+	      nodes { id number title dueOn url }
+	    }
+	  }
+	}`
+
+	result, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+		"owner": c.Owner,
+		"repo":  c.Repo,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository struct {
+				Milestones struct {
+					Nodes []ScheduleMilestone `json:"nodes"`
+				} `json:"milestones"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse milestones response: %w", err)
+	}
+	return response.Data.Repository.Milestones.Nodes, nil
+}
+
+// CreateMilestone creates a milestone due on the given date (YYYY-MM-DD),
+// stored as midnight UTC like the dates compared by dueDate.
+// GraphQL has no milestone mutation, so this uses the REST API.
+func (c *GitHubClient) CreateMilestone(title, due string) (*ScheduleMilestone, error) {
+	path := fmt.Sprintf("/repos/%s/%s/milestones", c.Owner, c.Repo)
+	result, err := c.RunRESTRequest("POST", path, map[string]interface{}{
+		"title":  title,
+		"due_on": due + "T00:00:00Z",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		NodeID  string `json:"node_id"`
+		Number  int    `json:"number"`
+		Title   string `json:"title"`
+		DueOn   string `json:"due_on"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse milestone response: %w", err)
+	}
+	return &ScheduleMilestone{
+		ID:     response.NodeID,
+		Number: response.Number,
+		Title:  response.Title,
+		DueOn:  response.DueOn,
+		URL:    response.HTMLURL,
+	}, nil
+}
+
+// scheduleIssueInfo is an issue with its current milestone
+type scheduleIssueInfo struct {
+	ID        string `json:"id"`
+	Number    int    `json:"number"`
+	Milestone *struct {
+		Title string `json:"title"`
+	} `json:"milestone"`
+}
+
+// getScheduleIssues fetches issues by number in one aliased query
+func (c *GitHubClient) getScheduleIssues(numbers []int) (map[int]*scheduleIssueInfo, error) {
+	var fields []string
+	for i, number := range numbers {
+		fields = append(fields, fmt.Sprintf("i%d: issue(number: %d) { id number milestone { title } }", i, number))
+	}
+	query := fmt.Sprintf(`
+	query($owner: String!, $repo: String!) {
+	  repository(owner: $owner, name: $repo) {
+	    %s
+	  }
+	}`, strings.Join(fields, "\n    "))
+
+	result, _, err := c.RunGraphQLQueryPartial(query, map[string]interface{}{
+		"owner": c.Owner,
+		"repo":  c.Repo,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository map[string]*scheduleIssueInfo `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse issues response: %w", err)
+	}
+
+	issues := make(map[int]*scheduleIssueInfo, len(numbers))
+	for _, issue := range response.Data.Repository {
+		if issue != nil {
+			issues[issue.Number] = issue
+		}
+	}
+	return issues, nil
+}
+
+// ScheduledIssue is the result for one issue in 'issues schedule'
+type ScheduledIssue struct {
+	Issue    int    `json:"issue"`
+	Previous string `json:"previous,omitempty"`
+	Status   string `json:"status"` // assigned, unchanged, would-assign, failed
+	Error    string `json:"error,omitempty"`
+}
+
+func scheduleIssues(cmd *cobra.Command, args []string) error {
+	due, err := cmd.Flags().GetString("due")
+	if err != nil {
+		return fmt.Errorf("failed to get 'due' flag: %w", err)
+	}
+	items, err := cmd.Flags().GetString("items")
+	if err != nil {
+		return fmt.Errorf("failed to get 'items' flag: %w", err)
+	}
+	newTitle, err := cmd.Flags().GetString("new-title")
+	if err != nil {
+		return fmt.Errorf("failed to get 'new-title' flag: %w", err)
+	}
+	noCreate, err := cmd.Flags().GetBool("no-create")
+	if err != nil {
+		return fmt.Errorf("failed to get 'no-create' flag: %w", err)
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get 'dry-run' flag: %w", err)
+	}
+
+	if _, err := time.Parse("2006-01-02", due); err != nil {
+		return fmt.Errorf("invalid --due %q (expected YYYY-MM-DD)", due)
+	}
+
+	var numbers []int
+	if len(args) > 0 {
+		numbers, err = parseIssueNumberList(args[0])
+		if err != nil {
+			return err
+		}
+	}
+	if items != "" {
+		more, err := parseIssueNumberList(items)
+		if err != nil {
+			return err
+		}
+		numbers = append(numbers, more...)
+	}
+	if len(numbers) == 0 {
+		return fmt.Errorf("specify an issue number or --items")
+	}
+
+	client := newCommandClient(cmd)
+	milestones, err := client.GetOpenMilestones()
+	if err != nil {
+		return fmt.Errorf("failed to list milestones: %w", err)
+	}
+
+	// Resolve and confirm everything before creating a milestone, so a bad
+	// issue number or a declined confirmation leaves nothing behind
+	issues, err := client.getScheduleIssues(numbers)
+	if err != nil {
+		return fmt.Errorf("failed to get issues: %w", err)
+	}
+	var missing []string
+	for _, number := range numbers {
+		if _, ok := issues[number]; !ok {
+			missing = append(missing, fmt.Sprintf("#%d", number))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("issue(s) not found: %s", strings.Join(missing, ", "))
+	}
+
+	decision := map[string]interface{}{"due": due}
+	milestone, found := pickMilestoneForDate(milestones, due)
+	if found {
+		decision["action"] = "existing"
+		decision["reason"] = fmt.Sprintf("earliest open milestone due on or after %s (due %s)", due, milestone.dueDate())
+	} else {
+		if noCreate {
+			return fmt.Errorf("no open milestone is due on or after %s", due)
+		}
+		if newTitle == "" {
+			newTitle = due
+		}
+		decision["action"] = "create"
+		decision["reason"] = fmt.Sprintf("no open milestone is due on or after %s", due)
+		milestone = &ScheduleMilestone{Title: newTitle, DueOn: due}
+	}
+
+	var toAssign []string
+	for _, number := range numbers {
+		if issue := issues[number]; issue.Milestone == nil || issue.Milestone.Title != milestone.Title {
+			toAssign = append(toAssign, fmt.Sprintf("#%d → %s", number, milestone.Title))
+		}
+	}
+	if !dryRun {
+		if err := confirmBulkOperation(cmd, "change the milestone of", toAssign, false); err != nil {
+			return err
+		}
+		if !found {
+			milestone, err = client.CreateMilestone(newTitle, due)
+			if err != nil {
+				return fmt.Errorf("failed to create milestone %q: %w", newTitle, err)
+			}
+			decision["action"] = "created"
+		}
+	}
+	decision["milestone"] = milestone
+
+	results := []ScheduledIssue{}
+	failed := 0
+	for _, number := range numbers {
+		result := ScheduledIssue{Issue: number}
+		issue := issues[number]
+		switch {
+		case issue.Milestone != nil && issue.Milestone.Title == milestone.Title:
+			result.Previous = issue.Milestone.Title
+			result.Status = "unchanged"
+		default:
+			if issue.Milestone != nil {
+				result.Previous = issue.Milestone.Title
+			}
+			if dryRun {
+				result.Status = "would-assign"
+			} else if err := client.SetIssueMilestone(issue.ID, milestone.ID); err != nil {
+				result.Status = "failed"
+				result.Error = err.Error()
+			} else {
+				result.Status = "assigned"
+			}
+		}
+		if result.Status == "failed" {
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	output := map[string]interface{}{
+		"decision": decision,
+		"issues":   results,
+	}
+	if dryRun {
+		output["dryRun"] = true
+	}
+	if err := EncodeOutputWithCmd(cmd, map[string]interface{}{"schedule": output}); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to schedule %d of %d issue(s)", failed, len(numbers))
+	}
+	return nil
+}
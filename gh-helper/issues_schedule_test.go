@@ -0,0 +1,47 @@
+package main
+
+import "testing"
+
+func TestPickMilestoneForDate(t *testing.T) {
+	milestones := []ScheduleMilestone{
+		{Title: "backlog"},
+		{Title: "v0.19.0", DueOn: "2025-01-15T08:00:00Z"},
+		{Title: "v0.21.0", DueOn: "2025-03-01T08:00:00Z"},
+		{Title: "v0.20.0", DueOn: "2025-02-01T08:00:00Z"},
+	}
+
+	tests := []struct {
+		due       string
+		wantTitle string
+		wantFound bool
+	}{
+		{"2025-01-10", "v0.19.0", true},
+		{"2025-02-01", "v0.20.0", true}, // due date itself is covered
+		{"2025-02-02", "v0.21.0", true},
+		{"2025-04-01", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.due, func(t *testing.T) {
+			got, found := pickMilestoneForDate(milestones, tt.due)
+			if found != tt.wantFound {
+				t.Fatalf("found = %v, want %v", found, tt.wantFound)
+			}
+			if found && got.Title != tt.wantTitle {
+				t.Errorf("milestone = %q, want %q", got.Title, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestParseIssueNumberList(t *testing.T) {
+	got, err := parseIssueNumberList("123, #124,,130")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[0] != 123 || got[1] != 124 || got[2] != 130 {
+		t.Errorf("parseIssueNumberList() = %v", got)
+	}
+	if _, err := parseIssueNumberList("12,abc"); err == nil {
+		t.Error("expected error for non-numeric item")
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import "testing"
+
+func TestNewLinkedPRsInfo(t *testing.T) {
+	tests := []struct {
+		name       string
+		prs        []LinkedPullRequest
+		wantOpen   int
+		wantMerged bool
+	}{
+		{name: "none", prs: nil},
+		{
+			name: "open closing PR",
+			prs: []LinkedPullRequest{
+				{Number: 1, State: "OPEN", WillClose: true},
+				{Number: 2, State: "CLOSED"},
+			},
+			wantOpen: 1,
+		},
+		{
+			name: "merged reference does not close",
+			prs: []LinkedPullRequest{
+				{Number: 3, State: "MERGED", WillClose: false},
+			},
+		},
+		{
+			name: "merged closing PR",
+			prs: []LinkedPullRequest{
+				{Number: 4, State: "MERGED", WillClose: true},
+			},
+			wantMerged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := newLinkedPRsInfo(tt.prs)
+			if info.TotalCount != len(tt.prs) {
+				t.Errorf("TotalCount = %d, want %d", info.TotalCount, len(tt.prs))
+			}
+			if info.OpenCount != tt.wantOpen {
+				t.Errorf("OpenCount = %d, want %d", info.OpenCount, tt.wantOpen)
+			}
+			if info.Merged != tt.wantMerged {
+				t.Errorf("Merged = %v, want %v", info.Merged, tt.wantMerged)
+			}
+			if info.Nodes == nil {
+				t.Error("Nodes should be non-nil for stable output")
+			}
+		})
+	}
+}
@@ -64,17 +64,19 @@ func init() {
 	addLabelsCmd.Flags().String("items", "", "Comma-separated list of items (e.g., 254,issue/238,pull/267)")
 	addLabelsCmd.Flags().String("title-pattern", "", "Regex pattern to match titles")
 	addLabelsCmd.Flags().Bool("dry-run", false, "Show what would be done without making changes")
-	addLabelsCmd.Flags().Bool("confirm", false, "Interactive confirmation for bulk operations")
+	addLabelsCmd.Flags().Bool("confirm", false, "Always ask for confirmation, regardless of --confirm-threshold")
 	addLabelsCmd.Flags().Bool("parallel", true, "Execute mutations concurrently")
 	addLabelsCmd.Flags().Int("max-concurrent", 5, "Maximum concurrent requests")
+	addForceFlag(addLabelsCmd)
 
 	// Add flags for remove command
 	removeLabelsCmd.Flags().String("items", "", "Comma-separated list of items (e.g., 254,issue/238,pull/267)")
 	removeLabelsCmd.Flags().String("title-pattern", "", "Regex pattern to match titles")
 	removeLabelsCmd.Flags().Bool("dry-run", false, "Show what would be done without making changes")
-	removeLabelsCmd.Flags().Bool("confirm", false, "Interactive confirmation for bulk operations")
+	removeLabelsCmd.Flags().Bool("confirm", false, "Always ask for confirmation, regardless of --confirm-threshold")
 	removeLabelsCmd.Flags().Bool("parallel", true, "Execute mutations concurrently")
 	removeLabelsCmd.Flags().Int("max-concurrent", 5, "Maximum concurrent requests")
+	addForceFlag(removeLabelsCmd)
 
 	// Add flags for add-from-issues command
 	addFromIssuesCmd.Flags().Int("pr", 0, "Pull request number")
@@ -157,6 +159,10 @@ func addLabels(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get 'dry-run' flag: %w", err)
 	}
+	confirm, err := cmd.Flags().GetBool("confirm")
+	if err != nil {
+		return fmt.Errorf("failed to get 'confirm' flag: %w", err)
+	}
 	parallel, err := cmd.Flags().GetBool("parallel")
 	if err != nil {
 		return fmt.Errorf("failed to get 'parallel' flag: %w", err)
@@ -170,7 +176,7 @@ func addLabels(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("either --items or --title-pattern must be specified")
 	}
 
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
 
 	// Get repository info
 	repoID, err := client.getRepositoryID()
@@ -236,7 +242,7 @@ func addLabels(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(itemsToProcess) == 0 {
-		fmt.Println("No items found to process")
+		fmt.Fprintln(messageWriter(cmd), "No items found to process")
 		return nil
 	}
 
@@ -252,13 +258,25 @@ func addLabels(cmd *cobra.Command, args []string) error {
 	itemsToProcess = uniqueItems
 
 	if dryRun {
-		fmt.Printf("Would add labels %v to %d items:\n", labels, len(itemsToProcess))
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Would add labels %v to %d items:\n", labels, len(itemsToProcess))
 		for _, item := range itemsToProcess {
-			fmt.Printf("  - %s #%d: %s\n", item.Type, item.Number, item.Title)
+			fmt.Fprintf(out, "  - %s #%d: %s\n", item.Type, item.Number, item.Title)
 		}
 		return nil
 	}
 
+	if err := checkFreshTargets(cmd, func() ([]prTarget, error) { return client.getPRTargetsForNodes(pullRequestIDs(itemsToProcess)) }); err != nil {
+		return err
+	}
+
+	// Adding labels is not destructive, so only prompt when --confirm is given
+	if confirm {
+		if err := confirmBulkOperation(cmd, fmt.Sprintf("add labels %v to", labels), describeItemsToLabel(itemsToProcess), true); err != nil {
+			return err
+		}
+	}
+
 	// Execute label additions
 	results := ExecuteParallel(
 		itemsToProcess,
@@ -324,6 +342,10 @@ func removeLabels(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get 'dry-run' flag: %w", err)
 	}
+	confirm, err := cmd.Flags().GetBool("confirm")
+	if err != nil {
+		return fmt.Errorf("failed to get 'confirm' flag: %w", err)
+	}
 	parallel, err := cmd.Flags().GetBool("parallel")
 	if err != nil {
 		return fmt.Errorf("failed to get 'parallel' flag: %w", err)
@@ -337,7 +359,7 @@ func removeLabels(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("either --items or --title-pattern must be specified")
 	}
 
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
 
 	// Get repository info
 	repoID, err := client.getRepositoryID()
@@ -362,7 +384,7 @@ func removeLabels(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(labelIDs) == 0 {
-		fmt.Println("No valid labels to remove")
+		fmt.Fprintln(messageWriter(cmd), "No valid labels to remove")
 		return nil
 	}
 
@@ -409,7 +431,7 @@ func removeLabels(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(itemsToProcess) == 0 {
-		fmt.Println("No items found to process")
+		fmt.Fprintln(messageWriter(cmd), "No items found to process")
 		return nil
 	}
 
@@ -425,13 +447,22 @@ func removeLabels(cmd *cobra.Command, args []string) error {
 	itemsToProcess = uniqueItems
 
 	if dryRun {
-		fmt.Printf("Would remove labels %v from %d items:\n", labels, len(itemsToProcess))
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Would remove labels %v from %d items:\n", labels, len(itemsToProcess))
 		for _, item := range itemsToProcess {
-			fmt.Printf("  - %s #%d: %s\n", item.Type, item.Number, item.Title)
+			fmt.Fprintf(out, "  - %s #%d: %s\n", item.Type, item.Number, item.Title)
 		}
 		return nil
 	}
 
+	if err := checkFreshTargets(cmd, func() ([]prTarget, error) { return client.getPRTargetsForNodes(pullRequestIDs(itemsToProcess)) }); err != nil {
+		return err
+	}
+
+	if err := confirmBulkOperation(cmd, fmt.Sprintf("remove labels %v from", labels), describeItemsToLabel(itemsToProcess), confirm); err != nil {
+		return err
+	}
+
 	// Execute label removals
 	results := ExecuteParallel(
 		itemsToProcess,
@@ -475,6 +506,26 @@ func removeLabels(cmd *cobra.Command, args []string) error {
 	return EncodeOutputWithCmd(cmd, summary)
 }
 
+// pullRequestIDs returns the node IDs of the pull requests among items
+func pullRequestIDs(items []ItemToLabel) []string {
+	var ids []string
+	for _, item := range items {
+		if item.Type == "PullRequest" {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids
+}
+
+// describeItemsToLabel formats items for confirmation previews
+func describeItemsToLabel(items []ItemToLabel) []string {
+	descriptions := make([]string, 0, len(items))
+	for _, item := range items {
+		descriptions = append(descriptions, fmt.Sprintf("%s #%d: %s", item.Type, item.Number, item.Title))
+	}
+	return descriptions
+}
+
 func addFromIssues(cmd *cobra.Command, args []string) error {
 	prNumber, err := cmd.Flags().GetInt("pr")
 	if err != nil {
@@ -485,7 +536,7 @@ func addFromIssues(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get 'dry-run' flag: %w", err)
 	}
 
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
 
 	// Get PR info with linked issues
 	pr, err := client.GetPRWithLinkedIssues(prNumber)
@@ -518,16 +569,17 @@ func addFromIssues(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(labelsToAdd) == 0 {
-		fmt.Printf("PR #%d already has all labels from its linked issues\n", prNumber)
+		fmt.Fprintf(messageWriter(cmd), "PR #%d already has all labels from its linked issues\n", prNumber)
 		return nil
 	}
 
 	if dryRun {
-		fmt.Printf("Would add the following labels to PR #%d:\n", prNumber)
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Would add the following labels to PR #%d:\n", prNumber)
 		for _, label := range labelsToAdd {
-			fmt.Printf("  - %s\n", label)
+			fmt.Fprintf(out, "  - %s\n", label)
 		}
-		fmt.Printf("\nLabels inherited from %d linked issue(s)\n", len(pr.ClosingIssuesReferences.Nodes))
+		fmt.Fprintf(out, "\nLabels inherited from %d linked issue(s)\n", len(pr.ClosingIssuesReferences.Nodes))
 		return nil
 	}
 
@@ -0,0 +1,542 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check repository conventions",
+	Long:  `Check pull requests and issues against team conventions and report violations with suggested fixes.`,
+}
+
+var titlesLintCmd = NewOperationalCommand(
+	"titles [flags]",
+	"Check PR and issue titles against title rules",
+	`Check the titles of PRs and issues updated since --since against rules and
+report each violation with a suggested fix. Exits with code 7 when any title
+violates a rule, for CI gating.
+
+Rules (--rules, comma-separated):
+  conventional-commits  "type(scope)!: description" with a known type; the
+                        suggested type comes from labels or the title's verb
+  max-length            At most --max-length characters
+  issue-reference       PRs reference an issue (#N in the title, or a closing
+                        reference such as "Fixes #N" in the description);
+                        issues are not checked
+
+Defaults come from the "lint.titles" section of the config file (see
+'issues list --help'), then conventional-commits,max-length and 72:
+
+  lint:
+    titles:
+      rules: [conventional-commits, max-length, issue-reference]
+      maxLength: 72
+      types: [feat, fix, docs, chore]   # replaces the default type list
+
+The conventional commit types default to feat, fix, docs, chore, refactor,
+perf, test, build, ci, style, and revert; feat, fix, docs, chore, and ci map to
+the labels 'releases analyze' suggests.
+
+With --comment, each open PR with violations gets a comment listing them,
+updated in place on later runs.
+
+Examples:
+  # PR titles from the last 30 days
+  gh-helper lint titles --prs --since 30d --rules conventional-commits
+
+  # Open PRs and issues, all rules, with a comment on offending PRs
+  gh-helper lint titles --prs --issues --state open --rules conventional-commits,max-length,issue-reference --comment
+
+  # Only the suggested titles
+  gh-helper lint titles --prs --jq '.titleLint.results[] | "#\(.number) \(.suggestedTitle)"'`,
+	lintTitles,
+)
+
+func init() {
+	titlesLintCmd.Flags().Bool("prs", false, "Check pull request titles (default when neither --prs nor --issues is given)")
+	titlesLintCmd.Flags().Bool("issues", false, "Check issue titles")
+	titlesLintCmd.Flags().String("since", "30d", "Check items updated since (days like 30d, YYYY-MM-DD, RFC 3339, or duration)")
+	titlesLintCmd.Flags().String("state", "all", "Item state: open, closed, or all")
+	titlesLintCmd.Flags().StringSlice("rules", nil, "Rules to check (default: config, else conventional-commits,max-length)")
+	titlesLintCmd.Flags().Int("max-length", 0, "Maximum title length for max-length (default: config, else 72)")
+	titlesLintCmd.Flags().Int("limit", 200, "Maximum items to check")
+	titlesLintCmd.Flags().Bool("comment", false, "Comment on open PRs with violations (updated in place)")
+	titlesLintCmd.Flags().Bool("dry-run", false, "With --comment, report the comments without posting")
+
+	lintCmd.AddCommand(titlesLintCmd)
+	rootCmd.AddCommand(lintCmd)
+}
+
+// LintConfig configures 'lint' commands
+type LintConfig struct {
+	Titles TitleLintConfig `yaml:"titles"`
+}
+
+// TitleLintConfig configures 'lint titles'
+type TitleLintConfig struct {
+	// Rules are the rules checked when --rules is not given
+	Rules []string `yaml:"rules"`
+	// MaxLength is the max-length limit when --max-length is not given
+	MaxLength int `yaml:"maxLength"`
+	// Types replaces the allowed conventional commit types
+	Types []string `yaml:"types"`
+}
+
+// merge overlays a higher-priority config file's lint settings
+func (l *LintConfig) merge(other LintConfig) {
+	if len(other.Titles.Rules) > 0 {
+		l.Titles.Rules = other.Titles.Rules
+	}
+	if other.Titles.MaxLength != 0 {
+		l.Titles.MaxLength = other.Titles.MaxLength
+	}
+	if len(other.Titles.Types) > 0 {
+		l.Titles.Types = other.Titles.Types
+	}
+}
+
+const (
+	defaultTitleMaxLength = 72
+	titleLintMarker       = "<!-- gh-helper:title-lint -->"
+)
+
+var (
+	defaultTitleRules        = []string{"conventional-commits", "max-length"}
+	defaultConventionalTypes = []string{"feat", "fix", "docs", "chore", "refactor", "perf", "test", "build", "ci", "style", "revert"}
+
+	// conventionalTitlePattern captures the type, optional scope and breaking
+	// marker, and the description
+	conventionalTitlePattern = regexp.MustCompile(`^([A-Za-z]+)(\([^)]*\))?(!)?:\s*(.*)$`)
+	titleIssueRefPattern     = regexp.MustCompile(`#\d+\b`)
+)
+
+// lintItem is a PR or issue whose title is checked
+type lintItem struct {
+	Kind          string // "pr" or "issue"
+	ID            string
+	Number        int
+	Title         string
+	URL           string
+	State         string
+	Labels        []string
+	ClosingIssues int // closing issue references of a PR
+}
+
+// TitleViolation is one rule a title breaks
+type TitleViolation struct {
+	Rule       string `json:"rule"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// TitleLintResult lists the violations of one item
+type TitleLintResult struct {
+	Kind           string           `json:"kind"`
+	Number         int              `json:"number"`
+	Title          string           `json:"title"`
+	URL            string           `json:"url,omitempty"`
+	Violations     []TitleViolation `json:"violations"`
+	SuggestedTitle string           `json:"suggestedTitle,omitempty"`
+	Comment        string           `json:"comment,omitempty"` // created, updated, unchanged, or would-post
+}
+
+// titleLinter checks titles against the selected rules
+type titleLinter struct {
+	rules     []string
+	maxLength int
+	types     []string
+}
+
+// titleRuleNames are the rules known to the linter, in documentation order
+var titleRuleNames = []string{"conventional-commits", "max-length", "issue-reference"}
+
+func newTitleLinter(rules []string, maxLength int, types []string) (*titleLinter, error) {
+	for _, rule := range rules {
+		if !slices.Contains(titleRuleNames, rule) {
+			return nil, fmt.Errorf("unknown rule %q (available: %s)", rule, strings.Join(titleRuleNames, ", "))
+		}
+	}
+	if maxLength <= 0 {
+		return nil, fmt.Errorf("max length must be positive")
+	}
+	return &titleLinter{rules: rules, maxLength: maxLength, types: types}, nil
+}
+
+// suggestTitleType infers a conventional commit type from labels, then from
+// the title's leading verb; "" when nothing fits
+func suggestTitleType(item lintItem, types []string) string {
+	labelTypes := map[string]string{
+		"bug": "fix", "enhancement": "feat", "feature": "feat", "documentation": "docs",
+		"chore": "chore", "refactor": "refactor", "performance": "perf", "test": "test", "ci": "ci",
+	}
+	for _, label := range item.Labels {
+		if t, ok := labelTypes[strings.ToLower(label)]; ok && slices.Contains(types, t) {
+			return t
+		}
+	}
+
+	verbTypes := map[string]string{
+		"fix": "fix", "fixes": "fix", "fixed": "fix", "correct": "fix",
+		"add": "feat", "adds": "feat", "implement": "feat", "support": "feat", "introduce": "feat", "allow": "feat",
+		"refactor": "refactor", "simplify": "refactor", "extract": "refactor", "rename": "refactor",
+		"document": "docs", "docs": "docs",
+		"test": "test", "tests": "test",
+		"bump": "build", "upgrade": "build",
+		"revert": "revert",
+	}
+	first, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(item.Title)), " ")
+	if t, ok := verbTypes[first]; ok && slices.Contains(types, t) {
+		return t
+	}
+	return ""
+}
+
+// lowerFirst lowercases the first letter unless the first word looks like an
+// acronym or identifier (e.g. "API", "GraphQL")
+func lowerFirst(s string) string {
+	word, _, _ := strings.Cut(s, " ")
+	r, size := utf8.DecodeRuneInString(word)
+	if size == 0 || !unicode.IsUpper(r) {
+		return s
+	}
+	for _, rest := range word[size:] {
+		if unicode.IsUpper(rest) {
+			return s
+		}
+	}
+	return string(unicode.ToLower(r)) + s[size:]
+}
+
+// Lint returns the violations of an item's title and a suggested title when
+// a fix can be derived
+func (l *titleLinter) Lint(item lintItem) ([]TitleViolation, string) {
+	var violations []TitleViolation
+	title := strings.TrimSpace(item.Title)
+	suggested := title
+
+	for _, rule := range l.rules {
+		switch rule {
+		case "conventional-commits":
+			m := conventionalTitlePattern.FindStringSubmatch(title)
+			if m != nil && slices.Contains(l.types, strings.ToLower(m[1])) {
+				if strings.TrimSpace(m[4]) == "" {
+					violations = append(violations, TitleViolation{Rule: rule, Message: "missing description after the type"})
+				}
+				continue
+			}
+			description := title
+			violation := TitleViolation{Rule: rule, Message: "missing conventional commit type prefix"}
+			if m != nil {
+				violation.Message = fmt.Sprintf("unknown type %q (allowed: %s)", m[1], strings.Join(l.types, ", "))
+				description = m[4]
+			}
+			if t := suggestTitleType(item, l.types); t != "" {
+				suggested = t + ": " + lowerFirst(description)
+				violation.Suggestion = fmt.Sprintf("use %q", suggested)
+			} else {
+				violation.Suggestion = fmt.Sprintf("prefix with a type such as %q", l.types[0]+": ")
+			}
+			violations = append(violations, violation)
+		case "max-length":
+			// Checked below against the suggested title, which may be longer
+		case "issue-reference":
+			if item.Kind != "pr" || item.ClosingIssues > 0 || titleIssueRefPattern.MatchString(title) {
+				continue
+			}
+			violations = append(violations, TitleViolation{
+				Rule:       rule,
+				Message:    "no issue reference",
+				Suggestion: `add "Fixes #N" to the description, or reference the issue in the title`,
+			})
+		}
+	}
+
+	if slices.Contains(l.rules, "max-length") {
+		if length := utf8.RuneCountInString(suggested); length > l.maxLength {
+			violations = append(violations, TitleViolation{
+				Rule:       "max-length",
+				Message:    fmt.Sprintf("%d characters, over the limit of %d", length, l.maxLength),
+				Suggestion: fmt.Sprintf("shorten by %d characters", length-l.maxLength),
+			})
+		}
+	}
+
+	if suggested == title {
+		suggested = ""
+	}
+	return violations, suggested
+}
+
+// renderTitleLintComment renders the comment posted on an offending PR
+func renderTitleLintComment(result TitleLintResult) string {
+	var b strings.Builder
+	b.WriteString(titleLintMarker + "\n")
+	b.WriteString("### Title check\n\n")
+	fmt.Fprintf(&b, "The title `%s` does not follow the title conventions:\n\n", strings.ReplaceAll(result.Title, "`", "'"))
+	for _, violation := range result.Violations {
+		fmt.Fprintf(&b, "- **%s**: %s", violation.Rule, violation.Message)
+		if violation.Suggestion != "" {
+			fmt.Fprintf(&b, " (%s)", violation.Suggestion)
+		}
+		b.WriteString("\n")
+	}
+	if result.SuggestedTitle != "" {
+		fmt.Fprintf(&b, "\nSuggested title:\n\n```\n%s\n```\n", result.SuggestedTitle)
+	}
+	b.WriteString("\n<sub>Posted by `gh-helper lint titles`; updated on the next run.</sub>\n")
+	return b.String()
+}
+
+// searchLintItems fetches PRs and/or issues updated since the given time
+func (c *GitHubClient) searchLintItems(kinds []string, state string, since time.Time, limit int) ([]lintItem, error) {
+	searchQuery := fmt.Sprintf("repo:%s/%s updated:>=%s sort:updated-desc", c.Owner, c.Repo, since.UTC().Format("2006-01-02"))
+	if len(kinds) == 1 {
+		searchQuery += " is:" + kinds[0]
+	}
+	if state != "all" {
+		searchQuery += " is:" + state
+	}
+	query := `
+	query($searchQuery: String!, $first: Int!, $after: String) {
+		search(query: $searchQuery, type: ISSUE, first: $first, after: $after) {
+			nodes {
+				__typename
+				... on PullRequest {
+					id number title url state
+					labels(first: 20) { nodes { name } }
+					closingIssuesReferences { totalCount }
+				}
+				... on Issue {
+					id number title url state
+					labels(first: 20) { nodes { name } }
+				}
+			}
+			pageInfo { hasNextPage endCursor }
+		}
+	}`
+
+	var items []lintItem
+	var after *string
+	for len(items) < limit {
+		responseData, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+			"searchQuery": searchQuery,
+			"first":       min(limit-len(items), 100),
+			"after":       after,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var response struct {
+			Data struct {
+				Search struct {
+					Nodes []struct {
+						Typename                string       `json:"__typename"`
+						ID                      string       `json:"id"`
+						Number                  int          `json:"number"`
+						Title                   string       `json:"title"`
+						URL                     string       `json:"url"`
+						State                   string       `json:"state"`
+						Labels                  exportLabels `json:"labels"`
+						ClosingIssuesReferences *struct {
+							TotalCount int `json:"totalCount"`
+						} `json:"closingIssuesReferences"`
+					} `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"search"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse search response: %w", err)
+		}
+
+		for _, node := range response.Data.Search.Nodes {
+			item := lintItem{Kind: "issue", ID: node.ID, Number: node.Number, Title: node.Title, URL: node.URL, State: node.State}
+			if node.Typename == "PullRequest" {
+				item.Kind = "pr"
+			}
+			for _, label := range node.Labels.Nodes {
+				item.Labels = append(item.Labels, label.Name)
+			}
+			if node.ClosingIssuesReferences != nil {
+				item.ClosingIssues = node.ClosingIssuesReferences.TotalCount
+			}
+			items = append(items, item)
+		}
+		if !response.Data.Search.PageInfo.HasNextPage {
+			break
+		}
+		cursor := response.Data.Search.PageInfo.EndCursor
+		after = &cursor
+	}
+	return items, nil
+}
+
+func lintTitles(cmd *cobra.Command, args []string) error {
+	checkPRs, err := cmd.Flags().GetBool("prs")
+	if err != nil {
+		return fmt.Errorf("failed to get 'prs' flag: %w", err)
+	}
+	checkIssues, err := cmd.Flags().GetBool("issues")
+	if err != nil {
+		return fmt.Errorf("failed to get 'issues' flag: %w", err)
+	}
+	sinceValue, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return fmt.Errorf("failed to get 'since' flag: %w", err)
+	}
+	state, err := cmd.Flags().GetString("state")
+	if err != nil {
+		return fmt.Errorf("failed to get 'state' flag: %w", err)
+	}
+	rules, err := cmd.Flags().GetStringSlice("rules")
+	if err != nil {
+		return fmt.Errorf("failed to get 'rules' flag: %w", err)
+	}
+	maxLength, err := cmd.Flags().GetInt("max-length")
+	if err != nil {
+		return fmt.Errorf("failed to get 'max-length' flag: %w", err)
+	}
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return fmt.Errorf("failed to get 'limit' flag: %w", err)
+	}
+	comment, err := cmd.Flags().GetBool("comment")
+	if err != nil {
+		return fmt.Errorf("failed to get 'comment' flag: %w", err)
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get 'dry-run' flag: %w", err)
+	}
+
+	if state != "open" && state != "closed" && state != "all" {
+		return fmt.Errorf("invalid --state %q (expected open, closed, or all)", state)
+	}
+	var kinds []string
+	if checkPRs || !checkIssues {
+		kinds = append(kinds, "pr")
+	}
+	if checkIssues {
+		kinds = append(kinds, "issue")
+	}
+	since, err := parseSince(sinceValue, time.Now())
+	if err != nil {
+		return err
+	}
+
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		rules = config.Lint.Titles.Rules
+	}
+	if len(rules) == 0 {
+		rules = defaultTitleRules
+	}
+	if maxLength == 0 {
+		maxLength = config.Lint.Titles.MaxLength
+	}
+	if maxLength == 0 {
+		maxLength = defaultTitleMaxLength
+	}
+	types := config.Lint.Titles.Types
+	if len(types) == 0 {
+		types = defaultConventionalTypes
+	}
+	linter, err := newTitleLinter(rules, maxLength, types)
+	if err != nil {
+		return err
+	}
+
+	client := newCommandClient(cmd)
+	items, err := client.searchLintItems(kinds, state, since, limit)
+	if err != nil {
+		return fmt.Errorf("failed to search titles: %w", err)
+	}
+
+	results := []TitleLintResult{}
+	byRule := map[string]int{}
+	for _, item := range items {
+		violations, suggested := linter.Lint(item)
+		if len(violations) == 0 {
+			continue
+		}
+		for _, violation := range violations {
+			byRule[violation.Rule]++
+		}
+		result := TitleLintResult{
+			Kind:           item.Kind,
+			Number:         item.Number,
+			Title:          item.Title,
+			URL:            item.URL,
+			Violations:     violations,
+			SuggestedTitle: suggested,
+		}
+
+		if comment && item.Kind == "pr" && item.State == "OPEN" {
+			body := renderTitleLintComment(result)
+			existing, err := client.findMarkedComment(item.Number, titleLintMarker)
+			switch {
+			case err != nil:
+				WarningMsg("Failed to look up the title comment on PR #%d: %v", item.Number, err).Print(cmd)
+			case existing != nil && existing.Body == body:
+				result.Comment = "unchanged"
+			case dryRun:
+				result.Comment = "would-post"
+			default:
+				commentID := ""
+				result.Comment = "created"
+				if existing != nil {
+					commentID = existing.ID
+					result.Comment = "updated"
+				}
+				if _, err := client.upsertComment(item.ID, commentID, body); err != nil {
+					WarningMsg("Failed to comment on PR #%d: %v", item.Number, err).Print(cmd)
+					result.Comment = ""
+				}
+			}
+		}
+		results = append(results, result)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Kind != results[j].Kind {
+			return results[i].Kind == "pr"
+		}
+		return results[i].Number > results[j].Number
+	})
+
+	output := map[string]interface{}{
+		"titleLint": map[string]interface{}{
+			"rules":   rules,
+			"since":   since.UTC().Format(time.RFC3339),
+			"checked": len(items),
+			"byRule":  byRule,
+			"results": results,
+		},
+	}
+	if err := EncodeOutputWithCmd(cmd, output); err != nil {
+		return err
+	}
+	if len(results) > 0 {
+		return &ExitError{
+			Code: ExitCodeLintViolations,
+			Err:  fmt.Errorf("%d title(s) violate the title rules", len(results)),
+		}
+	}
+	return nil
+}
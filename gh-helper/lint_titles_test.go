@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTitleLinter(t *testing.T) {
+	linter, err := newTitleLinter([]string{"conventional-commits", "max-length", "issue-reference"}, 40, defaultConventionalTypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		item      lintItem
+		rules     []string
+		suggested string
+	}{
+		{"valid", lintItem{Kind: "pr", Title: "feat(cli): add lint command", ClosingIssues: 1}, nil, ""},
+		{"breaking with issue in title", lintItem{Kind: "pr", Title: "fix!: drop v1 API (#12)"}, nil, ""},
+		{"verb suggests type", lintItem{Kind: "issue", Title: "Add retry to uploads"}, []string{"conventional-commits"}, "feat: add retry to uploads"},
+		{"label wins over verb", lintItem{Kind: "issue", Title: "Add missing nil check", Labels: []string{"bug"}}, []string{"conventional-commits"}, "fix: add missing nil check"},
+		{"acronym kept", lintItem{Kind: "issue", Title: "API timeout on retry", Labels: []string{"bug"}}, []string{"conventional-commits"}, "fix: API timeout on retry"},
+		{"unknown type", lintItem{Kind: "issue", Title: "feature: dark mode", Labels: []string{"enhancement"}}, []string{"conventional-commits"}, "feat: dark mode"},
+		{"no reference", lintItem{Kind: "pr", Title: "docs: explain config"}, []string{"issue-reference"}, ""},
+		{"too long", lintItem{Kind: "issue", Title: "chore: " + strings.Repeat("x", 40)}, []string{"max-length"}, ""},
+		{"no suggestion", lintItem{Kind: "issue", Title: "Dark mode"}, []string{"conventional-commits"}, ""},
+	}
+	for _, tt := range tests {
+		violations, suggested := linter.Lint(tt.item)
+		var rules []string
+		for _, violation := range violations {
+			rules = append(rules, violation.Rule)
+		}
+		if strings.Join(rules, ",") != strings.Join(tt.rules, ",") || suggested != tt.suggested {
+			t.Errorf("%s: Lint() = %v, %q, want %v, %q", tt.name, violations, suggested, tt.rules, tt.suggested)
+		}
+	}
+}
+
+func TestNewTitleLinterUnknownRule(t *testing.T) {
+	if _, err := newTitleLinter([]string{"emoji"}, 72, defaultConventionalTypes); err == nil || !strings.Contains(err.Error(), "issue-reference") {
+		t.Errorf("newTitleLinter(emoji) = %v, want unknown rule error listing the rules", err)
+	}
+}
+
+func TestLintConfigMerge(t *testing.T) {
+	config := LintConfig{Titles: TitleLintConfig{Rules: []string{"max-length"}, MaxLength: 50}}
+	config.merge(LintConfig{Titles: TitleLintConfig{MaxLength: 100}})
+	if strings.Join(config.Titles.Rules, ",") != "max-length" || config.Titles.MaxLength != 100 {
+		t.Errorf("merged config = %+v", config)
+	}
+}
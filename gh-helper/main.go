@@ -7,11 +7,15 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
+	"github.com/apstndb/gh-dev-tools/pkg/ghclient"
+	"github.com/apstndb/gh-dev-tools/pkg/reviewwait"
 	"github.com/goccy/go-yaml"
 	"github.com/spf13/cobra"
 )
@@ -38,15 +42,15 @@ func resolvePRNumberFromArgs(args []string, client *GitHubClient) (string, error
 	return fmt.Sprintf("%d", prNumberInt), nil
 }
 
-// parseTimeout provides backwards compatibility wrapper  
-func parseTimeout() (time.Duration, error) {
-	return ParseTimeoutString(timeoutStr)
+// parseTimeout parses the command's --timeout
+func parseTimeout(cmd *cobra.Command) (time.Duration, error) {
+	return ParseTimeoutString(commandContext(cmd).Timeout)
 }
 
 // calculateEffectiveTimeout handles timeout calculation with Claude Code constraints consistently
 // Returns the effective timeout and a user-friendly display string
-func calculateEffectiveTimeout() (time.Duration, string, error) {
-	result, err := CalculateTimeoutFromString(timeoutStr)
+func calculateEffectiveTimeout(cmd *cobra.Command) (time.Duration, string, error) {
+	result, err := CalculateTimeoutFromString(commandContext(cmd).Timeout)
 	if err != nil {
 		return 0, "", err
 	}
@@ -54,7 +58,7 @@ func calculateEffectiveTimeout() (time.Duration, string, error) {
 	// Show warning if timeout was constrained
 	if result.Requested > 0 && result.Effective != result.Requested {
 		WarningMsg("Requested timeout (%v) exceeds Claude Code limit. Using %v.", 
-			result.Requested, result.Effective).Print()
+			result.Requested, result.Effective).Print(cmd)
 	}
 	
 	return result.Effective, result.Display, nil
@@ -104,6 +108,26 @@ Use --request-review to automatically request Gemini review before waiting.
 Use --async to check reviews once and return immediately (non-blocking).
 Use --async --detailed to get comprehensive status data including PR comments.
 Use --request-summary to request and wait for Gemini summary.
+Use --require-fresh-summary to also require a Gemini summary posted after the
+latest push; a stale summary is refreshed by posting /gemini summary once per head.
+Use --fail-on-changes-requested to stop as soon as a reviewer requests changes
+(exit code 3 with a structured summary), instead of waiting for checks.
+
+Use --wait-for to replace the default "reviews && checks" gate with an
+expression over these conditions, combined with !, &&, ||, and parentheses:
+  reviews            new reviews are available
+  checks             all PR checks have completed
+  mergeable          the PR is mergeable
+  changes-requested  a new review requests changes
+  summary-fresh      the Gemini summary postdates the latest push
+  comment("re")      a comment since the latest push matches the regexp
+  deployment("env")  a deployment of the head succeeded (any environment without an argument)
+With --wait-for, merge conflicts no longer stop the wait; gate on "mergeable" instead.
+
+Examples:
+  gh-helper reviews wait 306 --wait-for 'reviews && checks && !changes-requested'
+  gh-helper reviews wait 306 --wait-for 'checks && deployment("preview")'
+  gh-helper reviews wait 306 --wait-for 'comment("^/approve") || reviews'
 
 `+prNumberArgsHelp+`
 
@@ -131,6 +155,12 @@ AI-FRIENDLY DESIGN (Issue #301): The reply text can be provided via:
 
 BULK OPERATIONS: You can specify custom messages for individual threads using
 the format THREAD_ID:"Custom message" or use a uniform message for all threads.
+A +resolve or -resolve marker after the thread ID (THREAD_ID:+resolve or
+THREAD_ID:+resolve:"Custom message") overrides --resolve for that thread;
+--resolve-only lists the threads to resolve while the others only get a reply.
+
+THREAD ALIASES: Short aliases from 'threads list' (3 or #3) can be used in place
+of thread IDs; the PR comes from --pr or the current branch.
 
 Examples:
   # Standard response with immediate resolution
@@ -142,8 +172,18 @@ Examples:
   # Reply with custom messages per thread
   gh-helper threads reply PRRT_1:"Fixed the typo" PRRT_2:"Refactored as suggested" --resolve
   
+  # Reply by alias (assigned by 'threads list')
+  gh-helper threads reply 3 --pr 306 --message "Fixed as suggested" --resolve
+  
   # Mix custom and default messages
   gh-helper threads reply PRRT_1 PRRT_2:"Custom fix" PRRT_3 --message "Default fix" --resolve
+
+  # Resolve some threads, only reply to others
+  gh-helper threads reply PRRT_1:+resolve:"Fixed" PRRT_2:"Could you clarify?"
+  gh-helper threads reply PRRT_1 PRRT_2 PRRT_3 --message "Done" --resolve-only PRRT_2,PRRT_3
+
+  # Resolving more than --confirm-threshold threads needs confirmation (or --yes)
+  gh-helper threads reply 1 2 3 4 5 6 --pr 306 --message "Done" --resolve --yes
   
   # Explain without code changes
   gh-helper threads reply PRRT_kwDONC6gMM5SU-GH --message "This is intentional behavior for compatibility" --resolve
@@ -160,7 +200,16 @@ Examples:
   EOF
   
   # Reference current commit hash  
-  gh-helper threads reply PRRT_kwDONC6gMM5SU-GH --commit-hash <HASH> --message "Implemented suggested changes" --resolve`,
+  gh-helper threads reply PRRT_kwDONC6gMM5SU-GH --commit-hash <HASH> --message "Implemented suggested changes" --resolve
+
+  # Quote the reviewer's last comment (first 5 lines) for context in long threads
+  gh-helper threads reply PRRT_1 PRRT_2 --quote --message "Done" --resolve
+
+  # Quote only lines 2-4 of the reviewer's comment
+  gh-helper threads reply PRRT_kwDONC6gMM5SU-GH --quote-selection 2:4 --message "Agreed, fixed"
+
+  # Draft replies for human review instead of posting (appends to the plan file)
+  gh-helper threads reply PRRT_1:"Fixed" PRRT_2:"Won't fix: intended" --commit-hash abc123 --draft-to replies.yaml`,
 	replyToThread,
 )
 
@@ -183,8 +232,15 @@ Examples:
   # Show multiple threads at once
   gh-helper threads show PRRT_kwDONC6gMM5SgXT2 PRRT_kwDONC6gMM5SgXT3
   
+  # Show threads by alias (assigned by 'threads list')
+  gh-helper threads show 1 2 --pr 306
+  
   # Show many threads (useful for batch inspection)
-  gh-helper threads show PRRT_kwDONC6gMM5SgXT2 PRRT_kwDONC6gMM5SgXT3 PRRT_kwDONC6gMM5SgXT4`,
+  gh-helper threads show PRRT_kwDONC6gMM5SgXT2 PRRT_kwDONC6gMM5SgXT3 PRRT_kwDONC6gMM5SgXT4
+
+  # Show original and translated comment bodies
+  GH_HELPER_TRANSLATE_CMD='trans -b :{lang}' gh-helper threads show 1 --pr 306 --translate ja
+  gh-helper threads show 1 --pr 306 --translate en --translate-endpoint http://localhost:5000/translate`,
 	Args:         cobra.MinimumNArgs(1),
 	SilenceUsage: true,
 	RunE:         showThread,
@@ -209,28 +265,65 @@ Examples:
   # Resolve multiple threads at once
   gh-helper threads resolve PRRT_kwDONC6gMM5SgXT2 PRRT_kwDONC6gMM5SgXT3
   
+  # Resolve by alias (assigned by 'threads list')
+  gh-helper threads resolve 1 2 --pr 306
+  
   # Resolve many threads after addressing all feedback
-  gh-helper threads resolve PRRT_kwDONC6gMM5SgXT2 PRRT_kwDONC6gMM5SgXT3 PRRT_kwDONC6gMM5SgXT4`,
+  gh-helper threads resolve PRRT_kwDONC6gMM5SgXT2 PRRT_kwDONC6gMM5SgXT3 PRRT_kwDONC6gMM5SgXT4
+  
+  # More than --confirm-threshold threads needs confirmation (or --yes in scripts)
+  gh-helper threads resolve 1 2 3 4 5 6 --pr 306 --yes`,
 	resolveThread,
 )
 
 // replyWithCommitCmd removed - use 'threads reply' with --message for commit references
 
-var (
-	owner          string
-	repo           string
-	message        string
-	mentionUser    string
-	commitHash     string
-	timeoutStr     string
-	requestReview  bool
-	excludeReviews bool
-	excludeChecks  bool
-	autoResolve    bool
-	async          bool
-	detailed       bool
-	requestSummary bool
-)
+// waitOptions holds the flags of 'reviews wait'
+type waitOptions struct {
+	RequestReview          bool
+	ExcludeReviews         bool
+	ExcludeChecks          bool
+	Async                  bool
+	Detailed               bool
+	RequestSummary         bool
+	FailOnChangesRequested bool
+	RequireFreshSummary    bool
+	WaitFor                string               // --wait-for expression; "" for the default gate
+	Condition              reviewwait.Condition // parsed --wait-for
+}
+
+// readWaitOptions reads the 'reviews wait' flags
+func readWaitOptions(cmd *cobra.Command) (waitOptions, error) {
+	var opts waitOptions
+	for name, target := range map[string]*bool{
+		"request-review":            &opts.RequestReview,
+		"exclude-reviews":           &opts.ExcludeReviews,
+		"exclude-checks":            &opts.ExcludeChecks,
+		"async":                     &opts.Async,
+		"detailed":                  &opts.Detailed,
+		"request-summary":           &opts.RequestSummary,
+		"fail-on-changes-requested": &opts.FailOnChangesRequested,
+		"require-fresh-summary":     &opts.RequireFreshSummary,
+	} {
+		value, err := cmd.Flags().GetBool(name)
+		if err != nil {
+			return opts, fmt.Errorf("failed to get '%s' flag: %w", name, err)
+		}
+		*target = value
+	}
+
+	waitFor, err := cmd.Flags().GetString("wait-for")
+	if err != nil {
+		return opts, fmt.Errorf("failed to get 'wait-for' flag: %w", err)
+	}
+	if waitFor != "" {
+		if opts.Condition, err = reviewwait.ParseCondition(waitFor, waitConditions); err != nil {
+			return opts, fmt.Errorf("invalid --wait-for: %w", err)
+		}
+		opts.WaitFor = waitFor
+	}
+	return opts, nil
+}
 
 // Common help text for PR number arguments
 const prNumberArgsHelp = `Arguments:
@@ -252,10 +345,14 @@ func init() {
 	resolveThreadCmd.Args = cobra.MinimumNArgs(1)
 	
 	// Configure flags
-	rootCmd.PersistentFlags().StringVar(&owner, "owner", DefaultOwner, "GitHub repository owner")
-	rootCmd.PersistentFlags().StringVar(&repo, "repo", DefaultRepo, "GitHub repository name")
-	rootCmd.PersistentFlags().StringVar(&timeoutStr, "timeout", "5m", "Timeout duration (e.g., 90s, 1.5m, 2m30s, 15m)")
-	rootCmd.PersistentFlags().String("format", "yaml", "Output format (yaml|json)")
+	rootCmd.PersistentFlags().String("owner", DefaultOwner, "GitHub repository owner")
+	rootCmd.PersistentFlags().String("repo", DefaultRepo, "GitHub repository name")
+	rootCmd.PersistentFlags().String("timeout", "5m", "Timeout duration (e.g., 90s, 1.5m, 2m30s, 15m)")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Suppress informational messages")
+	rootCmd.PersistentFlags().Bool("show-usage", false, "Append an apiUsage section (queries, mutations, totalCost, remaining) to the output")
+	rootCmd.PersistentFlags().BoolP("yes", "y", false, "Skip confirmation for destructive bulk operations")
+	rootCmd.PersistentFlags().Int("confirm-threshold", envConfirmThreshold(), "Require confirmation when a destructive operation affects more than N items (default from $GH_HELPER_CONFIRM_THRESHOLD)")
+	rootCmd.PersistentFlags().String("format", "yaml", "Output format (yaml|json; some commands also support markdown|csv|tsv|jsonl|dot|mermaid)")
 	rootCmd.PersistentFlags().Bool("json", false, "Output JSON format (alias for --format=json)")
 	rootCmd.PersistentFlags().Bool("yaml", false, "Output YAML format (alias for --format=yaml)")
 	rootCmd.PersistentFlags().String("jq", "", "Apply jq query to filter/transform output")
@@ -265,48 +362,63 @@ func init() {
 	rootCmd.MarkFlagsMutuallyExclusive("format", "yaml")
 	rootCmd.MarkFlagsMutuallyExclusive("json", "yaml")
 
-	replyThreadsCmd.Flags().StringVar(&message, "message", "", "Reply message (or use stdin)")
-	replyThreadsCmd.Flags().StringVar(&mentionUser, "mention", "", "Username to mention (without @)")
-	replyThreadsCmd.Flags().StringVar(&commitHash, "commit-hash", "", "Commit hash to reference in reply")
-	replyThreadsCmd.Flags().BoolVar(&autoResolve, "resolve", false, "Automatically resolve thread after replying")
+	replyThreadsCmd.Flags().String("message", "", "Reply message (or use stdin)")
+	replyThreadsCmd.Flags().String("mention", "", "Username to mention (without @)")
+	replyThreadsCmd.Flags().String("commit-hash", "", "Commit hash to reference in reply")
+	replyThreadsCmd.Flags().Bool("resolve", false, "Automatically resolve thread after replying")
+	replyThreadsCmd.Flags().StringSlice("resolve-only", nil, "Resolve only these threads after replying (comma-separated IDs or aliases)")
 	replyThreadsCmd.Flags().Bool("parallel", true, "Execute mutations concurrently")
 	replyThreadsCmd.Flags().Int("max-concurrent", 5, "Maximum concurrent requests")
-
-	waitReviewsCmd.Flags().BoolVar(&excludeReviews, "exclude-reviews", false, "Exclude reviews, wait for PR checks only")
-	waitReviewsCmd.Flags().BoolVar(&excludeChecks, "exclude-checks", false, "Exclude checks, wait for reviews only")
-	waitReviewsCmd.Flags().BoolVar(&requestReview, "request-review", false, "Request Gemini review before waiting")
-	waitReviewsCmd.Flags().BoolVar(&async, "async", false, "Check reviews once and return immediately (non-blocking, replaces 'reviews check' for review functionality)")
-	waitReviewsCmd.Flags().BoolVar(&detailed, "detailed", false, "Include comprehensive status data including PR comments (requires --async)")
-	waitReviewsCmd.Flags().BoolVar(&requestSummary, "request-summary", false, "Request Gemini summary and wait for it (mutually exclusive with --async)")
+	replyThreadsCmd.Flags().Bool("quote", false, "Prefix the reply with a blockquote of the last reviewer comment")
+	replyThreadsCmd.Flags().Int("quote-lines", 5, "Trim the quote to the first N lines (0: unlimited)")
+	replyThreadsCmd.Flags().String("quote-selection", "", "Quote only lines start:end of the comment (1-based, implies --quote)")
+	replyThreadsCmd.Flags().String("draft-to", "", "Append rendered replies to a reply-plan YAML file instead of posting")
+	replyThreadsCmd.MarkFlagsMutuallyExclusive("resolve", "resolve-only")
+	addForceFlag(replyThreadsCmd)
+	addForceFlag(resolveThreadCmd)
+
+	waitReviewsCmd.Flags().Bool("exclude-reviews", false, "Exclude reviews, wait for PR checks only")
+	waitReviewsCmd.Flags().Bool("exclude-checks", false, "Exclude checks, wait for reviews only")
+	waitReviewsCmd.Flags().Bool("request-review", false, "Request Gemini review before waiting")
+	waitReviewsCmd.Flags().Bool("async", false, "Check reviews once and return immediately (non-blocking, replaces 'reviews check' for review functionality)")
+	waitReviewsCmd.Flags().Bool("detailed", false, "Include comprehensive status data including PR comments (requires --async)")
+	waitReviewsCmd.Flags().Bool("request-summary", false, "Request Gemini summary and wait for it (mutually exclusive with --async)")
+	waitReviewsCmd.Flags().Bool("require-fresh-summary", false, "Also wait for a Gemini summary newer than the latest push, requesting one if stale")
+	waitReviewsCmd.Flags().String("wait-for", "", `Condition expression to wait for, e.g. "reviews && checks && !changes-requested"`)
+	addForceFlag(waitReviewsCmd)
+	waitReviewsCmd.Flags().Bool("fail-on-changes-requested", false, fmt.Sprintf("Exit immediately with code %d when a reviewer requests changes", ExitCodeChangesRequested))
 
 	// Thread command flags
 	showThreadCmd.Flags().Bool("exclude-urls", false, "Exclude URLs from output")
+	showThreadCmd.Flags().Int("max-hunk-lines", 0, "Trim the diff hunk to the last N lines (0: unlimited)")
+	showThreadCmd.Flags().Bool("omit-diff-hunks", false, "Omit the diff hunk entirely")
+	addTranslateFlags(showThreadCmd)
 
 	// Add subcommands
 	reviewsCmd.AddCommand(fetchReviewsCmd, waitReviewsCmd)
 	threadsCmd.AddCommand(showThreadCmd, replyThreadsCmd, resolveThreadCmd)
-	rootCmd.AddCommand(reviewsCmd, threadsCmd, labelsCmd, issuesCmd, releasesCmd, nodeIDCmd)
+	rootCmd.AddCommand(reviewsCmd, threadsCmd, labelsCmd, issuesCmd, releasesCmd, prCmd, nodeIDCmd)
 }
 
 func main() {
-	// Set log level to WARN by default (suppress INFO logs)
+	// Set log level to WARN by default (suppress INFO logs);
+	// GH_HELPER_LOG_LEVEL=debug also logs per-command timing from the middleware
+	level := slog.LevelWarn
+	if value := os.Getenv("GH_HELPER_LOG_LEVEL"); value != "" {
+		_ = level.UnmarshalText([]byte(value))
+	}
 	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
-		Level: slog.LevelWarn,
+		Level: level,
 	})))
 	
+	applyMiddleware(rootCmd)
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		writeCommandError(os.Stderr, err)
+		os.Exit(exitCodeOf(err))
 	}
 }
 
 
-// getCurrentUser returns the current authenticated GitHub username
-func getCurrentUser() (string, error) {
-	client := NewGitHubClient(owner, repo)
-	return client.GetCurrentUser()
-}
-
 // ReviewState represents the state of the last known review
 type ReviewState struct {
 	ID        string `json:"id"`
@@ -438,8 +550,88 @@ func hasNewReviews(reviews []ReviewFields, lastState *ReviewState) bool {
 	return false
 }
 
-// checkClaudeCodeEnvironment checks for Claude Code timeout environment variables
-// and provides guidance based on GitHub issues research.
+// findChangesRequested returns new CHANGES_REQUESTED reviews that are each author's
+// current verdict. COMMENTED reviews do not change a reviewer's verdict, so they are skipped.
+func findChangesRequested(reviews []ReviewFields, lastState *ReviewState) []ReviewFields {
+	latest := make(map[string]ReviewFields)
+	var authors []string
+	for _, review := range reviews {
+		if review.State == "COMMENTED" || review.State == "PENDING" {
+			continue
+		}
+		if _, seen := latest[review.Author.Login]; !seen {
+			authors = append(authors, review.Author.Login)
+		}
+		if prev, ok := latest[review.Author.Login]; !ok || review.CreatedAt >= prev.CreatedAt {
+			latest[review.Author.Login] = review
+		}
+	}
+
+	var result []ReviewFields
+	for _, author := range authors {
+		review := latest[author]
+		if review.State != "CHANGES_REQUESTED" {
+			continue
+		}
+		if hasNewReviews([]ReviewFields{review}, lastState) {
+			result = append(result, review)
+		}
+	}
+	return result
+}
+
+// reportChangesRequested saves review state, emits a structured summary, and returns
+// an ExitError so the process exits with ExitCodeChangesRequested
+func reportChangesRequested(cmd *cobra.Command, prNumber string, reviews []ReviewFields, changesRequested []ReviewFields, startTime time.Time) error {
+	fmt.Fprintf(messageWriter(cmd), "\n❌ [%s] Changes requested on PR #%s\n", time.Now().Format("15:04:05"), prNumber)
+
+	// Record the latest review so the next wait only reacts to newer reviews
+	if len(reviews) > 0 {
+		latestReview := reviews[len(reviews)-1]
+		_ = saveReviewState(prNumber, ReviewState{ID: latestReview.ID, CreatedAt: latestReview.CreatedAt}) // Best effort state save
+	}
+
+	requested := []map[string]interface{}{}
+	reviewers := []string{}
+	for _, review := range changesRequested {
+		entry := map[string]interface{}{
+			"id":        review.ID,
+			"author":    review.Author.Login,
+			"state":     review.State,
+			"createdAt": review.CreatedAt,
+		}
+		if review.Body != "" {
+			entry["body"] = review.Body
+		}
+		if items := extractActionItems(review.Body); len(items) > 0 {
+			entry["actionItems"] = items
+		}
+		requested = append(requested, entry)
+		reviewers = append(reviewers, review.Author.Login)
+	}
+
+	output := map[string]interface{}{
+		"reviewWait": map[string]interface{}{
+			"pr":               prNumber,
+			"result":           "CHANGES_REQUESTED",
+			"exitCode":         ExitCodeChangesRequested,
+			"elapsed":          time.Since(startTime).Truncate(time.Second).String(),
+			"changesRequested": requested,
+		},
+	}
+	if err := EncodeOutputWithCmd(cmd, output); err != nil {
+		return err
+	}
+	ListThreadsGuidance(prNumber).Print(cmd)
+
+	return &ExitError{
+		Code: ExitCodeChangesRequested,
+		Err:  fmt.Errorf("changes requested by %s", strings.Join(reviewers, ", ")),
+	}
+}
+
+// checkClaudeCodeEnvironment checks for Claude Code timeout environment variables,
+// reporting them on w, and provides guidance based on GitHub issues research.
 //
 // Key findings from anthropics/claude-code#1039, anthropics/claude-code#1216, anthropics/claude-code#1717:
 // - BASH_MAX_TIMEOUT_MS: Upper limit for explicit timeout requests (our use case)
@@ -447,20 +639,20 @@ func hasNewReviews(reviews []ReviewFields, lastState *ReviewState) bool {
 // - Claude Code defaults to 2-minute hard limit when no env vars are set
 // - Environment variables are read from ~/.claude/settings.json or project .claude/settings.json
 // - Project settings should be committed, local settings (.claude/settings.local.json) should not
-func checkClaudeCodeEnvironment() (time.Duration, bool) {
+func checkClaudeCodeEnvironment(w io.Writer) (time.Duration, bool) {
 	// Check for BASH_MAX_TIMEOUT_MS (upper limit for explicit timeouts)
 	if maxTimeout, err := ParseClaudeCodeTimeoutEnv("BASH_MAX_TIMEOUT_MS"); err != nil {
-		fmt.Printf("⚠️  %v\n", err)
+		fmt.Fprintf(w, "⚠️  %v\n", err)
 	} else if maxTimeout > 0 {
-		fmt.Printf("🔧 Claude Code BASH_MAX_TIMEOUT_MS detected: %v\n", maxTimeout)
+		fmt.Fprintf(w, "🔧 Claude Code BASH_MAX_TIMEOUT_MS detected: %v\n", maxTimeout)
 		return maxTimeout, true
 	}
 	
 	// Check for BASH_DEFAULT_TIMEOUT_MS (default when no timeout specified)
 	if defaultTimeout, err := ParseClaudeCodeTimeoutEnv("BASH_DEFAULT_TIMEOUT_MS"); err != nil {
-		fmt.Printf("⚠️  %v\n", err)
+		fmt.Fprintf(w, "⚠️  %v\n", err)
 	} else if defaultTimeout > 0 {
-		fmt.Printf("🔧 Claude Code BASH_DEFAULT_TIMEOUT_MS detected: %v\n", defaultTimeout)
+		fmt.Fprintf(w, "🔧 Claude Code BASH_DEFAULT_TIMEOUT_MS detected: %v\n", defaultTimeout)
 		return defaultTimeout, true
 	}
 	
@@ -469,8 +661,9 @@ func checkClaudeCodeEnvironment() (time.Duration, bool) {
 
 // performAsyncReviewCheck performs a single review check without waiting
 // This replaces the functionality of the removed checkReviews command
-func performAsyncReviewCheck(client *GitHubClient, prNumber string) error {
-	StatusMsg("Checking reviews for PR #%s in %s/%s...", prNumber, owner, repo).Print()
+func performAsyncReviewCheck(cmd *cobra.Command, client *GitHubClient, prNumber string) error {
+	w := messageWriter(cmd)
+	StatusMsg("Checking reviews for PR #%s in %s/%s...", prNumber, client.Owner, client.Repo).Print(cmd)
 
 	// Fetch current review data
 	opts := DefaultUnifiedReviewOptions()
@@ -482,12 +675,12 @@ func performAsyncReviewCheck(client *GitHubClient, prNumber string) error {
 		return fmt.Errorf("failed to fetch review data: %w", err)
 	}
 
-	StatusMsg("Found %d reviews for PR #%s", len(data.Reviews), prNumber).Print()
+	StatusMsg("Found %d reviews for PR #%s", len(data.Reviews), prNumber).Print(cmd)
 
 	// Load existing review state
 	lastState, err := loadReviewState(prNumber)
 	if err == nil {
-		fmt.Printf("Last known review: %s at %s\n", lastState.ID, lastState.CreatedAt)
+		fmt.Fprintf(w, "Last known review: %s at %s\n", lastState.ID, lastState.CreatedAt)
 
 		// Check for new reviews
 		hasNew := false
@@ -495,29 +688,29 @@ func performAsyncReviewCheck(client *GitHubClient, prNumber string) error {
 			if review.CreatedAt > lastState.CreatedAt ||
 				(review.CreatedAt == lastState.CreatedAt && review.ID != lastState.ID) {
 				hasNew = true
-				fmt.Printf("\n🎉 New review from %s at %s (%s)\n", review.Author, review.CreatedAt, review.State)
+				fmt.Fprintf(w, "\n🎉 New review from %s at %s (%s)\n", review.Author, review.CreatedAt, review.State)
 				if review.Body != "" {
 					preview := review.Body
 					if len(preview) > 100 {
 						preview = preview[:100] + "..."
 					}
-					fmt.Printf("Preview: %s\n", preview)
+					fmt.Fprintf(w, "Preview: %s\n", preview)
 				}
 			}
 		}
 
 		if !hasNew {
-			InfoMsg("No new reviews since last check").Print()
+			InfoMsg("No new reviews since last check").Print(cmd)
 		}
 	} else {
 		// Provide more specific error information for non-file-not-found errors
 		if !os.IsNotExist(err) {
 			slog.Info("failed to load previous review state", "pr", prNumber, "error", err)
 		}
-		WarningMsg("No previous state found or state could not be loaded, showing all recent reviews...").Print()
-		fmt.Printf("\n📋 Found %d review(s) total\n", len(data.Reviews))
+		WarningMsg("No previous state found or state could not be loaded, showing all recent reviews...").Print(cmd)
+		fmt.Fprintf(w, "\n📋 Found %d review(s) total\n", len(data.Reviews))
 		for _, review := range data.Reviews {
-			fmt.Printf("  - %s at %s (%s)\n", review.Author, review.CreatedAt, review.State)
+			fmt.Fprintf(w, "  - %s at %s (%s)\n", review.Author, review.CreatedAt, review.State)
 		}
 	}
 
@@ -531,17 +724,17 @@ func performAsyncReviewCheck(client *GitHubClient, prNumber string) error {
 		if err := saveReviewState(prNumber, *newState); err != nil {
 			slog.Warn("failed to save review state", "pr", prNumber, "error", err)
 		} else {
-			fmt.Printf("\n💾 Updated state: Latest review %s at %s\n", latestReview.ID, latestReview.CreatedAt)
+			fmt.Fprintf(w, "\n💾 Updated state: Latest review %s at %s\n", latestReview.ID, latestReview.CreatedAt)
 		}
 	}
 
-	fmt.Println("\n✅ Review check complete")
+	fmt.Fprintln(w, "\n✅ Review check complete")
 	return nil
 }
 
 // performDetailedStatusCheck performs comprehensive status check including PR comments
 func performDetailedStatusCheck(cmd *cobra.Command, client *GitHubClient, prNumber string) error {
-	StatusMsg("Collecting detailed status for PR #%s...", prNumber).Print()
+	StatusMsg("Collecting detailed status for PR #%s...", prNumber).Print(cmd)
 	
 	// Convert PR number to integer
 	prNumberInt, err := strconv.Atoi(prNumber)
@@ -550,7 +743,7 @@ func performDetailedStatusCheck(cmd *cobra.Command, client *GitHubClient, prNumb
 	}
 	
 	// Fetch comprehensive PR data
-	config := NewPRQueryConfig(owner, repo, prNumberInt).
+	config := NewPRQueryConfig(client.Owner, client.Repo, prNumberInt).
 		ForReviewsAndStatus().
 		WithThreads().
 		WithComments()
@@ -787,17 +980,33 @@ func performDetailedStatusCheck(cmd *cobra.Command, client *GitHubClient, prNumb
 	return EncodeOutputWithCmd(cmd, output)
 }
 
+// summaryComments converts PR comments for reviewwait.SummaryFresh
+func summaryComments(comments []CommentFields) []reviewwait.Comment {
+	result := make([]reviewwait.Comment, 0, len(comments))
+	for _, comment := range comments {
+		result = append(result, reviewwait.Comment{Body: comment.Body, CreatedAt: parseTimestamp(comment.CreatedAt)})
+	}
+	return result
+}
+
+// parseTimestamp parses an RFC 3339 timestamp, returning the zero time when empty or invalid
+func parseTimestamp(value string) time.Time {
+	t, _ := time.Parse(time.RFC3339, value)
+	return t
+}
+
 // performRequestSummaryAndWait requests a Gemini summary and waits for it
 func performRequestSummaryAndWait(cmd *cobra.Command, client *GitHubClient, prNumber string) error {
-	fmt.Printf("📝 Requesting Gemini summary for PR #%s...\n", prNumber)
+	w := messageWriter(cmd)
+	fmt.Fprintf(w, "📝 Requesting Gemini summary for PR #%s...\n", prNumber)
 	
 	// Post /gemini summary comment
 	if err := client.CreatePRComment(prNumber, "/gemini summary"); err != nil {
 		return fmt.Errorf("failed to request Gemini summary: %w", err)
 	}
 	
-	fmt.Println("✅ Gemini summary requested")
-	fmt.Println("⏳ Waiting for summary to be posted...")
+	fmt.Fprintln(w, "✅ Gemini summary requested")
+	fmt.Fprintln(w, "⏳ Waiting for summary to be posted...")
 	
 	// Convert PR number to integer
 	prNumberInt, err := strconv.Atoi(prNumber)
@@ -806,15 +1015,15 @@ func performRequestSummaryAndWait(cmd *cobra.Command, client *GitHubClient, prNu
 	}
 	
 	// Calculate timeout
-	effectiveTimeout, timeoutDisplay, err := calculateEffectiveTimeout()
+	effectiveTimeout, timeoutDisplay, err := calculateEffectiveTimeout(cmd)
 	if err != nil {
 		return err
 	}
 	
-	fmt.Printf("🔄 Waiting for summary (timeout: %s)...\n", timeoutDisplay)
+	fmt.Fprintf(w, "🔄 Waiting for summary (timeout: %s)...\n", timeoutDisplay)
 	
 	// Get initial comments count
-	config := NewPRQueryConfig(owner, repo, prNumberInt).WithComments()
+	config := NewPRQueryConfig(client.Owner, client.Repo, prNumberInt).WithComments()
 	initialResponse, err := client.FetchPRData(config)
 	if err != nil {
 		return fmt.Errorf("failed to fetch initial PR data: %w", err)
@@ -833,110 +1042,136 @@ func performRequestSummaryAndWait(cmd *cobra.Command, client *GitHubClient, prNu
 	}
 	
 	if foundExistingSummary {
-		fmt.Println("✅ Found existing summary in recent comments")
+		fmt.Fprintln(w, "✅ Found existing summary in recent comments")
 		return nil
 	}
 	
-	startTime := time.Now()
-	
-	// Poll for new summary comment
-	for {
-		// Check timeout
-		if time.Since(startTime) > effectiveTimeout {
-			fmt.Printf("\n⏰ Timeout reached (%v). Summary not posted yet.\n", effectiveTimeout)
-			return fmt.Errorf("timeout waiting for Gemini summary")
-		}
-		
-		// Wait before checking
-		time.Sleep(5 * time.Second)
-		
-		// Fetch updated comments
+	// Poll for a new summary comment
+	var summary *CommentFields
+	fetcher := reviewwait.FetcherFunc(func(needs reviewwait.Requirements) (reviewwait.Poll, error) {
 		response, err := client.FetchPRData(config)
 		if err != nil {
-			fmt.Printf("Error fetching PR data: %v\n", err)
-			continue
+			return reviewwait.Poll{}, fmt.Errorf("failed to fetch PR data: %w", err)
 		}
-		
 		comments := response.GetComments()
-		
-		// Check for new comments
-		if len(comments) > initialCount {
-			// Check new comments for summary
-			for i := initialCount; i < len(comments); i++ {
-				if strings.Contains(comments[i].Body, geminiSummaryHeader) {
-					fmt.Printf("\n🎉 Summary posted by %s at %s\n", 
-						comments[i].Author.Login, comments[i].CreatedAt)
-					
-					// Show preview
-					preview := comments[i].Body
-					if len(preview) > 200 {
-						preview = preview[:200] + "..."
-					}
-					fmt.Printf("\nPreview:\n%s\n", preview)
-					
-					return nil
-				}
+		newComments := comments[min(initialCount, len(comments)):]
+		for i := range newComments {
+			if strings.Contains(newComments[i].Body, geminiSummaryHeader) {
+				summary = &newComments[i]
+				break
 			}
 		}
-		
-		elapsed := time.Since(startTime)
-		remaining := effectiveTimeout - elapsed
-		fmt.Printf("[%s] Waiting for summary... (remaining: %v)\n",
-			time.Now().Format("15:04:05"), remaining.Truncate(time.Second))
+		return reviewwait.Poll{Snapshot: reviewwait.Snapshot{Comments: summaryComments(newComments)}}, nil
+	})
+	condition := reviewwait.CommentMatching(regexp.MustCompile(regexp.QuoteMeta(geminiSummaryHeader)))
+	waiter := newPRWaiter(w, fetcher, condition, effectiveTimeout, 5*time.Second)
+	waiter.OnPoll = func(progress reviewwait.Progress) error {
+		if !progress.Met {
+			fmt.Fprintf(w, "[%s] Waiting for summary... (remaining: %v)\n",
+				time.Now().Format("15:04:05"), progress.Remaining.Truncate(time.Second))
+		}
+		return nil
+	}
+	result, err := waiter.Wait()
+	if err != nil {
+		return err
+	}
+	if result.TimedOut {
+		fmt.Fprintf(w, "\n⏰ Timeout reached (%v). Summary not posted yet.\n", effectiveTimeout)
+		printOutageSummary(w, &waiter.Outage)
+		return fmt.Errorf("timeout waiting for Gemini summary")
 	}
+	
+	fmt.Fprintf(w, "\n🎉 Summary posted by %s at %s\n", summary.Author.Login, summary.CreatedAt)
+	
+	// Show preview
+	preview := summary.Body
+	if len(preview) > 200 {
+		preview = preview[:200] + "..."
+	}
+	fmt.Fprintf(w, "\nPreview:\n%s\n", preview)
+	printOutageSummary(w, &waiter.Outage)
+	return nil
 }
 
 func waitForReviews(cmd *cobra.Command, args []string) error {
-	client := NewGitHubClient(owner, repo)
+	w := messageWriter(cmd)
+	opts, err := readWaitOptions(cmd)
+	if err != nil {
+		return err
+	}
+	client := newCommandClient(cmd)
 	prNumber, err := resolvePRNumberFromArgs(args, client)
 	if err != nil {
 		return err
 	}
 	
 	// Validate flags
-	if excludeReviews && excludeChecks {
+	if opts.ExcludeReviews && opts.ExcludeChecks {
 		return fmt.Errorf("cannot exclude both reviews and checks")
 	}
 	
 	// Validate mutually exclusive flags
-	if requestSummary && async {
+	if opts.RequestSummary && opts.Async {
 		return fmt.Errorf("--request-summary and --async are mutually exclusive")
 	}
 	
 	// Validate --detailed requires --async
-	if detailed && !async {
+	if opts.Detailed && !opts.Async {
 		return fmt.Errorf("--detailed requires --async")
 	}
 	
+	// Validate --fail-on-changes-requested applies to a blocking review wait
+	if opts.FailOnChangesRequested && (opts.Async || opts.RequestSummary || opts.ExcludeReviews) {
+		return fmt.Errorf("--fail-on-changes-requested cannot be combined with --async, --request-summary, or --exclude-reviews")
+	}
+	
+	// The summary gate is part of the combined reviews and checks wait
+	if opts.RequireFreshSummary && (opts.Async || opts.RequestSummary || opts.ExcludeChecks) {
+		return fmt.Errorf("--require-fresh-summary cannot be combined with --async, --request-summary, or --exclude-checks")
+	}
+	
+	// Requesting a review or summary posts a comment; refuse on a merged or closed PR
+	if opts.RequestReview || opts.RequestSummary || opts.RequireFreshSummary {
+		if err := checkFreshTargets(cmd, func() ([]prTarget, error) { return client.getPRTarget(prNumber) }); err != nil {
+			return err
+		}
+	}
+	
+	// --wait-for replaces the combined reviews and checks gate
+	if opts.WaitFor != "" && (opts.Async || opts.RequestSummary || opts.ExcludeReviews || opts.ExcludeChecks) {
+		return fmt.Errorf("--wait-for cannot be combined with --async, --request-summary, --exclude-reviews, or --exclude-checks")
+	}
+	
 	// Handle async mode - single check and return (replaces reviews check)
-	if async {
-		if detailed {
-			InfoMsg("Running in async mode with detailed status").Print()
+	if opts.Async {
+		if opts.Detailed {
+			InfoMsg("Running in async mode with detailed status").Print(cmd)
 			return performDetailedStatusCheck(cmd, client, prNumber)
-		} else if !excludeReviews {
-			InfoMsg("Running in async mode (single check, no waiting)").Print()
-			return performAsyncReviewCheck(client, prNumber)
+		} else if !opts.ExcludeReviews {
+			InfoMsg("Running in async mode (single check, no waiting)").Print(cmd)
+			return performAsyncReviewCheck(cmd, client, prNumber)
 		} else {
 			return fmt.Errorf("async mode currently only supports review checking")
 		}
 	}
 	
 	// Handle request-summary mode
-	if requestSummary {
+	if opts.RequestSummary {
 		return performRequestSummaryAndWait(cmd, client, prNumber)
 	}
 	
 	// Determine what to wait for
-	waitForReviews := !excludeReviews
-	waitForChecks := !excludeChecks
+	waitForReviews := !opts.ExcludeReviews
+	waitForChecks := !opts.ExcludeChecks
 	
 	// Request Gemini review if flag is set
-	if requestReview && waitForReviews {
-		fmt.Printf("📝 Requesting Gemini review for PR #%s...\n", prNumber)
+	if opts.RequestReview && waitForReviews {
+		fmt.Fprintf(w, "📝 Requesting Gemini review for PR #%s...\n", prNumber)
 		if err := client.CreatePRComment(prNumber, "/gemini review"); err != nil {
 			return fmt.Errorf("failed to request Gemini review: %w", err)
 		}
-		fmt.Println("✅ Gemini review requested")
+		fmt.Fprintln(w, "✅ Gemini review requested")
 	}
 	
 	// Display what we're waiting for
@@ -949,40 +1184,84 @@ func waitForReviews(cmd *cobra.Command, args []string) error {
 	}
 	
 	// Calculate timeout with Claude Code constraints
-	_, timeoutDisplay, err := calculateEffectiveTimeout()
+	_, timeoutDisplay, err := calculateEffectiveTimeout(cmd)
 	if err != nil {
 		return err
 	}
 	
-	fmt.Printf("🔄 Waiting for %s on PR #%s (timeout: %s)...\n", 
+	fmt.Fprintf(w, "🔄 Waiting for %s on PR #%s (timeout: %s)...\n", 
 		strings.Join(waitingFor, " and "), prNumber, timeoutDisplay)
-	fmt.Println("Press Ctrl+C to stop monitoring")
+	fmt.Fprintln(w, "Press Ctrl+C to stop monitoring")
 
 	// For now, simply delegate to waitForReviewsAndChecks with appropriate flags
 	// This ensures the new default behavior (both reviews and checks) works
 	
-	// Temporarily override global flags for delegation
-	originalRequestReview := requestReview
-	defer func() { requestReview = originalRequestReview }()
-	
 	// Disable review request in delegated function since we already handled it above
-	requestReview = false
+	opts.RequestReview = false
 	
 	// If we're only waiting for reviews, use the original simpler logic
 	if waitForReviews && !waitForChecks {
-		fmt.Printf("⚠️  Reviews-only mode: Using simplified wait logic\n")
+		fmt.Fprintf(w, "⚠️  Reviews-only mode: Using simplified wait logic\n")
 		// Simple polling for reviews only (original behavior)
-		return waitForReviewsOnly(prNumber)
+		return waitForReviewsOnly(cmd, prNumber, opts)
 	}
 	
 	// For all other cases (checks-only or both), delegate to the full implementation
-	err = waitForReviewsAndChecks(cmd, args)
+	err = waitForReviewsAndChecks(cmd, args, opts)
 	// Don't wrap the error to avoid double error messages
 	return err
 }
 
+// newPRWaiter returns the reviewwait.Waiter of a PR polling loop ('reviews wait',
+// 'poll-events'). Failed polls are printed to w: GitHub outages switch the wait
+// into degraded mode with a longer interval and print only the first and
+// changed errors; other errors are printed every poll.
+func newPRWaiter(w io.Writer, fetcher reviewwait.Fetcher, condition reviewwait.Condition, timeout, interval time.Duration) *reviewwait.Waiter {
+	return &reviewwait.Waiter{
+		Fetcher:   fetcher,
+		Condition: condition,
+		Timeout:   timeout,
+		Interval:  interval,
+		IsOutage:  ghclient.IsOutage,
+		OnFailure: func(failure reviewwait.PollFailure) {
+			switch {
+			case !failure.Outage:
+				fmt.Fprintf(w, "Error: %v\n", failure.Err)
+			case !failure.Notify:
+			case failure.Continuing:
+				fmt.Fprintf(w, "🌩️  [%s] Still degraded: %v\n", time.Now().Format("15:04:05"), failure.Err)
+			default:
+				fmt.Fprintf(w, "🌩️  [%s] GitHub API outage detected, polling every %v until it recovers: %v\n",
+					time.Now().Format("15:04:05"), failure.Next, failure.Err)
+			}
+		},
+		OnRecover: func(duration time.Duration, failures int) {
+			fmt.Fprintf(w, "✅ [%s] GitHub API recovered after %v (%d failed poll(s))\n",
+				time.Now().Format("15:04:05"), duration.Truncate(time.Second), failures)
+		},
+	}
+}
+
+// printOutageSummary reports the time spent in outages, if any
+func printOutageSummary(w io.Writer, outage *reviewwait.OutageTracker) {
+	if summary := outage.Summary(time.Now()); summary != "" {
+		fmt.Fprintln(w, summary)
+	}
+}
+
+// failOnChangesRequested reports a review requesting changes as an ExitError
+// when --fail-on-changes-requested is set; checks no longer matter then
+func failOnChangesRequested(cmd *cobra.Command, prNumber string, opts waitOptions, progress reviewwait.Progress, reviews []ReviewFields, startTime time.Time) error {
+	if !opts.FailOnChangesRequested || !progress.Poll.Snapshot.ChangesRequested {
+		return nil
+	}
+	lastState, _ := loadReviewState(prNumber)
+	return reportChangesRequested(cmd, prNumber, reviews, findChangesRequested(reviews, lastState), startTime)
+}
+
 // waitForReviewsOnly waits specifically for new reviews without checking PR status
-func waitForReviewsOnly(prNumber string) error {
+func waitForReviewsOnly(cmd *cobra.Command, prNumber string, opts waitOptions) error {
+	w := messageWriter(cmd)
 	// Convert PR number to integer for GraphQL
 	prNumberInt, err := strconv.Atoi(prNumber)
 	if err != nil {
@@ -990,83 +1269,79 @@ func waitForReviewsOnly(prNumber string) error {
 	}
 	
 	// Create GitHub client once for better performance (token caching)
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
 	
 	// Apply Claude Code timeout constraints
-	effectiveTimeout, timeoutDisplay, err := calculateEffectiveTimeout()
+	effectiveTimeout, timeoutDisplay, err := calculateEffectiveTimeout(cmd)
 	if err != nil {
 		return err
 	}
 	
-	fmt.Printf("🔄 Waiting for reviews only on PR #%s (timeout: %s)...\n", prNumber, timeoutDisplay)
-	fmt.Println("Press Ctrl+C to stop monitoring")
+	fmt.Fprintf(w, "🔄 Waiting for reviews only on PR #%s (timeout: %s)...\n", prNumber, timeoutDisplay)
+	fmt.Fprintln(w, "Press Ctrl+C to stop monitoring")
 	
 	// Load existing state
 	lastState, err := loadReviewState(prNumber)
 	if err == nil {
-		fmt.Printf("📊 Tracking reviews since: %s\n", lastState.CreatedAt)
+		fmt.Fprintf(w, "📊 Tracking reviews since: %s\n", lastState.CreatedAt)
 	}
 	
 	startTime := time.Now()
-	for {
-		// Check timeout
-		if time.Since(startTime) > effectiveTimeout {
-			fmt.Printf("\n⏰ Timeout reached (%v). No new reviews found.\n", effectiveTimeout)
-			return nil
+	fetcher := &prWaitFetcher{client: client, prNumber: prNumber, number: prNumberInt, reviewsOnly: true}
+	waiter := newPRWaiter(w, fetcher, reviewwait.NewReview(), effectiveTimeout, 30*time.Second)
+	waiter.OnPoll = func(progress reviewwait.Progress) error {
+		if err := failOnChangesRequested(cmd, prNumber, opts, progress, fetcher.response.GetReviews(), startTime); err != nil {
+			return err
 		}
-		
-		// Use unified architecture for review polling
-		config := NewPRQueryConfig(owner, repo, prNumberInt).ForReviewsOnly()
-		response, err := client.FetchPRData(config)
-		if err != nil {
-			fmt.Printf("Error fetching reviews: %v\n", err)
-			time.Sleep(30 * time.Second)
-			continue
+		if !progress.Met {
+			fmt.Fprintf(w, "[%s] No new reviews yet (remaining: %v)\n",
+				time.Now().Format("15:04:05"), progress.Remaining.Truncate(time.Second))
 		}
-		
-		reviews := response.GetReviews()
-		
-		if hasNewReviews(reviews, lastState) {
-			// Find and display new reviews
-			if lastState == nil {
-				fmt.Printf("\n🎉 Found %d review(s)\n", len(reviews))
-			} else {
-				// Show details of new reviews
-				for _, review := range reviews {
-					if review.CreatedAt > lastState.CreatedAt ||
-						(review.CreatedAt == lastState.CreatedAt && review.ID != lastState.ID) {
-						fmt.Printf("\n🎉 New review detected from %s at %s\n", review.Author.Login, review.CreatedAt)
-						if review.Body != "" && len(review.Body) > 100 {
-							fmt.Printf("Preview: %s...\n", review.Body[:100])
-						}
-						break // Show only the first new review for brevity
-					}
-				}
-			}
-			
-			// Update state with latest review
-			if len(reviews) > 0 {
-				latestReview := reviews[len(reviews)-1]
-				newState := ReviewState{
-					ID:        latestReview.ID,
-					CreatedAt: latestReview.CreatedAt,
+		return nil
+	}
+	result, err := waiter.Wait()
+	if err != nil {
+		return err
+	}
+	if result.TimedOut {
+		fmt.Fprintf(w, "\n⏰ Timeout reached (%v). No new reviews found.\n", effectiveTimeout)
+		printOutageSummary(w, &waiter.Outage)
+		return nil
+	}
+	
+	// Find and display new reviews
+	reviews := fetcher.response.GetReviews()
+	if lastState == nil {
+		fmt.Fprintf(w, "\n🎉 Found %d review(s)\n", len(reviews))
+	} else {
+		// Show details of new reviews
+		for _, review := range reviews {
+			if review.CreatedAt > lastState.CreatedAt ||
+				(review.CreatedAt == lastState.CreatedAt && review.ID != lastState.ID) {
+				fmt.Fprintf(w, "\n🎉 New review detected from %s at %s\n", review.Author.Login, review.CreatedAt)
+				if review.Body != "" && len(review.Body) > 100 {
+					fmt.Fprintf(w, "Preview: %s...\n", review.Body[:100])
 				}
-				_ = saveReviewState(prNumber, newState) // Best effort state save
+				break // Show only the first new review for brevity
 			}
-			
-			fmt.Println("\n✅ New reviews available!")
-			ListThreadsGuidance(prNumber).Print()
-			fmt.Println("⚠️  IMPORTANT: Please read the review feedback carefully before proceeding")
-			return nil
 		}
-		
-		elapsed := time.Since(startTime)
-		remaining := effectiveTimeout - elapsed
-		fmt.Printf("[%s] No new reviews yet (remaining: %v)\n",
-			time.Now().Format("15:04:05"), remaining.Truncate(time.Second))
-		
-		time.Sleep(30 * time.Second)
 	}
+	
+	// Update state with latest review
+	if len(reviews) > 0 {
+		latestReview := reviews[len(reviews)-1]
+		newState := ReviewState{
+			ID:        latestReview.ID,
+			CreatedAt: latestReview.CreatedAt,
+		}
+		_ = saveReviewState(prNumber, newState) // Best effort state save
+	}
+	
+	fmt.Fprintln(w, "\n✅ New reviews available!")
+	printOutageSummary(w, &waiter.Outage)
+	ListThreadsGuidance(prNumber).Print(cmd)
+	fmt.Fprintln(w, "⚠️  IMPORTANT: Please read the review feedback carefully before proceeding")
+	return nil
 }
 
 // Status message maps for consistent display formatting
@@ -1086,9 +1361,10 @@ func getStatusMessage(state string, withIcon bool) string {
 	return FormatStatusState(state, withIcon)
 }
 
-func waitForReviewsAndChecks(cmd *cobra.Command, args []string) error {
+func waitForReviewsAndChecks(cmd *cobra.Command, args []string, opts waitOptions) error {
+	w := messageWriter(cmd)
 	// Create GitHub client once for better performance (token caching)
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
 	
 	prNumber, err := resolvePRNumberFromArgs(args, client)
 	if err != nil {
@@ -1102,30 +1378,30 @@ func waitForReviewsAndChecks(cmd *cobra.Command, args []string) error {
 	}
 	
 	// Calculate timeout with Claude Code constraints
-	effectiveTimeout, timeoutDisplay, err := calculateEffectiveTimeout()
+	effectiveTimeout, timeoutDisplay, err := calculateEffectiveTimeout(cmd)
 	if err != nil {
 		return err
 	}
 	
 	// Show additional guidance for extending timeout if needed
-	timeoutDuration, parseErr := parseTimeout()
+	timeoutDuration, parseErr := parseTimeout(cmd)
 	if parseErr == nil && effectiveTimeout < timeoutDuration {
-		fmt.Printf("💡 To extend timeout, set BASH_MAX_TIMEOUT_MS in ~/.claude/settings.json\n")
-		fmt.Printf("💡 Example: {\"env\": {\"BASH_MAX_TIMEOUT_MS\": \"900000\"}} for 15 minutes\n")
-		fmt.Printf("💡 Manual retry: bin/gh-helper reviews wait %s --timeout=%v\n", prNumber, timeoutDuration)
+		fmt.Fprintf(w, "💡 To extend timeout, set BASH_MAX_TIMEOUT_MS in ~/.claude/settings.json\n")
+		fmt.Fprintf(w, "💡 Example: {\"env\": {\"BASH_MAX_TIMEOUT_MS\": \"900000\"}} for 15 minutes\n")
+		fmt.Fprintf(w, "💡 Manual retry: bin/gh-helper reviews wait %s --timeout=%v\n", prNumber, timeoutDuration)
 	}
 	
 	// Request Gemini review if flag is set
-	if requestReview {
-		fmt.Printf("📝 Requesting Gemini review for PR #%s...\n", prNumber)
+	if opts.RequestReview {
+		fmt.Fprintf(w, "📝 Requesting Gemini review for PR #%s...\n", prNumber)
 		if err := client.CreatePRComment(prNumber, "/gemini review"); err != nil {
 			return fmt.Errorf("failed to request Gemini review: %w", err)
 		}
-		fmt.Println("✅ Gemini review requested")
+		fmt.Fprintln(w, "✅ Gemini review requested")
 	}
 	
-	fmt.Printf("🔄 Waiting for both reviews AND PR checks for PR #%s (timeout: %s)...\n", prNumber, timeoutDisplay)
-	fmt.Println("Press Ctrl+C to stop monitoring")
+	fmt.Fprintf(w, "🔄 Waiting for both reviews AND PR checks for PR #%s (timeout: %s)...\n", prNumber, timeoutDisplay)
+	fmt.Fprintln(w, "Press Ctrl+C to stop monitoring")
 
 	// Setup signal handling for graceful termination with proper guidance
 	sigChan := make(chan os.Signal, 1)
@@ -1134,53 +1410,49 @@ func waitForReviewsAndChecks(cmd *cobra.Command, args []string) error {
 	// Exit code 130 is standard for SIGINT (Ctrl+C)
 	go func() {
 		sig := <-sigChan
-		fmt.Printf("\n🛑 Received signal %v - terminating gracefully\n", sig)
+		fmt.Fprintf(w, "\n🛑 Received signal %v - terminating gracefully\n", sig)
 		if effectiveTimeout < timeoutDuration {
-			fmt.Printf("💡 Claude Code timeout interrupted. To continue, run:\n")
-			fmt.Printf("    bin/gh-helper reviews wait %s --timeout=%v\n", prNumber, timeoutDuration)
+			fmt.Fprintf(w, "💡 Claude Code timeout interrupted. To continue, run:\n")
+			fmt.Fprintf(w, "    bin/gh-helper reviews wait %s --timeout=%v\n", prNumber, timeoutDuration)
 		}
 		os.Exit(130) // Standard exit code for SIGINT
 	}()
 
-	// Setup timeout (effectiveTimeout is already time.Duration)
-	// timeoutDuration := timeoutDuration  // Already defined above
 	startTime := time.Now()
 
-	// Get initial state
-	initialCheck := true
-	reviewsReady := false
-	checksComplete := false
-
-	for {
-		// Check timeout
-		if time.Since(startTime) > effectiveTimeout {
-			fmt.Printf("\n⏰ Timeout reached (%v).\n", effectiveTimeout)
-			if reviewsReady && checksComplete {
-				fmt.Println("✅ Both reviews and checks completed!")
-				return nil
-			} else {
-				fmt.Printf("Status: Reviews ready: %v, Checks complete: %v\n", reviewsReady, checksComplete)
-				if effectiveTimeout < timeoutDuration {
-					fmt.Printf("💡 To continue waiting, run: bin/gh-helper reviews wait %s\n", prNumber)
-				}
-				return nil
-			}
-		}
+	// The gate of the wait: --wait-for, or new reviews and completed checks.
+	// With --require-fresh-summary, the Gemini summary must postdate the latest push.
+	condition := opts.Condition
+	if condition == nil {
+		condition = reviewwait.All(reviewwait.NewReview(), reviewwait.ChecksComplete())
+	}
+	if opts.RequireFreshSummary {
+		condition = reviewwait.All(condition, reviewwait.FreshSummary(geminiSummaryHeader))
+	}
 
-		// Use unified architecture for reviews + status monitoring
-		config := NewPRQueryConfig(owner, repo, prNumberInt).ForReviewsAndStatus()
-		response, err := client.FetchPRData(config)
-		if err != nil {
-			fmt.Printf("Error fetching PR data: %v\n", err)
-			time.Sleep(30 * time.Second)
-			continue
-		}
+	// State of the latest poll for the progress and final output; checks of an
+	// obsolete commit never count as complete
+	reviewsReady, checksComplete, summaryReady := false, false, !opts.RequireFreshSummary
+	summaryRequestedFor := ""
 
-		// Check reviews status using common state tracking
+	fetcher := &prWaitFetcher{client: client, prNumber: prNumber, number: prNumberInt}
+	waiter := newPRWaiter(w, fetcher, condition, effectiveTimeout, 30*time.Second)
+	waiter.OnPoll = func(progress reviewwait.Progress) error {
+		response, snapshot := fetcher.response, progress.Poll.Snapshot
 		reviews := response.GetReviews()
-		if len(reviews) > 0 && !reviewsReady {
-			lastState, _ := loadReviewState(prNumber)
-			reviewsReady = hasNewReviews(reviews, lastState)
+
+		// A force-push or new commit restarts the check wait for the new head
+		if progress.HeadChange != nil {
+			fmt.Fprintf(w, "\n🔀 [%s] PR head changed: %s - waiting for checks on the new commit\n",
+				time.Now().Format("15:04:05"), progress.HeadChange)
+		}
+		reviewsReady = snapshot.NewReviews
+		checksComplete = !snapshot.HeadSettling && reviewwait.ChecksTerminal(snapshot.Checks)
+
+		if !opts.ExcludeReviews {
+			if err := failOnChangesRequested(cmd, prNumber, opts, progress, reviews, startTime); err != nil {
+				return err
+			}
 		}
 
 		// Check mergeable status first - if conflicting, stop immediately
@@ -1188,131 +1460,158 @@ func waitForReviewsAndChecks(cmd *cobra.Command, args []string) error {
 		// which prevents CI from running. This is GitHub's intentional behavior.
 		// Must check mergeable before assuming "no checks required" scenario.
 		mergeable, mergeStatus := response.GetMergeStatus()
-		if mergeable == "CONFLICTING" {
-			fmt.Printf("\n❌ [%s] PR has merge conflicts (status: %s)\n", time.Now().Format("15:04:05"), mergeStatus)
-			fmt.Println("⚠️  CI checks will not run until conflicts are resolved")
-			fmt.Printf("💡 Resolve conflicts with: git rebase origin/main\n")
-			fmt.Printf("💡 Then push and run: bin/gh-helper reviews wait %s\n", prNumber)
+		if mergeable == "CONFLICTING" && opts.WaitFor == "" {
+			fmt.Fprintf(w, "\n❌ [%s] PR has merge conflicts (status: %s)\n", time.Now().Format("15:04:05"), mergeStatus)
+			fmt.Fprintln(w, "⚠️  CI checks will not run until conflicts are resolved")
+			fmt.Fprintf(w, "💡 Resolve conflicts with: git rebase origin/main\n")
+			fmt.Fprintf(w, "💡 Then push and run: bin/gh-helper reviews wait %s\n", prNumber)
 			return fmt.Errorf("merge conflicts prevent CI execution")
 		}
 
-		// Check PR checks status
-		statusCheckRollup := response.GetStatusCheckRollup()
-		if statusCheckRollup != nil {
-			rollupState := statusCheckRollup.State
-			checksComplete = (rollupState == "SUCCESS" || rollupState == "FAILURE" || rollupState == "ERROR")
-		} else {
-			// StatusCheckRollup is nil - this can mean:
-			// 1. No checks are configured for this repository (truly complete)
-			// 2. Checks are configured but haven't started yet (not complete)
-			// 3. PR was just created or pushed (checks pending)
-			
-			// Check merge status for better determination
-			mergeable, mergeStatus := response.GetMergeStatus()
-			
-			// If PR has conflicts, checks won't run until resolved
-			if mergeable == "CONFLICTING" {
-				checksComplete = true // No point waiting for checks that won't run
-			} else if mergeStatus == "CLEAN" || mergeStatus == "HAS_HOOKS" {
-				// CLEAN: No checks configured, ready to merge
-				// HAS_HOOKS: Only merge hooks configured, no status checks
-				checksComplete = true
-			} else {
-				// PENDING, BLOCKED, DIRTY, UNKNOWN, etc. - checks may still be starting
-				checksComplete = false
+		// A summary older than the latest push describes an obsolete head; request
+		// a refresh once per head and keep waiting until it is posted
+		if opts.RequireFreshSummary {
+			summaryReady = reviewwait.SummaryFresh(snapshot.Comments, geminiSummaryHeader, snapshot.LastPush)
+			if headOid := progress.Poll.HeadOid; !summaryReady && summaryRequestedFor != headOid {
+				if err := client.CreatePRComment(prNumber, "/gemini summary"); err != nil {
+					fmt.Fprintf(w, "⚠️  Failed to request Gemini summary: %v\n", err)
+				} else {
+					fmt.Fprintf(w, "📝 [%s] Gemini summary is stale or missing - requested /gemini summary\n", time.Now().Format("15:04:05"))
+					summaryRequestedFor = headOid
+				}
 			}
 		}
 
-		if initialCheck {
-			fmt.Printf("[%s] Monitoring started.\n", time.Now().Format("15:04:05"))
-			fmt.Printf("   Reviews: %d found, Ready: %v\n", len(reviews), reviewsReady)
+		statusCheckRollup := response.GetStatusCheckRollup()
+		if progress.First {
+			fmt.Fprintf(w, "[%s] Monitoring started.\n", time.Now().Format("15:04:05"))
+			fmt.Fprintf(w, "   Reviews: %d found, Ready: %v\n", len(reviews), reviewsReady)
 			
 			// Show mergeable status
-			mergeable, mergeStatus := response.GetMergeStatus()
-			
 			msg, exists := mergeStatusMessages[mergeable]
 			if !exists {
 				msg = mergeable // Use raw value for unknown states
 			}
 			
 			if mergeable == "CONFLICTING" {
-				fmt.Printf("   Merge: %s (status: %s)\n", msg, mergeStatus)
+				fmt.Fprintf(w, "   Merge: %s (status: %s)\n", msg, mergeStatus)
 			} else {
-				fmt.Printf("   Merge: %s\n", msg)
+				fmt.Fprintf(w, "   Merge: %s\n", msg)
 			}
 			if statusCheckRollup != nil {
-				rollupState := statusCheckRollup.State
-				
-				statusMsg := getStatusMessage(rollupState, false)
-				fmt.Printf("   Checks: %s, Complete: %v\n", statusMsg, checksComplete)
+				statusMsg := getStatusMessage(statusCheckRollup.State, false)
+				fmt.Fprintf(w, "   Checks: %s, Complete: %v\n", statusMsg, checksComplete)
 			} else {
-				fmt.Printf("   Checks: None required, Complete: %v\n", checksComplete)
+				fmt.Fprintf(w, "   Checks: None required, Complete: %v\n", checksComplete)
 			}
-			initialCheck = false
+		}
+		if progress.Met {
+			return nil
 		}
 
-		// Check if both conditions are met
-		if reviewsReady && checksComplete {
-			fmt.Printf("\n🎉 [%s] Both reviews and checks are ready!\n", time.Now().Format("15:04:05"))
-			
-			if reviewsReady {
-				fmt.Println("✅ Reviews: New reviews available")
-				
-				// Output review details to reduce subsequent API calls
-				fmt.Println("\n📋 Recent Reviews:")
-				for i, review := range reviews {
-					if i >= 5 { // Limit to 5 most recent reviews
-						break
-					}
-					fmt.Printf("   • %s by %s (%s) - %s\n", 
-						review.ID, 
-						review.Author.Login, 
-						review.State,
-						review.CreatedAt)
-					if review.Body != "" && len(review.Body) > 100 {
-						fmt.Printf("     Preview: %s...\n", review.Body[:100])
-					} else if review.Body != "" {
-						fmt.Printf("     Preview: %s\n", review.Body)
-					}
-				}
-				
-				fmt.Println()
-				ListThreadsGuidance(prNumber).Print()
-				fmt.Println("⚠️  IMPORTANT: Please read the review feedback carefully before proceeding")
+		remaining := progress.Remaining.Truncate(time.Second)
+		if opts.WaitFor != "" {
+			fmt.Fprintf(w, "[%s] Status: %s (remaining: %v)\n",
+				time.Now().Format("15:04:05"), progress.Reason, remaining)
+		} else if opts.RequireFreshSummary {
+			fmt.Fprintf(w, "[%s] Status: Reviews: %v, Checks: %v, Summary: %v (remaining: %v)\n",
+				time.Now().Format("15:04:05"), reviewsReady, checksComplete, summaryReady, remaining)
+		} else {
+			fmt.Fprintf(w, "[%s] Status: Reviews: %v, Checks: %v (remaining: %v)\n",
+				time.Now().Format("15:04:05"), reviewsReady, checksComplete, remaining)
+		}
+		return nil
+	}
+
+	result, err := waiter.Wait()
+	if err != nil {
+		return err
+	}
+
+	if result.TimedOut {
+		fmt.Fprintf(w, "\n⏰ Timeout reached (%v).\n", effectiveTimeout)
+		if summary := waiter.Head.Summary(); summary != "" {
+			fmt.Fprintln(w, summary)
+		}
+		printOutageSummary(w, &waiter.Outage)
+		if opts.WaitFor != "" {
+			reason := "no poll completed"
+			if result.Last != nil {
+				reason = result.Reason
 			}
-			
-			// Show merge conflicts warning if present
-			mergeable, _ := response.GetMergeStatus()
-			if mergeable == "CONFLICTING" {
-				fmt.Printf("\n⚠️  Merge conflicts detected - CI may not run until resolved\n")
-				fmt.Printf("💡 Resolve conflicts and push to trigger CI checks\n")
+			fmt.Fprintf(w, "Status: %s (waiting for %s)\n", reason, opts.WaitFor)
+		} else {
+			fmt.Fprintf(w, "Status: Reviews ready: %v, Checks complete: %v\n", reviewsReady, checksComplete)
+		}
+		if !summaryReady {
+			fmt.Fprintln(w, "Summary: Gemini summary predates the latest push")
+		}
+		if effectiveTimeout < timeoutDuration {
+			fmt.Fprintf(w, "💡 To continue waiting, run: bin/gh-helper reviews wait %s\n", prNumber)
+		}
+		return nil
+	}
+
+	if opts.WaitFor != "" {
+		fmt.Fprintf(w, "\n🎉 [%s] Condition met: %s\n", time.Now().Format("15:04:05"), result.Reason)
+	} else {
+		fmt.Fprintf(w, "\n🎉 [%s] Both reviews and checks are ready!\n", time.Now().Format("15:04:05"))
+	}
+	if summary := waiter.Head.Summary(); summary != "" {
+		fmt.Fprintln(w, summary)
+	}
+	printOutageSummary(w, &waiter.Outage)
+	
+	response := fetcher.response
+	if reviewsReady {
+		fmt.Fprintln(w, "✅ Reviews: New reviews available")
+		
+		// Output review details to reduce subsequent API calls
+		fmt.Fprintln(w, "\n📋 Recent Reviews:")
+		for i, review := range response.GetReviews() {
+			if i >= 5 { // Limit to 5 most recent reviews
+				break
 			}
-			if checksComplete {
-				if statusCheckRollup != nil {
-					rollupState := statusCheckRollup.State
-					
-					fmt.Printf("Checks: %s\n", getStatusMessage(rollupState, true))
-				} else {
-					fmt.Println("✅ Checks: No checks required")
-				}
+			fmt.Fprintf(w, "   • %s by %s (%s) - %s\n", 
+				review.ID, 
+				review.Author.Login, 
+				review.State,
+				review.CreatedAt)
+			if review.Body != "" && len(review.Body) > 100 {
+				fmt.Fprintf(w, "     Preview: %s...\n", review.Body[:100])
+			} else if review.Body != "" {
+				fmt.Fprintf(w, "     Preview: %s\n", review.Body)
 			}
-			
-			return nil
 		}
-
-		elapsed := time.Since(startTime)
-		remaining := timeoutDuration - elapsed
-		fmt.Printf("[%s] Status: Reviews: %v, Checks: %v (remaining: %v)\n",
-			time.Now().Format("15:04:05"), reviewsReady, checksComplete, remaining.Truncate(time.Second))
 		
-		time.Sleep(30 * time.Second)
+		fmt.Fprintln(w)
+		ListThreadsGuidance(prNumber).Print(cmd)
+		fmt.Fprintln(w, "⚠️  IMPORTANT: Please read the review feedback carefully before proceeding")
 	}
+	
+	// Show merge conflicts warning if present
+	if mergeable, _ := response.GetMergeStatus(); mergeable == "CONFLICTING" {
+		fmt.Fprintf(w, "\n⚠️  Merge conflicts detected - CI may not run until resolved\n")
+		fmt.Fprintf(w, "💡 Resolve conflicts and push to trigger CI checks\n")
+	}
+	if checksComplete {
+		if statusCheckRollup := response.GetStatusCheckRollup(); statusCheckRollup != nil {
+			fmt.Fprintf(w, "Checks: %s\n", getStatusMessage(statusCheckRollup.State, true))
+		} else {
+			fmt.Fprintln(w, "✅ Checks: No checks required")
+		}
+	}
+	if opts.RequireFreshSummary {
+		fmt.Fprintln(w, "✅ Summary: Gemini summary is up to date with the latest push")
+	}
+	
+	return nil
 }
 
 
 func showThread(cmd *cobra.Command, args []string) error {
 	// Create GitHub client once for better performance (token caching)
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
 
 	// Get output format using unified resolver
 	// Get exclude-urls flag
@@ -1321,14 +1620,33 @@ func showThread(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read 'exclude-urls' flag: %w", err)
 	}
 
+	maxHunkLines, err := cmd.Flags().GetInt("max-hunk-lines")
+	if err != nil {
+		return fmt.Errorf("failed to read 'max-hunk-lines' flag: %w", err)
+	}
+	omitDiffHunks, err := cmd.Flags().GetBool("omit-diff-hunks")
+	if err != nil {
+		return fmt.Errorf("failed to read 'omit-diff-hunks' flag: %w", err)
+	}
+	translator, err := newTranslatorFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Resolve short aliases (#1, #2, ...) to thread IDs
+	args, err = resolveThreadIDs(cmd, client, args)
+	if err != nil {
+		return err
+	}
+
 	// Use batch query for multiple threads or single thread
-	threadsMap, err := client.GetThreadBatch(args, excludeURLs)
+	threadsMap, failures, err := client.GetThreadBatch(args, excludeURLs)
 	if err != nil {
 		return fmt.Errorf("failed to fetch threads: %w", err)
 	}
 
 	// Get current user for reply detection
-	currentUser, _ := getCurrentUser()
+	currentUser, _ := client.GetCurrentUser()
 	
 	results := []map[string]interface{}{}
 	
@@ -1336,7 +1654,19 @@ func showThread(cmd *cobra.Command, args []string) error {
 	for _, threadID := range args {
 		thread, exists := threadsMap[threadID]
 		if !exists {
-			return fmt.Errorf("thread not found: %s", threadID)
+			reason := "thread not found"
+			if message, ok := failures[threadID]; ok {
+				reason = message
+			}
+			// Report per-thread failures when showing several threads
+			if len(args) > 1 {
+				results = append(results, map[string]interface{}{
+					"id":    threadID,
+					"error": reason,
+				})
+				continue
+			}
+			return fmt.Errorf("%s: %s", reason, threadID)
 		}
 
 		// Build output structure using GitHub GraphQL API field names
@@ -1371,8 +1701,25 @@ func showThread(cmd *cobra.Command, args []string) error {
 				commentData["url"] = comment.URL
 			}
 			
-			if i == 0 && comment.DiffHunk != "" {
-				commentData["diffHunk"] = comment.DiffHunk
+			if i == 0 && comment.DiffHunk != "" && !omitDiffHunks {
+				hunk, omitted := trimDiffHunk(comment.DiffHunk, maxHunkLines)
+				commentData["diffHunk"] = hunk
+				if omitted > 0 {
+					commentData["diffHunkOmittedLines"] = omitted
+				}
+			}
+			
+			// Keep the original body and add the translation alongside it
+			if translator != nil {
+				translated, err := translator.Translate(comment.Body)
+				if err != nil {
+					WarningMsg("Failed to translate comment %s: %v", comment.ID, err).Print(cmd)
+				} else {
+					commentData["translation"] = map[string]string{
+						"lang": translator.Target,
+						"body": translated,
+					}
+				}
 			}
 			
 			comments = append(comments, commentData)
@@ -1406,7 +1753,21 @@ func showThread(cmd *cobra.Command, args []string) error {
 
 func resolveThread(cmd *cobra.Command, args []string) error {
 	// Create GitHub client
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
+	
+	// Resolve short aliases (#1, #2, ...) to thread IDs
+	args, err := resolveThreadIDs(cmd, client, args)
+	if err != nil {
+		return err
+	}
+	
+	if err := checkFreshTargets(cmd, func() ([]prTarget, error) { return client.getPRTargetsForNodes(args) }); err != nil {
+		return err
+	}
+
+	if err := confirmBulkOperation(cmd, "resolve threads", args, false); err != nil {
+		return err
+	}
 	
 	// Get output format using unified resolver
 	resolvedAt := time.Now().Format("2006-01-02T15:04:05Z07:00")
@@ -1439,6 +1800,30 @@ func resolveThread(cmd *cobra.Command, args []string) error {
 type threadInput struct {
 	ID            string
 	CustomMessage string
+	Resolve       *bool // per-thread +resolve/-resolve marker; nil follows the flags
+}
+
+// parseThreadInput parses a reply argument: THREAD_ID, THREAD_ID:message,
+// THREAD_ID:+resolve, or THREAD_ID:+resolve:message (-resolve likewise)
+func parseThreadInput(arg string) threadInput {
+	id, rest, found := strings.Cut(arg, ":")
+	input := threadInput{ID: id}
+	if !found {
+		return input
+	}
+	for _, marker := range []struct {
+		prefix  string
+		resolve bool
+	}{{"+resolve", true}, {"-resolve", false}} {
+		if rest == marker.prefix || strings.HasPrefix(rest, marker.prefix+":") {
+			resolve := marker.resolve
+			input.Resolve = &resolve
+			rest = strings.TrimPrefix(strings.TrimPrefix(rest, marker.prefix), ":")
+			break
+		}
+	}
+	input.CustomMessage = rest
+	return input
 }
 
 // replyResult represents the result of a thread reply operation
@@ -1453,19 +1838,75 @@ type replyResult struct {
 }
 
 func replyToThread(cmd *cobra.Command, args []string) error {
+	message, err := cmd.Flags().GetString("message")
+	if err != nil {
+		return fmt.Errorf("failed to get 'message' flag: %w", err)
+	}
+	mentionUser, err := cmd.Flags().GetString("mention")
+	if err != nil {
+		return fmt.Errorf("failed to get 'mention' flag: %w", err)
+	}
+	commitHash, err := cmd.Flags().GetString("commit-hash")
+	if err != nil {
+		return fmt.Errorf("failed to get 'commit-hash' flag: %w", err)
+	}
+	autoResolve, err := cmd.Flags().GetBool("resolve")
+	if err != nil {
+		return fmt.Errorf("failed to get 'resolve' flag: %w", err)
+	}
+	resolveOnly, err := cmd.Flags().GetStringSlice("resolve-only")
+	if err != nil {
+		return fmt.Errorf("failed to get 'resolve-only' flag: %w", err)
+	}
+	draftTo, err := cmd.Flags().GetString("draft-to")
+	if err != nil {
+		return fmt.Errorf("failed to get 'draft-to' flag: %w", err)
+	}
+
 	// Create GitHub client once for better performance (token caching)
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
 
 	// Parse thread IDs and custom messages
 	var threadInputs []threadInput
 	for _, arg := range args {
-		parts := strings.SplitN(arg, ":", 2)
-		input := threadInput{ID: parts[0]}
-		if len(parts) == 2 {
-			// Custom message provided
-			input.CustomMessage = parts[1]
+		threadInputs = append(threadInputs, parseThreadInput(arg))
+	}
+
+	// Resolve short aliases (#1, #2, ...) to thread IDs
+	ids := make([]string, len(threadInputs))
+	for i, input := range threadInputs {
+		ids[i] = input.ID
+	}
+	ids, err = resolveThreadIDs(cmd, client, ids)
+	if err != nil {
+		return err
+	}
+	for i := range threadInputs {
+		threadInputs[i].ID = ids[i]
+	}
+
+	// --resolve-only selects the threads to resolve; every one must be replied to
+	resolveOnlySet := map[string]bool{}
+	if len(resolveOnly) > 0 {
+		resolveOnly, err = resolveThreadIDs(cmd, client, resolveOnly)
+		if err != nil {
+			return err
+		}
+		for _, id := range resolveOnly {
+			if !slices.Contains(ids, id) {
+				return fmt.Errorf("--resolve-only thread %s is not among the threads replied to", id)
+			}
+			resolveOnlySet[id] = true
 		}
-		threadInputs = append(threadInputs, input)
+	}
+
+	// shouldResolve reports whether a thread is resolved after the reply:
+	// a per-thread marker wins over --resolve-only and --resolve
+	shouldResolve := func(input threadInput) bool {
+		if input.Resolve != nil {
+			return *input.Resolve
+		}
+		return autoResolve || resolveOnlySet[input.ID]
 	}
 
 	// Get default message from flag or stdin
@@ -1498,6 +1939,110 @@ func replyToThread(cmd *cobra.Command, args []string) error {
 	parallel, _ := cmd.Flags().GetBool("parallel")
 	maxConcurrent, _ := cmd.Flags().GetInt("max-concurrent")
 
+	quote, err := cmd.Flags().GetBool("quote")
+	if err != nil {
+		return fmt.Errorf("failed to get 'quote' flag: %w", err)
+	}
+	quoteLines, err := cmd.Flags().GetInt("quote-lines")
+	if err != nil {
+		return fmt.Errorf("failed to get 'quote-lines' flag: %w", err)
+	}
+	quoteSelection, err := cmd.Flags().GetString("quote-selection")
+	if err != nil {
+		return fmt.Errorf("failed to get 'quote-selection' flag: %w", err)
+	}
+
+	// Quote the comment being answered, fetching all threads in one request
+	var quotes, quoteFailures map[string]string
+	if quote || quoteSelection != "" {
+		quoteOpts := QuoteOptions{MaxLines: quoteLines}
+		if quoteSelection != "" {
+			quoteOpts.Start, quoteOpts.End, err = parseQuoteSelection(quoteSelection)
+			if err != nil {
+				return err
+			}
+			quoteOpts.Selected = true
+		}
+		quotes, quoteFailures, err = buildThreadQuotes(client, ids, quoteOpts)
+		if err != nil {
+			return fmt.Errorf("failed to fetch threads to quote: %w", err)
+		}
+	}
+
+	// messageFor returns the message of one reply before expansion
+	messageFor := func(input threadInput) string {
+		if input.CustomMessage != "" {
+			return input.CustomMessage
+		}
+		return defaultMessage
+	}
+
+	// renderReply returns the body posted for one reply
+	renderReply := func(input threadInput) (string, error) {
+		replyText := messageFor(input)
+
+		// Add commit reference if provided
+		if commitHash != "" {
+			replyText = fmt.Sprintf("%s\n\nFixed in commit %s.", strings.TrimSpace(replyText), commitHash)
+		}
+
+		// Add mention if provided
+		if mentionUser != "" {
+			replyText = fmt.Sprintf("@%s %s", mentionUser, replyText)
+		}
+
+		// Template variable expansion
+		replyText = strings.ReplaceAll(replyText, "{commit}", commitHash)
+
+		if reason, failed := quoteFailures[input.ID]; failed {
+			return "", fmt.Errorf("cannot quote: %s", reason)
+		}
+		if q, ok := quotes[input.ID]; ok {
+			replyText = q + "\n" + replyText
+		}
+		return replyText, nil
+	}
+
+	// Draft mode: append rendered replies to a plan file instead of posting
+	if draftTo != "" {
+		entries := make([]ReplyPlanEntry, 0, len(threadInputs))
+		draftedAt := time.Now().UTC().Format(time.RFC3339)
+		for _, input := range threadInputs {
+			body, err := renderReply(input)
+			if err != nil {
+				return fmt.Errorf("failed to render reply to thread %s: %w", input.ID, err)
+			}
+			entries = append(entries, ReplyPlanEntry{ThreadID: input.ID, Body: body, Resolve: shouldResolve(input), DraftedAt: draftedAt})
+		}
+		total, err := appendReplyPlan(draftTo, entries)
+		if err != nil {
+			return err
+		}
+		return EncodeOutputWithCmd(cmd, map[string]interface{}{
+			"draft": map[string]interface{}{
+				"file":    draftTo,
+				"added":   len(entries),
+				"total":   total,
+				"replies": entries,
+			},
+		})
+	}
+
+	if err := checkFreshTargets(cmd, func() ([]prTarget, error) { return client.getPRTargetsForNodes(ids) }); err != nil {
+		return err
+	}
+
+	// Resolving is gated like 'threads resolve'; the replies alone are not
+	var toResolve []string
+	for _, input := range threadInputs {
+		if shouldResolve(input) {
+			toResolve = append(toResolve, input.ID)
+		}
+	}
+	if err := confirmBulkOperation(cmd, "resolve threads", toResolve, false); err != nil {
+		return err
+	}
+
 	// Execute replies in parallel
 	results := ExecuteParallel(
 		threadInputs,
@@ -1505,30 +2050,18 @@ func replyToThread(cmd *cobra.Command, args []string) error {
 			result := replyResult{
 				ThreadID: input.ID,
 				Status:   "success",
+				Message:  messageFor(input),
 			}
 
-			// Determine message to use
-			replyText := input.CustomMessage
-			if replyText == "" {
-				replyText = defaultMessage
-			}
-			result.Message = replyText
-
-			// Add commit reference if provided
-			if commitHash != "" {
-				replyText = fmt.Sprintf("%s\n\nFixed in commit %s.", strings.TrimSpace(replyText), commitHash)
-			}
-
-			// Add mention if provided
-			if mentionUser != "" {
-				replyText = fmt.Sprintf("@%s %s", mentionUser, replyText)
+			replyText, err := renderReply(input)
+			if err != nil {
+				result.Status = "failed"
+				result.Error = err.Error()
+				return result, nil
 			}
 
-			// Template variable expansion
-			replyText = strings.ReplaceAll(replyText, "{commit}", commitHash)
-
 			// Execute mutation
-			err := executeReplyMutation(client, input.ID, replyText, &result)
+			err = executeReplyMutation(client, input.ID, replyText, &result)
 			if err != nil {
 				result.Status = "failed"
 				result.Error = err.Error()
@@ -1536,7 +2069,7 @@ func replyToThread(cmd *cobra.Command, args []string) error {
 			}
 
 			// Auto-resolve thread if requested
-			if autoResolve {
+			if shouldResolve(input) {
 				if err := client.ResolveThread(input.ID); err != nil {
 					// Don't fail - reply succeeded, resolution failed
 					result.Resolved = false
@@ -1564,7 +2097,7 @@ func replyToThread(cmd *cobra.Command, args []string) error {
 			"url":       result.URL,
 			"repliedAt": time.Now().Format("2006-01-02T15:04:05Z07:00"),
 		}
-		if autoResolve {
+		if shouldResolve(threadInputs[0]) {
 			outputData["isResolved"] = result.Resolved
 		}
 		return EncodeOutputWithCmd(cmd, outputData)
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"io"
 	"os"
 	"testing"
 	"time"
+
+	"github.com/spf13/cobra"
 )
 
 func TestParseTimeout(t *testing.T) {
@@ -63,10 +66,10 @@ func TestParseTimeout(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Set the global variable for the test
-			timeoutStr = tt.input
+			cmd := &cobra.Command{}
+			setCommandContext(cmd, &CommandContext{Timeout: tt.input})
 			
-			result, err := parseTimeout()
+			result, err := parseTimeout(cmd)
 			
 			if tt.expectError {
 				if err == nil {
@@ -145,13 +148,13 @@ func TestCalculateEffectiveTimeout(t *testing.T) {
 				_ = os.Setenv("BASH_DEFAULT_TIMEOUT_MS", tt.bashDefaultTimeoutMS)
 			}
 			
-			// Set global timeout variable
-			timeoutStr = tt.requestedTimeout
+			cmd := &cobra.Command{}
+			setCommandContext(cmd, &CommandContext{Timeout: tt.requestedTimeout})
 			
 			// Capture stdout to avoid cluttering test output
 			// (In a real implementation, you might want to inject a writer or use a testing-specific version)
 			
-			effectiveTimeout, timeoutDisplay, err := calculateEffectiveTimeout()
+			effectiveTimeout, timeoutDisplay, err := calculateEffectiveTimeout(cmd)
 			
 			if err != nil {
 				t.Errorf("calculateEffectiveTimeout() unexpected error: %v", err)
@@ -227,7 +230,7 @@ func TestCheckClaudeCodeEnvironment(t *testing.T) {
 				_ = os.Setenv("BASH_DEFAULT_TIMEOUT_MS", tt.bashDefaultTimeoutMS)
 			}
 			
-			duration, hasEnv := checkClaudeCodeEnvironment()
+			duration, hasEnv := checkClaudeCodeEnvironment(io.Discard)
 			
 			if duration != tt.expectedDuration {
 				t.Errorf("checkClaudeCodeEnvironment() duration = %v, expected %v", duration, tt.expectedDuration)
@@ -242,4 +245,89 @@ func TestCheckClaudeCodeEnvironment(t *testing.T) {
 			_ = os.Unsetenv("BASH_DEFAULT_TIMEOUT_MS")
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestFindChangesRequested(t *testing.T) {
+	review := func(id, author, state, createdAt string) ReviewFields {
+		r := ReviewFields{ID: id, State: state, CreatedAt: createdAt}
+		r.Author.Login = author
+		return r
+	}
+
+	tests := []struct {
+		name      string
+		reviews   []ReviewFields
+		lastState *ReviewState
+		expected  []string
+	}{
+		{
+			name: "new changes requested review",
+			reviews: []ReviewFields{
+				review("R1", "alice", "COMMENTED", "2024-01-01T00:00:00Z"),
+				review("R2", "bob", "CHANGES_REQUESTED", "2024-01-01T01:00:00Z"),
+			},
+			expected: []string{"R2"},
+		},
+		{
+			name: "later approval supersedes changes requested",
+			reviews: []ReviewFields{
+				review("R1", "bob", "CHANGES_REQUESTED", "2024-01-01T00:00:00Z"),
+				review("R2", "bob", "APPROVED", "2024-01-01T01:00:00Z"),
+			},
+			expected: nil,
+		},
+		{
+			name: "later comment does not supersede changes requested",
+			reviews: []ReviewFields{
+				review("R1", "bob", "CHANGES_REQUESTED", "2024-01-01T00:00:00Z"),
+				review("R2", "bob", "COMMENTED", "2024-01-01T01:00:00Z"),
+			},
+			expected: []string{"R1"},
+		},
+		{
+			name: "already seen changes requested is ignored",
+			reviews: []ReviewFields{
+				review("R1", "bob", "CHANGES_REQUESTED", "2024-01-01T00:00:00Z"),
+			},
+			lastState: &ReviewState{ID: "R1", CreatedAt: "2024-01-01T00:00:00Z"},
+			expected:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findChangesRequested(tt.reviews, tt.lastState)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("findChangesRequested() returned %d reviews, want %d", len(got), len(tt.expected))
+			}
+			for i, r := range got {
+				if r.ID != tt.expected[i] {
+					t.Errorf("review[%d] = %s, want %s", i, r.ID, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseThreadInput(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		arg  string
+		want threadInput
+	}{
+		{"PRRT_1", threadInput{ID: "PRRT_1"}},
+		{"PRRT_1:Fixed: see commit", threadInput{ID: "PRRT_1", CustomMessage: "Fixed: see commit"}},
+		{"PRRT_1:+resolve", threadInput{ID: "PRRT_1", Resolve: &yes}},
+		{"PRRT_1:+resolve:Fixed", threadInput{ID: "PRRT_1", CustomMessage: "Fixed", Resolve: &yes}},
+		{"#3:-resolve:Could you clarify?", threadInput{ID: "#3", CustomMessage: "Could you clarify?", Resolve: &no}},
+		{"PRRT_1:+resolved later", threadInput{ID: "PRRT_1", CustomMessage: "+resolved later"}},
+	}
+	for _, tt := range tests {
+		got := parseThreadInput(tt.arg)
+		if got.ID != tt.want.ID || got.CustomMessage != tt.want.CustomMessage ||
+			(got.Resolve == nil) != (tt.want.Resolve == nil) ||
+			(got.Resolve != nil && *got.Resolve != *tt.want.Resolve) {
+			t.Errorf("parseThreadInput(%q) = %+v, want %+v", tt.arg, got, tt.want)
+		}
+	}
+}
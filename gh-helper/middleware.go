@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/apstndb/gh-dev-tools/pkg/ghclient"
+	"github.com/spf13/cobra"
+)
+
+// CommandContext holds the global flag values of one invocation. The command
+// middleware resolves it once before RunE and carries it in the command's
+// context, so handlers never read package-level flag variables.
+type CommandContext struct {
+	Owner            string
+	Repo             string
+	Timeout          string // raw --timeout; see parseTimeout and calculateEffectiveTimeout
+	Format           OutputFormat
+	DryRun           bool      // --dry-run, for commands that define it
+	Quiet            bool      // suppress informational messages
+	Messages         io.Writer // informational messages: stdout, or io.Discard with --quiet
+	AssumeYes        bool
+	ConfirmThreshold int
+	Usage            *ghclient.Usage // API usage of the invocation, with --show-usage
+
+	usageReported bool // apiUsage was included in the structured output
+}
+
+type commandContextKey struct{}
+
+// CommandError envelopes a handler error with the command that produced it
+type CommandError struct {
+	Command string
+	Format  OutputFormat
+	Err     error
+}
+
+func (e *CommandError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// exitCodeOf returns the process exit code for an error (ExitError code, else 1)
+func exitCodeOf(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return 1
+}
+
+// resolveCommandContext reads the global flags of cmd. Flags a command does
+// not define keep their defaults, so handlers can also run on bare commands.
+func resolveCommandContext(cmd *cobra.Command) (*CommandContext, error) {
+	cc := &CommandContext{
+		Owner:            DefaultOwner,
+		Repo:             DefaultRepo,
+		Timeout:          "5m",
+		Format:           ResolveFormat(cmd),
+		ConfirmThreshold: envConfirmThreshold(),
+	}
+
+	flags := cmd.Flags()
+	var err error
+	for name, target := range map[string]*string{"owner": &cc.Owner, "repo": &cc.Repo, "timeout": &cc.Timeout} {
+		if flags.Lookup(name) == nil {
+			continue
+		}
+		if *target, err = flags.GetString(name); err != nil {
+			return nil, fmt.Errorf("failed to get '%s' flag: %w", name, err)
+		}
+	}
+	showUsage := false
+	for name, target := range map[string]*bool{"dry-run": &cc.DryRun, "quiet": &cc.Quiet, "yes": &cc.AssumeYes, "show-usage": &showUsage} {
+		if flags.Lookup(name) == nil {
+			continue
+		}
+		if *target, err = flags.GetBool(name); err != nil {
+			return nil, fmt.Errorf("failed to get '%s' flag: %w", name, err)
+		}
+	}
+	if flags.Lookup("confirm-threshold") != nil {
+		if cc.ConfirmThreshold, err = flags.GetInt("confirm-threshold"); err != nil {
+			return nil, fmt.Errorf("failed to get 'confirm-threshold' flag: %w", err)
+		}
+	}
+
+	if showUsage {
+		cc.Usage = &ghclient.Usage{}
+	}
+	cc.Messages = cmd.OutOrStdout()
+	if cc.Quiet {
+		cc.Messages = io.Discard
+	}
+
+	if _, err := ParseTimeout(cc.Timeout); err != nil {
+		return nil, fmt.Errorf("invalid --timeout %q: %w", cc.Timeout, err)
+	}
+	if err := checkFormat(cmd); err != nil {
+		return nil, err
+	}
+	return cc, nil
+}
+
+// setCommandContext attaches cc to the command's context
+func setCommandContext(cmd *cobra.Command, cc *CommandContext) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cmd.SetContext(context.WithValue(ctx, commandContextKey{}, cc))
+}
+
+// commandContext returns the CommandContext attached by the middleware, or
+// resolves one from the flags when the handler runs without it (e.g., in tests)
+func commandContext(cmd *cobra.Command) *CommandContext {
+	if ctx := cmd.Context(); ctx != nil {
+		if cc, ok := ctx.Value(commandContextKey{}).(*CommandContext); ok {
+			return cc
+		}
+	}
+	cc, err := resolveCommandContext(cmd)
+	if err != nil {
+		// Only malformed flag values fail; the middleware reports those before RunE
+		cc = &CommandContext{Owner: DefaultOwner, Repo: DefaultRepo, Timeout: "5m", ConfirmThreshold: envConfirmThreshold(), Messages: cmd.OutOrStdout()}
+	}
+	setCommandContext(cmd, cc)
+	return cc
+}
+
+// newCommandClient creates a client for the repository selected by --owner/--repo
+// that records API usage for --show-usage
+func newCommandClient(cmd *cobra.Command) *GitHubClient {
+	cc := commandContext(cmd)
+	client := NewGitHubClient(cc.Owner, cc.Repo)
+	client.api.Usage = cc.Usage
+	return client
+}
+
+// withAPIUsage adds the apiUsage section to map outputs when --show-usage is set
+func withAPIUsage(cmd *cobra.Command, data interface{}) interface{} {
+	cc := commandContext(cmd)
+	output, ok := data.(map[string]interface{})
+	if cc.Usage == nil || !ok {
+		return data
+	}
+	withUsage := make(map[string]interface{}, len(output)+1)
+	for key, value := range output {
+		withUsage[key] = value
+	}
+	withUsage["apiUsage"] = cc.Usage.Report()
+	cc.usageReported = true
+	return withUsage
+}
+
+// withMiddleware wraps a RunE handler: it resolves the CommandContext, logs timing at debug level, and envelopes errors in CommandError
+func withMiddleware(runE func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		cc, err := resolveCommandContext(cmd)
+		if err != nil {
+			return &CommandError{Command: cmd.CommandPath(), Format: ResolveFormat(cmd), Err: err}
+		}
+		setCommandContext(cmd, cc)
+
+		start := time.Now()
+		err = runE(cmd, args)
+		if cc.Usage != nil && !cc.usageReported {
+			// Non-map and tabular outputs have no place for the section; report on stderr
+			_ = EncodeOutput(cmd.ErrOrStderr(), FormatYAML, map[string]interface{}{"apiUsage": cc.Usage.Report()})
+		}
+		slog.Debug("command finished",
+			"command", cmd.CommandPath(),
+			"owner", cc.Owner,
+			"repo", cc.Repo,
+			"duration", time.Since(start).Round(time.Millisecond),
+			"exitCode", exitCodeOf(err))
+		if err != nil {
+			var commandErr *CommandError
+			if errors.As(err, &commandErr) {
+				return err
+			}
+			return &CommandError{Command: cmd.CommandPath(), Format: cc.Format, Err: err}
+		}
+		return nil
+	}
+}
+
+// applyMiddleware wraps the RunE of cmd and all of its subcommands
+func applyMiddleware(cmd *cobra.Command) {
+	if cmd.RunE != nil {
+		cmd.RunE = withMiddleware(cmd.RunE)
+	}
+	for _, child := range cmd.Commands() {
+		applyMiddleware(child)
+	}
+}
+
+// detailedError is an error with structured fields for the JSON error envelope
+type detailedError interface {
+	error
+	ErrorDetails() map[string]interface{}
+}
+
+// writeCommandError reports err on w: a JSON envelope for JSON/JSONL output so
+// scripts can parse failures, plain "Error: ..." text otherwise
+func writeCommandError(w io.Writer, err error) {
+	var commandErr *CommandError
+	if errors.As(err, &commandErr) && (commandErr.Format == FormatJSON || commandErr.Format == FormatJSONL) {
+		body := map[string]interface{}{
+			"command":  commandErr.Command,
+			"message":  commandErr.Error(),
+			"exitCode": exitCodeOf(err),
+		}
+		var detailed detailedError
+		if errors.As(err, &detailed) {
+			for key, value := range detailed.ErrorDetails() {
+				body[key] = value
+			}
+		}
+		envelope := map[string]interface{}{"error": body}
+		if data, marshalErr := json.Marshal(envelope); marshalErr == nil {
+			fmt.Fprintln(w, string(data))
+			return
+		}
+	}
+	fmt.Fprintf(w, "Error: %v\n", err)
+}
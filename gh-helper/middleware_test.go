@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newMiddlewareTestCommand(runE func(*cobra.Command, []string) error) *cobra.Command {
+	root := &cobra.Command{Use: "gh-helper", SilenceErrors: true, SilenceUsage: true}
+	root.PersistentFlags().String("owner", "default-owner", "")
+	root.PersistentFlags().String("repo", "default-repo", "")
+	root.PersistentFlags().String("timeout", "5m", "")
+	root.PersistentFlags().Bool("quiet", false, "")
+	root.PersistentFlags().Bool("yes", false, "")
+	root.PersistentFlags().Bool("show-usage", false, "")
+	root.PersistentFlags().Int("confirm-threshold", 5, "")
+	root.PersistentFlags().String("format", "yaml", "")
+	root.PersistentFlags().Bool("json", false, "")
+	root.PersistentFlags().Bool("yaml", false, "")
+	root.PersistentFlags().String("jq", "", "")
+
+	child := &cobra.Command{Use: "child", RunE: runE}
+	child.Flags().Bool("dry-run", false, "")
+	root.AddCommand(child)
+	applyMiddleware(root)
+	return root
+}
+
+func TestMiddlewareResolvesCommandContext(t *testing.T) {
+	var got *CommandContext
+	root := newMiddlewareTestCommand(func(cmd *cobra.Command, args []string) error {
+		got = commandContext(cmd)
+		return nil
+	})
+	root.SetArgs([]string{"child", "--owner", "o", "--repo", "r", "--timeout", "90s", "--dry-run", "--yes", "--json"})
+	if err := root.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	want := CommandContext{Owner: "o", Repo: "r", Timeout: "90s", Format: FormatJSON, DryRun: true, AssumeYes: true, ConfirmThreshold: 5, Messages: os.Stdout}
+	if got == nil || *got != want {
+		t.Errorf("commandContext() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMiddlewareErrors(t *testing.T) {
+	root := newMiddlewareTestCommand(func(cmd *cobra.Command, args []string) error {
+		return &ExitError{Code: ExitCodeChangesRequested, Err: errors.New("changes requested")}
+	})
+	root.SetArgs([]string{"child", "--format", "json"})
+	err := root.Execute()
+
+	var commandErr *CommandError
+	if !errors.As(err, &commandErr) || commandErr.Command != "gh-helper child" {
+		t.Fatalf("error = %#v, want CommandError for 'gh-helper child'", err)
+	}
+	if code := exitCodeOf(err); code != ExitCodeChangesRequested {
+		t.Errorf("exitCodeOf() = %d, want %d", code, ExitCodeChangesRequested)
+	}
+
+	var out bytes.Buffer
+	writeCommandError(&out, err)
+	if want := `{"error":{"command":"gh-helper child","exitCode":3,"message":"changes requested"}}`; strings.TrimSpace(out.String()) != want {
+		t.Errorf("writeCommandError() = %q, want %q", out.String(), want)
+	}
+
+	root.SetArgs([]string{"child", "--timeout", "soon"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "invalid --timeout") {
+		t.Errorf("error = %v, want invalid --timeout", err)
+	}
+}
+
+func TestMiddlewareQuiet(t *testing.T) {
+	for _, quiet := range []bool{false, true} {
+		var out bytes.Buffer
+		root := newMiddlewareTestCommand(func(cmd *cobra.Command, args []string) error {
+			InfoMsg("progress").Print(cmd)
+			return nil
+		})
+		root.SetOut(&out)
+		args := []string{"child"}
+		if quiet {
+			args = append(args, "--quiet")
+		}
+		root.SetArgs(args)
+		if err := root.Execute(); err != nil {
+			t.Fatal(err)
+		}
+		if got := out.Len() > 0; got == quiet {
+			t.Errorf("quiet=%v: output = %q", quiet, out.String())
+		}
+	}
+}
+
+func TestMiddlewareShowUsage(t *testing.T) {
+	var out, errOut bytes.Buffer
+	root := newMiddlewareTestCommand(func(cmd *cobra.Command, args []string) error {
+		if newCommandClient(cmd).api.Usage == nil {
+			t.Error("client does not record usage with --show-usage")
+		}
+		return EncodeOutputWithCmd(cmd, map[string]interface{}{"result": "ok"})
+	})
+	root.SetOut(&out)
+	root.SetErr(&errOut)
+	root.SetArgs([]string{"child", "--show-usage", "--json"})
+	if err := root.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `"apiUsage"`) || !strings.Contains(out.String(), `"totalCost"`) {
+		t.Errorf("output = %q, want apiUsage section", out.String())
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("stderr = %q, want usage only in the structured output", errOut.String())
+	}
+}
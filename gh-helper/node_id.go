@@ -79,7 +79,7 @@ func nodeIDIssue(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid issue number: %s", args[0])
 	}
 	
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
 	
 	query := `
 	query($owner: String!, $repo: String!, $number: Int!) {
@@ -91,8 +91,8 @@ func nodeIDIssue(cmd *cobra.Command, args []string) error {
 	}`
 	
 	variables := map[string]interface{}{
-		"owner":  owner,
-		"repo":   repo,
+		"owner":  client.Owner,
+		"repo":   client.Repo,
 		"number": number,
 	}
 	
@@ -140,7 +140,7 @@ func nodeIDPR(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid pull request number: %s", args[0])
 	}
 	
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
 	
 	query := `
 	query($owner: String!, $repo: String!, $number: Int!) {
@@ -152,8 +152,8 @@ func nodeIDPR(cmd *cobra.Command, args []string) error {
 	}`
 	
 	variables := map[string]interface{}{
-		"owner":  owner,
-		"repo":   repo,
+		"owner":  client.Owner,
+		"repo":   client.Repo,
 		"number": number,
 	}
 	
@@ -244,11 +244,11 @@ func nodeIDBatch(cmd *cobra.Command, batch string) error {
 	queryBuilder.WriteString("  }\n")
 	queryBuilder.WriteString("}")
 	
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
 	
 	variables := map[string]interface{}{
-		"owner": owner,
-		"repo":  repo,
+		"owner": client.Owner,
+		"repo":  client.Repo,
 	}
 	
 	data, err := client.RunGraphQLQueryWithVariables(queryBuilder.String(), variables)
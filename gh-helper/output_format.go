@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"time"
 
@@ -19,13 +20,67 @@ const (
 	FormatYAML     OutputFormat = "yaml"
 	FormatJSON     OutputFormat = "json"
 	FormatMarkdown OutputFormat = "markdown"
+	FormatCSV      OutputFormat = "csv"
+	FormatTSV      OutputFormat = "tsv"
+	FormatJSONL    OutputFormat = "jsonl"
+	FormatDOT      OutputFormat = "dot"     // Graphviz, for graph outputs
+	FormatMermaid  OutputFormat = "mermaid" // Mermaid flowchart, for graph outputs
 
 	// jqQueryTimeout is the maximum time allowed for jq query execution
 	jqQueryTimeout = 30 * time.Second
 )
 
+// formatsAnnotation is the cobra annotation listing the formats a command
+// supports besides yaml and json (see addOutputFormats)
+const formatsAnnotation = "gh-helper/formats"
+
+// addOutputFormats declares the formats cmd writes besides yaml and json;
+// --format rejects any other value
+func addOutputFormats(cmd *cobra.Command, formats ...OutputFormat) {
+	names := make([]string, len(formats))
+	for i, format := range formats {
+		names[i] = string(format)
+	}
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[formatsAnnotation] = strings.Join(names, ",")
+}
+
+// supportedFormats returns the formats cmd accepts: yaml, json and the ones
+// declared with addOutputFormats
+func supportedFormats(cmd *cobra.Command) []OutputFormat {
+	formats := []OutputFormat{FormatYAML, FormatJSON}
+	if declared := cmd.Annotations[formatsAnnotation]; declared != "" {
+		for _, name := range strings.Split(declared, ",") {
+			formats = append(formats, OutputFormat(name))
+		}
+	}
+	return formats
+}
+
+// checkFormat rejects a --format value the command does not support. The
+// middleware calls it before RunE, so handlers can rely on ResolveFormat.
+func checkFormat(cmd *cobra.Command) error {
+	formatStr, _ := cmd.Flags().GetString("format")
+	format := OutputFormat(strings.ToLower(formatStr))
+	if format == "" {
+		return nil
+	}
+	formats := supportedFormats(cmd)
+	if slices.Contains(formats, format) {
+		return nil
+	}
+	names := make([]string, len(formats))
+	for i, supported := range formats {
+		names[i] = string(supported)
+	}
+	return fmt.Errorf("unsupported --format %q for '%s' (supported: %s)", formatStr, cmd.CommandPath(), strings.Join(names, "|"))
+}
+
 // ResolveFormat resolves the output format from command flags
-// Handles mutually exclusive --format, --json, --yaml flags (enforced by cobra), defaults to YAML
+// Handles mutually exclusive --format, --json, --yaml flags (enforced by cobra), defaults to YAML.
+// Formats the command does not declare are rejected earlier by checkFormat.
 func ResolveFormat(cmd *cobra.Command) OutputFormat {
 	// Check aliases first (these take precedence since they're more specific)
 	if jsonFlag, _ := cmd.Flags().GetBool("json"); jsonFlag {
@@ -38,12 +93,10 @@ func ResolveFormat(cmd *cobra.Command) OutputFormat {
 	// Check main format flag
 	formatStr, _ := cmd.Flags().GetString("format")
 	format := OutputFormat(strings.ToLower(formatStr))
-	switch format {
-	case FormatJSON, FormatYAML, FormatMarkdown:
+	if slices.Contains(supportedFormats(cmd), format) {
 		return format
-	default:
-		return FormatYAML // Default
 	}
+	return FormatYAML // Default
 }
 
 // EncodeOutput encodes data to stdout using the given format
@@ -61,6 +114,7 @@ func EncodeOutput(w io.Writer, format OutputFormat, data interface{}) error {
 // EncodeOutputWithCmd encodes data with optional jq query from command
 func EncodeOutputWithCmd(cmd *cobra.Command, data interface{}) error {
 	format := ResolveFormat(cmd)
+	data = withAPIUsage(cmd, data)
 	// GetString error is intentionally ignored as the jq flag is guaranteed to exist
 	// (registered in rootCmd) and will return empty string if not set
 	jqQuery, _ := cmd.Root().Flags().GetString("jq")
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"strings"
 	"testing"
+
+	"github.com/spf13/cobra"
 )
 
 func TestEncodeOutputWithJQ(t *testing.T) {
@@ -202,4 +204,42 @@ func TestEncodeOutputWithJQTimeout(t *testing.T) {
 			t.Errorf("Expected context.DeadlineExceeded or 'execution timeout after', got: %v", err)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestCheckFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		declared []OutputFormat
+		format   string
+		want     OutputFormat
+		wantErr  bool
+	}{
+		{name: "yaml is always supported", format: "yaml", want: FormatYAML},
+		{name: "json is always supported", format: "JSON", want: FormatJSON},
+		{name: "declared format", declared: []OutputFormat{FormatCSV, FormatTSV}, format: "tsv", want: FormatTSV},
+		{name: "undeclared format", declared: []OutputFormat{FormatMarkdown}, format: "csv", wantErr: true},
+		{name: "no formats declared", format: "markdown", wantErr: true},
+		{name: "unknown format", format: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "export"}
+			cmd.Flags().String("format", "yaml", "")
+			if len(tt.declared) > 0 {
+				addOutputFormats(cmd, tt.declared...)
+			}
+			if err := cmd.Flags().Set("format", tt.format); err != nil {
+				t.Fatal(err)
+			}
+
+			err := checkFormat(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && ResolveFormat(cmd) != tt.want {
+				t.Errorf("ResolveFormat() = %s, want %s", ResolveFormat(cmd), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,523 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apstndb/gh-dev-tools/pkg/reviewwait"
+	"github.com/spf13/cobra"
+)
+
+var pollEventsCmd = NewOperationalCommand(
+	"poll-events [flags]",
+	"Poll a PR timeline and run a hook for each new event",
+	`Long-poll a pull request timeline and invoke a hook command with a JSON event
+for each new item, giving webhook-like automation without registering real
+webhooks on the repository.
+
+Event types: review, comment, label, unlabel, push, force_push
+
+The hook runs via 'sh -c' with the event JSON on stdin and these variables set:
+  GH_HELPER_EVENT_TYPE  Event type
+  GH_HELPER_EVENT_ID    Timeline item node ID
+  GH_HELPER_PR          PR number
+
+Items already on the timeline when polling starts are skipped unless --replay
+is given. Each poll pages back to the last item already seen, so no event is
+dropped when many arrive between polls. A review seen while still pending is
+delivered again once it is submitted. Polling stops when --timeout is
+reached; during GitHub API outages it slows down until the API recovers.
+
+Examples:
+  # Run a hook for each new event for 30 minutes
+  gh-helper poll-events --pr 306 --exec ./hook.sh --timeout 30m
+
+  # Print events as JSON lines without a hook
+  gh-helper poll-events --pr 306 --interval 15s
+
+  # Process the existing timeline once and exit
+  gh-helper poll-events --pr 306 --exec ./hook.sh --replay --once`,
+	pollEvents,
+)
+
+func init() {
+	pollEventsCmd.Flags().String("pr", "", "PR number to watch (default: current branch's PR)")
+	pollEventsCmd.Flags().String("exec", "", "Hook command to run for each event (event JSON on stdin); prints events when omitted")
+	pollEventsCmd.Flags().Duration("interval", 30*time.Second, "Polling interval")
+	pollEventsCmd.Flags().Bool("replay", false, "Emit events already on the timeline at startup")
+	pollEventsCmd.Flags().Bool("once", false, "Poll once and exit")
+	pollEventsCmd.Flags().Bool("stop-on-hook-error", false, "Stop polling when the hook exits non-zero")
+
+	rootCmd.AddCommand(pollEventsCmd)
+}
+
+// TimelineEvent is the JSON payload passed to hooks
+type TimelineEvent struct {
+	Type      string                 `json:"type"`
+	ID        string                 `json:"id"`
+	PR        int                    `json:"pr"`
+	Actor     string                 `json:"actor,omitempty"`
+	CreatedAt string                 `json:"createdAt"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+}
+
+// timelineNode covers the timeline item types requested by fetchTimelineEvents
+type timelineNode struct {
+	Typename  string `json:"__typename"`
+	ID        string `json:"id"`
+	CreatedAt string `json:"createdAt"`
+	Actor     *struct {
+		Login string `json:"login"`
+	} `json:"actor"`
+	Author *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+
+	// PullRequestReview
+	State       string `json:"state"`
+	SubmittedAt string `json:"submittedAt"`
+
+	// PullRequestReview, IssueComment
+	Body string `json:"body"`
+	URL  string `json:"url"`
+
+	// LabeledEvent, UnlabeledEvent
+	Label *struct {
+		Name string `json:"name"`
+	} `json:"label"`
+
+	// PullRequestCommit
+	Commit *struct {
+		OID             string `json:"oid"`
+		MessageHeadline string `json:"messageHeadline"`
+		CommittedDate   string `json:"committedDate"`
+	} `json:"commit"`
+
+	// HeadRefForcePushedEvent
+	BeforeCommit *struct {
+		OID string `json:"oid"`
+	} `json:"beforeCommit"`
+	AfterCommit *struct {
+		OID string `json:"oid"`
+	} `json:"afterCommit"`
+}
+
+// toTimelineEvent converts a timeline node to a hook event
+func (n timelineNode) toTimelineEvent(pr int) (TimelineEvent, bool) {
+	event := TimelineEvent{ID: n.ID, PR: pr, CreatedAt: n.CreatedAt, Data: map[string]interface{}{}}
+	if n.Actor != nil {
+		event.Actor = n.Actor.Login
+	} else if n.Author != nil {
+		event.Actor = n.Author.Login
+	}
+
+	switch n.Typename {
+	case "PullRequestReview":
+		event.Type = "review"
+		if n.SubmittedAt != "" {
+			event.CreatedAt = n.SubmittedAt
+		}
+		event.Data["state"] = n.State
+		event.Data["body"] = n.Body
+		event.Data["url"] = n.URL
+	case "IssueComment":
+		event.Type = "comment"
+		event.Data["body"] = n.Body
+		event.Data["url"] = n.URL
+	case "LabeledEvent", "UnlabeledEvent":
+		event.Type = "label"
+		if n.Typename == "UnlabeledEvent" {
+			event.Type = "unlabel"
+		}
+		if n.Label != nil {
+			event.Data["label"] = n.Label.Name
+		}
+	case "PullRequestCommit":
+		event.Type = "push"
+		if n.Commit != nil {
+			event.CreatedAt = n.Commit.CommittedDate
+			event.Data["sha"] = n.Commit.OID
+			event.Data["message"] = n.Commit.MessageHeadline
+		}
+	case "HeadRefForcePushedEvent":
+		event.Type = "force_push"
+		if n.BeforeCommit != nil {
+			event.Data["before"] = n.BeforeCommit.OID
+		}
+		if n.AfterCommit != nil {
+			event.Data["after"] = n.AfterCommit.OID
+		}
+	default:
+		return TimelineEvent{}, false
+	}
+	return event, true
+}
+
+// pendingReviewSuffix ends the seen key of a review that was still pending
+const pendingReviewSuffix = ":PENDING"
+
+// timelineSeenKey identifies a delivered node in seen. Reviews are keyed by ID
+// and state, so a review first seen as pending is delivered again once submitted.
+func timelineSeenKey(node timelineNode) string {
+	if node.Typename == "PullRequestReview" {
+		return node.ID + ":" + node.State
+	}
+	return node.ID
+}
+
+// newTimelineEvents returns events for nodes not yet in seen, marking them as seen
+func newTimelineEvents(nodes []timelineNode, seen map[string]bool, pr int) []TimelineEvent {
+	var events []TimelineEvent
+	for _, node := range nodes {
+		key := timelineSeenKey(node)
+		if node.ID == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		if node.Typename == "PullRequestReview" && node.State != "PENDING" {
+			delete(seen, node.ID+pendingReviewSuffix)
+		}
+		if event, ok := node.toTimelineEvent(pr); ok {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// timelinePageSize is the number of timeline items fetched per query
+const timelinePageSize = 50
+
+// timelinePage is one page of timeline items, oldest first
+type timelinePage struct {
+	Nodes           []timelineNode
+	HasPreviousPage bool
+	StartCursor     string
+}
+
+// collectTimelineNodes pages backwards from the newest timeline item until a
+// page contains an item already in seen, the timeline starts, or maxPages
+// pages were read (0 for no limit). Nodes newer than the newest seen item are
+// returned oldest first, along with older reviews seen as pending whose state
+// changed since; paging goes on until each of those is found again. Pending
+// reviews missing at the start of the timeline were discarded and are
+// removed from seen.
+func collectTimelineNodes(fetch func(before string) (timelinePage, error), seen map[string]bool, maxPages int) ([]timelineNode, error) {
+	pending := map[string]bool{}
+	for key := range seen {
+		if id, ok := strings.CutSuffix(key, pendingReviewSuffix); ok {
+			pending[id] = true
+		}
+	}
+
+	var nodes []timelineNode
+	before := ""
+	caughtUp := false
+	for pages := 1; ; pages++ {
+		page, err := fetch(before)
+		if err != nil {
+			return nil, err
+		}
+		// Items up to the newest seen one were delivered by earlier polls
+		lastSeen := -1
+		if caughtUp {
+			lastSeen = len(page.Nodes) - 1
+		} else {
+			for i, node := range page.Nodes {
+				if seen[timelineSeenKey(node)] {
+					lastSeen = i
+				}
+			}
+		}
+		var newer []timelineNode
+		for i, node := range page.Nodes {
+			submitted := pending[node.ID] && !seen[timelineSeenKey(node)]
+			delete(pending, node.ID)
+			if i > lastSeen || submitted {
+				newer = append(newer, node)
+			}
+		}
+		nodes = append(newer, nodes...)
+		caughtUp = caughtUp || lastSeen >= 0
+
+		if !page.HasPreviousPage || page.StartCursor == "" {
+			for id := range pending {
+				delete(seen, id+pendingReviewSuffix)
+			}
+			return nodes, nil
+		}
+		if (caughtUp && len(pending) == 0) || (maxPages > 0 && pages >= maxPages) {
+			return nodes, nil
+		}
+		before = page.StartCursor
+	}
+}
+
+// fetchTimelineNodes fetches the timeline items of supported types newer than
+// the last one in seen; see collectTimelineNodes for seen and maxPages
+func (c *GitHubClient) fetchTimelineNodes(prNumber int, seen map[string]bool, maxPages int) ([]timelineNode, error) {
+	return collectTimelineNodes(func(before string) (timelinePage, error) {
+		return c.fetchTimelinePage(prNumber, before)
+	}, seen, maxPages)
+}
+
+// fetchTimelinePage fetches the timeline items before the cursor (the newest
+// items when before is empty)
+func (c *GitHubClient) fetchTimelinePage(prNumber int, before string) (timelinePage, error) {
+	query := `
+	query($owner: String!, $repo: String!, $prNumber: Int!, $pageSize: Int!, $before: String) {
+		repository(owner: $owner, name: $repo) {
+			pullRequest(number: $prNumber) {
+				timelineItems(last: $pageSize, before: $before, itemTypes: [PULL_REQUEST_REVIEW, ISSUE_COMMENT, LABELED_EVENT, UNLABELED_EVENT, PULL_REQUEST_COMMIT, HEAD_REF_FORCE_PUSHED_EVENT]) {
+					pageInfo {
+						hasPreviousPage
+						startCursor
+					}
+					nodes {
+						__typename
+						... on PullRequestReview {
+							id
+							author { login }
+							state
+							body
+							url
+							createdAt
+							submittedAt
+						}
+						... on IssueComment {
+							id
+							author { login }
+							body
+							url
+							createdAt
+						}
+						... on LabeledEvent {
+							id
+							actor { login }
+							label { name }
+							createdAt
+						}
+						... on UnlabeledEvent {
+							id
+							actor { login }
+							label { name }
+							createdAt
+						}
+						... on PullRequestCommit {
+							id
+							commit {
+								oid
+								messageHeadline
+								committedDate
+							}
+						}
+						... on HeadRefForcePushedEvent {
+							id
+							actor { login }
+							beforeCommit { oid }
+							afterCommit { oid }
+							createdAt
+						}
+					}
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"owner":    c.Owner,
+		"repo":     c.Repo,
+		"prNumber": prNumber,
+		"pageSize": timelinePageSize,
+	}
+	if before != "" {
+		variables["before"] = before
+	}
+
+	responseData, err := c.RunGraphQLQueryWithVariables(query, variables)
+	if err != nil {
+		return timelinePage{}, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository struct {
+				PullRequest *struct {
+					TimelineItems struct {
+						PageInfo struct {
+							HasPreviousPage bool   `json:"hasPreviousPage"`
+							StartCursor     string `json:"startCursor"`
+						} `json:"pageInfo"`
+						Nodes []timelineNode `json:"nodes"`
+					} `json:"timelineItems"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return timelinePage{}, err
+	}
+
+	if response.Data.Repository.PullRequest == nil {
+		return timelinePage{}, fmt.Errorf("PR #%d not found", prNumber)
+	}
+
+	items := response.Data.Repository.PullRequest.TimelineItems
+	return timelinePage{
+		Nodes:           items.Nodes,
+		HasPreviousPage: items.PageInfo.HasPreviousPage,
+		StartCursor:     items.PageInfo.StartCursor,
+	}, nil
+}
+
+// runEventHook runs the hook command with the event JSON on stdin, passing its
+// output through to the command's stdout and stderr
+func runEventHook(cmd *cobra.Command, hook string, event TimelineEvent, payload []byte) error {
+	hookCmd := exec.Command("sh", "-c", hook)
+	hookCmd.Stdin = bytes.NewReader(payload)
+	hookCmd.Stdout = cmd.OutOrStdout()
+	hookCmd.Stderr = cmd.ErrOrStderr()
+	hookCmd.Env = append(os.Environ(),
+		"GH_HELPER_EVENT_TYPE="+event.Type,
+		"GH_HELPER_EVENT_ID="+event.ID,
+		"GH_HELPER_PR="+strconv.Itoa(event.PR),
+	)
+	return hookCmd.Run()
+}
+
+func pollEvents(cmd *cobra.Command, args []string) error {
+	prArg, err := cmd.Flags().GetString("pr")
+	if err != nil {
+		return fmt.Errorf("failed to get 'pr' flag: %w", err)
+	}
+	hook, err := cmd.Flags().GetString("exec")
+	if err != nil {
+		return fmt.Errorf("failed to get 'exec' flag: %w", err)
+	}
+	interval, err := cmd.Flags().GetDuration("interval")
+	if err != nil {
+		return fmt.Errorf("failed to get 'interval' flag: %w", err)
+	}
+	replay, err := cmd.Flags().GetBool("replay")
+	if err != nil {
+		return fmt.Errorf("failed to get 'replay' flag: %w", err)
+	}
+	once, err := cmd.Flags().GetBool("once")
+	if err != nil {
+		return fmt.Errorf("failed to get 'once' flag: %w", err)
+	}
+	stopOnHookError, err := cmd.Flags().GetBool("stop-on-hook-error")
+	if err != nil {
+		return fmt.Errorf("failed to get 'stop-on-hook-error' flag: %w", err)
+	}
+
+	client := newCommandClient(cmd)
+	var prArgs []string
+	if prArg != "" {
+		prArgs = []string{prArg}
+	}
+	prNumber, err := resolvePRNumberFromArgs(prArgs, client)
+	if err != nil {
+		return err
+	}
+	prNumberInt, err := strconv.Atoi(prNumber)
+	if err != nil {
+		return fmt.Errorf("invalid PR number format: %w", err)
+	}
+
+	effectiveTimeout, timeoutDisplay, err := calculateEffectiveTimeout(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Without --replay, the newest page marks the existing timeline as seen;
+	// later polls page back to it, so bursts between polls are not dropped
+	seen := make(map[string]bool)
+	if !replay {
+		nodes, err := client.fetchTimelineNodes(prNumberInt, seen, 1)
+		if err != nil {
+			return fmt.Errorf("failed to fetch timeline: %w", err)
+		}
+		newTimelineEvents(nodes, seen, prNumberInt)
+	}
+
+	// Events stream on stdout; status lines go to stderr
+	w, out := stderrMessageWriter(cmd), cmd.OutOrStdout()
+
+	delivered, hookFailures := 0, 0
+	deliver := func(nodes []timelineNode) error {
+		for _, event := range newTimelineEvents(nodes, seen, prNumberInt) {
+			payload, err := json.Marshal(event)
+			if err != nil {
+				return fmt.Errorf("failed to marshal event: %w", err)
+			}
+
+			if hook == "" {
+				fmt.Fprintln(out, string(payload))
+				delivered++
+				continue
+			}
+
+			if err := runEventHook(cmd, hook, event, payload); err != nil {
+				hookFailures++
+				fmt.Fprintf(w, "⚠️  Hook failed for %s event %s: %v\n", event.Type, event.ID, err)
+				if stopOnHookError {
+					return fmt.Errorf("hook failed for %s event %s: %w", event.Type, event.ID, err)
+				}
+				continue
+			}
+			delivered++
+		}
+		return nil
+	}
+
+	if once {
+		nodes, err := client.fetchTimelineNodes(prNumberInt, seen, 0)
+		if err != nil {
+			return fmt.Errorf("failed to fetch timeline: %w", err)
+		}
+		if err := deliver(nodes); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "✅ Delivered %d event(s), %d hook failure(s)\n", delivered, hookFailures)
+		return nil
+	}
+
+	fmt.Fprintf(w, "🔄 Polling events on PR #%s every %v (timeout: %s)...\n", prNumber, interval, timeoutDisplay)
+
+	// Poll until the timeout; failed polls are skipped and GitHub outages
+	// degrade polling like 'reviews wait'
+	var nodes []timelineNode
+	fetcher := reviewwait.FetcherFunc(func(needs reviewwait.Requirements) (reviewwait.Poll, error) {
+		var err error
+		if nodes, err = client.fetchTimelineNodes(prNumberInt, seen, 0); err != nil {
+			return reviewwait.Poll{}, fmt.Errorf("failed to fetch timeline: %w", err)
+		}
+		return reviewwait.Poll{}, nil
+	})
+	waiter := newPRWaiter(w, fetcher, untilTimeout{}, effectiveTimeout, interval)
+	waiter.OnPoll = func(reviewwait.Progress) error {
+		return deliver(nodes)
+	}
+	if _, err := waiter.Wait(); err != nil {
+		return err
+	}
+
+	printOutageSummary(w, &waiter.Outage)
+	fmt.Fprintf(w, "✅ Delivered %d event(s), %d hook failure(s)\n", delivered, hookFailures)
+	return nil
+}
+
+// untilTimeout is the condition of polling loops that only end through OnPoll
+// or the timeout ('poll-events', 'pr automerge')
+type untilTimeout struct{}
+
+func (untilTimeout) Satisfied(reviewwait.Snapshot) (bool, string) {
+	return false, "polling until timeout"
+}
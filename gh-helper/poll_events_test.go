@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewTimelineEvents(t *testing.T) {
+	raw := `[
+		{"__typename": "PullRequestReview", "id": "R1", "author": {"login": "gemini"}, "state": "COMMENTED", "body": "LGTM", "createdAt": "2024-01-01T00:00:00Z", "submittedAt": "2024-01-01T00:01:00Z"},
+		{"__typename": "LabeledEvent", "id": "L1", "actor": {"login": "alice"}, "label": {"name": "bug"}, "createdAt": "2024-01-01T00:02:00Z"},
+		{"__typename": "PullRequestCommit", "id": "C1", "commit": {"oid": "abc123", "messageHeadline": "fix: typo", "committedDate": "2024-01-01T00:03:00Z"}},
+		{"__typename": "HeadRefForcePushedEvent", "id": "F1", "actor": {"login": "alice"}, "beforeCommit": {"oid": "abc123"}, "afterCommit": {"oid": "def456"}, "createdAt": "2024-01-01T00:04:00Z"},
+		{}
+	]`
+
+	var nodes []timelineNode
+	if err := json.Unmarshal([]byte(raw), &nodes); err != nil {
+		t.Fatalf("failed to unmarshal fixture: %v", err)
+	}
+
+	seen := map[string]bool{"L1": true}
+	events := newTimelineEvents(nodes, seen, 306)
+
+	wantTypes := []string{"review", "push", "force_push"}
+	if len(events) != len(wantTypes) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(wantTypes), events)
+	}
+	for i, want := range wantTypes {
+		if events[i].Type != want {
+			t.Errorf("events[%d].Type = %s, want %s", i, events[i].Type, want)
+		}
+		if events[i].PR != 306 {
+			t.Errorf("events[%d].PR = %d, want 306", i, events[i].PR)
+		}
+	}
+	if events[0].CreatedAt != "2024-01-01T00:01:00Z" || events[0].Actor != "gemini" {
+		t.Errorf("review event should use submittedAt and author: %+v", events[0])
+	}
+	if events[1].Data["sha"] != "abc123" {
+		t.Errorf("push event should carry commit sha: %+v", events[1])
+	}
+
+	// Second pass delivers nothing new
+	if again := newTimelineEvents(nodes, seen, 306); len(again) != 0 {
+		t.Errorf("expected no events on second pass, got %+v", again)
+	}
+}
+
+func TestCollectTimelineNodes(t *testing.T) {
+	node := func(id string) timelineNode { return timelineNode{Typename: "IssueComment", ID: id} }
+	// Three pages, newest last: [A B] [C D] [E F]
+	pages := map[string]timelinePage{
+		"":   {Nodes: []timelineNode{node("E"), node("F")}, HasPreviousPage: true, StartCursor: "p2"},
+		"p2": {Nodes: []timelineNode{node("C"), node("D")}, HasPreviousPage: true, StartCursor: "p1"},
+		"p1": {Nodes: []timelineNode{node("A"), node("B")}},
+	}
+
+	tests := []struct {
+		name     string
+		seen     map[string]bool
+		maxPages int
+		want     string
+		fetches  int
+	}{
+		{name: "stops at the page with the last seen item", seen: map[string]bool{"C": true}, want: "DEF", fetches: 2},
+		{name: "pages to the start of the timeline", seen: map[string]bool{}, want: "ABCDEF", fetches: 3},
+		{name: "page limit", seen: map[string]bool{}, maxPages: 1, want: "EF", fetches: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetches := 0
+			nodes, err := collectTimelineNodes(func(before string) (timelinePage, error) {
+				fetches++
+				return pages[before], nil
+			}, tt.seen, tt.maxPages)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := ""
+			for _, n := range nodes {
+				got += n.ID
+			}
+			if got != tt.want || fetches != tt.fetches {
+				t.Errorf("got %s after %d fetch(es), want %s after %d", got, fetches, tt.want, tt.fetches)
+			}
+		})
+	}
+
+	// More than one page of new items between polls are all delivered once
+	seen := map[string]bool{"B": true}
+	nodes, err := collectTimelineNodes(func(before string) (timelinePage, error) { return pages[before], nil }, seen, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if events := newTimelineEvents(nodes, seen, 306); len(events) != 4 {
+		t.Errorf("got %d events, want 4 (C through F)", len(events))
+	}
+
+	// Nothing new: the newest page ends with the last seen item
+	if nodes, _ := collectTimelineNodes(func(before string) (timelinePage, error) { return pages[before], nil }, seen, 0); len(nodes) != 0 {
+		t.Errorf("expected no nodes after all were seen, got %+v", nodes)
+	}
+
+	// A review seen as pending behind later items is delivered once submitted
+	review := timelineNode{Typename: "PullRequestReview", ID: "B", State: "COMMENTED"}
+	submitted := map[string]timelinePage{
+		"":   pages[""],
+		"p2": pages["p2"],
+		"p1": {Nodes: []timelineNode{node("A"), review}},
+	}
+	seen = map[string]bool{"A": true, "B" + pendingReviewSuffix: true, "C": true, "D": true, "E": true, "F": true}
+	fetches := 0
+	fetchSubmitted := func(before string) (timelinePage, error) {
+		fetches++
+		return submitted[before], nil
+	}
+	nodes, err = collectTimelineNodes(fetchSubmitted, seen, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if events := newTimelineEvents(nodes, seen, 306); len(events) != 1 || events[0].ID != "B" || events[0].Data["state"] != "COMMENTED" || fetches != 3 {
+		t.Errorf("got events %+v after %d fetch(es), want the submitted review after 3", events, fetches)
+	}
+	fetches = 0
+	if nodes, _ := collectTimelineNodes(fetchSubmitted, seen, 0); len(nodes) != 0 || fetches != 1 {
+		t.Errorf("expected no nodes after 1 fetch once the review was delivered, got %+v after %d", nodes, fetches)
+	}
+}
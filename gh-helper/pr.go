@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "GitHub pull request operations",
+	Long:  `Manage GitHub pull requests beyond reviews and threads, such as description checklists.`,
+}
+
+// PRBody holds a pull request's node ID and description
+type PRBody struct {
+	ID     string `json:"id"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+}
+
+// GetPRBody fetches a pull request's node ID, title, and body
+func (c *GitHubClient) GetPRBody(prNumber string) (*PRBody, error) {
+	prNumberInt, err := strconv.Atoi(prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PR number format: %w", err)
+	}
+
+	query := `
+	query($owner: String!, $repo: String!, $prNumber: Int!) {
+		repository(owner: $owner, name: $repo) {
+			pullRequest(number: $prNumber) {
+				id
+				number
+				title
+				body
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"owner":    c.Owner,
+		"repo":     c.Repo,
+		"prNumber": prNumberInt,
+	}
+
+	responseData, err := c.RunGraphQLQueryWithVariables(query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository struct {
+				PullRequest *PRBody `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Data.Repository.PullRequest == nil {
+		return nil, fmt.Errorf("PR #%s not found", prNumber)
+	}
+
+	return response.Data.Repository.PullRequest, nil
+}
+
+// UpdatePRBody replaces a pull request's description
+func (c *GitHubClient) UpdatePRBody(prID, body string) error {
+	mutation := `
+	mutation($pullRequestId: ID!, $body: String!) {
+		updatePullRequest(input: {pullRequestId: $pullRequestId, body: $body}) {
+			pullRequest {
+				id
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"pullRequestId": prID,
+		"body":          body,
+	}
+
+	_, err := c.RunGraphQLQueryWithVariables(mutation, variables)
+	return err
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var autoRequestPRCmd = NewOperationalCommand(
+	"auto-request [pr-number] [flags]",
+	"Request reviewers based on PR size and reviewer load",
+	`Request reviewers for a pull request: one reviewer for small PRs and
+--large-reviewers for large ones, picking the candidates with the fewest
+open review requests in the repository.
+
+The PR author is never requested. Reviewers already requested or who have
+already reviewed count toward the target, so running the command again is a
+no-op once enough reviewers are assigned.
+
+Size classes (additions + deletions): XS < 10, S < 100, M < 400, L < 1000, XL.
+PRs at or above --large-threshold lines are "large".
+
+Candidates come from --reviewers, or the repository's assignable users.
+
+`+prNumberArgsHelp+`
+
+Examples:
+  # Request from a fixed pool
+  gh-helper pr auto-request 306 --reviewers alice,bob,carol
+
+  # Require three reviewers for PRs of 800+ changed lines
+  gh-helper pr auto-request 306 --reviewers alice,bob,carol,dave --large-threshold 800 --large-reviewers 3
+
+  # Preview the selection
+  gh-helper pr auto-request --dry-run`,
+	prAutoRequest,
+)
+
+func init() {
+	autoRequestPRCmd.Args = cobra.MaximumNArgs(1)
+
+	autoRequestPRCmd.Flags().StringSlice("reviewers", []string{}, "Candidate reviewers (default: repository assignable users)")
+	autoRequestPRCmd.Flags().Int("large-threshold", 400, "Changed lines at which a PR counts as large")
+	autoRequestPRCmd.Flags().Int("large-reviewers", 2, "Number of reviewers for large PRs")
+	autoRequestPRCmd.Flags().Bool("dry-run", false, "Show the selection without requesting reviews")
+
+	prCmd.AddCommand(autoRequestPRCmd)
+}
+
+// PRSize describes the size of a pull request
+type PRSize struct {
+	Additions    int    `json:"additions"`
+	Deletions    int    `json:"deletions"`
+	ChangedFiles int    `json:"changedFiles"`
+	Class        string `json:"class"`
+	Large        bool   `json:"large"`
+}
+
+// classifyPRSize returns the size class for a number of changed lines
+func classifyPRSize(changedLines int) string {
+	switch {
+	case changedLines < 10:
+		return "XS"
+	case changedLines < 100:
+		return "S"
+	case changedLines < 400:
+		return "M"
+	case changedLines < 1000:
+		return "L"
+	default:
+		return "XL"
+	}
+}
+
+// selectReviewers picks up to n candidates with the lowest load, skipping excluded logins.
+// Ties are broken by login for deterministic results.
+func selectReviewers(candidates []string, load map[string]int, exclude map[string]bool, n int) []string {
+	var eligible []string
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		key := strings.ToLower(candidate)
+		if candidate == "" || exclude[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		eligible = append(eligible, candidate)
+	}
+
+	sort.SliceStable(eligible, func(i, j int) bool {
+		if load[eligible[i]] != load[eligible[j]] {
+			return load[eligible[i]] < load[eligible[j]]
+		}
+		return eligible[i] < eligible[j]
+	})
+
+	if n < len(eligible) {
+		eligible = eligible[:n]
+	}
+	return eligible
+}
+
+// prReviewerState is the PR data needed to pick reviewers
+type prReviewerState struct {
+	ID        string
+	Author    string
+	Size      PRSize
+	Requested []string // Pending review requests (users only)
+	Reviewed  []string // Users who already submitted a review
+}
+
+// getPRReviewerState fetches the PR's size, author, and current reviewers
+func (c *GitHubClient) getPRReviewerState(prNumber int) (*prReviewerState, error) {
+	query := `
+	query($owner: String!, $repo: String!, $prNumber: Int!) {
+		repository(owner: $owner, name: $repo) {
+			pullRequest(number: $prNumber) {
+				id
+				additions
+				deletions
+				changedFiles
+				author { login }
+				reviewRequests(first: 50) {
+					nodes {
+						requestedReviewer {
+							... on User { login }
+						}
+					}
+				}
+				latestReviews(first: 50) {
+					nodes {
+						author { login }
+					}
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"owner":    c.Owner,
+		"repo":     c.Repo,
+		"prNumber": prNumber,
+	}
+
+	responseData, err := c.RunGraphQLQueryWithVariables(query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository struct {
+				PullRequest *struct {
+					ID           string `json:"id"`
+					Additions    int    `json:"additions"`
+					Deletions    int    `json:"deletions"`
+					ChangedFiles int    `json:"changedFiles"`
+					Author       *struct {
+						Login string `json:"login"`
+					} `json:"author"`
+					ReviewRequests struct {
+						Nodes []struct {
+							RequestedReviewer *struct {
+								Login string `json:"login"`
+							} `json:"requestedReviewer"`
+						} `json:"nodes"`
+					} `json:"reviewRequests"`
+					LatestReviews struct {
+						Nodes []struct {
+							Author *struct {
+								Login string `json:"login"`
+							} `json:"author"`
+						} `json:"nodes"`
+					} `json:"latestReviews"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil, err
+	}
+
+	pr := response.Data.Repository.PullRequest
+	if pr == nil {
+		return nil, fmt.Errorf("PR #%d not found", prNumber)
+	}
+
+	state := &prReviewerState{
+		ID: pr.ID,
+		Size: PRSize{
+			Additions:    pr.Additions,
+			Deletions:    pr.Deletions,
+			ChangedFiles: pr.ChangedFiles,
+			Class:        classifyPRSize(pr.Additions + pr.Deletions),
+		},
+	}
+	if pr.Author != nil {
+		state.Author = pr.Author.Login
+	}
+	for _, request := range pr.ReviewRequests.Nodes {
+		// Team requests have no login
+		if request.RequestedReviewer != nil && request.RequestedReviewer.Login != "" {
+			state.Requested = append(state.Requested, request.RequestedReviewer.Login)
+		}
+	}
+	for _, review := range pr.LatestReviews.Nodes {
+		if review.Author != nil && review.Author.Login != state.Author {
+			state.Reviewed = append(state.Reviewed, review.Author.Login)
+		}
+	}
+
+	return state, nil
+}
+
+// GetAssignableUsers returns the logins of users who can be assigned in the repository
+func (c *GitHubClient) GetAssignableUsers() ([]string, error) {
+	query := `
+	query($owner: String!, $repo: String!) {
+		repository(owner: $owner, name: $repo) {
+			assignableUsers(first: 100) {
+				nodes {
+					login
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"owner": c.Owner,
+		"repo":  c.Repo,
+	}
+
+	responseData, err := c.RunGraphQLQueryWithVariables(query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository struct {
+				AssignableUsers struct {
+					Nodes []struct {
+						Login string `json:"login"`
+					} `json:"nodes"`
+				} `json:"assignableUsers"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil, err
+	}
+
+	var logins []string
+	for _, user := range response.Data.Repository.AssignableUsers.Nodes {
+		logins = append(logins, user.Login)
+	}
+	return logins, nil
+}
+
+// reviewRequestSearchBatchSize is the number of aliased searches per GraphQL query
+const reviewRequestSearchBatchSize = 20
+
+// GetOpenReviewRequestCounts returns the number of open PRs in the repository
+// awaiting review from each user, reviewRequestSearchBatchSize users per query
+func (c *GitHubClient) GetOpenReviewRequestCounts(logins []string) (map[string]int, error) {
+	counts := make(map[string]int, len(logins))
+	for start := 0; start < len(logins); start += reviewRequestSearchBatchSize {
+		batch := logins[start:min(start+reviewRequestSearchBatchSize, len(logins))]
+
+		var queryBuilder strings.Builder
+		queryBuilder.WriteString("query {\n")
+		for i, login := range batch {
+			searchQuery := fmt.Sprintf("repo:%s/%s is:pr is:open review-requested:%s", c.Owner, c.Repo, login)
+			fmt.Fprintf(&queryBuilder, "  u%d: search(query: %s, type: ISSUE, first: 0) { issueCount }\n", i, strconv.Quote(searchQuery))
+		}
+		queryBuilder.WriteString("}")
+
+		responseData, err := c.RunGraphQLQueryWithVariables(queryBuilder.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Data map[string]struct {
+				IssueCount int `json:"issueCount"`
+			} `json:"data"`
+		}
+
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return nil, err
+		}
+
+		for i, login := range batch {
+			counts[login] = response.Data[fmt.Sprintf("u%d", i)].IssueCount
+		}
+	}
+	return counts, nil
+}
+
+// RequestReviewers adds users to a pull request's review requests
+func (c *GitHubClient) RequestReviewers(prID string, userIDs []string) error {
+	mutation := `
+	mutation($input: RequestReviewsInput!) {
+		requestReviews(input: $input) {
+			pullRequest {
+				id
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"pullRequestId": prID,
+			"userIds":       userIDs,
+			"union":         true,
+		},
+	}
+
+	_, err := c.RunGraphQLQueryWithVariables(mutation, variables)
+	return err
+}
+
+func prAutoRequest(cmd *cobra.Command, args []string) error {
+	candidates, err := cmd.Flags().GetStringSlice("reviewers")
+	if err != nil {
+		return fmt.Errorf("failed to get 'reviewers' flag: %w", err)
+	}
+	largeThreshold, err := cmd.Flags().GetInt("large-threshold")
+	if err != nil {
+		return fmt.Errorf("failed to get 'large-threshold' flag: %w", err)
+	}
+	largeReviewers, err := cmd.Flags().GetInt("large-reviewers")
+	if err != nil {
+		return fmt.Errorf("failed to get 'large-reviewers' flag: %w", err)
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get 'dry-run' flag: %w", err)
+	}
+	if largeReviewers < 1 {
+		return fmt.Errorf("--large-reviewers must be at least 1")
+	}
+
+	client := newCommandClient(cmd)
+	prNumber, err := resolvePRNumberFromArgs(args, client)
+	if err != nil {
+		return err
+	}
+	prNumberInt, err := strconv.Atoi(prNumber)
+	if err != nil {
+		return fmt.Errorf("invalid PR number format: %w", err)
+	}
+
+	state, err := client.getPRReviewerState(prNumberInt)
+	if err != nil {
+		return fmt.Errorf("failed to fetch PR: %w", err)
+	}
+	state.Size.Large = state.Size.Additions+state.Size.Deletions >= largeThreshold
+
+	target := 1
+	if state.Size.Large {
+		target = largeReviewers
+	}
+
+	// Existing reviewers count toward the target and are never re-requested
+	exclude := map[string]bool{strings.ToLower(state.Author): true}
+	assigned := []string{}
+	for _, login := range append(append([]string{}, state.Requested...), state.Reviewed...) {
+		if !exclude[strings.ToLower(login)] {
+			exclude[strings.ToLower(login)] = true
+			assigned = append(assigned, login)
+		}
+	}
+
+	needed := target - len(assigned)
+	selected := []string{}
+	load := map[string]int{}
+	if needed > 0 {
+		if len(candidates) == 0 {
+			candidates, err = client.GetAssignableUsers()
+			if err != nil {
+				return fmt.Errorf("failed to fetch assignable users: %w", err)
+			}
+		}
+
+		var eligible []string
+		for _, candidate := range candidates {
+			if !exclude[strings.ToLower(candidate)] {
+				eligible = append(eligible, candidate)
+			}
+		}
+
+		load, err = client.GetOpenReviewRequestCounts(eligible)
+		if err != nil {
+			return fmt.Errorf("failed to fetch reviewer load: %w", err)
+		}
+		selected = selectReviewers(eligible, load, exclude, needed)
+		if len(selected) < needed {
+			WarningMsg("Only %d eligible reviewer(s) available, %d needed", len(selected), needed).Print(cmd)
+		}
+
+		if len(selected) > 0 && !dryRun {
+			userIDs, err := client.GetUserIDs(selected)
+			if err != nil {
+				return err
+			}
+			if err := client.RequestReviewers(state.ID, userIDs); err != nil {
+				return fmt.Errorf("failed to request reviews: %w", err)
+			}
+		}
+	}
+
+	output := map[string]interface{}{
+		"autoRequest": map[string]interface{}{
+			"pr":       prNumberInt,
+			"size":     state.Size,
+			"target":   target,
+			"existing": assigned,
+			"selected": selected,
+			"load":     load,
+			"dryRun":   dryRun,
+		},
+	}
+
+	return EncodeOutputWithCmd(cmd, output)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClassifyPRSize(t *testing.T) {
+	tests := map[int]string{0: "XS", 9: "XS", 10: "S", 99: "S", 100: "M", 399: "M", 400: "L", 999: "L", 1000: "XL"}
+	for lines, want := range tests {
+		if got := classifyPRSize(lines); got != want {
+			t.Errorf("classifyPRSize(%d) = %q, want %q", lines, got, want)
+		}
+	}
+}
+
+func TestSelectReviewers(t *testing.T) {
+	load := map[string]int{"alice": 3, "bob": 0, "carol": 1, "dave": 0}
+
+	tests := []struct {
+		name       string
+		candidates []string
+		exclude    map[string]bool
+		n          int
+		want       []string
+	}{
+		{name: "lowest load first", candidates: []string{"alice", "bob", "carol", "dave"}, n: 2, want: []string{"bob", "dave"}},
+		{name: "excluded author", candidates: []string{"alice", "bob", "carol"}, exclude: map[string]bool{"bob": true}, n: 1, want: []string{"carol"}},
+		{name: "fewer candidates than needed", candidates: []string{"alice"}, n: 2, want: []string{"alice"}},
+		{name: "duplicates ignored", candidates: []string{"carol", "Carol", "alice"}, n: 3, want: []string{"carol", "alice"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exclude := tt.exclude
+			if exclude == nil {
+				exclude = map[string]bool{}
+			}
+			got := selectReviewers(tt.candidates, load, exclude, tt.n)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selectReviewers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
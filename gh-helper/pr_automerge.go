@@ -0,0 +1,648 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apstndb/gh-dev-tools/pkg/reviewwait"
+	"github.com/spf13/cobra"
+)
+
+var automergePRCmd = NewOperationalCommand(
+	"automerge [pr-number] [flags]",
+	"Merge a PR as soon as all declared gates pass",
+	`Poll a pull request until every gate in --require passes, then merge it
+with the given strategy, and print a final report.
+
+Gates (comma-separated):
+  checks-green          Status check rollup is SUCCESS
+  approvals>=N          At least N approving reviews (latest review per reviewer)
+  threads-resolved      No unresolved review threads
+  no-changes-requested  No reviewer's latest review requests changes
+
+Draft PRs and merge conflicts always block merging. The merge is pinned to
+the head commit the gates were evaluated on, so a concurrent push aborts it.
+All reviews and review threads are read, however many there are. During
+GitHub API outages, polling slows down until the API recovers.
+
+With --github-auto-merge, once every gate except checks-green passes, GitHub
+auto-merge is enabled and GitHub merges when required checks pass (only
+branch-protection required checks are enforced by GitHub in that case). If
+auto-merge cannot be enabled, polling continues as usual.
+
+`+prNumberArgsHelp+`
+
+Examples:
+  # Squash-merge when checks are green, approved, and all threads resolved
+  gh-helper pr automerge 306 --strategy squash --require "checks-green,approvals>=1,threads-resolved" --timeout 30m
+
+  # Evaluate gates once without waiting
+  gh-helper pr automerge 306 --once
+
+  # Hand off to GitHub auto-merge when possible
+  gh-helper pr automerge 306 --github-auto-merge --timeout 1h`,
+	prAutomerge,
+)
+
+func init() {
+	automergePRCmd.Args = cobra.MaximumNArgs(1)
+
+	automergePRCmd.Flags().String("strategy", "squash", "Merge strategy: merge, squash, or rebase")
+	automergePRCmd.Flags().String("require", "checks-green,approvals>=1,threads-resolved", "Comma-separated gates that must pass before merging")
+	automergePRCmd.Flags().Duration("interval", 30*time.Second, "Polling interval")
+	automergePRCmd.Flags().Bool("once", false, "Evaluate gates once and merge only if they pass")
+	automergePRCmd.Flags().Bool("github-auto-merge", false, "Enable GitHub auto-merge when only checks are pending")
+
+	prCmd.AddCommand(automergePRCmd)
+}
+
+// MergeGate is a condition that must hold before merging
+type MergeGate struct {
+	Name         string // checks-green, approvals, threads-resolved, no-changes-requested
+	MinApprovals int
+}
+
+func (g MergeGate) String() string {
+	if g.Name == "approvals" {
+		return fmt.Sprintf("approvals>=%d", g.MinApprovals)
+	}
+	return g.Name
+}
+
+// GateResult is the outcome of evaluating one gate
+type GateResult struct {
+	Gate   string `json:"gate"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
+// PRMergeState is the PR state needed to evaluate merge gates
+type PRMergeState struct {
+	ID                string
+	Number            int
+	State             string
+	IsDraft           bool
+	Mergeable         string
+	MergeStateStatus  string
+	HeadOID           string
+	ChecksState       string // Empty when no checks are configured
+	Approvals         []string
+	ChangesRequested  []string
+	UnresolvedThreads int
+	AutoMergeEnabled  bool
+}
+
+var mergeStrategies = map[string]string{
+	"merge":  "MERGE",
+	"squash": "SQUASH",
+	"rebase": "REBASE",
+}
+
+// parseMergeGates parses a --require specification
+func parseMergeGates(spec string) ([]MergeGate, error) {
+	var gates []MergeGate
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		switch {
+		case part == "checks-green", part == "threads-resolved", part == "no-changes-requested":
+			gates = append(gates, MergeGate{Name: part})
+		case strings.HasPrefix(part, "approvals>="):
+			n, err := strconv.Atoi(strings.TrimPrefix(part, "approvals>="))
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid gate %q: approvals must be a non-negative number", part)
+			}
+			gates = append(gates, MergeGate{Name: "approvals", MinApprovals: n})
+		default:
+			return nil, fmt.Errorf("unknown gate %q (valid: checks-green, approvals>=N, threads-resolved, no-changes-requested)", part)
+		}
+	}
+	return gates, nil
+}
+
+// evaluateMergeGates evaluates the declared gates plus the implicit draft and conflict gates
+func evaluateMergeGates(gates []MergeGate, state PRMergeState) []GateResult {
+	draftDetail := "ready for review"
+	if state.IsDraft {
+		draftDetail = "PR is a draft"
+	}
+	results := []GateResult{
+		{Gate: "not-draft", Passed: !state.IsDraft, Detail: draftDetail},
+		{Gate: "no-conflicts", Passed: state.Mergeable == "MERGEABLE", Detail: "mergeable: " + state.Mergeable},
+	}
+
+	for _, gate := range gates {
+		result := GateResult{Gate: gate.String()}
+		switch gate.Name {
+		case "checks-green":
+			result.Passed = state.ChecksState == "SUCCESS"
+			if state.ChecksState == "" {
+				result.Detail = "no checks reported yet"
+			} else {
+				result.Detail = "checks: " + state.ChecksState
+			}
+		case "approvals":
+			result.Passed = len(state.Approvals) >= gate.MinApprovals
+			result.Detail = fmt.Sprintf("%d approval(s)", len(state.Approvals))
+			if len(state.Approvals) > 0 {
+				result.Detail += ": " + strings.Join(state.Approvals, ", ")
+			}
+		case "threads-resolved":
+			result.Passed = state.UnresolvedThreads == 0
+			result.Detail = fmt.Sprintf("%d unresolved thread(s)", state.UnresolvedThreads)
+		case "no-changes-requested":
+			result.Passed = len(state.ChangesRequested) == 0
+			result.Detail = "no changes requested"
+			if !result.Passed {
+				result.Detail = "changes requested by " + strings.Join(state.ChangesRequested, ", ")
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// allGatesPassed reports whether every result passed, optionally ignoring one gate
+func allGatesPassed(results []GateResult, ignore string) bool {
+	for _, result := range results {
+		if !result.Passed && result.Gate != ignore {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeStatePageSize is the page size of the review and thread connections
+const mergeStatePageSize = 100
+
+// mergeStateNode is a node of the latestOpinionatedReviews or reviewThreads
+// connection; each connection fills only its own fields
+type mergeStateNode struct {
+	Author *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	State      string `json:"state"`
+	IsResolved bool   `json:"isResolved"`
+}
+
+// mergeStateConnection is one page of a connection read by GetPRMergeState
+type mergeStateConnection struct {
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+	Nodes []mergeStateNode `json:"nodes"`
+}
+
+// mergeStateConnections maps each paged connection to its field and node selection
+var mergeStateConnections = map[string]struct{ Field, Nodes string }{
+	"latestOpinionatedReviews": {"latestOpinionatedReviews(first: $pageSize, after: $after, writersOnly: true)", "author { login } state"},
+	"reviewThreads":            {"reviewThreads(first: $pageSize, after: $after)", "isResolved"},
+}
+
+// collectMergeStateNodes returns the nodes of first and of the pages after it,
+// so gates never see a truncated review or thread list
+func collectMergeStateNodes(first mergeStateConnection, fetch func(after string) (mergeStateConnection, error)) ([]mergeStateNode, error) {
+	nodes := first.Nodes
+	for page := first; page.PageInfo.HasNextPage && page.PageInfo.EndCursor != ""; {
+		var err error
+		if page, err = fetch(page.PageInfo.EndCursor); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, page.Nodes...)
+	}
+	return nodes, nil
+}
+
+// getMergeStateConnectionPage fetches the page of a connection of
+// mergeStateConnections after the cursor
+func (c *GitHubClient) getMergeStateConnectionPage(prNumber int, connection, after string) (mergeStateConnection, error) {
+	query := fmt.Sprintf(`
+	query($owner: String!, $repo: String!, $prNumber: Int!, $pageSize: Int!, $after: String) {
+		repository(owner: $owner, name: $repo) {
+			pullRequest(number: $prNumber) {
+				connection: %s {
+					pageInfo { hasNextPage endCursor }
+					nodes {
+						%s
+					}
+				}
+			}
+		}
+	}`, mergeStateConnections[connection].Field, mergeStateConnections[connection].Nodes)
+
+	variables := map[string]interface{}{
+		"owner":    c.Owner,
+		"repo":     c.Repo,
+		"prNumber": prNumber,
+		"pageSize": mergeStatePageSize,
+		"after":    after,
+	}
+
+	responseData, err := c.RunGraphQLQueryWithVariables(query, variables)
+	if err != nil {
+		return mergeStateConnection{}, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository struct {
+				PullRequest *struct {
+					Connection mergeStateConnection `json:"connection"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return mergeStateConnection{}, err
+	}
+	if response.Data.Repository.PullRequest == nil {
+		return mergeStateConnection{}, fmt.Errorf("PR #%d not found", prNumber)
+	}
+	return response.Data.Repository.PullRequest.Connection, nil
+}
+
+// GetPRMergeState fetches the state needed to evaluate merge gates, paging
+// through all latest reviews and review threads
+func (c *GitHubClient) GetPRMergeState(prNumber int) (*PRMergeState, error) {
+	query := `
+	query($owner: String!, $repo: String!, $prNumber: Int!, $pageSize: Int!) {
+		repository(owner: $owner, name: $repo) {
+			pullRequest(number: $prNumber) {
+				id
+				number
+				state
+				isDraft
+				mergeable
+				mergeStateStatus
+				headRefOid
+				autoMergeRequest {
+					enabledAt
+				}
+				latestOpinionatedReviews(first: $pageSize, writersOnly: true) {
+					pageInfo { hasNextPage endCursor }
+					nodes {
+						author { login }
+						state
+					}
+				}
+				reviewThreads(first: $pageSize) {
+					pageInfo { hasNextPage endCursor }
+					nodes {
+						isResolved
+					}
+				}
+				commits(last: 1) {
+					nodes {
+						commit {
+							statusCheckRollup {
+								state
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"owner":    c.Owner,
+		"repo":     c.Repo,
+		"prNumber": prNumber,
+		"pageSize": mergeStatePageSize,
+	}
+
+	responseData, err := c.RunGraphQLQueryWithVariables(query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository struct {
+				PullRequest *struct {
+					ID               string `json:"id"`
+					Number           int    `json:"number"`
+					State            string `json:"state"`
+					IsDraft          bool   `json:"isDraft"`
+					Mergeable        string `json:"mergeable"`
+					MergeStateStatus string `json:"mergeStateStatus"`
+					HeadRefOid       string `json:"headRefOid"`
+					AutoMergeRequest *struct {
+						EnabledAt string `json:"enabledAt"`
+					} `json:"autoMergeRequest"`
+					LatestOpinionatedReviews mergeStateConnection `json:"latestOpinionatedReviews"`
+					ReviewThreads            mergeStateConnection `json:"reviewThreads"`
+					Commits                  struct {
+						Nodes []struct {
+							Commit struct {
+								StatusCheckRollup *struct {
+									State string `json:"state"`
+								} `json:"statusCheckRollup"`
+							} `json:"commit"`
+						} `json:"nodes"`
+					} `json:"commits"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil, err
+	}
+
+	pr := response.Data.Repository.PullRequest
+	if pr == nil {
+		return nil, fmt.Errorf("PR #%d not found", prNumber)
+	}
+
+	pageFetcher := func(connection string) func(after string) (mergeStateConnection, error) {
+		return func(after string) (mergeStateConnection, error) {
+			return c.getMergeStateConnectionPage(prNumber, connection, after)
+		}
+	}
+	reviews, err := collectMergeStateNodes(pr.LatestOpinionatedReviews, pageFetcher("latestOpinionatedReviews"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reviews: %w", err)
+	}
+	threads, err := collectMergeStateNodes(pr.ReviewThreads, pageFetcher("reviewThreads"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review threads: %w", err)
+	}
+
+	state := &PRMergeState{
+		ID:               pr.ID,
+		Number:           pr.Number,
+		State:            pr.State,
+		IsDraft:          pr.IsDraft,
+		Mergeable:        pr.Mergeable,
+		MergeStateStatus: pr.MergeStateStatus,
+		HeadOID:          pr.HeadRefOid,
+		AutoMergeEnabled: pr.AutoMergeRequest != nil,
+	}
+	for _, review := range reviews {
+		login := ""
+		if review.Author != nil {
+			login = review.Author.Login
+		}
+		switch review.State {
+		case "APPROVED":
+			state.Approvals = append(state.Approvals, login)
+		case "CHANGES_REQUESTED":
+			state.ChangesRequested = append(state.ChangesRequested, login)
+		}
+	}
+	for _, thread := range threads {
+		if !thread.IsResolved {
+			state.UnresolvedThreads++
+		}
+	}
+	if len(pr.Commits.Nodes) > 0 && pr.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+		state.ChecksState = pr.Commits.Nodes[0].Commit.StatusCheckRollup.State
+	}
+
+	return state, nil
+}
+
+// MergePR merges a pull request, failing if the head moved past expectedHeadOID.
+// Returns the merge commit SHA.
+func (c *GitHubClient) MergePR(prID, mergeMethod, expectedHeadOID string) (string, error) {
+	mutation := `
+	mutation($input: MergePullRequestInput!) {
+		mergePullRequest(input: $input) {
+			pullRequest {
+				mergeCommit {
+					oid
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"pullRequestId":   prID,
+			"mergeMethod":     mergeMethod,
+			"expectedHeadOid": expectedHeadOID,
+		},
+	}
+
+	responseData, err := c.RunGraphQLQueryWithVariables(mutation, variables)
+	if err != nil {
+		return "", err
+	}
+
+	var response struct {
+		Data struct {
+			MergePullRequest struct {
+				PullRequest struct {
+					MergeCommit *struct {
+						OID string `json:"oid"`
+					} `json:"mergeCommit"`
+				} `json:"pullRequest"`
+			} `json:"mergePullRequest"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return "", err
+	}
+
+	if commit := response.Data.MergePullRequest.PullRequest.MergeCommit; commit != nil {
+		return commit.OID, nil
+	}
+	return "", nil
+}
+
+// EnablePRAutoMerge enables GitHub auto-merge for a pull request
+func (c *GitHubClient) EnablePRAutoMerge(prID, mergeMethod, expectedHeadOID string) error {
+	mutation := `
+	mutation($input: EnablePullRequestAutoMergeInput!) {
+		enablePullRequestAutoMerge(input: $input) {
+			pullRequest {
+				id
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"pullRequestId":   prID,
+			"mergeMethod":     mergeMethod,
+			"expectedHeadOid": expectedHeadOID,
+		},
+	}
+
+	_, err := c.RunGraphQLQueryWithVariables(mutation, variables)
+	return err
+}
+
+func prAutomerge(cmd *cobra.Command, args []string) error {
+	strategy, err := cmd.Flags().GetString("strategy")
+	if err != nil {
+		return fmt.Errorf("failed to get 'strategy' flag: %w", err)
+	}
+	require, err := cmd.Flags().GetString("require")
+	if err != nil {
+		return fmt.Errorf("failed to get 'require' flag: %w", err)
+	}
+	interval, err := cmd.Flags().GetDuration("interval")
+	if err != nil {
+		return fmt.Errorf("failed to get 'interval' flag: %w", err)
+	}
+	once, err := cmd.Flags().GetBool("once")
+	if err != nil {
+		return fmt.Errorf("failed to get 'once' flag: %w", err)
+	}
+	githubAutoMerge, err := cmd.Flags().GetBool("github-auto-merge")
+	if err != nil {
+		return fmt.Errorf("failed to get 'github-auto-merge' flag: %w", err)
+	}
+
+	mergeMethod, ok := mergeStrategies[strings.ToLower(strategy)]
+	if !ok {
+		return fmt.Errorf("invalid strategy %q (valid: merge, squash, rebase)", strategy)
+	}
+	gates, err := parseMergeGates(require)
+	if err != nil {
+		return err
+	}
+
+	client := newCommandClient(cmd)
+	prNumber, err := resolvePRNumberFromArgs(args, client)
+	if err != nil {
+		return err
+	}
+	prNumberInt, err := strconv.Atoi(prNumber)
+	if err != nil {
+		return fmt.Errorf("invalid PR number format: %w", err)
+	}
+
+	effectiveTimeout, timeoutDisplay, err := calculateEffectiveTimeout(cmd)
+	if err != nil {
+		return err
+	}
+
+	w := messageWriter(cmd)
+	if !once {
+		fmt.Fprintf(w, "🔄 Waiting for merge gates on PR #%s (%s, timeout: %s)...\n", prNumber, require, timeoutDisplay)
+	}
+
+	startTime := time.Now()
+	report := func(result string, gateResults []GateResult, extra map[string]interface{}) error {
+		automerge := map[string]interface{}{
+			"pr":       prNumberInt,
+			"result":   result,
+			"strategy": strings.ToLower(strategy),
+			"gates":    gateResults,
+			"elapsed":  time.Since(startTime).Truncate(time.Second).String(),
+		}
+		for k, v := range extra {
+			automerge[k] = v
+		}
+		return EncodeOutputWithCmd(cmd, map[string]interface{}{"automerge": automerge})
+	}
+
+	// step evaluates the gates on one poll and merges when they pass. It
+	// returns the final report when the command is done, nil to keep polling.
+	autoMergeRequested := false
+	var gateResults []GateResult
+	step := func(state *PRMergeState) func() error {
+		switch state.State {
+		case "MERGED":
+			result := "MERGED"
+			if autoMergeRequested {
+				result = "MERGED_BY_AUTO_MERGE"
+			}
+			return func() error { return report(result, gateResults, nil) }
+		case "CLOSED":
+			return func() error {
+				if err := report("CLOSED", gateResults, nil); err != nil {
+					return err
+				}
+				return fmt.Errorf("PR #%s was closed without merging", prNumber)
+			}
+		}
+
+		gateResults = evaluateMergeGates(gates, *state)
+
+		if allGatesPassed(gateResults, "") {
+			mergeCommit, err := client.MergePR(state.ID, mergeMethod, state.HeadOID)
+			if err != nil {
+				if once {
+					return func() error { return fmt.Errorf("failed to merge PR #%s: %w", prNumber, err) }
+				}
+				// The head may have moved or GitHub may not consider it mergeable yet; re-evaluate next round
+				fmt.Fprintf(w, "⚠️  Merge attempt failed, will retry: %v\n", err)
+				return nil
+			}
+			return func() error {
+				return report("MERGED", gateResults, map[string]interface{}{
+					"headSha":     state.HeadOID,
+					"mergeCommit": mergeCommit,
+				})
+			}
+		}
+		if githubAutoMerge && !autoMergeRequested && !state.AutoMergeEnabled && allGatesPassed(gateResults, "checks-green") {
+			if err := client.EnablePRAutoMerge(state.ID, mergeMethod, state.HeadOID); err != nil {
+				WarningMsg("Could not enable GitHub auto-merge, continuing to poll: %v", err).Print(cmd)
+			} else {
+				InfoMsg("Enabled GitHub auto-merge (%s); waiting for GitHub to merge", strings.ToLower(strategy)).Print(cmd)
+			}
+			// Only try once; polling continues either way to produce the final report
+			autoMergeRequested = true
+		}
+		return nil
+	}
+
+	if once {
+		state, err := client.GetPRMergeState(prNumberInt)
+		if err != nil {
+			return fmt.Errorf("failed to fetch PR state: %w", err)
+		}
+		if finish := step(state); finish != nil {
+			return finish()
+		}
+		return report("NOT_READY", gateResults, nil)
+	}
+
+	// Poll until a step finishes; failed polls are skipped and GitHub outages
+	// degrade polling like 'reviews wait'
+	var state *PRMergeState
+	fetcher := reviewwait.FetcherFunc(func(needs reviewwait.Requirements) (reviewwait.Poll, error) {
+		var err error
+		if state, err = client.GetPRMergeState(prNumberInt); err != nil {
+			return reviewwait.Poll{}, fmt.Errorf("failed to fetch PR state: %w", err)
+		}
+		return reviewwait.Poll{}, nil
+	})
+	var finish func() error
+	waiter := newPRWaiter(w, fetcher, untilTimeout{}, effectiveTimeout, interval)
+	waiter.OnPoll = func(reviewwait.Progress) error {
+		if finish = step(state); finish != nil {
+			return errAutomergeFinished
+		}
+		return nil
+	}
+	if _, err := waiter.Wait(); err != nil && !errors.Is(err, errAutomergeFinished) {
+		return err
+	}
+	printOutageSummary(w, &waiter.Outage)
+	if finish != nil {
+		return finish()
+	}
+
+	if err := report("TIMEOUT", gateResults, nil); err != nil {
+		return err
+	}
+	return fmt.Errorf("timed out after %v waiting for merge gates on PR #%s", effectiveTimeout, prNumber)
+}
+
+// errAutomergeFinished ends the automerge poll once a step produced the final report
+var errAutomergeFinished = errors.New("automerge finished")
@@ -0,0 +1,121 @@
+package main
+
+import "testing"
+
+func TestParseMergeGates(t *testing.T) {
+	gates, err := parseMergeGates("checks-green, approvals>=2,threads-resolved,no-changes-requested")
+	if err != nil {
+		t.Fatalf("parseMergeGates() error = %v", err)
+	}
+	want := []string{"checks-green", "approvals>=2", "threads-resolved", "no-changes-requested"}
+	if len(gates) != len(want) {
+		t.Fatalf("got %d gates, want %d", len(gates), len(want))
+	}
+	for i, gate := range gates {
+		if gate.String() != want[i] {
+			t.Errorf("gate[%d] = %q, want %q", i, gate.String(), want[i])
+		}
+	}
+
+	for _, spec := range []string{"approvals>=x", "checks-red", "approvals>=-1"} {
+		if _, err := parseMergeGates(spec); err == nil {
+			t.Errorf("parseMergeGates(%q) expected error", spec)
+		}
+	}
+}
+
+func TestEvaluateMergeGates(t *testing.T) {
+	gates, _ := parseMergeGates("checks-green,approvals>=1,threads-resolved")
+
+	tests := []struct {
+		name        string
+		state       PRMergeState
+		wantAll     bool
+		wantNoCheck bool
+	}{
+		{
+			name:        "ready",
+			state:       PRMergeState{Mergeable: "MERGEABLE", ChecksState: "SUCCESS", Approvals: []string{"bob"}},
+			wantAll:     true,
+			wantNoCheck: true,
+		},
+		{
+			name:        "checks pending",
+			state:       PRMergeState{Mergeable: "MERGEABLE", ChecksState: "PENDING", Approvals: []string{"bob"}},
+			wantAll:     false,
+			wantNoCheck: true,
+		},
+		{
+			name:        "unresolved thread",
+			state:       PRMergeState{Mergeable: "MERGEABLE", ChecksState: "SUCCESS", Approvals: []string{"bob"}, UnresolvedThreads: 1},
+			wantAll:     false,
+			wantNoCheck: false,
+		},
+		{
+			name:        "draft",
+			state:       PRMergeState{IsDraft: true, Mergeable: "MERGEABLE", ChecksState: "SUCCESS", Approvals: []string{"bob"}},
+			wantAll:     false,
+			wantNoCheck: false,
+		},
+		{
+			name:        "conflicting",
+			state:       PRMergeState{Mergeable: "CONFLICTING", ChecksState: "SUCCESS", Approvals: []string{"bob"}},
+			wantAll:     false,
+			wantNoCheck: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := evaluateMergeGates(gates, tt.state)
+			if got := allGatesPassed(results, ""); got != tt.wantAll {
+				t.Errorf("allGatesPassed() = %v, want %v (%+v)", got, tt.wantAll, results)
+			}
+			if got := allGatesPassed(results, "checks-green"); got != tt.wantNoCheck {
+				t.Errorf("allGatesPassed(ignore checks) = %v, want %v", got, tt.wantNoCheck)
+			}
+		})
+	}
+}
+
+func TestCollectMergeStateNodes(t *testing.T) {
+	page := func(resolved []bool, next string) mergeStateConnection {
+		var c mergeStateConnection
+		for _, r := range resolved {
+			c.Nodes = append(c.Nodes, mergeStateNode{IsResolved: r})
+		}
+		c.PageInfo.HasNextPage = next != ""
+		c.PageInfo.EndCursor = next
+		return c
+	}
+	pages := map[string]mergeStateConnection{
+		"p1": page([]bool{false}, "p2"),
+		"p2": page([]bool{true, false}, ""),
+	}
+
+	var cursors []string
+	nodes, err := collectMergeStateNodes(page([]bool{true, true}, "p1"), func(after string) (mergeStateConnection, error) {
+		cursors = append(cursors, after)
+		return pages[after], nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unresolved := 0
+	for _, node := range nodes {
+		if !node.IsResolved {
+			unresolved++
+		}
+	}
+	if len(nodes) != 5 || unresolved != 2 || len(cursors) != 2 {
+		t.Errorf("got %d nodes (%d unresolved) after cursors %v, want 5 (2 unresolved) after [p1 p2]", len(nodes), unresolved, cursors)
+	}
+
+	// A single page needs no further requests
+	if _, err := collectMergeStateNodes(page([]bool{false}, ""), func(string) (mergeStateConnection, error) {
+		t.Fatal("unexpected page request")
+		return mergeStateConnection{}, nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var checklistPRCmd = NewOperationalCommand(
+	"checklist [pr-number] [flags]",
+	"Show or toggle task-list items in a PR description",
+	`Parse Markdown task-list items ("- [ ] ..." / "- [x] ...") in the PR description
+and optionally check or uncheck them, keeping execution-plan checklists in sync
+with actual progress.
+
+Items are matched by exact text first, then by a unique case-insensitive
+substring. Items inside fenced code blocks are ignored.
+
+`+prNumberArgsHelp+`
+
+Examples:
+  # Show checklist status
+  gh-helper pr checklist 306
+
+  # Toggle items
+  gh-helper pr checklist 306 --check "Add tests" --uncheck "Update docs"
+
+  # Check several items at once
+  gh-helper pr checklist --check "Add tests" --check "Run linter"`,
+	prChecklist,
+)
+
+func init() {
+	checklistPRCmd.Args = cobra.MaximumNArgs(1)
+
+	checklistPRCmd.Flags().StringArray("check", []string{}, "Mark an item as done (repeatable)")
+	checklistPRCmd.Flags().StringArray("uncheck", []string{}, "Mark an item as not done (repeatable)")
+
+	prCmd.AddCommand(checklistPRCmd)
+}
+
+// ChecklistItem is a Markdown task-list item
+type ChecklistItem struct {
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+	line    int    // Line index in the body
+}
+
+// taskListPattern matches "- [ ] text", "* [x] text", "1. [X] text" with any indentation
+var taskListPattern = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)([ xX])(\]\s+)(.*)$`)
+
+// parseChecklist extracts task-list items from a Markdown body
+func parseChecklist(body string) []ChecklistItem {
+	var items []ChecklistItem
+	inFence := false
+	for i, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		matches := taskListPattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if matches == nil {
+			continue
+		}
+		items = append(items, ChecklistItem{
+			Text:    strings.TrimSpace(matches[4]),
+			Checked: matches[2] != " ",
+			line:    i,
+		})
+	}
+	return items
+}
+
+// findChecklistItem returns the index of the item matching text
+func findChecklistItem(items []ChecklistItem, text string) (int, error) {
+	text = strings.TrimSpace(text)
+	for i, item := range items {
+		if item.Text == text {
+			return i, nil
+		}
+	}
+
+	found := -1
+	lower := strings.ToLower(text)
+	for i, item := range items {
+		if strings.Contains(strings.ToLower(item.Text), lower) {
+			if found >= 0 {
+				return -1, fmt.Errorf("checklist item %q is ambiguous (matches %q and %q)", text, items[found].Text, item.Text)
+			}
+			found = i
+		}
+	}
+	if found < 0 {
+		return -1, fmt.Errorf("checklist item not found: %q", text)
+	}
+	return found, nil
+}
+
+// toggleChecklist applies check/uncheck requests to body, returning the new body,
+// the resulting items, and the texts of items whose state changed
+func toggleChecklist(body string, check, uncheck []string) (string, []ChecklistItem, []string, error) {
+	items := parseChecklist(body)
+	lines := strings.Split(body, "\n")
+
+	var changed []string
+	apply := func(texts []string, checked bool) error {
+		for _, text := range texts {
+			index, err := findChecklistItem(items, text)
+			if err != nil {
+				return err
+			}
+			if items[index].Checked == checked {
+				continue
+			}
+			mark := " "
+			if checked {
+				mark = "x"
+			}
+			line := items[index].line
+			lines[line] = taskListPattern.ReplaceAllString(lines[line], "${1}"+mark+"${3}${4}")
+			items[index].Checked = checked
+			changed = append(changed, items[index].Text)
+		}
+		return nil
+	}
+
+	if err := apply(check, true); err != nil {
+		return "", nil, nil, err
+	}
+	if err := apply(uncheck, false); err != nil {
+		return "", nil, nil, err
+	}
+
+	return strings.Join(lines, "\n"), items, changed, nil
+}
+
+func prChecklist(cmd *cobra.Command, args []string) error {
+	check, err := cmd.Flags().GetStringArray("check")
+	if err != nil {
+		return fmt.Errorf("failed to get 'check' flag: %w", err)
+	}
+	uncheck, err := cmd.Flags().GetStringArray("uncheck")
+	if err != nil {
+		return fmt.Errorf("failed to get 'uncheck' flag: %w", err)
+	}
+
+	client := newCommandClient(cmd)
+	prNumber, err := resolvePRNumberFromArgs(args, client)
+	if err != nil {
+		return err
+	}
+
+	pr, err := client.GetPRBody(prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch PR description: %w", err)
+	}
+
+	newBody, items, changed, err := toggleChecklist(pr.Body, check, uncheck)
+	if err != nil {
+		return err
+	}
+
+	if len(items) == 0 && (len(check) > 0 || len(uncheck) > 0) {
+		return fmt.Errorf("PR #%s description has no task-list items", prNumber)
+	}
+
+	if len(changed) > 0 {
+		if err := client.UpdatePRBody(pr.ID, newBody); err != nil {
+			return fmt.Errorf("failed to update PR description: %w", err)
+		}
+	}
+
+	checked := 0
+	for _, item := range items {
+		if item.Checked {
+			checked++
+		}
+	}
+
+	output := map[string]interface{}{
+		"checklist": map[string]interface{}{
+			"pr":       pr.Number,
+			"updated":  len(changed) > 0,
+			"changed":  changed,
+			"total":    len(items),
+			"checked":  checked,
+			"complete": len(items) > 0 && checked == len(items),
+			"items":    items,
+		},
+	}
+
+	return EncodeOutputWithCmd(cmd, output)
+}
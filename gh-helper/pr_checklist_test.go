@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToggleChecklist(t *testing.T) {
+	body := strings.Join([]string{
+		"## Plan",
+		"- [ ] Add tests",
+		"- [x] Update docs",
+		"  * [ ] Add tests for edge cases",
+		"```markdown",
+		"- [ ] Not a real item",
+		"```",
+		"1. [X] Run linter",
+	}, "\n")
+
+	t.Run("parse ignores fenced code", func(t *testing.T) {
+		items := parseChecklist(body)
+		if len(items) != 4 {
+			t.Fatalf("expected 4 items, got %d: %+v", len(items), items)
+		}
+		if items[3].Text != "Run linter" || !items[3].Checked {
+			t.Errorf("unexpected ordered-list item: %+v", items[3])
+		}
+	})
+
+	t.Run("check and uncheck", func(t *testing.T) {
+		newBody, items, changed, err := toggleChecklist(body, []string{"Add tests"}, []string{"update docs"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(newBody, "- [x] Add tests\n") || !strings.Contains(newBody, "- [ ] Update docs") {
+			t.Errorf("unexpected body:\n%s", newBody)
+		}
+		if !strings.Contains(newBody, "  * [ ] Add tests for edge cases") || !strings.Contains(newBody, "- [ ] Not a real item") {
+			t.Errorf("unrelated items were modified:\n%s", newBody)
+		}
+		if len(changed) != 2 {
+			t.Errorf("expected 2 changes, got %v", changed)
+		}
+		if !items[0].Checked || items[1].Checked {
+			t.Errorf("unexpected item states: %+v", items)
+		}
+	})
+
+	t.Run("already in requested state", func(t *testing.T) {
+		_, _, changed, err := toggleChecklist(body, []string{"Update docs"}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(changed) != 0 {
+			t.Errorf("expected no changes, got %v", changed)
+		}
+	})
+
+	t.Run("ambiguous substring", func(t *testing.T) {
+		_, _, _, err := toggleChecklist(body, []string{"tests"}, nil)
+		if err == nil || !strings.Contains(err.Error(), "ambiguous") {
+			t.Errorf("expected ambiguity error, got %v", err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, _, _, err := toggleChecklist(body, []string{"Deploy"}, nil)
+		if err == nil {
+			t.Error("expected not found error")
+		}
+	})
+}
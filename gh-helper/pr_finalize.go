@@ -0,0 +1,343 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var finalizePRCmd = NewOperationalCommand(
+	"finalize [pr-number] [flags]",
+	"Post-merge housekeeping for a merged PR and its linked issues",
+	`Run post-merge housekeeping for a merged PR in one step. For each issue the
+PR closes (closingIssuesReferences):
+
+  - Copy the PR's milestone to the issue when it has none (same repository only;
+    milestones are per repository)
+  - Add the --label label (default "released-in-next") when it exists in the
+    issue's repository
+  - Close the issue as completed if GitHub did not auto-close it (e.g. issues
+    in another repository, or PRs merged into a non-default branch)
+
+Then post a completion comment on the PR summarizing what was done.
+Running it again skips completed steps (the comment is posted on each run
+unless --no-comment).
+
+`+prNumberArgsHelp+`
+
+Examples:
+  # After merging
+  gh-helper pr finalize 306
+
+  # Preview, with a custom label and no PR comment
+  gh-helper pr finalize 306 --label "fixed-in-next" --no-comment --dry-run`,
+	prFinalize,
+)
+
+func init() {
+	finalizePRCmd.Args = cobra.MaximumNArgs(1)
+
+	finalizePRCmd.Flags().String("label", "released-in-next", "Label to add to linked issues (empty to skip)")
+	finalizePRCmd.Flags().Bool("no-comment", false, "Do not post a completion comment on the PR")
+	finalizePRCmd.Flags().Bool("dry-run", false, "Show planned actions without changing anything")
+
+	prCmd.AddCommand(finalizePRCmd)
+}
+
+// finalizeIssue is an issue closed by the PR
+type finalizeIssue struct {
+	ID         string `json:"id"`
+	Number     int    `json:"number"`
+	State      string `json:"state"`
+	Repository struct {
+		NameWithOwner string `json:"nameWithOwner"`
+		Label         *struct {
+			ID string `json:"id"`
+		} `json:"label"`
+	} `json:"repository"`
+	Milestone *struct {
+		Title string `json:"title"`
+	} `json:"milestone"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+}
+
+// finalizePR is the merged PR with its milestone and closing issues
+type finalizePR struct {
+	ID        string `json:"id"`
+	Number    int    `json:"number"`
+	State     string `json:"state"`
+	MergedAt  string `json:"mergedAt"`
+	Milestone *struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	} `json:"milestone"`
+	ClosingIssuesReferences struct {
+		Nodes []finalizeIssue `json:"nodes"`
+	} `json:"closingIssuesReferences"`
+}
+
+// FinalizeIssuePlan lists the housekeeping actions for one linked issue
+type FinalizeIssuePlan struct {
+	Issue        string   `json:"issue"` // "#N" or "owner/repo#N"
+	SetMilestone string   `json:"setMilestone,omitempty"`
+	AddLabel     string   `json:"addLabel,omitempty"`
+	Close        bool     `json:"close,omitempty"`
+	Skipped      []string `json:"skipped,omitempty"`
+	Errors       []string `json:"errors,omitempty"`
+	issue        finalizeIssue
+	labelID      string
+}
+
+// planFinalizeActions decides what to do for each linked issue of a merged PR
+func planFinalizeActions(pr finalizePR, repoNameWithOwner, label string) []FinalizeIssuePlan {
+	plans := []FinalizeIssuePlan{}
+	for _, issue := range pr.ClosingIssuesReferences.Nodes {
+		sameRepo := strings.EqualFold(issue.Repository.NameWithOwner, repoNameWithOwner)
+		plan := FinalizeIssuePlan{issue: issue, Issue: fmt.Sprintf("#%d", issue.Number)}
+		if !sameRepo {
+			plan.Issue = fmt.Sprintf("%s#%d", issue.Repository.NameWithOwner, issue.Number)
+		}
+
+		if pr.Milestone != nil && issue.Milestone == nil {
+			if sameRepo {
+				plan.SetMilestone = pr.Milestone.Title
+			} else {
+				plan.Skipped = append(plan.Skipped, "milestone: issue is in another repository")
+			}
+		}
+
+		if label != "" {
+			hasLabel := false
+			for _, existing := range issue.Labels.Nodes {
+				if strings.EqualFold(existing.Name, label) {
+					hasLabel = true
+					break
+				}
+			}
+			switch {
+			case hasLabel:
+			case issue.Repository.Label == nil:
+				plan.Skipped = append(plan.Skipped, fmt.Sprintf("label: %q does not exist in %s", label, issue.Repository.NameWithOwner))
+			default:
+				plan.AddLabel = label
+				plan.labelID = issue.Repository.Label.ID
+			}
+		}
+
+		plan.Close = issue.State == "OPEN"
+		plans = append(plans, plan)
+	}
+	return plans
+}
+
+// getFinalizePR fetches a PR with its milestone and closing issues, including
+// whether the label exists in each issue's repository
+func (c *GitHubClient) getFinalizePR(prNumber int, label string) (*finalizePR, string, error) {
+	query := `
+	query($owner: String!, $repo: String!, $number: Int!, $label: String!) {
+	  repository(owner: $owner, name: $repo) {
+	    nameWithOwner
+	    pullRequest(number: $number) {
+	      id
+	      number
+	      state
+	      mergedAt
+	      milestone { id title }
+	      closingIssuesReferences(first: 50) {
+	        nodes {
+	          id
+	          number
+	          state
+	          repository {
+	            nameWithOwner
+	            label(name: $label) { id }
+	          }
+	          milestone { title }
+	          labels(first: 50) { nodes { name } }
+	        }
+	      }
+	    }
+	  }
+	}`
+
+	variables := map[string]interface{}{
+		"owner":  c.Owner,
+		"repo":   c.Repo,
+		"number": prNumber,
+		"label":  label,
+	}
+
+	result, err := c.RunGraphQLQueryWithVariables(query, variables)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var response struct {
+		Data struct {
+			Repository struct {
+				NameWithOwner string      `json:"nameWithOwner"`
+				PullRequest   *finalizePR `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return nil, "", fmt.Errorf("failed to parse PR response: %w", err)
+	}
+	if response.Data.Repository.PullRequest == nil {
+		return nil, "", fmt.Errorf("PR #%d not found", prNumber)
+	}
+	return response.Data.Repository.PullRequest, response.Data.Repository.NameWithOwner, nil
+}
+
+// SetIssueMilestone sets an issue's milestone
+func (c *GitHubClient) SetIssueMilestone(issueID, milestoneID string) error {
+	mutation := `
+	mutation($issueId: ID!, $milestoneId: ID!) {
+	  updateIssue(input: {id: $issueId, milestoneId: $milestoneId}) {
+	    issue { id }
+	  }
+	}`
+	_, err := c.RunGraphQLQueryWithVariables(mutation, map[string]interface{}{
+		"issueId":     issueID,
+		"milestoneId": milestoneID,
+	})
+	return err
+}
+
+// CloseIssue closes an issue with the given state reason (COMPLETED or NOT_PLANNED)
+func (c *GitHubClient) CloseIssue(issueID, stateReason string) error {
+	mutation := `
+	mutation($issueId: ID!, $stateReason: IssueClosedStateReason) {
+	  closeIssue(input: {issueId: $issueId, stateReason: $stateReason}) {
+	    issue { id }
+	  }
+	}`
+	_, err := c.RunGraphQLQueryWithVariables(mutation, map[string]interface{}{
+		"issueId":     issueID,
+		"stateReason": stateReason,
+	})
+	return err
+}
+
+// finalizeComment renders the completion comment posted on the PR
+func finalizeComment(plans []FinalizeIssuePlan) string {
+	var b strings.Builder
+	b.WriteString("Post-merge housekeeping completed")
+	if len(plans) == 0 {
+		b.WriteString(" (no linked issues).")
+		return b.String()
+	}
+	b.WriteString(":\n\n")
+	for _, plan := range plans {
+		var actions []string
+		if plan.SetMilestone != "" {
+			actions = append(actions, fmt.Sprintf("milestone %s", plan.SetMilestone))
+		}
+		if plan.AddLabel != "" {
+			actions = append(actions, fmt.Sprintf("labeled `%s`", plan.AddLabel))
+		}
+		if plan.Close {
+			actions = append(actions, "closed")
+		}
+		if len(plan.Errors) > 0 {
+			actions = append(actions, fmt.Sprintf("%d error(s)", len(plan.Errors)))
+		}
+		if len(actions) == 0 {
+			actions = append(actions, "already up to date")
+		}
+		fmt.Fprintf(&b, "- %s: %s\n", plan.Issue, strings.Join(actions, ", "))
+	}
+	return b.String()
+}
+
+func prFinalize(cmd *cobra.Command, args []string) error {
+	label, err := cmd.Flags().GetString("label")
+	if err != nil {
+		return fmt.Errorf("failed to get 'label' flag: %w", err)
+	}
+	noComment, err := cmd.Flags().GetBool("no-comment")
+	if err != nil {
+		return fmt.Errorf("failed to get 'no-comment' flag: %w", err)
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get 'dry-run' flag: %w", err)
+	}
+
+	client := newCommandClient(cmd)
+	prNumber, err := resolvePRNumberFromArgs(args, client)
+	if err != nil {
+		return err
+	}
+	prNumberInt, err := strconv.Atoi(prNumber)
+	if err != nil {
+		return fmt.Errorf("invalid PR number format: %w", err)
+	}
+
+	pr, repoNameWithOwner, err := client.getFinalizePR(prNumberInt, label)
+	if err != nil {
+		return fmt.Errorf("failed to get PR #%s: %w", prNumber, err)
+	}
+	if pr.State != "MERGED" {
+		return fmt.Errorf("PR #%s is %s, not merged", prNumber, strings.ToLower(pr.State))
+	}
+
+	plans := planFinalizeActions(*pr, repoNameWithOwner, label)
+
+	failed := 0
+	if !dryRun {
+		for i := range plans {
+			plan := &plans[i]
+			if plan.SetMilestone != "" {
+				if err := client.SetIssueMilestone(plan.issue.ID, pr.Milestone.ID); err != nil {
+					plan.Errors = append(plan.Errors, fmt.Sprintf("milestone: %v", err))
+				}
+			}
+			if plan.labelID != "" {
+				if _, err := client.AddLabelsToItem(plan.issue.ID, []string{plan.labelID}); err != nil {
+					plan.Errors = append(plan.Errors, fmt.Sprintf("label: %v", err))
+				}
+			}
+			if plan.Close {
+				if err := client.CloseIssue(plan.issue.ID, "COMPLETED"); err != nil {
+					plan.Errors = append(plan.Errors, fmt.Sprintf("close: %v", err))
+				}
+			}
+			if len(plan.Errors) > 0 {
+				failed++
+			}
+		}
+	}
+
+	result := map[string]interface{}{
+		"pr":     pr.Number,
+		"issues": plans,
+	}
+	if pr.Milestone != nil {
+		result["milestone"] = pr.Milestone.Title
+	}
+	if dryRun {
+		result["dryRun"] = true
+	} else if !noComment {
+		if err := client.CreatePRComment(prNumber, finalizeComment(plans)); err != nil {
+			result["commentError"] = err.Error()
+		} else {
+			result["commented"] = true
+		}
+	}
+
+	if err := EncodeOutputWithCmd(cmd, map[string]interface{}{"finalize": result}); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("finalize failed for %d of %d linked issue(s)", failed, len(plans))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPlanFinalizeActions(t *testing.T) {
+	var pr finalizePR
+	prJSON := `{
+  "number": 306,
+  "state": "MERGED",
+  "milestone": {"id": "MI_1", "title": "v0.20.0"},
+  "closingIssuesReferences": {"nodes": [
+    {"id": "I_1", "number": 10, "state": "CLOSED",
+     "repository": {"nameWithOwner": "apstndb/spanner-mycli", "label": {"id": "LA_1"}},
+     "milestone": null, "labels": {"nodes": []}},
+    {"id": "I_2", "number": 11, "state": "OPEN",
+     "repository": {"nameWithOwner": "apstndb/other", "label": null},
+     "milestone": null, "labels": {"nodes": []}},
+    {"id": "I_3", "number": 12, "state": "CLOSED",
+     "repository": {"nameWithOwner": "apstndb/spanner-mycli", "label": {"id": "LA_1"}},
+     "milestone": {"title": "v0.19.0"}, "labels": {"nodes": [{"name": "Released-In-Next"}]}}
+  ]}
+}`
+	if err := json.Unmarshal([]byte(prJSON), &pr); err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+
+	plans := planFinalizeActions(pr, "apstndb/spanner-mycli", "released-in-next")
+	if len(plans) != 3 {
+		t.Fatalf("got %d plans, want 3", len(plans))
+	}
+
+	same := plans[0]
+	if same.Issue != "#10" || same.SetMilestone != "v0.20.0" || same.AddLabel != "released-in-next" || same.labelID != "LA_1" || same.Close {
+		t.Errorf("same-repo plan = %+v", same)
+	}
+
+	cross := plans[1]
+	if cross.Issue != "apstndb/other#11" || cross.SetMilestone != "" || cross.AddLabel != "" || !cross.Close || len(cross.Skipped) != 2 {
+		t.Errorf("cross-repo plan = %+v", cross)
+	}
+
+	done := plans[2]
+	if done.SetMilestone != "" || done.AddLabel != "" || done.Close || len(done.Skipped) != 0 {
+		t.Errorf("already finalized plan = %+v", done)
+	}
+
+	comment := finalizeComment(plans)
+	for _, want := range []string{
+		"- #10: milestone v0.20.0, labeled `released-in-next`",
+		"- apstndb/other#11: closed",
+		"- #12: already up to date",
+	} {
+		if !strings.Contains(comment, want) {
+			t.Errorf("finalizeComment() missing %q in:\n%s", want, comment)
+		}
+	}
+}
@@ -0,0 +1,419 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apstndb/gh-dev-tools/pkg/reviewwait"
+	"github.com/spf13/cobra"
+)
+
+var scanPRCmd = NewOperationalCommand(
+	"scan [PR] [flags]",
+	"Scan a PR diff for secrets, binaries, large files, and forbidden paths",
+	`Fetch the files changed by a PR and report findings before merge:
+
+  secret          Added lines matching common token formats (GitHub, AWS, Slack,
+                  Google, private keys, generic key/password assignments)
+  binary          Added or modified binary files
+  large-file      Added or modified files larger than scan.maxFileBytes (default 1 MiB)
+  forbidden-path  Changes to paths matching scan.forbiddenPaths
+
+Exits with code 5 when any finding exists, for CI gating. Matched secrets are
+redacted in the output.
+
+Rules are read from the "scan" section of the config file (see 'issues list
+--saved' for file locations):
+
+  scan:
+    maxFileBytes: 2097152
+    forbiddenPaths: [".github/workflows/**", "go.sum"]
+    ignorePaths: ["testdata/**"]          # skipped entirely
+    secretPatterns:                       # added to the built-in rules
+      internal-token: "itk_[0-9a-f]{32}"
+
+`+prNumberArgsHelp+`
+
+Examples:
+  # Scan the current branch's PR
+  gh-helper pr scan
+
+  # Gate a merge in CI
+  gh-helper pr scan 306 --jq '.prScan.findings[] | "\(.kind) \(.path):\(.line)"'`,
+	scanPR,
+)
+
+func init() {
+	scanPRCmd.Args = cobra.MaximumNArgs(1)
+
+	prCmd.AddCommand(scanPRCmd)
+}
+
+// defaultScanMaxFileBytes is the large-file threshold when the config sets none
+const defaultScanMaxFileBytes = 1 << 20
+
+// ScanConfig configures 'pr scan' rules
+type ScanConfig struct {
+	// MaxFileBytes is the size above which added or modified files are reported
+	MaxFileBytes int64 `yaml:"maxFileBytes"`
+	// ForbiddenPaths are path patterns that must not change ("dir/**" matches a subtree)
+	ForbiddenPaths []string `yaml:"forbiddenPaths"`
+	// IgnorePaths are path patterns excluded from all checks
+	IgnorePaths []string `yaml:"ignorePaths"`
+	// SecretPatterns are additional named secret regexes
+	SecretPatterns map[string]string `yaml:"secretPatterns"`
+}
+
+// merge overlays a higher-priority config file's scan rules
+func (s *ScanConfig) merge(other ScanConfig) {
+	if other.MaxFileBytes != 0 {
+		s.MaxFileBytes = other.MaxFileBytes
+	}
+	s.ForbiddenPaths = append(s.ForbiddenPaths, other.ForbiddenPaths...)
+	s.IgnorePaths = append(s.IgnorePaths, other.IgnorePaths...)
+	for name, pattern := range other.SecretPatterns {
+		if s.SecretPatterns == nil {
+			s.SecretPatterns = map[string]string{}
+		}
+		s.SecretPatterns[name] = pattern
+	}
+}
+
+// ScanFinding is a single problem found by 'pr scan'
+type ScanFinding struct {
+	Kind     string `json:"kind"` // secret, binary, large-file, forbidden-path
+	Path     string `json:"path"`
+	Line     int    `json:"line,omitempty"`
+	Rule     string `json:"rule,omitempty"`
+	Severity string `json:"severity"` // high or medium
+	Detail   string `json:"detail"`
+}
+
+// secretRule is a named secret pattern
+type secretRule struct {
+	Name     string
+	Pattern  *regexp.Regexp
+	Severity string
+}
+
+// builtinSecretRules are common token formats; the generic rule is noisier and rated medium
+var builtinSecretRules = []secretRule{
+	{"github-token", regexp.MustCompile(`\b(?:ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{36}\b`), "high"},
+	{"github-fine-grained-token", regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{82}\b`), "high"},
+	{"aws-access-key-id", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), "high"},
+	{"private-key", regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----`), "high"},
+	{"slack-token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`), "high"},
+	{"google-api-key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`), "high"},
+	{"generic-secret", regexp.MustCompile(`(?i)\b(?:api[_-]?key|secret|password|passwd|token)\b["']?\s*[:=]\s*["'][^"'\s]{12,}["']`), "medium"},
+}
+
+// compileSecretRules returns the built-in rules plus configured patterns, sorted by name
+func compileSecretRules(patterns map[string]string) ([]secretRule, error) {
+	rules := append([]secretRule{}, builtinSecretRules...)
+	names := make([]string, 0, len(patterns))
+	for name := range patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pattern, err := regexp.Compile(patterns[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid scan.secretPatterns.%s: %w", name, err)
+		}
+		rules = append(rules, secretRule{Name: name, Pattern: pattern, Severity: "high"})
+	}
+	return rules, nil
+}
+
+// matchScanPath reports whether a file path matches a pattern. "dir/**" matches
+// everything under dir; patterns without a slash also match the base name.
+func matchScanPath(pattern, file string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+		return file == prefix || strings.HasPrefix(file, prefix+"/")
+	}
+	if matched, _ := path.Match(pattern, file); matched {
+		return true
+	}
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(file))
+		return matched
+	}
+	return false
+}
+
+// matchAnyScanPath returns the first pattern matching the file, or ""
+func matchAnyScanPath(patterns []string, file string) string {
+	for _, pattern := range patterns {
+		if matchScanPath(pattern, file) {
+			return pattern
+		}
+	}
+	return ""
+}
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// redactSecret keeps a short prefix of a matched secret
+func redactSecret(match string) string {
+	if len(match) <= 8 {
+		return strings.Repeat("*", len(match))
+	}
+	return match[:4] + "…" + strings.Repeat("*", 4)
+}
+
+// scanPatch reports secrets on added lines of a unified diff patch, with
+// line numbers in the new file
+func scanPatch(file, patch string, rules []secretRule) []ScanFinding {
+	var findings []ScanFinding
+	line := 0
+	for _, text := range strings.Split(patch, "\n") {
+		if match := hunkHeaderPattern.FindStringSubmatch(text); match != nil {
+			line, _ = strconv.Atoi(match[1])
+			continue
+		}
+		switch {
+		case strings.HasPrefix(text, "+"):
+			for _, rule := range rules {
+				if secret := rule.Pattern.FindString(text[1:]); secret != "" {
+					findings = append(findings, ScanFinding{
+						Kind:     "secret",
+						Path:     file,
+						Line:     line,
+						Rule:     rule.Name,
+						Severity: rule.Severity,
+						Detail:   fmt.Sprintf("possible %s: %s", rule.Name, redactSecret(secret)),
+					})
+				}
+			}
+			line++
+		case strings.HasPrefix(text, "-"), strings.HasPrefix(text, `\`):
+			// Removed lines and "\ No newline at end of file" don't advance the new file
+		default:
+			line++
+		}
+	}
+	return findings
+}
+
+// scanPRFile is a changed file from the pulls/{n}/files REST endpoint
+type scanPRFile struct {
+	Filename string `json:"filename"`
+	Status   string `json:"status"`
+	Patch    string `json:"patch"`
+}
+
+// scanBlob is size information for a file at the PR head
+type scanBlob struct {
+	ByteSize int64 `json:"byteSize"`
+	IsBinary bool  `json:"isBinary"`
+}
+
+// listScanPRFiles lists all files changed by a PR (GitHub returns at most 3000)
+func (c *GitHubClient) listScanPRFiles(prNumber string) ([]scanPRFile, error) {
+	var files []scanPRFile
+	for page := 1; ; page++ {
+		result, err := c.RunRESTRequest("GET", fmt.Sprintf("/repos/%s/%s/pulls/%s/files?per_page=100&page=%d", c.Owner, c.Repo, prNumber, page), nil)
+		if err != nil {
+			return nil, err
+		}
+		var pageFiles []scanPRFile
+		if err := json.Unmarshal(result, &pageFiles); err != nil {
+			return nil, fmt.Errorf("failed to parse PR files response: %w", err)
+		}
+		files = append(files, pageFiles...)
+		if len(pageFiles) < 100 {
+			return files, nil
+		}
+	}
+}
+
+// getScanBlobs returns size information for the given paths at the PR head, keyed by path
+func (c *GitHubClient) getScanBlobs(prNumber string, paths []string) (string, map[string]scanBlob, error) {
+	number, err := strconv.Atoi(prNumber)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid PR number: %s", prNumber)
+	}
+
+	headQuery := `
+	query($owner: String!, $repo: String!, $number: Int!) {
+	  repository(owner: $owner, name: $repo) {
+	    pullRequest(number: $number) { headRefOid }
+	  }
+	}`
+	result, err := c.RunGraphQLQueryWithVariables(headQuery, map[string]interface{}{
+		"owner":  c.Owner,
+		"repo":   c.Repo,
+		"number": number,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	var headResponse struct {
+		Data struct {
+			Repository struct {
+				PullRequest *struct {
+					HeadRefOid string `json:"headRefOid"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(result, &headResponse); err != nil {
+		return "", nil, fmt.Errorf("failed to parse PR response: %w", err)
+	}
+	if headResponse.Data.Repository.PullRequest == nil {
+		return "", nil, fmt.Errorf("PR #%s not found", prNumber)
+	}
+	head := headResponse.Data.Repository.PullRequest.HeadRefOid
+
+	blobs := make(map[string]scanBlob, len(paths))
+	const batchSize = 50
+	for start := 0; start < len(paths); start += batchSize {
+		batch := paths[start:min(start+batchSize, len(paths))]
+
+		var queryBuilder strings.Builder
+		queryBuilder.WriteString("query($owner: String!, $repo: String!) {\n  repository(owner: $owner, name: $repo) {\n")
+		for i, file := range batch {
+			fmt.Fprintf(&queryBuilder, "    f%d: object(expression: %s) { ... on Blob { byteSize isBinary } }\n", i, strconv.Quote(head+":"+file))
+		}
+		queryBuilder.WriteString("  }\n}")
+
+		result, err := c.RunGraphQLQueryWithVariables(queryBuilder.String(), map[string]interface{}{
+			"owner": c.Owner,
+			"repo":  c.Repo,
+		})
+		if err != nil {
+			return "", nil, err
+		}
+		var response struct {
+			Data struct {
+				Repository map[string]*scanBlob `json:"repository"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(result, &response); err != nil {
+			return "", nil, fmt.Errorf("failed to parse blob response: %w", err)
+		}
+		for i, file := range batch {
+			if blob := response.Data.Repository[fmt.Sprintf("f%d", i)]; blob != nil {
+				blobs[file] = *blob
+			}
+		}
+	}
+	return head, blobs, nil
+}
+
+// scanPRFiles applies all rules to the changed files
+func scanPRFiles(files []scanPRFile, blobs map[string]scanBlob, config ScanConfig, rules []secretRule) []ScanFinding {
+	maxFileBytes := config.MaxFileBytes
+	if maxFileBytes <= 0 {
+		maxFileBytes = defaultScanMaxFileBytes
+	}
+
+	findings := []ScanFinding{}
+	for _, file := range files {
+		if matchAnyScanPath(config.IgnorePaths, file.Filename) != "" {
+			continue
+		}
+
+		if pattern := matchAnyScanPath(config.ForbiddenPaths, file.Filename); pattern != "" {
+			findings = append(findings, ScanFinding{
+				Kind:     "forbidden-path",
+				Path:     file.Filename,
+				Rule:     pattern,
+				Severity: "high",
+				Detail:   fmt.Sprintf("%s file matches forbidden path %q", file.Status, pattern),
+			})
+		}
+
+		if file.Status == "removed" {
+			continue
+		}
+		findings = append(findings, scanPatch(file.Filename, file.Patch, rules)...)
+
+		blob, ok := blobs[file.Filename]
+		if !ok {
+			continue
+		}
+		if blob.IsBinary {
+			findings = append(findings, ScanFinding{
+				Kind:     "binary",
+				Path:     file.Filename,
+				Severity: "medium",
+				Detail:   fmt.Sprintf("%s binary file (%d bytes)", file.Status, blob.ByteSize),
+			})
+		}
+		if blob.ByteSize > maxFileBytes {
+			findings = append(findings, ScanFinding{
+				Kind:     "large-file",
+				Path:     file.Filename,
+				Severity: "medium",
+				Detail:   fmt.Sprintf("%d bytes exceeds %d", blob.ByteSize, maxFileBytes),
+			})
+		}
+	}
+	return findings
+}
+
+func scanPR(cmd *cobra.Command, args []string) error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	rules, err := compileSecretRules(config.Scan.SecretPatterns)
+	if err != nil {
+		return err
+	}
+
+	client := newCommandClient(cmd)
+	prNumber, err := resolvePRNumberFromArgs(args, client)
+	if err != nil {
+		return err
+	}
+
+	files, err := client.listScanPRFiles(prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to list PR files: %w", err)
+	}
+
+	var paths []string
+	for _, file := range files {
+		if file.Status != "removed" && matchAnyScanPath(config.Scan.IgnorePaths, file.Filename) == "" {
+			paths = append(paths, file.Filename)
+		}
+	}
+	head, blobs, err := client.getScanBlobs(prNumber, paths)
+	if err != nil {
+		return fmt.Errorf("failed to fetch file sizes: %w", err)
+	}
+
+	findings := scanPRFiles(files, blobs, config.Scan, rules)
+	byKind := map[string]int{}
+	for _, finding := range findings {
+		byKind[finding.Kind]++
+	}
+
+	output := map[string]interface{}{
+		"prScan": map[string]interface{}{
+			"pr":           prNumber,
+			"head":         reviewwait.ShortSHA(head),
+			"filesScanned": len(files),
+			"findings":     findings,
+			"byKind":       byKind,
+		},
+	}
+	if err := EncodeOutputWithCmd(cmd, output); err != nil {
+		return err
+	}
+
+	if len(findings) > 0 {
+		return &ExitError{
+			Code: ExitCodeScanFindings,
+			Err:  fmt.Errorf("%d scan finding(s) in PR #%s", len(findings), prNumber),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var startPRCmd = NewOperationalCommand(
+	"start <issue-number> [flags]",
+	"Create a branch and draft PR scaffold for an issue",
+	`Start work on an issue in one step:
+
+  1. Create a branch named from the issue (--branch-pattern) off --base
+  2. Add an empty "Start work on #N" commit so the PR can be opened
+  3. Push the branch to --remote with upstream tracking
+  4. Open a draft PR whose body contains "Closes #N"
+  5. Copy the issue's labels to the PR
+
+Branch pattern placeholders:
+  {number}  Issue number
+  {slug}    Lowercased issue title, non-alphanumerics replaced by "-" (max 40 chars)
+
+The pattern defaults to $GH_HELPER_BRANCH_PATTERN, or "issue-{number}-{slug}".
+
+Examples:
+  # Branch issue-123-fix-timeout-handling, draft PR against the default branch
+  gh-helper pr start 123
+
+  # Custom branch name and base
+  gh-helper pr start 123 --branch-pattern "feature/{number}" --base release/v0.20
+
+  # Show what would be created
+  gh-helper pr start 123 --dry-run
+
+  # PR number only, for scripts
+  gh-helper pr start 123 --jq .prStart.pr`,
+	prStart,
+)
+
+func init() {
+	startPRCmd.Args = cobra.ExactArgs(1)
+
+	startPRCmd.Flags().String("branch-pattern", "", "Branch name pattern (default: $GH_HELPER_BRANCH_PATTERN or issue-{number}-{slug})")
+	startPRCmd.Flags().String("base", "", "Base branch (default: repository default branch)")
+	startPRCmd.Flags().String("remote", "origin", "Git remote to push the branch to")
+	startPRCmd.Flags().String("title", "", "PR title (default: issue title)")
+	startPRCmd.Flags().Bool("dry-run", false, "Show the branch and PR that would be created without changing anything")
+
+	prCmd.AddCommand(startPRCmd)
+}
+
+// defaultBranchPattern is used when neither --branch-pattern nor GH_HELPER_BRANCH_PATTERN is set
+const defaultBranchPattern = "issue-{number}-{slug}"
+
+// maxBranchSlugLength keeps generated branch names readable
+const maxBranchSlugLength = 40
+
+var branchSlugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// branchSlug converts an issue title to a branch-name-safe slug
+func branchSlug(title string) string {
+	slug := strings.Trim(branchSlugPattern.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if len(slug) > maxBranchSlugLength {
+		slug = strings.TrimRight(slug[:maxBranchSlugLength], "-")
+	}
+	return slug
+}
+
+// renderBranchName expands {number} and {slug} in a branch pattern
+func renderBranchName(pattern string, number int, title string) (string, error) {
+	name := strings.NewReplacer(
+		"{number}", strconv.Itoa(number),
+		"{slug}", branchSlug(title),
+	).Replace(pattern)
+	name = strings.Trim(strings.ReplaceAll(name, "--", "-"), "-/")
+	if name == "" || strings.ContainsAny(name, " ~^:?*[\\") || strings.Contains(name, "..") {
+		return "", fmt.Errorf("invalid branch name %q from pattern %q", name, pattern)
+	}
+	return name, nil
+}
+
+// startIssueInfo is the issue and repository data needed to scaffold a PR
+type startIssueInfo struct {
+	Title         string
+	State         string
+	LabelIDs      []string
+	LabelNames    []string
+	DefaultBranch string
+}
+
+// getStartIssueInfo fetches the issue title, state, labels, and the repository default branch
+func (c *GitHubClient) getStartIssueInfo(number int) (*startIssueInfo, error) {
+	query := `
+	query($owner: String!, $repo: String!, $number: Int!) {
+	  repository(owner: $owner, name: $repo) {
+	    defaultBranchRef { name }
+	    issue(number: $number) {
+	      title
+	      state
+	      labels(first: 50) {
+	        nodes { id name }
+	      }
+	    }
+	  }
+	}`
+
+	variables := map[string]interface{}{
+		"owner":  c.Owner,
+		"repo":   c.Repo,
+		"number": number,
+	}
+
+	result, err := c.RunGraphQLQueryWithVariables(query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository struct {
+				DefaultBranchRef struct {
+					Name string `json:"name"`
+				} `json:"defaultBranchRef"`
+				Issue *struct {
+					Title  string `json:"title"`
+					State  string `json:"state"`
+					Labels struct {
+						Nodes []struct {
+							ID   string `json:"id"`
+							Name string `json:"name"`
+						} `json:"nodes"`
+					} `json:"labels"`
+				} `json:"issue"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := Unmarshal(result, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse issue response: %w", err)
+	}
+
+	issue := response.Data.Repository.Issue
+	if issue == nil {
+		return nil, fmt.Errorf("issue #%d not found", number)
+	}
+
+	info := &startIssueInfo{
+		Title:         issue.Title,
+		State:         issue.State,
+		DefaultBranch: response.Data.Repository.DefaultBranchRef.Name,
+	}
+	for _, label := range issue.Labels.Nodes {
+		info.LabelIDs = append(info.LabelIDs, label.ID)
+		info.LabelNames = append(info.LabelNames, label.Name)
+	}
+	return info, nil
+}
+
+// runGit runs a git command, including its stderr in the error
+func runGit(args ...string) error {
+	gitCmd := exec.Command("git", args...)
+	output, err := gitCmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func prStart(cmd *cobra.Command, args []string) error {
+	issueNumber, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+	if err != nil {
+		return fmt.Errorf("invalid issue number: %s", args[0])
+	}
+
+	pattern, err := cmd.Flags().GetString("branch-pattern")
+	if err != nil {
+		return fmt.Errorf("failed to get 'branch-pattern' flag: %w", err)
+	}
+	base, err := cmd.Flags().GetString("base")
+	if err != nil {
+		return fmt.Errorf("failed to get 'base' flag: %w", err)
+	}
+	remote, err := cmd.Flags().GetString("remote")
+	if err != nil {
+		return fmt.Errorf("failed to get 'remote' flag: %w", err)
+	}
+	title, err := cmd.Flags().GetString("title")
+	if err != nil {
+		return fmt.Errorf("failed to get 'title' flag: %w", err)
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get 'dry-run' flag: %w", err)
+	}
+
+	if pattern == "" {
+		pattern = os.Getenv("GH_HELPER_BRANCH_PATTERN")
+	}
+	if pattern == "" {
+		pattern = defaultBranchPattern
+	}
+
+	client := newCommandClient(cmd)
+	issue, err := client.getStartIssueInfo(issueNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get issue #%d: %w", issueNumber, err)
+	}
+	if issue.State != "OPEN" {
+		return fmt.Errorf("issue #%d is %s", issueNumber, strings.ToLower(issue.State))
+	}
+
+	branch, err := renderBranchName(pattern, issueNumber, issue.Title)
+	if err != nil {
+		return err
+	}
+	if base == "" {
+		base = issue.DefaultBranch
+	}
+	if title == "" {
+		title = issue.Title
+	}
+	body := fmt.Sprintf("Closes #%d", issueNumber)
+
+	result := map[string]interface{}{
+		"issue":  issueNumber,
+		"branch": branch,
+		"base":   base,
+		"title":  title,
+		"labels": issue.LabelNames,
+	}
+
+	if dryRun {
+		result["dryRun"] = true
+		return EncodeOutputWithCmd(cmd, map[string]interface{}{"prStart": result})
+	}
+
+	if !IsGitRepository() {
+		return fmt.Errorf("pr start must be run inside a git repository")
+	}
+
+	// Branch from the remote base so local state does not leak into the PR
+	if err := runGit("fetch", remote, base); err != nil {
+		return err
+	}
+	if err := runGit("switch", "-c", branch, remote+"/"+base); err != nil {
+		return err
+	}
+	// GitHub refuses to open a PR without commits between base and head
+	if err := runGit("commit", "--allow-empty", "-m", fmt.Sprintf("Start work on #%d: %s", issueNumber, issue.Title)); err != nil {
+		return err
+	}
+	if err := runGit("push", "-u", remote, branch); err != nil {
+		return err
+	}
+
+	pr, err := client.CreatePR(PRCreateOptions{
+		Title: title,
+		Body:  body,
+		Head:  branch,
+		Base:  base,
+		Draft: true,
+	})
+	if err != nil {
+		return fmt.Errorf("branch %s was pushed but the PR could not be created: %w", branch, err)
+	}
+	result["pr"] = pr.Number
+	result["draft"] = true
+
+	if len(issue.LabelIDs) > 0 {
+		if _, err := client.AddLabelsToItem(pr.ID, issue.LabelIDs); err != nil {
+			return fmt.Errorf("PR #%d was created but labels could not be added: %w", pr.Number, err)
+		}
+	}
+
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"prStart": result})
+}
@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestRenderBranchName(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		title   string
+		want    string
+		wantErr bool
+	}{
+		{"default pattern", defaultBranchPattern, "Fix timeout handling in `reviews wait`", "issue-123-fix-timeout-handling-in-reviews-wait", false},
+		{"number only", "feature/{number}", "Anything", "feature/123", false},
+		{"long title truncated", "{number}-{slug}", "This is a very long issue title that keeps going and going", "123-this-is-a-very-long-issue-title-that-kee", false},
+		{"non-ASCII title", defaultBranchPattern, "日本語のタイトル", "issue-123", false},
+		{"invalid characters", "wip:{number}", "x", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderBranchName(tt.pattern, 123, tt.title)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("renderBranchName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("renderBranchName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
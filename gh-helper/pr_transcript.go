@@ -0,0 +1,303 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var transcriptPRCmd = NewOperationalCommand(
+	"transcript [pr-number] [flags]",
+	"Export the PR discussion as a chronological transcript",
+	`Merge the PR description, reviews, review thread comments, and conversation
+comments into one chronological transcript with authorship and timestamps,
+for feeding an LLM or archiving discussion history.
+
+Formats:
+  --format markdown  Readable transcript
+  --format jsonl     One JSON object per entry
+  --format yaml/json Structured output (default: yaml)
+
+Up to 100 reviews, threads (100 comments each), and conversation comments are included.
+
+`+prNumberArgsHelp+`
+
+Examples:
+  # Markdown transcript to a file
+  gh-helper pr transcript 306 --format markdown > pr-306.md
+
+  # JSONL for processing
+  gh-helper pr transcript 306 --format jsonl | jq -r 'select(.kind == "review") | .author'
+
+  # Without bot comments
+  gh-helper pr transcript 306 --format markdown --exclude-authors github-actions`,
+	prTranscript,
+)
+
+func init() {
+	addOutputFormats(transcriptPRCmd, FormatMarkdown, FormatJSONL)
+	transcriptPRCmd.Args = cobra.MaximumNArgs(1)
+
+	transcriptPRCmd.Flags().StringSlice("exclude-authors", []string{}, "Omit entries by these authors (e.g., bots)")
+
+	prCmd.AddCommand(transcriptPRCmd)
+}
+
+// TranscriptEntry is one message in a PR transcript
+type TranscriptEntry struct {
+	Kind      string `json:"kind"` // description, review, review-comment, comment
+	Author    string `json:"author"`
+	CreatedAt string `json:"createdAt"`
+	Body      string `json:"body"`
+	State     string `json:"state,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	ThreadID  string `json:"threadId,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+// transcriptPR is the raw discussion data of a PR
+type transcriptPR struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	URL       string    `json:"url"`
+	CreatedAt string    `json:"createdAt"`
+	Author    *actorRef `json:"author"`
+	Reviews   struct {
+		Nodes []struct {
+			Author      *actorRef `json:"author"`
+			State       string    `json:"state"`
+			Body        string    `json:"body"`
+			SubmittedAt string    `json:"submittedAt"`
+			URL         string    `json:"url"`
+		} `json:"nodes"`
+	} `json:"reviews"`
+	ReviewThreads struct {
+		Nodes []struct {
+			ID       string `json:"id"`
+			Path     string `json:"path"`
+			Line     int    `json:"line"`
+			Comments struct {
+				Nodes []struct {
+					Author    *actorRef `json:"author"`
+					Body      string    `json:"body"`
+					CreatedAt string    `json:"createdAt"`
+					URL       string    `json:"url"`
+				} `json:"nodes"`
+			} `json:"comments"`
+		} `json:"nodes"`
+	} `json:"reviewThreads"`
+	Comments struct {
+		Nodes []struct {
+			Author    *actorRef `json:"author"`
+			Body      string    `json:"body"`
+			CreatedAt string    `json:"createdAt"`
+			URL       string    `json:"url"`
+		} `json:"nodes"`
+	} `json:"comments"`
+}
+
+func (c *GitHubClient) getTranscriptPR(prNumber int) (*transcriptPR, error) {
+	query := `
+	query($owner: String!, $repo: String!, $number: Int!) {
+	  repository(owner: $owner, name: $repo) {
+	    pullRequest(number: $number) {
+	      number
+	      title
+	      body
+	      url
+	      createdAt
+	      author { login }
+	      reviews(first: 100) {
+	        nodes { author { login } state body submittedAt url }
+	      }
+	      reviewThreads(first: 100) {
+	        nodes {
+	          id
+	          path
+	          line
+	          comments(first: 100) {
+	            nodes { author { login } body createdAt url }
+	          }
+	        }
+	      }
+	      comments(first: 100) {
+	        nodes { author { login } body createdAt url }
+	      }
+	    }
+	  }
+	}`
+
+	variables := map[string]interface{}{
+		"owner":  c.Owner,
+		"repo":   c.Repo,
+		"number": prNumber,
+	}
+
+	result, err := c.RunGraphQLQueryWithVariables(query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository struct {
+				PullRequest *transcriptPR `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse PR response: %w", err)
+	}
+	if response.Data.Repository.PullRequest == nil {
+		return nil, fmt.Errorf("PR #%d not found", prNumber)
+	}
+	return response.Data.Repository.PullRequest, nil
+}
+
+// buildTranscript merges all PR discussion into chronological entries
+func buildTranscript(pr *transcriptPR, excludeAuthors []string) []TranscriptEntry {
+	excluded := make(map[string]bool, len(excludeAuthors))
+	for _, author := range excludeAuthors {
+		excluded[strings.ToLower(author)] = true
+	}
+
+	entries := []TranscriptEntry{}
+	add := func(entry TranscriptEntry) {
+		if entry.Author == "" {
+			entry.Author = "ghost" // deleted account, as GitHub displays it
+		}
+		if !excluded[strings.ToLower(entry.Author)] {
+			entries = append(entries, entry)
+		}
+	}
+
+	add(TranscriptEntry{Kind: "description", Author: pr.Author.login(), CreatedAt: pr.CreatedAt, Body: pr.Body, URL: pr.URL})
+	for _, review := range pr.Reviews.Nodes {
+		// A COMMENTED review without a body only wraps thread comments, which appear on their own
+		if review.State == "COMMENTED" && strings.TrimSpace(review.Body) == "" {
+			continue
+		}
+		add(TranscriptEntry{Kind: "review", Author: review.Author.login(), CreatedAt: review.SubmittedAt, Body: review.Body, State: review.State, URL: review.URL})
+	}
+	for _, thread := range pr.ReviewThreads.Nodes {
+		for _, comment := range thread.Comments.Nodes {
+			add(TranscriptEntry{
+				Kind:      "review-comment",
+				Author:    comment.Author.login(),
+				CreatedAt: comment.CreatedAt,
+				Body:      comment.Body,
+				Path:      thread.Path,
+				Line:      thread.Line,
+				ThreadID:  thread.ID,
+				URL:       comment.URL,
+			})
+		}
+	}
+	for _, comment := range pr.Comments.Nodes {
+		add(TranscriptEntry{Kind: "comment", Author: comment.Author.login(), CreatedAt: comment.CreatedAt, Body: comment.Body, URL: comment.URL})
+	}
+
+	// RFC 3339 UTC timestamps from the API sort lexically; keep the description first on ties
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt < entries[j].CreatedAt
+	})
+	return entries
+}
+
+// writeTranscriptMarkdown renders a transcript as Markdown
+func writeTranscriptMarkdown(w io.Writer, pr *transcriptPR, entries []TranscriptEntry) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# PR #%d: %s\n\n%s\n", pr.Number, pr.Title, pr.URL)
+
+	for _, entry := range entries {
+		var heading string
+		switch entry.Kind {
+		case "description":
+			heading = fmt.Sprintf("@%s opened the pull request", entry.Author)
+		case "review":
+			heading = fmt.Sprintf("@%s reviewed (%s)", entry.Author, strings.ToLower(strings.ReplaceAll(entry.State, "_", " ")))
+		case "review-comment":
+			location := entry.Path
+			if entry.Line > 0 {
+				location = fmt.Sprintf("%s:%d", entry.Path, entry.Line)
+			}
+			heading = fmt.Sprintf("@%s commented on `%s`", entry.Author, location)
+		default:
+			heading = fmt.Sprintf("@%s commented", entry.Author)
+		}
+
+		timestamp := entry.CreatedAt
+		if t, err := time.Parse(time.RFC3339, entry.CreatedAt); err == nil {
+			timestamp = t.UTC().Format("2006-01-02 15:04 UTC")
+		}
+		fmt.Fprintf(&b, "\n## %s — %s\n\n", heading, timestamp)
+
+		body := strings.TrimSpace(entry.Body)
+		if body == "" {
+			body = "_(no text)_"
+		}
+		b.WriteString(body)
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeTranscriptJSONL writes one JSON object per entry
+func writeTranscriptJSONL(w io.Writer, entries []TranscriptEntry) error {
+	encoder := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func prTranscript(cmd *cobra.Command, args []string) error {
+	excludeAuthors, err := cmd.Flags().GetStringSlice("exclude-authors")
+	if err != nil {
+		return fmt.Errorf("failed to get 'exclude-authors' flag: %w", err)
+	}
+
+	client := newCommandClient(cmd)
+	prNumber, err := resolvePRNumberFromArgs(args, client)
+	if err != nil {
+		return err
+	}
+	prNumberInt, err := strconv.Atoi(prNumber)
+	if err != nil {
+		return fmt.Errorf("invalid PR number format: %w", err)
+	}
+
+	pr, err := client.getTranscriptPR(prNumberInt)
+	if err != nil {
+		return fmt.Errorf("failed to get PR #%s: %w", prNumber, err)
+	}
+	entries := buildTranscript(pr, excludeAuthors)
+
+	switch ResolveFormat(cmd) {
+	case FormatMarkdown:
+		return writeTranscriptMarkdown(cmd.OutOrStdout(), pr, entries)
+	case FormatJSONL:
+		return writeTranscriptJSONL(cmd.OutOrStdout(), entries)
+	default:
+		return EncodeOutputWithCmd(cmd, map[string]interface{}{
+			"transcript": map[string]interface{}{
+				"pr":      pr.Number,
+				"title":   pr.Title,
+				"url":     pr.URL,
+				"entries": entries,
+			},
+		})
+	}
+}
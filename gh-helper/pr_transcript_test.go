@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestBuildTranscript(t *testing.T) {
+	var pr transcriptPR
+	prJSON := `{
+  "number": 306,
+  "title": "Add feature",
+  "body": "Implements the feature.",
+  "url": "https://github.com/o/r/pull/306",
+  "createdAt": "2025-06-01T10:00:00Z",
+  "author": {"login": "alice"},
+  "reviews": {"nodes": [
+    {"author": {"login": "bob"}, "state": "COMMENTED", "body": "", "submittedAt": "2025-06-01T11:00:00Z"},
+    {"author": {"login": "bob"}, "state": "CHANGES_REQUESTED", "body": "Please fix.", "submittedAt": "2025-06-01T12:00:00Z"}
+  ]},
+  "reviewThreads": {"nodes": [
+    {"id": "PRRT_1", "path": "main.go", "line": 42, "comments": {"nodes": [
+      {"author": {"login": "bob"}, "body": "Typo here", "createdAt": "2025-06-01T11:00:00Z"},
+      {"author": {"login": "alice"}, "body": "Fixed", "createdAt": "2025-06-01T13:00:00Z"}
+    ]}}
+  ]},
+  "comments": {"nodes": [
+    {"author": {"login": "github-actions"}, "body": "CI report", "createdAt": "2025-06-01T10:30:00Z"},
+    {"author": null, "body": "Ghost comment", "createdAt": "2025-06-01T14:00:00Z"}
+  ]}
+}`
+	if err := json.Unmarshal([]byte(prJSON), &pr); err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+
+	entries := buildTranscript(&pr, []string{"GitHub-Actions"})
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Kind+":"+entry.Author)
+	}
+	want := []string{
+		"description:alice",
+		"review-comment:bob",
+		"review:bob",
+		"review-comment:alice",
+		"comment:ghost",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("entries = %v, want %v", got, want)
+	}
+
+	var md bytes.Buffer
+	if err := writeTranscriptMarkdown(&md, &pr, entries); err != nil {
+		t.Fatal(err)
+	}
+	for _, fragment := range []string{
+		"# PR #306: Add feature",
+		"## @bob commented on `main.go:42` — 2025-06-01 11:00 UTC",
+		"## @bob reviewed (changes requested) — 2025-06-01 12:00 UTC",
+	} {
+		if !strings.Contains(md.String(), fragment) {
+			t.Errorf("markdown missing %q:\n%s", fragment, md.String())
+		}
+	}
+
+	var jsonl bytes.Buffer
+	if err := writeTranscriptJSONL(&jsonl, entries); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(jsonl.String(), "\n"); lines != len(entries) {
+		t.Errorf("jsonl has %d lines, want %d", lines, len(entries))
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"time"
@@ -43,6 +44,8 @@ Examples:
 )
 
 func init() {
+	addOutputFormats(analyzeReleaseCmd, FormatMarkdown)
+
 	// Configure flags for analyze command
 	analyzeReleaseCmd.Flags().String("milestone", "", "Milestone title to analyze")
 	analyzeReleaseCmd.Flags().String("since", "", "Start date (YYYY-MM-DD)")
@@ -58,6 +61,7 @@ func init() {
 type ReleaseAnalysis struct {
 	Milestone             string                    `json:"milestone,omitempty" yaml:"milestone,omitempty"`
 	DateRange             *DateRange                `json:"dateRange,omitempty" yaml:"dateRange,omitempty"`
+	TagRange              string                    `json:"tagRange,omitempty" yaml:"tagRange,omitempty"`
 	TotalPRs              int                       `json:"totalPRs" yaml:"totalPRs"`
 	MissingClassification []PRClassificationSuggestion `json:"missingClassification,omitempty" yaml:"missingClassification,omitempty"`
 	ShouldIgnore          []PRIgnoreSuggestion         `json:"shouldIgnore,omitempty" yaml:"shouldIgnore,omitempty"`
@@ -163,7 +167,7 @@ func analyzeRelease(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create GitHub client
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
 
 	// Fetch PRs based on filter
 	var prs []PRData
@@ -230,7 +234,7 @@ func analyzeRelease(cmd *cobra.Command, args []string) error {
 	// Special handling for markdown format
 	format := ResolveFormat(cmd)
 	if format == FormatMarkdown {
-		return outputMarkdownAnalysis(analysis)
+		return outputMarkdownAnalysis(cmd.OutOrStdout(), analysis)
 	}
 
 	output := map[string]interface{}{
@@ -455,52 +459,55 @@ func hasLabel(labels []string, target string) bool {
 }
 
 // outputMarkdownAnalysis outputs the analysis in markdown format
-func outputMarkdownAnalysis(analysis ReleaseAnalysis) error {
-	fmt.Printf("# Release Notes Analysis")
+func outputMarkdownAnalysis(w io.Writer, analysis ReleaseAnalysis) error {
+	fmt.Fprintf(w, "# Release Notes Analysis")
 	if analysis.Milestone != "" {
-		fmt.Printf(" for %s", analysis.Milestone)
+		fmt.Fprintf(w, " for %s", analysis.Milestone)
 	}
 	if analysis.DateRange != nil {
-		fmt.Printf(" (%s to %s)", analysis.DateRange.Since, analysis.DateRange.Until)
+		fmt.Fprintf(w, " (%s to %s)", analysis.DateRange.Since, analysis.DateRange.Until)
+	}
+	if analysis.TagRange != "" {
+		fmt.Fprintf(w, " for %s", analysis.TagRange)
 	}
-	fmt.Printf("\n\n")
+	fmt.Fprintf(w, "\n\n")
 	
-	fmt.Printf("**Total PRs analyzed**: %d\n\n", analysis.TotalPRs)
+	fmt.Fprintf(w, "**Total PRs analyzed**: %d\n\n", analysis.TotalPRs)
 	
 	if len(analysis.MissingClassification) > 0 {
-		fmt.Printf("## PRs Missing Primary Classification\n\n")
+		fmt.Fprintf(w, "## PRs Missing Primary Classification\n\n")
 		for _, pr := range analysis.MissingClassification {
-			fmt.Printf("- **#%d**: %s\n", pr.Number, pr.Title)
-			fmt.Printf("  - **Suggested**: `%s`\n", pr.SuggestedLabel)
-			fmt.Printf("  - **Reason**: %s\n\n", pr.Reasoning)
+			fmt.Fprintf(w, "- **#%d**: %s\n", pr.Number, pr.Title)
+			fmt.Fprintf(w, "  - **Suggested**: `%s`\n", pr.SuggestedLabel)
+			fmt.Fprintf(w, "  - **Reason**: %s\n\n", pr.Reasoning)
 		}
 	}
 	
 	if len(analysis.ShouldIgnore) > 0 {
-		fmt.Printf("## PRs That Should Have 'ignore-for-release'\n\n")
+		fmt.Fprintf(w, "## PRs That Should Have 'ignore-for-release'\n\n")
 		for _, pr := range analysis.ShouldIgnore {
-			fmt.Printf("- **#%d**: %s\n", pr.Number, pr.Title)
-			fmt.Printf("  - **Reason**: %s\n\n", pr.Reasoning)
+			fmt.Fprintf(w, "- **#%d**: %s\n", pr.Number, pr.Title)
+			fmt.Fprintf(w, "  - **Reason**: %s\n\n", pr.Reasoning)
 		}
 	}
 	
 	if len(analysis.InconsistentLabeling) > 0 {
-		fmt.Printf("## PRs With Inconsistent Labeling\n\n")
+		fmt.Fprintf(w, "## PRs With Inconsistent Labeling\n\n")
 		for _, pr := range analysis.InconsistentLabeling {
-			fmt.Printf("- **#%d**: %s\n", pr.Number, pr.Title)
-			fmt.Printf("  - **Current labels**: %s\n", strings.Join(pr.CurrentLabels, ", "))
-			fmt.Printf("  - **Issue**: %s\n", pr.Issue)
-			fmt.Printf("  - **Suggestion**: %s\n\n", pr.Suggestion)
+			fmt.Fprintf(w, "- **#%d**: %s\n", pr.Number, pr.Title)
+			fmt.Fprintf(w, "  - **Current labels**: %s\n", strings.Join(pr.CurrentLabels, ", "))
+			fmt.Fprintf(w, "  - **Issue**: %s\n", pr.Issue)
+			fmt.Fprintf(w, "  - **Suggestion**: %s\n\n", pr.Suggestion)
 		}
 	}
 	
-	fmt.Printf("## Summary\n\n")
-	fmt.Printf("- **Well-labeled PRs**: %d\n", analysis.Summary.WellLabeled)
-	fmt.Printf("- **PRs needing attention**: %d\n", analysis.Summary.NeedsAttention)
-	fmt.Printf("- **Ready for release**: %s\n", formatBool(analysis.Summary.ReadyForRelease))
+	fmt.Fprintf(w, "## Summary\n\n")
+	fmt.Fprintf(w, "- **Well-labeled PRs**: %d\n", analysis.Summary.WellLabeled)
+	fmt.Fprintf(w, "- **PRs needing attention**: %d\n", analysis.Summary.NeedsAttention)
+	fmt.Fprintf(w, "- **Ready for release**: %s\n", formatBool(analysis.Summary.ReadyForRelease))
 	
 	if !analysis.Summary.ReadyForRelease {
-		fmt.Printf("\n⚠️ **Action Required**: Please review and apply the suggested labels before creating release notes.\n")
+		fmt.Fprintf(w, "\n⚠️ **Action Required**: Please review and apply the suggested labels before creating release notes.\n")
 	}
 	
 	return nil
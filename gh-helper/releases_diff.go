@@ -0,0 +1,238 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var diffReleaseCmd = NewOperationalCommand(
+	"diff <base>..<head>",
+	"List and categorize merged PRs between two tags",
+	`List merged PRs between two tags (or any refs) using the compare commit range
+and each commit's associated pull requests, then categorize them by labels
+like 'releases analyze'.
+
+This validates release scopes even when milestones are not maintained.
+
+Examples:
+  # PRs that landed between two tags
+  gh-helper releases diff v0.19.0..v0.20.0-rc1
+
+  # Release candidate against main, as markdown
+  gh-helper releases diff v0.20.0-rc1..main --format markdown
+
+  # Just the PR numbers
+  gh-helper releases diff v0.19.0..v0.20.0-rc1 --jq '.releaseDiff.pullRequests[].number'`,
+	diffRelease,
+)
+
+func init() {
+	addOutputFormats(diffReleaseCmd, FormatMarkdown)
+	diffReleaseCmd.Args = cobra.RangeArgs(1, 2)
+
+	releasesCmd.AddCommand(diffReleaseCmd)
+}
+
+// associatedPRBatchSize is the number of commits looked up per GraphQL query
+const associatedPRBatchSize = 50
+
+func diffRelease(cmd *cobra.Command, args []string) error {
+	base, head, err := parseCompareRange(args)
+	if err != nil {
+		return err
+	}
+
+	client := newCommandClient(cmd)
+
+	shas, err := client.ListCompareCommitSHAs(base, head)
+	if err != nil {
+		return fmt.Errorf("failed to list commits between %s and %s: %w", base, head, err)
+	}
+
+	prs, err := client.GetMergedPRsForCommits(shas)
+	if err != nil {
+		return fmt.Errorf("failed to fetch associated PRs: %w", err)
+	}
+
+	analysis := analyzePRs(prs)
+	analysis.TagRange = fmt.Sprintf("%s..%s", base, head)
+
+	if ResolveFormat(cmd) == FormatMarkdown {
+		return outputMarkdownAnalysis(cmd.OutOrStdout(), analysis)
+	}
+
+	pullRequests := []map[string]interface{}{}
+	for _, pr := range prs {
+		pullRequests = append(pullRequests, map[string]interface{}{
+			"number":   pr.Number,
+			"title":    pr.Title,
+			"author":   pr.Author,
+			"labels":   pr.Labels,
+			"mergedAt": pr.MergedAt,
+		})
+	}
+
+	output := map[string]interface{}{
+		"releaseDiff": map[string]interface{}{
+			"base":         base,
+			"head":         head,
+			"commits":      len(shas),
+			"pullRequests": pullRequests,
+			"analysis":     analysis,
+		},
+	}
+
+	return EncodeOutputWithCmd(cmd, output)
+}
+
+// associatedPRNode is a pull request associated with a commit
+type associatedPRNode struct {
+	Number   int    `json:"number"`
+	Title    string `json:"title"`
+	Body     string `json:"body"`
+	State    string `json:"state"`
+	MergedAt string `json:"mergedAt"`
+	Author   *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	ClosingIssuesReferences struct {
+		Nodes []struct {
+			Number int `json:"number"`
+			Labels struct {
+				Nodes []struct {
+					Name string `json:"name"`
+				} `json:"nodes"`
+			} `json:"labels"`
+		} `json:"nodes"`
+	} `json:"closingIssuesReferences"`
+}
+
+// GetMergedPRsForCommits returns the merged PRs associated with the given commits,
+// deduplicated and sorted by PR number
+func (c *GitHubClient) GetMergedPRsForCommits(shas []string) ([]PRData, error) {
+	seen := make(map[int]bool)
+	var prs []PRData
+
+	for start := 0; start < len(shas); start += associatedPRBatchSize {
+		end := min(start+associatedPRBatchSize, len(shas))
+
+		// Build aliased object lookups for this batch of commits
+		var queryBuilder strings.Builder
+		queryBuilder.WriteString("query($owner: String!, $repo: String!) {\n")
+		queryBuilder.WriteString("  repository(owner: $owner, name: $repo) {\n")
+		for i, sha := range shas[start:end] {
+			fmt.Fprintf(&queryBuilder, `    c%d: object(oid: "%s") {
+      ... on Commit {
+        associatedPullRequests(first: 5) {
+          nodes {
+            number
+            title
+            body
+            state
+            mergedAt
+            author { login }
+            labels(first: 20) { nodes { name } }
+            closingIssuesReferences(first: 10) {
+              nodes {
+                number
+                labels(first: 20) { nodes { name } }
+              }
+            }
+          }
+        }
+      }
+    }
+`, i, sha)
+		}
+		queryBuilder.WriteString("  }\n}")
+
+		variables := map[string]interface{}{
+			"owner": c.Owner,
+			"repo":  c.Repo,
+		}
+
+		responseData, err := c.RunGraphQLQueryWithVariables(queryBuilder.String(), variables)
+		if err != nil {
+			return nil, err
+		}
+
+		batch, err := mergedPRsFromCommitBatch(responseData, end-start, seen)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, batch...)
+	}
+
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].Number < prs[j].Number
+	})
+
+	return prs, nil
+}
+
+// mergedPRsFromCommitBatch maps the aliased c0..c<commits-1> lookups of one
+// batch to the merged PRs not yet in seen, recording them in seen
+func mergedPRsFromCommitBatch(responseData []byte, commits int, seen map[int]bool) ([]PRData, error) {
+	var response struct {
+		Data struct {
+			Repository map[string]*struct {
+				AssociatedPullRequests struct {
+					Nodes []associatedPRNode `json:"nodes"`
+				} `json:"associatedPullRequests"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil, err
+	}
+
+	var prs []PRData
+	for i := range commits {
+		commit := response.Data.Repository[fmt.Sprintf("c%d", i)]
+		if commit == nil {
+			continue
+		}
+		for _, node := range commit.AssociatedPullRequests.Nodes {
+			if node.State != "MERGED" || seen[node.Number] {
+				continue
+			}
+			seen[node.Number] = true
+
+			pr := PRData{
+				Number:   node.Number,
+				Title:    node.Title,
+				Body:     node.Body,
+				MergedAt: node.MergedAt,
+			}
+			if node.Author != nil {
+				pr.Author = node.Author.Login
+			}
+			for _, label := range node.Labels.Nodes {
+				if label.Name != "" {
+					pr.Labels = append(pr.Labels, label.Name)
+				}
+			}
+			for _, issue := range node.ClosingIssuesReferences.Nodes {
+				linkedIssue := LinkedIssue{
+					Number: issue.Number,
+				}
+				for _, label := range issue.Labels.Nodes {
+					linkedIssue.Labels = append(linkedIssue.Labels, label.Name)
+				}
+				pr.LinkedIssues = append(pr.LinkedIssues, linkedIssue)
+			}
+			prs = append(prs, pr)
+		}
+	}
+	return prs, nil
+}
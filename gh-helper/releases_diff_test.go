@@ -0,0 +1,104 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestMergedPRsFromCommitBatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		commits  int
+		seen     map[int]bool
+		expected []int
+		check    func(t *testing.T, prs []PRData)
+	}{
+		{
+			name: "maps aliased commits to merged PRs",
+			response: `{"data":{"repository":{
+				"c0":{"associatedPullRequests":{"nodes":[{
+					"number":12,"title":"feat: add diff","state":"MERGED","mergedAt":"2025-06-01T00:00:00Z",
+					"author":{"login":"alice"},
+					"labels":{"nodes":[{"name":"enhancement"}]},
+					"closingIssuesReferences":{"nodes":[{"number":3,"labels":{"nodes":[{"name":"bug"}]}}]}
+				}]}},
+				"c1":{"associatedPullRequests":{"nodes":[{"number":15,"title":"fix: typo","state":"MERGED","author":null}]}}
+			}}}`,
+			commits:  2,
+			seen:     map[int]bool{},
+			expected: []int{12, 15},
+			check: func(t *testing.T, prs []PRData) {
+				pr := prs[0]
+				if pr.Author != "alice" || pr.MergedAt != "2025-06-01T00:00:00Z" || len(pr.Labels) != 1 || pr.Labels[0] != "enhancement" {
+					t.Errorf("unexpected PR mapping: %+v", pr)
+				}
+				if len(pr.LinkedIssues) != 1 || pr.LinkedIssues[0].Number != 3 || pr.LinkedIssues[0].Labels[0] != "bug" {
+					t.Errorf("unexpected linked issues: %+v", pr.LinkedIssues)
+				}
+				if prs[1].Author != "" {
+					t.Errorf("expected empty author for a deleted account, got %q", prs[1].Author)
+				}
+			},
+		},
+		{
+			name: "skips open and closed PRs",
+			response: `{"data":{"repository":{
+				"c0":{"associatedPullRequests":{"nodes":[
+					{"number":20,"state":"OPEN"},
+					{"number":21,"state":"CLOSED"},
+					{"number":22,"state":"MERGED"}
+				]}}
+			}}}`,
+			commits:  1,
+			seen:     map[int]bool{},
+			expected: []int{22},
+		},
+		{
+			name: "deduplicates PRs within and across batches",
+			response: `{"data":{"repository":{
+				"c0":{"associatedPullRequests":{"nodes":[{"number":30,"state":"MERGED"}]}},
+				"c1":{"associatedPullRequests":{"nodes":[{"number":30,"state":"MERGED"},{"number":31,"state":"MERGED"}]}},
+				"c2":{"associatedPullRequests":{"nodes":[{"number":32,"state":"MERGED"}]}}
+			}}}`,
+			commits:  3,
+			seen:     map[int]bool{32: true},
+			expected: []int{30, 31},
+		},
+		{
+			name:     "tolerates commits missing from the response",
+			response: `{"data":{"repository":{"c0":null}}}`,
+			commits:  2,
+			seen:     map[int]bool{},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prs, err := mergedPRsFromCommitBatch([]byte(tt.response), tt.commits, tt.seen)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var numbers []int
+			for _, pr := range prs {
+				numbers = append(numbers, pr.Number)
+			}
+			if len(numbers) != len(tt.expected) {
+				t.Fatalf("expected PRs %v, got %v", tt.expected, numbers)
+			}
+			for i := range numbers {
+				if numbers[i] != tt.expected[i] {
+					t.Fatalf("expected PRs %v, got %v", tt.expected, numbers)
+				}
+			}
+			for _, number := range tt.expected {
+				if !tt.seen[number] {
+					t.Errorf("PR #%d not recorded as seen", number)
+				}
+			}
+			if tt.check != nil {
+				tt.check(t, prs)
+			}
+		})
+	}
+}
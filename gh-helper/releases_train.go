@@ -0,0 +1,576 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/apstndb/gh-dev-tools/pkg/reviewwait"
+	"github.com/spf13/cobra"
+)
+
+var trainReleaseCmd = NewOperationalCommand(
+	"train [flags]",
+	"Aggregate milestone readiness across an organization's repositories",
+	`Report release readiness for every repository in an organization with an open
+milestone matching --milestone-pattern, for release managers coordinating
+simultaneous releases.
+
+For each matching milestone:
+  - Open items: open issues and open PRs still in the milestone
+  - Unlabeled PRs: open or merged PRs in the milestone without any label
+
+For each repository, the latest release branches (--release-branch-prefix):
+  - Failing required checks on the branch head. When the branch protection
+    rule is not readable, all failing checks are reported (requiredKnown: false).
+
+A repository is ready when all of these are empty.
+The --milestone-pattern is a glob (e.g. "v0.20.*"); archived repositories are skipped.
+
+Examples:
+  # Train readiness across the organization
+  gh-helper releases train --org myorg --milestone-pattern "v0.20.*"
+
+  # Selected repositories, as markdown for the release channel
+  gh-helper releases train --org myorg --milestone-pattern "v0.20.*" --repos api,web --format markdown`,
+	releaseTrain,
+)
+
+func init() {
+	addOutputFormats(trainReleaseCmd, FormatMarkdown)
+	trainReleaseCmd.Flags().String("org", "", "Organization to scan (required)")
+	trainReleaseCmd.Flags().String("milestone-pattern", "", "Glob matched against open milestone titles (required)")
+	trainReleaseCmd.Flags().StringSlice("repos", []string{}, "Only these repositories (default: all non-archived repositories)")
+	trainReleaseCmd.Flags().String("release-branch-prefix", "release/", "Branch prefix of release branches whose checks are inspected (empty to skip)")
+	for _, name := range []string{"org", "milestone-pattern"} {
+		if err := trainReleaseCmd.MarkFlagRequired(name); err != nil {
+			panic(fmt.Sprintf("failed to mark %s flag as required: %v", name, err))
+		}
+	}
+
+	releasesCmd.AddCommand(trainReleaseCmd)
+}
+
+// trainReleaseBranchLimit is how many of the most recent release branches are inspected per repository
+const trainReleaseBranchLimit = 5
+
+// trainRepoBatchSize is how many repositories are inspected per GraphQL request
+const trainRepoBatchSize = 10
+
+// TrainPR is a PR needing attention in a release train
+type TrainPR struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+}
+
+// TrainMilestone is the readiness of one milestone
+type TrainMilestone struct {
+	Title        string    `json:"title"`
+	URL          string    `json:"url"`
+	DueOn        string    `json:"dueOn,omitempty"`
+	OpenIssues   int       `json:"openIssues"`
+	OpenPRs      int       `json:"openPRs"`
+	UnlabeledPRs []TrainPR `json:"unlabeledPRs"`
+}
+
+// TrainBranch is the check state of a release branch head
+type TrainBranch struct {
+	Name          string   `json:"name"`
+	Commit        string   `json:"commit"`
+	State         string   `json:"state,omitempty"` // statusCheckRollup state
+	FailingChecks []string `json:"failingChecks"`
+	RequiredKnown bool     `json:"requiredKnown"`
+}
+
+// TrainRepo is the readiness of one repository
+type TrainRepo struct {
+	Repo            string           `json:"repo"`
+	Ready           bool             `json:"ready"`
+	Milestones      []TrainMilestone `json:"milestones"`
+	ReleaseBranches []TrainBranch    `json:"releaseBranches,omitempty"`
+	Error           string           `json:"error,omitempty"`
+}
+
+// TrainSummary totals a release train report
+type TrainSummary struct {
+	Repositories    int  `json:"repositories"`
+	ReadyRepos      int  `json:"readyRepos"`
+	OpenIssues      int  `json:"openIssues"`
+	OpenPRs         int  `json:"openPRs"`
+	UnlabeledPRs    int  `json:"unlabeledPRs"`
+	FailingBranches int  `json:"failingBranches"`
+	Ready           bool `json:"ready"`
+}
+
+// TrainReport is the output of 'releases train'
+type TrainReport struct {
+	Org              string       `json:"org"`
+	MilestonePattern string       `json:"milestonePattern"`
+	Repositories     []TrainRepo  `json:"repositories"`
+	Summary          TrainSummary `json:"summary"`
+}
+
+// trainCandidate is a repository with the numbers of its matching milestones
+type trainCandidate struct {
+	Name       string
+	Milestones []int
+}
+
+// matchMilestoneTitles returns the titles matching a glob pattern
+func matchMilestoneTitles(pattern string, titles []string) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid --milestone-pattern %q: %w", pattern, err)
+	}
+	var matched []string
+	for _, title := range titles {
+		if ok, _ := path.Match(pattern, title); ok {
+			matched = append(matched, title)
+		}
+	}
+	return matched, nil
+}
+
+// trainCheckContext is one context of a statusCheckRollup
+type trainCheckContext struct {
+	Typename   string `json:"__typename"`
+	Name       string `json:"name"`       // CheckRun
+	Conclusion string `json:"conclusion"` // CheckRun
+	Context    string `json:"context"`    // StatusContext
+	State      string `json:"state"`      // StatusContext
+}
+
+func (c trainCheckContext) checkName() string {
+	if c.Typename == "StatusContext" {
+		return c.Context
+	}
+	return c.Name
+}
+
+func (c trainCheckContext) failed() bool {
+	if c.Typename == "StatusContext" {
+		return c.State == "FAILURE" || c.State == "ERROR"
+	}
+	switch c.Conclusion {
+	case "FAILURE", "TIMED_OUT", "CANCELLED", "ACTION_REQUIRED", "STARTUP_FAILURE":
+		return true
+	}
+	return false
+}
+
+// failingRequiredChecks returns failing check names, restricted to required
+// checks when the required set is known
+func failingRequiredChecks(contexts []trainCheckContext, required []string, requiredKnown bool) []string {
+	requiredSet := make(map[string]bool, len(required))
+	for _, name := range required {
+		requiredSet[name] = true
+	}
+	failing := []string{}
+	for _, context := range contexts {
+		if !context.failed() {
+			continue
+		}
+		if requiredKnown && !requiredSet[context.checkName()] {
+			continue
+		}
+		failing = append(failing, context.checkName())
+	}
+	sort.Strings(failing)
+	return failing
+}
+
+// summarizeTrain marks each repository's readiness and totals the report
+func summarizeTrain(repos []TrainRepo) TrainSummary {
+	summary := TrainSummary{Repositories: len(repos)}
+	for i := range repos {
+		repo := &repos[i]
+		ready := repo.Error == ""
+		for _, milestone := range repo.Milestones {
+			summary.OpenIssues += milestone.OpenIssues
+			summary.OpenPRs += milestone.OpenPRs
+			summary.UnlabeledPRs += len(milestone.UnlabeledPRs)
+			if milestone.OpenIssues > 0 || milestone.OpenPRs > 0 || len(milestone.UnlabeledPRs) > 0 {
+				ready = false
+			}
+		}
+		for _, branch := range repo.ReleaseBranches {
+			if len(branch.FailingChecks) > 0 {
+				summary.FailingBranches++
+				ready = false
+			}
+		}
+		repo.Ready = ready
+		if ready {
+			summary.ReadyRepos++
+		}
+	}
+	summary.Ready = summary.Repositories > 0 && summary.ReadyRepos == summary.Repositories
+	return summary
+}
+
+// listTrainCandidates lists the organization's non-archived repositories with
+// open milestones matching the pattern
+func (c *GitHubClient) listTrainCandidates(org, pattern string, only []string) ([]trainCandidate, error) {
+	query := `
+	query($org: String!, $after: String) {
+	  organization(login: $org) {
+	    repositories(first: 100, after: $after, isArchived: false, orderBy: {field: NAME, direction: ASC}) {
+	      pageInfo { hasNextPage endCursor }
+	      nodes {
+	        name
+	        milestones(first: 50, states: [OPEN]) { nodes { number title } }
+	      }
+	    }
+	  }
+	}`
+
+	onlySet := make(map[string]bool, len(only))
+	for _, name := range only {
+		onlySet[strings.ToLower(name)] = true
+	}
+
+	var candidates []trainCandidate
+	var after interface{}
+	for {
+		result, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{"org": org, "after": after})
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Data struct {
+				Organization *struct {
+					Repositories struct {
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+						Nodes []struct {
+							Name       string `json:"name"`
+							Milestones struct {
+								Nodes []struct {
+									Number int    `json:"number"`
+									Title  string `json:"title"`
+								} `json:"nodes"`
+							} `json:"milestones"`
+						} `json:"nodes"`
+					} `json:"repositories"`
+				} `json:"organization"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(result, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse repositories response: %w", err)
+		}
+		if response.Data.Organization == nil {
+			return nil, fmt.Errorf("organization %q not found", org)
+		}
+
+		repos := response.Data.Organization.Repositories
+		for _, node := range repos.Nodes {
+			if len(onlySet) > 0 && !onlySet[strings.ToLower(node.Name)] {
+				continue
+			}
+			numbers := make(map[string]int, len(node.Milestones.Nodes))
+			titles := make([]string, 0, len(node.Milestones.Nodes))
+			for _, milestone := range node.Milestones.Nodes {
+				numbers[milestone.Title] = milestone.Number
+				titles = append(titles, milestone.Title)
+			}
+			matched, err := matchMilestoneTitles(pattern, titles)
+			if err != nil {
+				return nil, err
+			}
+			if len(matched) == 0 {
+				continue
+			}
+			candidate := trainCandidate{Name: node.Name}
+			for _, title := range matched {
+				candidate.Milestones = append(candidate.Milestones, numbers[title])
+			}
+			candidates = append(candidates, candidate)
+		}
+
+		if !repos.PageInfo.HasNextPage {
+			break
+		}
+		after = repos.PageInfo.EndCursor
+	}
+	return candidates, nil
+}
+
+// trainMilestoneNode is a milestone with its readiness counts
+type trainMilestoneNode struct {
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	DueOn      string `json:"dueOn"`
+	OpenIssues struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"openIssues"`
+	OpenPRs struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"openPRs"`
+	PullRequests struct {
+		Nodes []struct {
+			Number int    `json:"number"`
+			Title  string `json:"title"`
+			URL    string `json:"url"`
+			Labels struct {
+				TotalCount int `json:"totalCount"`
+			} `json:"labels"`
+		} `json:"nodes"`
+	} `json:"pullRequests"`
+}
+
+// trainRefNode is a release branch with its head check state
+type trainRefNode struct {
+	Name   string `json:"name"`
+	Target struct {
+		Oid               string `json:"oid"`
+		StatusCheckRollup *struct {
+			State    string `json:"state"`
+			Contexts struct {
+				Nodes []trainCheckContext `json:"nodes"`
+			} `json:"contexts"`
+		} `json:"statusCheckRollup"`
+	} `json:"target"`
+	BranchProtectionRule *struct {
+		RequiredStatusCheckContexts []string `json:"requiredStatusCheckContexts"`
+	} `json:"branchProtectionRule"`
+}
+
+// inspectTrainRepos fetches milestone readiness and release branch checks for
+// a batch of repositories in one aliased query; failures are per repository
+func (c *GitHubClient) inspectTrainRepos(org, branchPrefix string, candidates []trainCandidate) ([]TrainRepo, error) {
+	milestoneFields := `title url dueOn
+	        openIssues: issues(states: [OPEN]) { totalCount }
+	        openPRs: pullRequests(states: [OPEN]) { totalCount }
+	        pullRequests(first: 100, states: [OPEN, MERGED]) { nodes { number title url labels { totalCount } } }`
+	refsField := ""
+	if branchPrefix != "" {
+		refsField = fmt.Sprintf(`
+	      refs(refPrefix: $refPrefix, first: %d, orderBy: {field: TAG_COMMIT_DATE, direction: DESC}) {
+	        nodes {
+	          name
+	          target {
+	            ... on Commit {
+	              oid
+	              statusCheckRollup {
+	                state
+	                contexts(first: 100) {
+	                  nodes {
+	                    __typename
+	                    ... on CheckRun { name conclusion }
+	                    ... on StatusContext { context state }
+	                  }
+	                }
+	              }
+	            }
+	          }
+	          branchProtectionRule { requiredStatusCheckContexts }
+	        }
+	      }`, trainReleaseBranchLimit)
+	}
+
+	var repoFields []string
+	for i, candidate := range candidates {
+		var milestones []string
+		for j, number := range candidate.Milestones {
+			milestones = append(milestones, fmt.Sprintf("m%d: milestone(number: %d) { %s }", j, number, milestoneFields))
+		}
+		repoFields = append(repoFields, fmt.Sprintf("r%d: repository(owner: $org, name: %q) {\n\t      %s%s\n\t    }",
+			i, candidate.Name, strings.Join(milestones, "\n\t      "), refsField))
+	}
+
+	variables := map[string]interface{}{"org": org}
+	header := "query($org: String!)"
+	if branchPrefix != "" {
+		header = "query($org: String!, $refPrefix: String!)"
+		variables["refPrefix"] = "refs/heads/" + strings.TrimSuffix(branchPrefix, "/") + "/"
+	}
+	query := fmt.Sprintf("%s {\n\t    %s\n\t}", header, strings.Join(repoFields, "\n\t    "))
+
+	result, graphqlErrors, err := c.RunGraphQLQueryPartial(query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unreadable protection rules only mean the required set is unknown
+	repoErrors := make(map[string]string)
+	for _, graphqlErr := range graphqlErrors {
+		if graphqlErr.HasPathElement("branchProtectionRule") {
+			continue
+		}
+		if _, exists := repoErrors[graphqlErr.Alias()]; !exists {
+			repoErrors[graphqlErr.Alias()] = graphqlErr.Message
+		}
+	}
+
+	var response struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse repository response: %w", err)
+	}
+
+	repos := make([]TrainRepo, 0, len(candidates))
+	for i, candidate := range candidates {
+		alias := fmt.Sprintf("r%d", i)
+		repo := TrainRepo{Repo: fmt.Sprintf("%s/%s", org, candidate.Name), Milestones: []TrainMilestone{}}
+		if message, failed := repoErrors[alias]; failed {
+			repo.Error = message
+			repos = append(repos, repo)
+			continue
+		}
+
+		var fields map[string]json.RawMessage
+		if raw, ok := response.Data[alias]; ok {
+			if err := json.Unmarshal(raw, &fields); err != nil {
+				return nil, fmt.Errorf("failed to parse repository %s: %w", candidate.Name, err)
+			}
+		}
+		for j := range candidate.Milestones {
+			var node *trainMilestoneNode
+			if raw, ok := fields[fmt.Sprintf("m%d", j)]; ok {
+				if err := json.Unmarshal(raw, &node); err != nil {
+					return nil, fmt.Errorf("failed to parse milestone of %s: %w", candidate.Name, err)
+				}
+			}
+			if node == nil {
+				continue
+			}
+			milestone := TrainMilestone{
+				Title:        node.Title,
+				URL:          node.URL,
+				DueOn:        node.DueOn,
+				OpenIssues:   node.OpenIssues.TotalCount,
+				OpenPRs:      node.OpenPRs.TotalCount,
+				UnlabeledPRs: []TrainPR{},
+			}
+			for _, pr := range node.PullRequests.Nodes {
+				if pr.Labels.TotalCount == 0 {
+					milestone.UnlabeledPRs = append(milestone.UnlabeledPRs, TrainPR{Number: pr.Number, Title: pr.Title, URL: pr.URL})
+				}
+			}
+			repo.Milestones = append(repo.Milestones, milestone)
+		}
+
+		if raw, ok := fields["refs"]; ok {
+			var refs struct {
+				Nodes []trainRefNode `json:"nodes"`
+			}
+			if err := json.Unmarshal(raw, &refs); err != nil {
+				return nil, fmt.Errorf("failed to parse release branches of %s: %w", candidate.Name, err)
+			}
+			for _, ref := range refs.Nodes {
+				branch := TrainBranch{
+					Name:          strings.TrimSuffix(branchPrefix, "/") + "/" + ref.Name,
+					Commit:        reviewwait.ShortSHA(ref.Target.Oid),
+					FailingChecks: []string{},
+				}
+				var required []string
+				if ref.BranchProtectionRule != nil {
+					branch.RequiredKnown = true
+					required = ref.BranchProtectionRule.RequiredStatusCheckContexts
+				}
+				if rollup := ref.Target.StatusCheckRollup; rollup != nil {
+					branch.State = rollup.State
+					branch.FailingChecks = failingRequiredChecks(rollup.Contexts.Nodes, required, branch.RequiredKnown)
+				}
+				repo.ReleaseBranches = append(repo.ReleaseBranches, branch)
+			}
+		}
+		repos = append(repos, repo)
+	}
+	return repos, nil
+}
+
+// writeTrainMarkdown renders a release train report as Markdown
+func writeTrainMarkdown(w io.Writer, report TrainReport) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Release Train: %s (%s)\n\n", report.Org, report.MilestonePattern)
+	fmt.Fprintf(&b, "**Ready**: %s (%d/%d repositories)\n\n", formatBool(report.Summary.Ready), report.Summary.ReadyRepos, report.Summary.Repositories)
+	b.WriteString("| Repository | Milestone | Open issues | Open PRs | Unlabeled PRs | Failing release branches | Ready |\n")
+	b.WriteString("|---|---|---|---|---|---|---|\n")
+	for _, repo := range report.Repositories {
+		var titles []string
+		openIssues, openPRs, unlabeled := 0, 0, 0
+		for _, milestone := range repo.Milestones {
+			titles = append(titles, milestone.Title)
+			openIssues += milestone.OpenIssues
+			openPRs += milestone.OpenPRs
+			unlabeled += len(milestone.UnlabeledPRs)
+		}
+		var failing []string
+		for _, branch := range repo.ReleaseBranches {
+			if len(branch.FailingChecks) > 0 {
+				failing = append(failing, fmt.Sprintf("`%s` (%s)", branch.Name, strings.Join(branch.FailingChecks, ", ")))
+			}
+		}
+		ready := formatBool(repo.Ready)
+		if repo.Error != "" {
+			ready = "⚠️ " + repo.Error
+		}
+		fmt.Fprintf(&b, "| %s | %s | %d | %d | %d | %s | %s |\n",
+			repo.Repo, strings.Join(titles, ", "), openIssues, openPRs, unlabeled, strings.Join(failing, "<br>"), ready)
+	}
+
+	for _, repo := range report.Repositories {
+		for _, milestone := range repo.Milestones {
+			if len(milestone.UnlabeledPRs) == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "\n## Unlabeled PRs: %s %s\n\n", repo.Repo, milestone.Title)
+			for _, pr := range milestone.UnlabeledPRs {
+				fmt.Fprintf(&b, "- [#%d](%s) %s\n", pr.Number, pr.URL, pr.Title)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func releaseTrain(cmd *cobra.Command, args []string) error {
+	org, err := cmd.Flags().GetString("org")
+	if err != nil {
+		return fmt.Errorf("failed to get 'org' flag: %w", err)
+	}
+	pattern, err := cmd.Flags().GetString("milestone-pattern")
+	if err != nil {
+		return fmt.Errorf("failed to get 'milestone-pattern' flag: %w", err)
+	}
+	only, err := cmd.Flags().GetStringSlice("repos")
+	if err != nil {
+		return fmt.Errorf("failed to get 'repos' flag: %w", err)
+	}
+	branchPrefix, err := cmd.Flags().GetString("release-branch-prefix")
+	if err != nil {
+		return fmt.Errorf("failed to get 'release-branch-prefix' flag: %w", err)
+	}
+
+	client := NewOrgClient(org)
+	client.api.Usage = commandContext(cmd).Usage
+	candidates, err := client.listTrainCandidates(org, pattern, only)
+	if err != nil {
+		return fmt.Errorf("failed to list repositories of %s: %w", org, err)
+	}
+
+	report := TrainReport{Org: org, MilestonePattern: pattern, Repositories: []TrainRepo{}}
+	for start := 0; start < len(candidates); start += trainRepoBatchSize {
+		end := min(start+trainRepoBatchSize, len(candidates))
+		repos, err := client.inspectTrainRepos(org, branchPrefix, candidates[start:end])
+		if err != nil {
+			return fmt.Errorf("failed to inspect repositories of %s: %w", org, err)
+		}
+		report.Repositories = append(report.Repositories, repos...)
+	}
+	report.Summary = summarizeTrain(report.Repositories)
+
+	if ResolveFormat(cmd) == FormatMarkdown {
+		return writeTrainMarkdown(cmd.OutOrStdout(), report)
+	}
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"train": report})
+}
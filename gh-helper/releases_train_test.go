@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatchMilestoneTitles(t *testing.T) {
+	got, err := matchMilestoneTitles("v0.20.*", []string{"v0.20.0", "v0.20.1", "v0.2.0", "v0.21.0", "backlog"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"v0.20.0", "v0.20.1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("matchMilestoneTitles() = %v, want %v", got, want)
+	}
+	if _, err := matchMilestoneTitles("v0.[", nil); err == nil {
+		t.Error("expected error for malformed pattern")
+	}
+}
+
+func TestFailingRequiredChecks(t *testing.T) {
+	contexts := []trainCheckContext{
+		{Typename: "CheckRun", Name: "test", Conclusion: "FAILURE"},
+		{Typename: "CheckRun", Name: "lint", Conclusion: "SUCCESS"},
+		{Typename: "CheckRun", Name: "flaky-e2e", Conclusion: "TIMED_OUT"},
+		{Typename: "StatusContext", Context: "ci/legacy", State: "ERROR"},
+	}
+
+	if got, want := failingRequiredChecks(contexts, []string{"test", "lint", "ci/legacy"}, true), []string{"ci/legacy", "test"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("required known: got %v, want %v", got, want)
+	}
+	if got, want := failingRequiredChecks(contexts, nil, false), []string{"ci/legacy", "flaky-e2e", "test"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("required unknown: got %v, want %v", got, want)
+	}
+}
+
+func TestSummarizeTrain(t *testing.T) {
+	repos := []TrainRepo{
+		{Repo: "org/api", Milestones: []TrainMilestone{{Title: "v0.20.0"}}},
+		{Repo: "org/web", Milestones: []TrainMilestone{{Title: "v0.20.0", OpenIssues: 2, UnlabeledPRs: []TrainPR{{Number: 5, Title: "Fix"}}}}},
+		{Repo: "org/cli", Milestones: []TrainMilestone{{Title: "v0.20.0"}}, ReleaseBranches: []TrainBranch{{Name: "release/v0.20", FailingChecks: []string{"test"}}}},
+		{Repo: "org/docs", Error: "Resource not accessible"},
+	}
+
+	summary := summarizeTrain(repos)
+	want := TrainSummary{Repositories: 4, ReadyRepos: 1, OpenIssues: 2, UnlabeledPRs: 1, FailingBranches: 1}
+	if summary != want {
+		t.Errorf("summarizeTrain() = %+v, want %+v", summary, want)
+	}
+	if !repos[0].Ready || repos[1].Ready || repos[2].Ready || repos[3].Ready {
+		t.Errorf("unexpected readiness: %v %v %v %v", repos[0].Ready, repos[1].Ready, repos[2].Ready, repos[3].Ready)
+	}
+
+	var md bytes.Buffer
+	if err := writeTrainMarkdown(&md, TrainReport{Org: "org", MilestonePattern: "v0.20.*", Repositories: repos, Summary: summary}); err != nil {
+		t.Fatal(err)
+	}
+	for _, fragment := range []string{"| org/web | v0.20.0 | 2 | 0 | 1 |", "`release/v0.20` (test)", "## Unlabeled PRs: org/web v0.20.0"} {
+		if !strings.Contains(md.String(), fragment) {
+			t.Errorf("markdown missing %q:\n%s", fragment, md.String())
+		}
+	}
+}
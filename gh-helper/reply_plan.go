@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-yaml"
+)
+
+// ReplyPlan is a reviewable list of fully rendered thread replies.
+// 'threads reply --draft-to' appends to it instead of posting, so a human can
+// edit or delete entries before anything is sent to GitHub.
+type ReplyPlan struct {
+	Replies []ReplyPlanEntry `json:"replies"`
+}
+
+// ReplyPlanEntry is one drafted reply
+type ReplyPlanEntry struct {
+	ThreadID  string `json:"threadId"`
+	Body      string `json:"body"` // after message, commit, mention, and quote expansion
+	Resolve   bool   `json:"resolve,omitempty"`
+	DraftedAt string `json:"draftedAt"`
+}
+
+// loadReplyPlan reads a reply plan; a missing file is an empty plan
+func loadReplyPlan(path string) (*ReplyPlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ReplyPlan{}, nil
+		}
+		return nil, fmt.Errorf("failed to read reply plan: %w", err)
+	}
+
+	var plan ReplyPlan
+	if err := Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse reply plan %s: %w", path, err)
+	}
+	return &plan, nil
+}
+
+// appendReplyPlan appends entries to the plan at path and returns the number of replies in it
+func appendReplyPlan(path string, entries []ReplyPlanEntry) (int, error) {
+	plan, err := loadReplyPlan(path)
+	if err != nil {
+		return 0, err
+	}
+	plan.Replies = append(plan.Replies, entries...)
+
+	data, err := yaml.MarshalWithOptions(plan, yaml.UseJSONMarshaler(), yaml.UseLiteralStyleIfMultiline(true))
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal reply plan: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return 0, fmt.Errorf("failed to create reply plan directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return 0, fmt.Errorf("failed to write reply plan: %w", err)
+	}
+	return len(plan.Replies), nil
+}
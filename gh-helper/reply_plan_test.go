@@ -0,0 +1,36 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAppendReplyPlan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "drafts", "replies.yaml")
+
+	first := []ReplyPlanEntry{{ThreadID: "PRRT_1", Body: "> quoted\n\nFixed.\n\nFixed in commit abc123.", Resolve: true, DraftedAt: "2026-05-01T00:00:00Z"}}
+	second := []ReplyPlanEntry{
+		{ThreadID: "PRRT_2", Body: "@alice Won't fix: intended", DraftedAt: "2026-05-01T00:01:00Z"},
+		{ThreadID: "PRRT_3", Body: "Done", DraftedAt: "2026-05-01T00:01:00Z"},
+	}
+
+	if total, err := appendReplyPlan(path, first); err != nil || total != 1 {
+		t.Fatalf("appendReplyPlan() = %d, %v; want 1, nil", total, err)
+	}
+	if total, err := appendReplyPlan(path, second); err != nil || total != 3 {
+		t.Fatalf("appendReplyPlan() = %d, %v; want 3, nil", total, err)
+	}
+
+	plan, err := loadReplyPlan(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := append(first, second...); !reflect.DeepEqual(plan.Replies, want) {
+		t.Errorf("loadReplyPlan() = %+v, want %+v", plan.Replies, want)
+	}
+
+	if plan, err := loadReplyPlan(filepath.Join(t.TempDir(), "missing.yaml")); err != nil || len(plan.Replies) != 0 {
+		t.Errorf("loadReplyPlan(missing) = %+v, %v; want empty plan", plan, err)
+	}
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+)
+
+var repoCmd = &cobra.Command{
+	Use:   "repo",
+	Short: "Repository metadata and capabilities",
+	Long:  `Inspect repository settings and feature availability so scripts and other commands can adapt their behavior.`,
+}
+
+var infoRepoCmd = NewOperationalCommand(
+	"info [flags]",
+	"Show repository settings and feature capabilities",
+	`Show repository metadata and probe feature availability:
+
+  - Default branch, visibility, archived state, viewer permission
+  - Allowed merge methods, auto-merge, delete-branch-on-merge
+  - Squash commit title/message style
+  - Default branch protection summary (null when not readable with the token)
+  - Whether issues, discussions, projects, wiki, sub-issues, and a merge queue
+    on the default branch are available
+
+Results are cached per repository in .cache/repo/ for --max-age.
+
+Examples:
+  # Full info
+  gh-helper repo info
+
+  # Adapt a script to allowed merge methods
+  gh-helper repo info --jq '.repo.mergeMethods'
+
+  # Bypass the cache
+  gh-helper repo info --refresh`,
+	repoInfo,
+)
+
+func init() {
+	infoRepoCmd.Flags().Bool("refresh", false, "Ignore cached info and query GitHub")
+	infoRepoCmd.Flags().Duration("max-age", 24*time.Hour, "Maximum age of cached info")
+
+	repoCmd.AddCommand(infoRepoCmd)
+	rootCmd.AddCommand(repoCmd)
+}
+
+// RepoProtection summarizes the default branch protection rule
+type RepoProtection struct {
+	Pattern                        string   `json:"pattern"`
+	RequiredApprovals              int      `json:"requiredApprovals"`
+	RequiresCodeOwnerReviews       bool     `json:"requiresCodeOwnerReviews"`
+	RequiresStatusChecks           bool     `json:"requiresStatusChecks"`
+	RequiredStatusChecks           []string `json:"requiredStatusChecks,omitempty"`
+	RequiresStrictStatusChecks     bool     `json:"requiresStrictStatusChecks"`
+	RequiresLinearHistory          bool     `json:"requiresLinearHistory"`
+	RequiresConversationResolution bool     `json:"requiresConversationResolution"`
+	RequiresSignatures             bool     `json:"requiresSignatures"`
+}
+
+// RepoFeatures reports which optional features are available
+type RepoFeatures struct {
+	Issues      bool `json:"issues"`
+	Discussions bool `json:"discussions"`
+	Projects    bool `json:"projects"`
+	Wiki        bool `json:"wiki"`
+	SubIssues   bool `json:"subIssues"`
+	MergeQueue  bool `json:"mergeQueue"`
+}
+
+// RepoInfo is repository metadata used to adapt command behavior
+type RepoInfo struct {
+	NameWithOwner            string          `json:"nameWithOwner"`
+	DefaultBranch            string          `json:"defaultBranch"`
+	Visibility               string          `json:"visibility"`
+	IsArchived               bool            `json:"isArchived"`
+	IsFork                   bool            `json:"isFork"`
+	ViewerPermission         string          `json:"viewerPermission"`
+	MergeMethods             []string        `json:"mergeMethods"`
+	AutoMergeAllowed         bool            `json:"autoMergeAllowed"`
+	DeleteBranchOnMerge      bool            `json:"deleteBranchOnMerge"`
+	SquashMergeCommitTitle   string          `json:"squashMergeCommitTitle,omitempty"`
+	SquashMergeCommitMessage string          `json:"squashMergeCommitMessage,omitempty"`
+	Protection               *RepoProtection `json:"protection"`
+	Features                 RepoFeatures    `json:"features"`
+	FetchedAt                time.Time       `json:"fetchedAt"`
+}
+
+// AllowsMergeMethod reports whether a merge method (MERGE, SQUASH, REBASE) is allowed
+func (r *RepoInfo) AllowsMergeMethod(method string) bool {
+	for _, allowed := range r.MergeMethods {
+		if allowed == method {
+			return true
+		}
+	}
+	return false
+}
+
+func repoInfoFile(owner, repo string) string {
+	return filepath.Join(GetCacheDir(), "repo", fmt.Sprintf("%s-%s.json", owner, repo))
+}
+
+// loadCachedRepoInfo returns cached info no older than maxAge, or nil
+func loadCachedRepoInfo(owner, repo string, maxAge time.Duration) *RepoInfo {
+	data, err := os.ReadFile(repoInfoFile(owner, repo))
+	if err != nil {
+		return nil
+	}
+	var info RepoInfo
+	if err := Unmarshal(data, &info); err != nil || time.Since(info.FetchedAt) > maxAge {
+		return nil
+	}
+	return &info
+}
+
+func saveRepoInfo(owner, repo string, info *RepoInfo) error {
+	file := repoInfoFile(owner, repo)
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return fmt.Errorf("failed to create repo cache directory: %w", err)
+	}
+	data, err := yaml.MarshalWithOptions(info, yaml.UseJSONMarshaler())
+	if err != nil {
+		return fmt.Errorf("failed to marshal repo info: %w", err)
+	}
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("failed to write repo info: %w", err)
+	}
+	return nil
+}
+
+// GetRepoInfo returns repository info, using the per-repository cache when it is
+// younger than maxAge (maxAge <= 0 always queries GitHub). Cache write failures
+// are reported on w.
+func (c *GitHubClient) GetRepoInfo(w io.Writer, maxAge time.Duration) (*RepoInfo, error) {
+	if maxAge > 0 {
+		if info := loadCachedRepoInfo(c.Owner, c.Repo, maxAge); info != nil {
+			return info, nil
+		}
+	}
+
+	info, err := c.fetchRepoInfo()
+	if err != nil {
+		return nil, err
+	}
+	if err := saveRepoInfo(c.Owner, c.Repo, info); err != nil {
+		fmt.Fprintf(w, "⚠️  Failed to cache repository info: %v\n", err)
+	}
+	return info, nil
+}
+
+func (c *GitHubClient) fetchRepoInfo() (*RepoInfo, error) {
+	query := `
+	query($owner: String!, $repo: String!) {
+	  repository(owner: $owner, name: $repo) {
+	    nameWithOwner
+	    visibility
+	    isArchived
+	    isFork
+	    viewerPermission
+	    mergeCommitAllowed
+	    squashMergeAllowed
+	    rebaseMergeAllowed
+	    autoMergeAllowed
+	    deleteBranchOnMerge
+	    squashMergeCommitTitle
+	    squashMergeCommitMessage
+	    hasIssuesEnabled
+	    hasDiscussionsEnabled
+	    hasProjectsEnabled
+	    hasWikiEnabled
+	    defaultBranchRef {
+	      name
+	      branchProtectionRule {
+	        pattern
+	        requiredApprovingReviewCount
+	        requiresCodeOwnerReviews
+	        requiresStatusChecks
+	        requiredStatusCheckContexts
+	        requiresStrictStatusChecks
+	        requiresLinearHistory
+	        requiresConversationResolution
+	        requiresCommitSignatures
+	      }
+	    }
+	  }
+	}`
+
+	variables := map[string]interface{}{
+		"owner": c.Owner,
+		"repo":  c.Repo,
+	}
+
+	// Protection rules may not be readable with the token; keep the rest of the data
+	result, graphqlErrors, err := c.RunGraphQLQueryPartial(query, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository info: %w", err)
+	}
+	for _, graphqlErr := range graphqlErrors {
+		if !graphqlErr.HasPathElement("branchProtectionRule") {
+			return nil, fmt.Errorf("failed to get repository info: GraphQL error: %s", graphqlErr.Message)
+		}
+	}
+
+	var response struct {
+		Data struct {
+			Repository *struct {
+				NameWithOwner            string `json:"nameWithOwner"`
+				Visibility               string `json:"visibility"`
+				IsArchived               bool   `json:"isArchived"`
+				IsFork                   bool   `json:"isFork"`
+				ViewerPermission         string `json:"viewerPermission"`
+				MergeCommitAllowed       bool   `json:"mergeCommitAllowed"`
+				SquashMergeAllowed       bool   `json:"squashMergeAllowed"`
+				RebaseMergeAllowed       bool   `json:"rebaseMergeAllowed"`
+				AutoMergeAllowed         bool   `json:"autoMergeAllowed"`
+				DeleteBranchOnMerge      bool   `json:"deleteBranchOnMerge"`
+				SquashMergeCommitTitle   string `json:"squashMergeCommitTitle"`
+				SquashMergeCommitMessage string `json:"squashMergeCommitMessage"`
+				HasIssuesEnabled         bool   `json:"hasIssuesEnabled"`
+				HasDiscussionsEnabled    bool   `json:"hasDiscussionsEnabled"`
+				HasProjectsEnabled       bool   `json:"hasProjectsEnabled"`
+				HasWikiEnabled           bool   `json:"hasWikiEnabled"`
+				DefaultBranchRef         *struct {
+					Name                 string `json:"name"`
+					BranchProtectionRule *struct {
+						Pattern                        string   `json:"pattern"`
+						RequiredApprovingReviewCount   int      `json:"requiredApprovingReviewCount"`
+						RequiresCodeOwnerReviews       bool     `json:"requiresCodeOwnerReviews"`
+						RequiresStatusChecks           bool     `json:"requiresStatusChecks"`
+						RequiredStatusCheckContexts    []string `json:"requiredStatusCheckContexts"`
+						RequiresStrictStatusChecks     bool     `json:"requiresStrictStatusChecks"`
+						RequiresLinearHistory          bool     `json:"requiresLinearHistory"`
+						RequiresConversationResolution bool     `json:"requiresConversationResolution"`
+						RequiresCommitSignatures       bool     `json:"requiresCommitSignatures"`
+					} `json:"branchProtectionRule"`
+				} `json:"defaultBranchRef"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse repository info: %w", err)
+	}
+
+	r := response.Data.Repository
+	if r == nil {
+		return nil, fmt.Errorf("repository %s/%s not found", c.Owner, c.Repo)
+	}
+
+	info := &RepoInfo{
+		NameWithOwner:            r.NameWithOwner,
+		Visibility:               r.Visibility,
+		IsArchived:               r.IsArchived,
+		IsFork:                   r.IsFork,
+		ViewerPermission:         r.ViewerPermission,
+		MergeMethods:             []string{},
+		AutoMergeAllowed:         r.AutoMergeAllowed,
+		DeleteBranchOnMerge:      r.DeleteBranchOnMerge,
+		SquashMergeCommitTitle:   r.SquashMergeCommitTitle,
+		SquashMergeCommitMessage: r.SquashMergeCommitMessage,
+		Features: RepoFeatures{
+			Issues:      r.HasIssuesEnabled,
+			Discussions: r.HasDiscussionsEnabled,
+			Projects:    r.HasProjectsEnabled,
+			Wiki:        r.HasWikiEnabled,
+		},
+		FetchedAt: time.Now(),
+	}
+	if r.MergeCommitAllowed {
+		info.MergeMethods = append(info.MergeMethods, "MERGE")
+	}
+	if r.SquashMergeAllowed {
+		info.MergeMethods = append(info.MergeMethods, "SQUASH")
+	}
+	if r.RebaseMergeAllowed {
+		info.MergeMethods = append(info.MergeMethods, "REBASE")
+	}
+
+	if r.DefaultBranchRef != nil {
+		info.DefaultBranch = r.DefaultBranchRef.Name
+		if rule := r.DefaultBranchRef.BranchProtectionRule; rule != nil {
+			info.Protection = &RepoProtection{
+				Pattern:                        rule.Pattern,
+				RequiredApprovals:              rule.RequiredApprovingReviewCount,
+				RequiresCodeOwnerReviews:       rule.RequiresCodeOwnerReviews,
+				RequiresStatusChecks:           rule.RequiresStatusChecks,
+				RequiredStatusChecks:           rule.RequiredStatusCheckContexts,
+				RequiresStrictStatusChecks:     rule.RequiresStrictStatusChecks,
+				RequiresLinearHistory:          rule.RequiresLinearHistory,
+				RequiresConversationResolution: rule.RequiresConversationResolution,
+				RequiresSignatures:             rule.RequiresCommitSignatures,
+			}
+		}
+	}
+
+	_, info.Features.SubIssues = c.probeGraphQL(`
+	query($owner: String!, $repo: String!) {
+	  repository(owner: $owner, name: $repo) {
+	    issues(first: 1) { nodes { subIssuesSummary { total } } }
+	  }
+	}`, variables)
+
+	if info.DefaultBranch != "" {
+		mergeQueueVariables := map[string]interface{}{
+			"owner":  c.Owner,
+			"repo":   c.Repo,
+			"branch": info.DefaultBranch,
+		}
+		if result, ok := c.probeGraphQL(`
+		query($owner: String!, $repo: String!, $branch: String!) {
+		  repository(owner: $owner, name: $repo) {
+		    mergeQueue(branch: $branch) { id }
+		  }
+		}`, mergeQueueVariables); ok {
+			var mergeQueueResponse struct {
+				Data struct {
+					Repository struct {
+						MergeQueue *struct {
+							ID string `json:"id"`
+						} `json:"mergeQueue"`
+					} `json:"repository"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(result, &mergeQueueResponse); err == nil {
+				info.Features.MergeQueue = mergeQueueResponse.Data.Repository.MergeQueue != nil
+			}
+		}
+	}
+
+	return info, nil
+}
+
+// probeGraphQL runs a capability probe query; ok is false if it fails or returns GraphQL errors
+func (c *GitHubClient) probeGraphQL(query string, variables map[string]interface{}) ([]byte, bool) {
+	result, graphqlErrors, err := c.RunGraphQLQueryPartial(query, variables)
+	if err != nil || len(graphqlErrors) > 0 {
+		return nil, false
+	}
+	return result, true
+}
+
+func repoInfo(cmd *cobra.Command, args []string) error {
+	refresh, err := cmd.Flags().GetBool("refresh")
+	if err != nil {
+		return fmt.Errorf("failed to get 'refresh' flag: %w", err)
+	}
+	maxAge, err := cmd.Flags().GetDuration("max-age")
+	if err != nil {
+		return fmt.Errorf("failed to get 'max-age' flag: %w", err)
+	}
+	if refresh {
+		maxAge = 0
+	}
+
+	client := newCommandClient(cmd)
+	info, err := client.GetRepoInfo(messageWriter(cmd), maxAge)
+	if err != nil {
+		return err
+	}
+
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"repo": info})
+}
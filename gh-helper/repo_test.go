@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+func TestRepoInfoCacheRoundTrip(t *testing.T) {
+	fetchedAt := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	info := &RepoInfo{
+		NameWithOwner: "apstndb/spanner-mycli",
+		DefaultBranch: "main",
+		MergeMethods:  []string{"SQUASH"},
+		Protection:    &RepoProtection{RequiredApprovals: 1, RequiredStatusChecks: []string{"lint"}},
+		Features:      RepoFeatures{Issues: true, SubIssues: true},
+		FetchedAt:     fetchedAt,
+	}
+
+	data, err := yaml.MarshalWithOptions(info, yaml.UseJSONMarshaler())
+	if err != nil {
+		t.Fatalf("marshal error = %v", err)
+	}
+	var got RepoInfo
+	if err := Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !got.FetchedAt.Equal(fetchedAt) {
+		t.Errorf("FetchedAt = %v, want %v", got.FetchedAt, fetchedAt)
+	}
+	if got.Protection == nil || got.Protection.RequiredApprovals != 1 {
+		t.Errorf("Protection = %+v, want 1 required approval", got.Protection)
+	}
+	if !got.Features.SubIssues || got.Features.MergeQueue {
+		t.Errorf("Features = %+v", got.Features)
+	}
+	if !got.AllowsMergeMethod("SQUASH") || got.AllowsMergeMethod("MERGE") {
+		t.Errorf("AllowsMergeMethod() mismatch for %v", got.MergeMethods)
+	}
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Resolvable name kinds, also used as output keys
+const (
+	ResolveLabels     = "labels"
+	ResolveUsers      = "users"
+	ResolveMilestones = "milestones"
+	ResolveProjects   = "projects"
+	ResolveTeams      = "teams"
+)
+
+// resolveKinds lists kinds in output order with their query alias prefixes
+var resolveKinds = []struct {
+	kind   string
+	prefix string
+}{
+	{ResolveLabels, "l"},
+	{ResolveUsers, "u"},
+	{ResolveMilestones, "m"},
+	{ResolveProjects, "p"},
+	{ResolveTeams, "t"},
+}
+
+// ResolveRequest lists names to resolve to node IDs.
+// Users may include "@me"; teams are slugs in the repository owner's organization.
+type ResolveRequest struct {
+	Labels     []string
+	Users      []string
+	Milestones []string
+	Projects   []string
+	Teams      []string
+}
+
+func (r ResolveRequest) names(kind string) []string {
+	switch kind {
+	case ResolveLabels:
+		return r.Labels
+	case ResolveUsers:
+		return r.Users
+	case ResolveMilestones:
+		return r.Milestones
+	case ResolveProjects:
+		return r.Projects
+	case ResolveTeams:
+		return r.Teams
+	}
+	return nil
+}
+
+// ResolveResult holds resolved node IDs and unknown names, both keyed by kind
+type ResolveResult struct {
+	IDs     map[string]map[string]string `json:"resolved"`
+	Unknown map[string][]string          `json:"unknown"`
+}
+
+// ID returns the node ID resolved for a name
+func (r *ResolveResult) ID(kind, name string) (string, bool) {
+	id, ok := r.IDs[kind][name]
+	return id, ok
+}
+
+// IDsOf returns node IDs for names in order, skipping unknown names
+func (r *ResolveResult) IDsOf(kind string, names []string) []string {
+	var ids []string
+	for _, name := range names {
+		if id, ok := r.ID(kind, name); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Err returns an error listing every unknown name, or nil if all names resolved
+func (r *ResolveResult) Err() error {
+	var parts []string
+	for _, k := range resolveKinds {
+		if names := r.Unknown[k.kind]; len(names) > 0 {
+			parts = append(parts, fmt.Sprintf("unknown %s: %s", k.kind, strings.Join(names, ", ")))
+		}
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(parts, "; "))
+}
+
+// Resolver resolves labels, users, milestones, projects, and teams to node IDs
+// in a single aliased GraphQL query, caching results for the client's lifetime
+type Resolver struct {
+	client *GitHubClient
+	cache  map[string]map[string]string
+}
+
+// Resolver returns the client's shared resolver
+func (c *GitHubClient) Resolver() *Resolver {
+	if c.resolver == nil {
+		c.resolver = &Resolver{client: c, cache: make(map[string]map[string]string)}
+	}
+	return c.resolver
+}
+
+// resolveQueryItem is one aliased lookup in the batch query
+type resolveQueryItem struct {
+	kind  string
+	name  string
+	alias string
+}
+
+// pendingResolveItems returns uncached, deduplicated names with their aliases
+func (r *Resolver) pendingResolveItems(req ResolveRequest) []resolveQueryItem {
+	var items []resolveQueryItem
+	for _, k := range resolveKinds {
+		seen := make(map[string]bool)
+		for _, name := range req.names(k.kind) {
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			if _, ok := r.cache[k.kind][name]; ok {
+				continue
+			}
+			items = append(items, resolveQueryItem{kind: k.kind, name: name, alias: fmt.Sprintf("%s%d", k.prefix, len(seen)-1)})
+		}
+	}
+	return items
+}
+
+// buildResolveQuery builds the aliased query and its variables for the given items
+func buildResolveQuery(owner, repo string, items []resolveQueryItem) (string, map[string]interface{}) {
+	variables := map[string]interface{}{
+		"owner": owner,
+		"repo":  repo,
+	}
+	var params, repoFields, rootFields, teamFields strings.Builder
+	params.WriteString("$owner: String!, $repo: String!")
+
+	for _, item := range items {
+		if item.kind == ResolveUsers && item.name == "@me" {
+			fmt.Fprintf(&rootFields, "  %s: viewer { id }\n", item.alias)
+			continue
+		}
+		fmt.Fprintf(&params, ", $%s: String!", item.alias)
+		variables[item.alias] = item.name
+
+		switch item.kind {
+		case ResolveLabels:
+			fmt.Fprintf(&repoFields, "    %s: label(name: $%s) { id }\n", item.alias, item.alias)
+		case ResolveMilestones:
+			fmt.Fprintf(&repoFields, "    %s: milestones(query: $%s, first: 20) { nodes { id title } }\n", item.alias, item.alias)
+		case ResolveProjects:
+			fmt.Fprintf(&repoFields, "    %s: projectsV2(query: $%s, first: 20) { nodes { id title } }\n", item.alias, item.alias)
+		case ResolveUsers:
+			fmt.Fprintf(&rootFields, "  %s: user(login: $%s) { id }\n", item.alias, item.alias)
+		case ResolveTeams:
+			fmt.Fprintf(&teamFields, "    %s: team(slug: $%s) { id }\n", item.alias, item.alias)
+		}
+	}
+
+	var query strings.Builder
+	fmt.Fprintf(&query, "query(%s) {\n", params.String())
+	if repoFields.Len() > 0 {
+		fmt.Fprintf(&query, "  repository(owner: $owner, name: $repo) {\n%s  }\n", repoFields.String())
+	}
+	if teamFields.Len() > 0 {
+		fmt.Fprintf(&query, "  organization(login: $owner) {\n%s  }\n", teamFields.String())
+	}
+	query.WriteString(rootFields.String())
+	query.WriteString("}")
+	return query.String(), variables
+}
+
+// extractResolvedID returns the node ID for an item from its aliased response field
+func extractResolvedID(kind, name string, raw json.RawMessage) (string, bool) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", false
+	}
+
+	switch kind {
+	case ResolveMilestones, ResolveProjects:
+		// Search results may contain partial matches; require the exact title
+		var connection struct {
+			Nodes []struct {
+				ID    string `json:"id"`
+				Title string `json:"title"`
+			} `json:"nodes"`
+		}
+		if err := json.Unmarshal(raw, &connection); err != nil {
+			return "", false
+		}
+		for _, node := range connection.Nodes {
+			if node.Title == name {
+				return node.ID, true
+			}
+		}
+		return "", false
+	default:
+		var node struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(raw, &node); err != nil || node.ID == "" {
+			return "", false
+		}
+		return node.ID, true
+	}
+}
+
+// Resolve resolves all requested names, querying GitHub once for names not yet cached.
+// Unknown names are reported in the result rather than as an error; use result.Err()
+// to fail before running mutations.
+func (r *Resolver) Resolve(req ResolveRequest) (*ResolveResult, error) {
+	items := r.pendingResolveItems(req)
+	if len(items) > 0 {
+		query, variables := buildResolveQuery(r.client.Owner, r.client.Repo, items)
+
+		// Unknown users and organizations are reported as per-alias errors
+		responseData, graphqlErrors, err := r.client.RunGraphQLQueryPartial(query, variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve names: %w", err)
+		}
+		// (a missing organization means the owner is a user, so teams stay unknown)
+		known := map[string]bool{"organization": true}
+		for _, item := range items {
+			known[item.alias] = true
+		}
+		for _, graphqlErr := range graphqlErrors {
+			alias := graphqlErr.Alias()
+			if alias == "repository" && len(graphqlErr.Path) > 1 {
+				continue
+			}
+			if !known[alias] {
+				return nil, fmt.Errorf("failed to resolve names: GraphQL error: %s", graphqlErr.Message)
+			}
+		}
+
+		var response struct {
+			Data map[string]json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse resolve response: %w", err)
+		}
+		fields := make(map[string]json.RawMessage)
+		for key, raw := range response.Data {
+			if key == "repository" || key == "organization" {
+				var nested map[string]json.RawMessage
+				if err := json.Unmarshal(raw, &nested); err == nil {
+					for alias, value := range nested {
+						fields[alias] = value
+					}
+				}
+				continue
+			}
+			fields[key] = raw
+		}
+
+		for _, item := range items {
+			if id, ok := extractResolvedID(item.kind, item.name, fields[item.alias]); ok {
+				if r.cache[item.kind] == nil {
+					r.cache[item.kind] = make(map[string]string)
+				}
+				r.cache[item.kind][item.name] = id
+			}
+		}
+	}
+
+	result := &ResolveResult{
+		IDs:     make(map[string]map[string]string),
+		Unknown: make(map[string][]string),
+	}
+	for _, k := range resolveKinds {
+		for _, name := range req.names(k.kind) {
+			if name == "" {
+				continue
+			}
+			if id, ok := r.cache[k.kind][name]; ok {
+				if result.IDs[k.kind] == nil {
+					result.IDs[k.kind] = make(map[string]string)
+				}
+				result.IDs[k.kind][name] = id
+			} else if !containsString(result.Unknown[k.kind], name) {
+				result.Unknown[k.kind] = append(result.Unknown[k.kind], name)
+			}
+		}
+	}
+	return result, nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
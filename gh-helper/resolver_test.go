@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestBuildResolveQuery(t *testing.T) {
+	r := &Resolver{cache: map[string]map[string]string{
+		ResolveLabels: {"cached": "LA_cached"},
+	}}
+	items := r.pendingResolveItems(ResolveRequest{
+		Labels:     []string{"bug", "cached", "bug"},
+		Users:      []string{"@me", "octocat"},
+		Milestones: []string{"v0.20.0"},
+		Teams:      []string{"backend"},
+	})
+
+	var aliases []string
+	for _, item := range items {
+		aliases = append(aliases, item.alias)
+	}
+	if got, want := strings.Join(aliases, ","), "l0,u0,u1,m0,t0"; got != want {
+		t.Fatalf("aliases = %s, want %s", got, want)
+	}
+
+	query, variables := buildResolveQuery("o", "r", items)
+	for _, want := range []string{
+		"$l0: String!",
+		"l0: label(name: $l0) { id }",
+		"u0: viewer { id }",
+		"u1: user(login: $u1) { id }",
+		"m0: milestones(query: $m0, first: 20)",
+		"organization(login: $owner) {\n    t0: team(slug: $t0) { id }",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query missing %q:\n%s", want, query)
+		}
+	}
+	if strings.Contains(query, "$u0") {
+		t.Errorf("@me should not need a variable:\n%s", query)
+	}
+	if variables["l0"] != "bug" || variables["u1"] != "octocat" || variables["t0"] != "backend" {
+		t.Errorf("unexpected variables: %v", variables)
+	}
+}
+
+func TestExtractResolvedID(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   string
+		input  string
+		raw    string
+		want   string
+		wantOK bool
+	}{
+		{"label", ResolveLabels, "bug", `{"id":"LA_1"}`, "LA_1", true},
+		{"missing label", ResolveLabels, "bgu", `null`, "", false},
+		{"milestone exact title", ResolveMilestones, "v0.2", `{"nodes":[{"id":"MI_1","title":"v0.20"},{"id":"MI_2","title":"v0.2"}]}`, "MI_2", true},
+		{"milestone partial only", ResolveMilestones, "v0", `{"nodes":[{"id":"MI_1","title":"v0.20"}]}`, "", false},
+		{"absent field", ResolveUsers, "ghost", ``, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := extractResolvedID(tt.kind, tt.input, json.RawMessage(tt.raw))
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("extractResolvedID() = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestResolveResultErr(t *testing.T) {
+	result := &ResolveResult{Unknown: map[string][]string{
+		ResolveUsers:  {"ghost"},
+		ResolveLabels: {"bgu", "featur"},
+	}}
+	want := "unknown labels: bgu, featur; unknown users: ghost"
+	if err := result.Err(); err == nil || err.Error() != want {
+		t.Errorf("Err() = %v, want %q", err, want)
+	}
+	if err := (&ResolveResult{}).Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
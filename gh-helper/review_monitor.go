@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/apstndb/gh-dev-tools/pkg/reviewwait"
 )
 
 // ReviewMonitor provides comprehensive review tracking to prevent missing important feedback
@@ -25,12 +27,12 @@ type ReviewMonitor struct {
 }
 
 // ReviewSeverity represents the priority level of review feedback
-type ReviewSeverity string
+type ReviewSeverity = reviewwait.Severity
 
 const (
-	SeverityCritical ReviewSeverity = "CRITICAL"
-	SeverityHigh     ReviewSeverity = "HIGH"
-	SeverityInfo     ReviewSeverity = "INFO"
+	SeverityCritical = reviewwait.SeverityCritical
+	SeverityHigh     = reviewwait.SeverityHigh
+	SeverityInfo     = reviewwait.SeverityInfo
 )
 
 // ReviewItem represents an actionable item from a review
@@ -130,14 +132,7 @@ query($owner: String!, $repo: String!, $prNumber: Int!) {
 
 // detectSeverity analyzes review text for Gemini severity markers
 func (m *ReviewMonitor) detectSeverity(body string) ReviewSeverity {
-	// Only recognize Gemini's explicit severity markers
-	if strings.Contains(body, "![critical]") {
-		return SeverityCritical
-	}
-	if strings.Contains(body, "![high]") {
-		return SeverityHigh
-	}
-	return SeverityInfo
+	return reviewwait.MarkedSeverity(body)
 }
 
 
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var heatmapReviewsCmd = NewOperationalCommand(
+	"heatmap [flags]",
+	"Rank directories by review thread density across merged PRs",
+	`Aggregate review threads of merged PRs per directory and rank directories by
+review friction: threads per 100 changed lines (additions + deletions).
+
+Directories are the first --depth path segments; files at the top level count
+as "(root)". Directories with fewer than --min-lines changed lines are listed
+but ranked after the others, since a few threads on a tiny change say little.
+
+--since accepts days (90d), a duration (720h), or a date (YYYY-MM-DD).
+
+Examples:
+  # Last 90 days, as a table
+  gh-helper reviews heatmap --since 90d --format markdown
+
+  # Second-level directories of a monorepo
+  gh-helper reviews heatmap --since 2025-01-01 --depth 2 --format csv`,
+	reviewsHeatmap,
+)
+
+func init() {
+	addOutputFormats(heatmapReviewsCmd, FormatMarkdown, FormatCSV, FormatTSV)
+	heatmapReviewsCmd.Flags().String("since", "90d", "Only PRs merged after this (e.g., 90d, 720h, 2025-01-01)")
+	heatmapReviewsCmd.Flags().Int("depth", 1, "Number of path segments that make up a directory")
+	heatmapReviewsCmd.Flags().Int("min-lines", 50, "Rank directories with fewer changed lines last")
+	heatmapReviewsCmd.Flags().Int("limit", 200, "Maximum number of merged PRs to scan (up to 1000)")
+
+	reviewsCmd.AddCommand(heatmapReviewsCmd)
+}
+
+// HeatmapDirectory is the review friction of one directory
+type HeatmapDirectory struct {
+	Directory          string  `json:"directory"`
+	PRs                int     `json:"prs"`
+	ChangedLines       int     `json:"changedLines"`
+	Threads            int     `json:"threads"`
+	Comments           int     `json:"comments"`
+	ThreadsPer100Lines float64 `json:"threadsPer100Lines"`
+	BelowMinLines      bool    `json:"belowMinLines,omitempty"`
+	prs                map[int]bool
+}
+
+// heatmapPR is a merged PR with its changed files and review threads
+type heatmapPR struct {
+	Number int `json:"number"`
+	Files  struct {
+		Nodes []struct {
+			Path      string `json:"path"`
+			Additions int    `json:"additions"`
+			Deletions int    `json:"deletions"`
+		} `json:"nodes"`
+	} `json:"files"`
+	ReviewThreads struct {
+		Nodes []struct {
+			Path     string `json:"path"`
+			Comments struct {
+				TotalCount int `json:"totalCount"`
+			} `json:"comments"`
+		} `json:"nodes"`
+	} `json:"reviewThreads"`
+}
+
+// heatmapDirectory returns the first depth segments of the directory of path
+func heatmapDirectory(path string, depth int) string {
+	segments := strings.Split(path, "/")
+	segments = segments[:len(segments)-1] // drop the file name
+	if len(segments) == 0 {
+		return "(root)"
+	}
+	if len(segments) > depth {
+		segments = segments[:depth]
+	}
+	return strings.Join(segments, "/")
+}
+
+// computeHeatmap aggregates PRs per directory, densest first. Directories
+// below minLines changed lines are ranked after the others.
+func computeHeatmap(prs []heatmapPR, depth, minLines int) []HeatmapDirectory {
+	byDirectory := map[string]*HeatmapDirectory{}
+	directory := func(path string) *HeatmapDirectory {
+		name := heatmapDirectory(path, depth)
+		dir, ok := byDirectory[name]
+		if !ok {
+			dir = &HeatmapDirectory{Directory: name, prs: map[int]bool{}}
+			byDirectory[name] = dir
+		}
+		return dir
+	}
+
+	for _, pr := range prs {
+		for _, file := range pr.Files.Nodes {
+			dir := directory(file.Path)
+			dir.ChangedLines += file.Additions + file.Deletions
+			dir.prs[pr.Number] = true
+		}
+		for _, thread := range pr.ReviewThreads.Nodes {
+			dir := directory(thread.Path)
+			dir.Threads++
+			dir.Comments += thread.Comments.TotalCount
+			dir.prs[pr.Number] = true
+		}
+	}
+
+	directories := make([]HeatmapDirectory, 0, len(byDirectory))
+	for _, dir := range byDirectory {
+		dir.PRs = len(dir.prs)
+		if dir.ChangedLines > 0 {
+			dir.ThreadsPer100Lines = math.Round(float64(dir.Threads)*100/float64(dir.ChangedLines)*100) / 100
+		}
+		dir.BelowMinLines = dir.ChangedLines < minLines
+		directories = append(directories, *dir)
+	}
+	sort.Slice(directories, func(i, j int) bool {
+		a, b := directories[i], directories[j]
+		if a.BelowMinLines != b.BelowMinLines {
+			return !a.BelowMinLines
+		}
+		if a.ThreadsPer100Lines != b.ThreadsPer100Lines {
+			return a.ThreadsPer100Lines > b.ThreadsPer100Lines
+		}
+		if a.Threads != b.Threads {
+			return a.Threads > b.Threads
+		}
+		return a.Directory < b.Directory
+	})
+	return directories
+}
+
+// fetchHeatmapPRs fetches up to limit PRs merged since the given time, newest first
+func (c *GitHubClient) fetchHeatmapPRs(since time.Time, limit int) ([]heatmapPR, error) {
+	searchQuery := fmt.Sprintf("repo:%s/%s is:pr is:merged merged:>=%s sort:updated-desc", c.Owner, c.Repo, since.Format("2006-01-02"))
+	query := `
+	query($searchQuery: String!, $first: Int!, $after: String) {
+		search(query: $searchQuery, type: ISSUE, first: $first, after: $after) {
+			nodes {
+				... on PullRequest {
+					number
+					files(first: 100) { nodes { path additions deletions } }
+					reviewThreads(first: 100) { nodes { path comments { totalCount } } }
+				}
+			}
+			pageInfo { hasNextPage endCursor }
+		}
+	}`
+
+	var prs []heatmapPR
+	var after *string
+	for len(prs) < limit {
+		responseData, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+			"searchQuery": searchQuery,
+			"first":       min(limit-len(prs), 50),
+			"after":       after,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Data struct {
+				Search struct {
+					Nodes    []heatmapPR `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"search"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse search response: %w", err)
+		}
+
+		prs = append(prs, response.Data.Search.Nodes...)
+		if !response.Data.Search.PageInfo.HasNextPage {
+			break
+		}
+		cursor := response.Data.Search.PageInfo.EndCursor
+		after = &cursor
+	}
+	return prs, nil
+}
+
+// writeHeatmapDelimited writes the ranked directories as CSV (TSV when comma is '\t')
+func writeHeatmapDelimited(w io.Writer, directories []HeatmapDirectory, comma rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+
+	rows := [][]string{{"rank", "directory", "threadsPer100Lines", "threads", "comments", "changedLines", "prs"}}
+	for i, dir := range directories {
+		rows = append(rows, []string{
+			strconv.Itoa(i + 1),
+			dir.Directory,
+			strconv.FormatFloat(dir.ThreadsPer100Lines, 'f', -1, 64),
+			strconv.Itoa(dir.Threads),
+			strconv.Itoa(dir.Comments),
+			strconv.Itoa(dir.ChangedLines),
+			strconv.Itoa(dir.PRs),
+		})
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func outputMarkdownHeatmap(w io.Writer, since string, prs int, directories []HeatmapDirectory, minLines int) {
+	fmt.Fprintf(w, "# Review heatmap since %s\n\n", since)
+	fmt.Fprintf(w, "%d merged PRs. Directories with fewer than %d changed lines are marked with *.\n\n", prs, minLines)
+	fmt.Fprintln(w, "| Rank | Directory | Threads/100 lines | Threads | Comments | Changed lines | PRs |")
+	fmt.Fprintln(w, "|------|-----------|-------------------|---------|----------|---------------|-----|")
+	for i, dir := range directories {
+		name := dir.Directory
+		if dir.BelowMinLines {
+			name += " *"
+		}
+		fmt.Fprintf(w, "| %d | %s | %s | %d | %d | %d | %d |\n", i+1, name,
+			strconv.FormatFloat(dir.ThreadsPer100Lines, 'f', -1, 64), dir.Threads, dir.Comments, dir.ChangedLines, dir.PRs)
+	}
+}
+
+func reviewsHeatmap(cmd *cobra.Command, args []string) error {
+	sinceFlag, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return fmt.Errorf("failed to get 'since' flag: %w", err)
+	}
+	depth, err := cmd.Flags().GetInt("depth")
+	if err != nil {
+		return fmt.Errorf("failed to get 'depth' flag: %w", err)
+	}
+	minLines, err := cmd.Flags().GetInt("min-lines")
+	if err != nil {
+		return fmt.Errorf("failed to get 'min-lines' flag: %w", err)
+	}
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return fmt.Errorf("failed to get 'limit' flag: %w", err)
+	}
+
+	if depth <= 0 {
+		return fmt.Errorf("--depth must be positive")
+	}
+	if limit <= 0 || limit > 1000 {
+		return fmt.Errorf("--limit must be between 1 and 1000")
+	}
+	since, err := parseSince(sinceFlag, time.Now())
+	if err != nil {
+		return err
+	}
+
+	client := newCommandClient(cmd)
+	prs, err := client.fetchHeatmapPRs(since, limit)
+	if err != nil {
+		return fmt.Errorf("failed to fetch merged pull requests: %w", err)
+	}
+	directories := computeHeatmap(prs, depth, minLines)
+	sinceDate := since.Format("2006-01-02")
+
+	switch ResolveFormat(cmd) {
+	case FormatCSV:
+		return writeHeatmapDelimited(cmd.OutOrStdout(), directories, ',')
+	case FormatTSV:
+		return writeHeatmapDelimited(cmd.OutOrStdout(), directories, '\t')
+	case FormatMarkdown:
+		outputMarkdownHeatmap(cmd.OutOrStdout(), sinceDate, len(prs), directories, minLines)
+		return nil
+	}
+
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{
+		"reviewHeatmap": map[string]interface{}{
+			"since":       sinceDate,
+			"prs":         len(prs),
+			"depth":       depth,
+			"directories": directories,
+		},
+	})
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestHeatmapDirectory(t *testing.T) {
+	tests := []struct {
+		path  string
+		depth int
+		want  string
+	}{
+		{"README.md", 1, "(root)"},
+		{"gh-helper/main.go", 1, "gh-helper"},
+		{"pkg/ghclient/client.go", 1, "pkg"},
+		{"pkg/ghclient/client.go", 2, "pkg/ghclient"},
+		{"pkg/ghclient/client.go", 5, "pkg/ghclient"},
+	}
+	for _, tt := range tests {
+		if got := heatmapDirectory(tt.path, tt.depth); got != tt.want {
+			t.Errorf("heatmapDirectory(%q, %d) = %q, want %q", tt.path, tt.depth, got, tt.want)
+		}
+	}
+}
+
+func TestComputeHeatmap(t *testing.T) {
+	var prs []heatmapPR
+	data := `[
+		{"number": 1,
+		 "files": {"nodes": [
+			{"path": "pkg/a.go", "additions": 80, "deletions": 20},
+			{"path": "cmd/main.go", "additions": 200, "deletions": 0},
+			{"path": "README.md", "additions": 2, "deletions": 0}]},
+		 "reviewThreads": {"nodes": [
+			{"path": "pkg/a.go", "comments": {"totalCount": 3}},
+			{"path": "pkg/a.go", "comments": {"totalCount": 1}},
+			{"path": "README.md", "comments": {"totalCount": 2}}]}},
+		{"number": 2,
+		 "files": {"nodes": [{"path": "cmd/main.go", "additions": 100, "deletions": 100}]},
+		 "reviewThreads": {"nodes": [{"path": "cmd/main.go", "comments": {"totalCount": 1}}]}}
+	]`
+	if err := json.Unmarshal([]byte(data), &prs); err != nil {
+		t.Fatal(err)
+	}
+
+	directories := computeHeatmap(prs, 1, 50)
+	if len(directories) != 3 {
+		t.Fatalf("computeHeatmap() = %+v, want 3 directories", directories)
+	}
+	pkg, cmd, root := directories[0], directories[1], directories[2]
+	if pkg.Directory != "pkg" || pkg.Threads != 2 || pkg.Comments != 4 || pkg.ThreadsPer100Lines != 2 {
+		t.Errorf("first = %+v, want pkg with 2 threads per 100 lines", pkg)
+	}
+	if cmd.Directory != "cmd" || cmd.PRs != 2 || cmd.ChangedLines != 400 || cmd.ThreadsPer100Lines != 0.25 {
+		t.Errorf("second = %+v, want cmd over 2 PRs", cmd)
+	}
+	// The densest directory is ranked last when it changed too few lines
+	if root.Directory != "(root)" || !root.BelowMinLines || root.ThreadsPer100Lines != 50 {
+		t.Errorf("last = %+v, want (root) below --min-lines", root)
+	}
+}
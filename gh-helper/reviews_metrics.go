@@ -0,0 +1,480 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var metricsReviewsCmd = NewOperationalCommand(
+	"metrics [pr-number...] [flags]",
+	"Compute review thread metrics per reviewer and per file",
+	`Compute review-health metrics from review threads of the given PRs, or of all
+PRs in --milestone:
+
+  reviewers     Threads opened, comments, and threads resolved per reviewer, with
+                the average time from a thread's first comment to its resolution
+  files         Files attracting the most threads and comments
+  authorReplies How quickly each PR author first replied to reviewer threads
+
+GitHub does not expose when a thread was resolved, so resolution time is
+measured to the last comment of a resolved thread.
+
+--format csv/tsv emits one "metric,key,value" row per number (long format),
+ready for dashboards and spreadsheets.
+
+Examples:
+  # Metrics for one PR
+  gh-helper reviews metrics 306
+
+  # Milestone-wide metrics for a dashboard
+  gh-helper reviews metrics --milestone v0.20.0 --format csv > review-metrics.csv
+
+  # Top 5 files by feedback
+  gh-helper reviews metrics --milestone v0.20.0 --jq '.reviewMetrics.files[:5]'`,
+	reviewsMetrics,
+)
+
+func init() {
+	addOutputFormats(metricsReviewsCmd, FormatCSV, FormatTSV)
+	metricsReviewsCmd.Flags().String("milestone", "", "Compute metrics for all PRs in this milestone")
+	metricsReviewsCmd.Flags().Int("limit", 100, "Maximum PRs to include from --milestone")
+
+	reviewsCmd.AddCommand(metricsReviewsCmd)
+}
+
+// ReviewerMetrics summarizes the review threads opened by one reviewer
+type ReviewerMetrics struct {
+	Reviewer             string  `json:"reviewer"`
+	ThreadsOpened        int     `json:"threadsOpened"`
+	Comments             int     `json:"comments"`
+	Resolved             int     `json:"resolved"`
+	AvgResolutionHours   float64 `json:"avgResolutionHours"`
+	resolutionTotalHours float64
+}
+
+// FileMetrics summarizes review feedback on one file
+type FileMetrics struct {
+	Path      string `json:"path"`
+	Threads   int    `json:"threads"`
+	Comments  int    `json:"comments"`
+	Reviewers int    `json:"reviewers"`
+	reviewers map[string]bool
+}
+
+// AuthorReplyMetrics summarizes how quickly a PR author replied to reviewer threads
+type AuthorReplyMetrics struct {
+	Author           string  `json:"author"`
+	Threads          int     `json:"threads"`
+	Replied          int     `json:"replied"`
+	AvgReplyHours    float64 `json:"avgReplyHours"`
+	MedianReplyHours float64 `json:"medianReplyHours"`
+	replyHours       []float64
+}
+
+// ReviewMetrics is the result of 'reviews metrics'
+type ReviewMetrics struct {
+	PRs           int                  `json:"prs"`
+	Threads       int                  `json:"threads"`
+	Reviewers     []ReviewerMetrics    `json:"reviewers"`
+	Files         []FileMetrics        `json:"files"`
+	AuthorReplies []AuthorReplyMetrics `json:"authorReplies"`
+}
+
+// metricsPR is the per-PR data needed for review metrics
+type metricsPR struct {
+	Number        int       `json:"number"`
+	Author        *actorRef `json:"author"`
+	ReviewThreads struct {
+		Nodes []struct {
+			IsResolved bool   `json:"isResolved"`
+			Path       string `json:"path"`
+			Comments   struct {
+				Nodes []struct {
+					Author    *actorRef `json:"author"`
+					CreatedAt string    `json:"createdAt"`
+				} `json:"nodes"`
+			} `json:"comments"`
+		} `json:"nodes"`
+	} `json:"reviewThreads"`
+}
+
+// GitHub rejects queries that may return over 500,000 nodes, counted as the
+// product of the first: arguments along each connection path
+const (
+	metricsPRBatchSize       = 20  // PRs per query (page or alias batch)
+	metricsThreadsPerPR      = 100 // reviewThreads(first:)
+	metricsCommentsPerThread = 50  // comments(first:)
+)
+
+var metricsPRFields = fmt.Sprintf(`
+	number
+	author { login }
+	reviewThreads(first: %d) {
+		nodes {
+			isResolved
+			path
+			comments(first: %d) { nodes { author { login } createdAt } }
+		}
+	}`, metricsThreadsPerPR, metricsCommentsPerThread)
+
+// metricsQueryNodes is the worst-case node count of one metrics query
+func metricsQueryNodes() int {
+	return metricsPRBatchSize * (1 + metricsThreadsPerPR*(1+metricsCommentsPerThread))
+}
+
+// roundHours rounds hours to one decimal place
+func roundHours(hours float64) float64 {
+	return math.Round(hours*10) / 10
+}
+
+// computeReviewMetrics aggregates review thread metrics across PRs
+func computeReviewMetrics(prs []metricsPR) ReviewMetrics {
+	reviewers := map[string]*ReviewerMetrics{}
+	files := map[string]*FileMetrics{}
+	replies := map[string]*AuthorReplyMetrics{}
+	metrics := ReviewMetrics{PRs: len(prs)}
+
+	for _, pr := range prs {
+		author := pr.Author.login()
+		for _, thread := range pr.ReviewThreads.Nodes {
+			comments := thread.Comments.Nodes
+			if len(comments) == 0 {
+				continue
+			}
+			metrics.Threads++
+
+			file := files[thread.Path]
+			if file == nil {
+				file = &FileMetrics{Path: thread.Path, reviewers: map[string]bool{}}
+				files[thread.Path] = file
+			}
+			file.Threads++
+			file.Comments += len(comments)
+
+			for _, comment := range comments {
+				login := comment.Author.login()
+				if login == "" || login == author {
+					continue
+				}
+				file.reviewers[login] = true
+				if reviewers[login] == nil {
+					reviewers[login] = &ReviewerMetrics{Reviewer: login}
+				}
+				reviewers[login].Comments++
+			}
+
+			// Threads opened by the PR author (self-notes) don't count as feedback
+			opener := comments[0].Author.login()
+			if opener == "" || opener == author {
+				continue
+			}
+			openedAt, err := time.Parse(time.RFC3339, comments[0].CreatedAt)
+			if err != nil {
+				continue
+			}
+
+			reviewer := reviewers[opener]
+			reviewer.ThreadsOpened++
+			if thread.IsResolved {
+				if lastAt, err := time.Parse(time.RFC3339, comments[len(comments)-1].CreatedAt); err == nil {
+					reviewer.Resolved++
+					reviewer.resolutionTotalHours += lastAt.Sub(openedAt).Hours()
+				}
+			}
+
+			if author == "" {
+				continue
+			}
+			reply := replies[author]
+			if reply == nil {
+				reply = &AuthorReplyMetrics{Author: author}
+				replies[author] = reply
+			}
+			reply.Threads++
+			for _, comment := range comments[1:] {
+				if comment.Author.login() != author {
+					continue
+				}
+				if repliedAt, err := time.Parse(time.RFC3339, comment.CreatedAt); err == nil {
+					reply.Replied++
+					reply.replyHours = append(reply.replyHours, repliedAt.Sub(openedAt).Hours())
+				}
+				break
+			}
+		}
+	}
+
+	metrics.Reviewers = []ReviewerMetrics{}
+	for _, reviewer := range reviewers {
+		if reviewer.Resolved > 0 {
+			reviewer.AvgResolutionHours = roundHours(reviewer.resolutionTotalHours / float64(reviewer.Resolved))
+		}
+		metrics.Reviewers = append(metrics.Reviewers, *reviewer)
+	}
+	sort.Slice(metrics.Reviewers, func(i, j int) bool {
+		a, b := metrics.Reviewers[i], metrics.Reviewers[j]
+		if a.ThreadsOpened != b.ThreadsOpened {
+			return a.ThreadsOpened > b.ThreadsOpened
+		}
+		return a.Reviewer < b.Reviewer
+	})
+
+	metrics.Files = []FileMetrics{}
+	for _, file := range files {
+		file.Reviewers = len(file.reviewers)
+		metrics.Files = append(metrics.Files, *file)
+	}
+	sort.Slice(metrics.Files, func(i, j int) bool {
+		a, b := metrics.Files[i], metrics.Files[j]
+		if a.Threads != b.Threads {
+			return a.Threads > b.Threads
+		}
+		if a.Comments != b.Comments {
+			return a.Comments > b.Comments
+		}
+		return a.Path < b.Path
+	})
+
+	metrics.AuthorReplies = []AuthorReplyMetrics{}
+	for _, reply := range replies {
+		if len(reply.replyHours) > 0 {
+			total := 0.0
+			for _, hours := range reply.replyHours {
+				total += hours
+			}
+			sort.Float64s(reply.replyHours)
+			reply.AvgReplyHours = roundHours(total / float64(len(reply.replyHours)))
+			middle := len(reply.replyHours) / 2
+			median := reply.replyHours[middle]
+			if len(reply.replyHours)%2 == 0 {
+				median = (reply.replyHours[middle-1] + median) / 2
+			}
+			reply.MedianReplyHours = roundHours(median)
+		}
+		metrics.AuthorReplies = append(metrics.AuthorReplies, *reply)
+	}
+	sort.Slice(metrics.AuthorReplies, func(i, j int) bool {
+		return metrics.AuthorReplies[i].Author < metrics.AuthorReplies[j].Author
+	})
+
+	return metrics
+}
+
+// writeReviewMetricsDelimited writes metrics as "metric,key,value" rows (TSV when comma is '\t')
+func writeReviewMetricsDelimited(w io.Writer, metrics ReviewMetrics, comma rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+
+	formatFloat := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	rows := [][]string{
+		{"metric", "key", "value"},
+		{"prs", "", strconv.Itoa(metrics.PRs)},
+		{"threads", "", strconv.Itoa(metrics.Threads)},
+	}
+	for _, reviewer := range metrics.Reviewers {
+		rows = append(rows,
+			[]string{"reviewer.threadsOpened", reviewer.Reviewer, strconv.Itoa(reviewer.ThreadsOpened)},
+			[]string{"reviewer.comments", reviewer.Reviewer, strconv.Itoa(reviewer.Comments)},
+			[]string{"reviewer.resolved", reviewer.Reviewer, strconv.Itoa(reviewer.Resolved)},
+			[]string{"reviewer.avgResolutionHours", reviewer.Reviewer, formatFloat(reviewer.AvgResolutionHours)},
+		)
+	}
+	for _, file := range metrics.Files {
+		rows = append(rows,
+			[]string{"file.threads", file.Path, strconv.Itoa(file.Threads)},
+			[]string{"file.comments", file.Path, strconv.Itoa(file.Comments)},
+		)
+	}
+	for _, reply := range metrics.AuthorReplies {
+		rows = append(rows,
+			[]string{"author.threads", reply.Author, strconv.Itoa(reply.Threads)},
+			[]string{"author.replied", reply.Author, strconv.Itoa(reply.Replied)},
+			[]string{"author.avgReplyHours", reply.Author, formatFloat(reply.AvgReplyHours)},
+			[]string{"author.medianReplyHours", reply.Author, formatFloat(reply.MedianReplyHours)},
+		)
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// fetchMetricsPRs fetches review threads of the given PRs, or of up to limit
+// PRs in a milestone, metricsPRBatchSize PRs per query
+func (c *GitHubClient) fetchMetricsPRs(prNumbers []int, milestoneNumber, limit int) ([]metricsPR, error) {
+	if len(prNumbers) == 0 {
+		return c.fetchMilestoneMetricsPRs(milestoneNumber, limit)
+	}
+
+	prs := make([]metricsPR, 0, len(prNumbers))
+	for start := 0; start < len(prNumbers); start += metricsPRBatchSize {
+		batch := prNumbers[start:min(start+metricsPRBatchSize, len(prNumbers))]
+		var queryBuilder strings.Builder
+		queryBuilder.WriteString("query($owner: String!, $repo: String!) {\n  repository(owner: $owner, name: $repo) {\n")
+		for i, number := range batch {
+			fmt.Fprintf(&queryBuilder, "    pr%d: pullRequest(number: %d) {%s\n    }\n", i, number, metricsPRFields)
+		}
+		queryBuilder.WriteString("  }\n}")
+
+		responseData, err := c.RunGraphQLQueryWithVariables(queryBuilder.String(), map[string]interface{}{
+			"owner": c.Owner,
+			"repo":  c.Repo,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Data struct {
+				Repository map[string]*metricsPR `json:"repository"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return nil, err
+		}
+		for i, number := range batch {
+			pr := response.Data.Repository[fmt.Sprintf("pr%d", i)]
+			if pr == nil {
+				return nil, fmt.Errorf("PR #%d not found", number)
+			}
+			prs = append(prs, *pr)
+		}
+	}
+	return prs, nil
+}
+
+// fetchMilestoneMetricsPRs fetches up to limit PRs of a milestone, newest first
+func (c *GitHubClient) fetchMilestoneMetricsPRs(milestoneNumber, limit int) ([]metricsPR, error) {
+	query := `
+	query($owner: String!, $repo: String!, $milestone: Int!, $first: Int!, $after: String) {
+		repository(owner: $owner, name: $repo) {
+			milestone(number: $milestone) {
+				pullRequests(first: $first, after: $after, orderBy: {field: CREATED_AT, direction: DESC}) {
+					nodes {` + metricsPRFields + `
+					}
+					pageInfo { hasNextPage endCursor }
+				}
+			}
+		}
+	}`
+
+	var prs []metricsPR
+	var after *string
+	for len(prs) < limit {
+		responseData, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+			"owner":     c.Owner,
+			"repo":      c.Repo,
+			"milestone": milestoneNumber,
+			"first":     min(limit-len(prs), metricsPRBatchSize),
+			"after":     after,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Data struct {
+				Repository struct {
+					Milestone *struct {
+						PullRequests struct {
+							Nodes    []metricsPR `json:"nodes"`
+							PageInfo struct {
+								HasNextPage bool   `json:"hasNextPage"`
+								EndCursor   string `json:"endCursor"`
+							} `json:"pageInfo"`
+						} `json:"pullRequests"`
+					} `json:"milestone"`
+				} `json:"repository"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return nil, err
+		}
+		if response.Data.Repository.Milestone == nil {
+			return nil, fmt.Errorf("milestone #%d not found", milestoneNumber)
+		}
+
+		page := response.Data.Repository.Milestone.PullRequests
+		prs = append(prs, page.Nodes...)
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		cursor := page.PageInfo.EndCursor
+		after = &cursor
+	}
+	return prs, nil
+}
+
+func reviewsMetrics(cmd *cobra.Command, args []string) error {
+	milestone, err := cmd.Flags().GetString("milestone")
+	if err != nil {
+		return fmt.Errorf("failed to get 'milestone' flag: %w", err)
+	}
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return fmt.Errorf("failed to get 'limit' flag: %w", err)
+	}
+
+	if (milestone == "") == (len(args) == 0) {
+		return fmt.Errorf("specify either PR numbers or --milestone")
+	}
+	if limit <= 0 || limit > 100 {
+		return fmt.Errorf("--limit must be between 1 and 100")
+	}
+
+	var prNumbers []int
+	for _, arg := range args {
+		number, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+		if err != nil {
+			return fmt.Errorf("invalid PR number: %s", arg)
+		}
+		prNumbers = append(prNumbers, number)
+	}
+
+	client := newCommandClient(cmd)
+
+	var milestoneNumber int
+	if milestone != "" {
+		milestoneNumber, err = client.GetMilestoneNumber(milestone)
+		if err != nil {
+			return fmt.Errorf("failed to resolve milestone: %w", err)
+		}
+	}
+
+	prs, err := client.fetchMetricsPRs(prNumbers, milestoneNumber, limit)
+	if err != nil {
+		return fmt.Errorf("failed to fetch pull requests: %w", err)
+	}
+	metrics := computeReviewMetrics(prs)
+
+	switch ResolveFormat(cmd) {
+	case FormatCSV:
+		return writeReviewMetricsDelimited(cmd.OutOrStdout(), metrics, ',')
+	case FormatTSV:
+		return writeReviewMetricsDelimited(cmd.OutOrStdout(), metrics, '\t')
+	}
+
+	result := map[string]interface{}{
+		"prs":           metrics.PRs,
+		"threads":       metrics.Threads,
+		"reviewers":     metrics.Reviewers,
+		"files":         metrics.Files,
+		"authorReplies": metrics.AuthorReplies,
+	}
+	if milestone != "" {
+		result["milestone"] = milestone
+	}
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"reviewMetrics": result})
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestComputeReviewMetrics(t *testing.T) {
+	// Thread 1: bob opens, alice (author) replies after 2h, resolved after 4h.
+	// Thread 2: carol opens, unanswered. Thread 3: alice's own note.
+	data := `{
+	  "number": 1,
+	  "author": {"login": "alice"},
+	  "reviewThreads": {"nodes": [
+	    {"isResolved": true, "path": "main.go", "comments": {"nodes": [
+	      {"author": {"login": "bob"}, "createdAt": "2025-01-01T00:00:00Z"},
+	      {"author": {"login": "alice"}, "createdAt": "2025-01-01T02:00:00Z"},
+	      {"author": {"login": "bob"}, "createdAt": "2025-01-01T04:00:00Z"}
+	    ]}},
+	    {"isResolved": false, "path": "main.go", "comments": {"nodes": [
+	      {"author": {"login": "carol"}, "createdAt": "2025-01-01T00:00:00Z"}
+	    ]}},
+	    {"isResolved": false, "path": "README.md", "comments": {"nodes": [
+	      {"author": {"login": "alice"}, "createdAt": "2025-01-01T00:00:00Z"}
+	    ]}}
+	  ]}
+	}`
+	var pr metricsPR
+	if err := json.Unmarshal([]byte(data), &pr); err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := computeReviewMetrics([]metricsPR{pr})
+	if metrics.Threads != 3 {
+		t.Errorf("threads = %d, want 3", metrics.Threads)
+	}
+
+	if len(metrics.Reviewers) != 2 || metrics.Reviewers[0].Reviewer != "bob" {
+		t.Fatalf("reviewers = %+v, want bob then carol", metrics.Reviewers)
+	}
+	bob := metrics.Reviewers[0]
+	if bob.ThreadsOpened != 1 || bob.Comments != 2 || bob.Resolved != 1 || bob.AvgResolutionHours != 4 {
+		t.Errorf("bob = %+v", bob)
+	}
+
+	if metrics.Files[0].Path != "main.go" || metrics.Files[0].Threads != 2 || metrics.Files[0].Reviewers != 2 {
+		t.Errorf("top file = %+v, want main.go with 2 threads from 2 reviewers", metrics.Files[0])
+	}
+
+	if len(metrics.AuthorReplies) != 1 {
+		t.Fatalf("authorReplies = %+v", metrics.AuthorReplies)
+	}
+	reply := metrics.AuthorReplies[0]
+	if reply.Author != "alice" || reply.Threads != 2 || reply.Replied != 1 || reply.MedianReplyHours != 2 {
+		t.Errorf("alice replies = %+v", reply)
+	}
+
+	var buf bytes.Buffer
+	if err := writeReviewMetricsDelimited(&buf, metrics, ','); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "reviewer.avgResolutionHours,bob,4\n") {
+		t.Errorf("CSV missing bob's resolution time:\n%s", buf.String())
+	}
+}
+
+func TestMetricsQueryNodeBudget(t *testing.T) {
+	// GitHub rejects a query whose worst-case node count exceeds 500,000
+	if nodes := metricsQueryNodes(); nodes > 500000 {
+		t.Errorf("metrics query may return %d nodes, over the 500,000 limit", nodes)
+	}
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var postSummaryReviewsCmd = NewOperationalCommand(
+	"post-summary [pr-number] [flags]",
+	"Post or update a human-readable PR status comment",
+	`Generate a concise status comment for human collaborators and post it on
+the PR, or update it in place when one was posted before:
+
+  - CI state of the head commit
+  - Approvals, pending review requests, and requested changes
+  - Unresolved review threads per file, and how many await the author's reply
+  - Next steps derived from the above
+
+The comment is identified by a hidden marker, so repeated runs keep a single
+comment up to date (it is only edited when its content changes). Threads
+snoozed with 'threads snooze' are not counted unless --include-snoozed.
+
+`+prNumberArgsHelp+`
+
+Examples:
+  # After pushing fixes and replying to threads
+  gh-helper reviews post-summary 306
+
+  # Preview the comment without posting
+  gh-helper reviews post-summary 306 --dry-run`,
+	postStatusSummary,
+)
+
+func init() {
+	postSummaryReviewsCmd.Args = cobra.MaximumNArgs(1)
+	postSummaryReviewsCmd.Flags().Bool("dry-run", false, "Print the comment without posting it")
+	postSummaryReviewsCmd.Flags().Int("thread-limit", 100, "Maximum threads to fetch")
+	addIncludeSnoozedFlag(postSummaryReviewsCmd)
+	addForceFlag(postSummaryReviewsCmd)
+
+	reviewsCmd.AddCommand(postSummaryReviewsCmd)
+}
+
+// statusSummaryMarker identifies the comment maintained by post-summary
+const statusSummaryMarker = "<!-- gh-helper:status-summary -->"
+
+// StatusSummary is the PR state rendered into the status comment
+type StatusSummary struct {
+	Number            int            `json:"number"`
+	HeadOID           string         `json:"headOid"`
+	IsDraft           bool           `json:"isDraft"`
+	Mergeable         string         `json:"mergeable"`
+	ChecksState       string         `json:"checksState"` // empty when no checks are reported
+	Approvals         []string       `json:"approvals"`
+	PendingReviewers  []string       `json:"pendingReviewers"`
+	ChangesRequested  []string       `json:"changesRequested"`
+	UnresolvedByFile  map[string]int `json:"unresolvedByFile"`
+	UnresolvedThreads int            `json:"unresolvedThreads"`
+	AwaitingReply     int            `json:"awaitingReply"` // unresolved threads where a reviewer spoke last
+}
+
+// buildStatusSummary combines the merge state, reviewer state, and unified
+// review data into a summary
+func buildStatusSummary(state *PRMergeState, reviewers *prReviewerState, data *UnifiedReviewData) StatusSummary {
+	summary := StatusSummary{
+		Number:           state.Number,
+		HeadOID:          state.HeadOID,
+		IsDraft:          state.IsDraft,
+		Mergeable:        state.Mergeable,
+		ChecksState:      state.ChecksState,
+		Approvals:        state.Approvals,
+		PendingReviewers: reviewers.Requested,
+		ChangesRequested: state.ChangesRequested,
+		UnresolvedByFile: map[string]int{},
+	}
+	for _, thread := range data.Threads {
+		if thread.IsResolved {
+			continue
+		}
+		summary.UnresolvedThreads++
+		summary.UnresolvedByFile[thread.Path]++
+		if thread.LastReplier != reviewers.Author {
+			summary.AwaitingReply++
+		}
+	}
+	return summary
+}
+
+// nextSteps lists what has to happen before the PR can merge, most urgent first
+func (s StatusSummary) nextSteps() []string {
+	var steps []string
+	if s.Mergeable == "CONFLICTING" {
+		steps = append(steps, "Resolve merge conflicts with the base branch")
+	}
+	if len(s.ChangesRequested) > 0 {
+		steps = append(steps, "Address changes requested by "+mentionList(s.ChangesRequested))
+	}
+	switch s.ChecksState {
+	case "FAILURE", "ERROR":
+		steps = append(steps, "Fix failing checks")
+	case "PENDING", "EXPECTED":
+		steps = append(steps, "Wait for checks to finish")
+	}
+	if s.AwaitingReply > 0 {
+		steps = append(steps, fmt.Sprintf("Reply to %d review thread(s) awaiting a response", s.AwaitingReply))
+	}
+	if s.UnresolvedThreads > s.AwaitingReply {
+		steps = append(steps, fmt.Sprintf("Resolve %d answered review thread(s) or follow up", s.UnresolvedThreads-s.AwaitingReply))
+	}
+	if len(s.PendingReviewers) > 0 {
+		steps = append(steps, "Wait for review from "+mentionList(s.PendingReviewers))
+	}
+	if s.IsDraft {
+		steps = append(steps, "Mark the PR ready for review")
+	}
+	return steps
+}
+
+// mentionList formats logins as plain code spans so the comment does not notify anyone
+func mentionList(logins []string) string {
+	quoted := make([]string, len(logins))
+	for i, login := range logins {
+		quoted[i] = "`" + login + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// checksLine describes the CI state for the comment
+func checksLine(state string) string {
+	switch state {
+	case "SUCCESS":
+		return "✅ passing"
+	case "FAILURE", "ERROR":
+		return "❌ failing"
+	case "PENDING", "EXPECTED":
+		return "⏳ running"
+	case "":
+		return "no checks reported"
+	}
+	return strings.ToLower(state)
+}
+
+// renderStatusSummary renders the status comment body, including the marker.
+// The body only depends on the summary so unchanged state renders identically.
+func renderStatusSummary(s StatusSummary) string {
+	var b strings.Builder
+	b.WriteString(statusSummaryMarker + "\n")
+	b.WriteString("### PR status\n\n")
+
+	fmt.Fprintf(&b, "- **CI:** %s\n", checksLine(s.ChecksState))
+
+	var reviews []string
+	if len(s.Approvals) > 0 {
+		reviews = append(reviews, fmt.Sprintf("%d approval(s) (%s)", len(s.Approvals), mentionList(s.Approvals)))
+	} else {
+		reviews = append(reviews, "no approvals yet")
+	}
+	if len(s.ChangesRequested) > 0 {
+		reviews = append(reviews, "changes requested by "+mentionList(s.ChangesRequested))
+	}
+	if len(s.PendingReviewers) > 0 {
+		reviews = append(reviews, "waiting on "+mentionList(s.PendingReviewers))
+	}
+	fmt.Fprintf(&b, "- **Reviews:** %s\n", strings.Join(reviews, "; "))
+
+	if s.UnresolvedThreads == 0 {
+		b.WriteString("- **Threads:** all resolved\n")
+	} else {
+		fmt.Fprintf(&b, "- **Threads:** %d unresolved, %d awaiting a reply\n", s.UnresolvedThreads, s.AwaitingReply)
+
+		files := make([]string, 0, len(s.UnresolvedByFile))
+		for file := range s.UnresolvedByFile {
+			files = append(files, file)
+		}
+		sort.Slice(files, func(i, j int) bool {
+			if s.UnresolvedByFile[files[i]] != s.UnresolvedByFile[files[j]] {
+				return s.UnresolvedByFile[files[i]] > s.UnresolvedByFile[files[j]]
+			}
+			return files[i] < files[j]
+		})
+		b.WriteString("\n| File | Unresolved |\n|---|---:|\n")
+		for _, file := range files {
+			fmt.Fprintf(&b, "| `%s` | %d |\n", file, s.UnresolvedByFile[file])
+		}
+	}
+
+	b.WriteString("\n**Next steps**\n\n")
+	steps := s.nextSteps()
+	if len(steps) == 0 {
+		steps = []string{"Ready to merge"}
+	}
+	for _, step := range steps {
+		fmt.Fprintf(&b, "- %s\n", step)
+	}
+
+	head := s.HeadOID
+	if len(head) > 7 {
+		head = head[:7]
+	}
+	fmt.Fprintf(&b, "\n<sub>Maintained by `gh-helper reviews post-summary` for %s.</sub>\n", head)
+	return b.String()
+}
+
+// markedComment is an existing comment identified by a hidden marker
+type markedComment struct {
+	ID   string
+	URL  string
+	Body string
+}
+
+// findMarkedComment returns the newest PR comment posted by the current user
+// that starts with marker, or nil when there is none
+func (c *GitHubClient) findMarkedComment(prNumber int, marker string) (*markedComment, error) {
+	query := `
+	query($owner: String!, $repo: String!, $prNumber: Int!) {
+		repository(owner: $owner, name: $repo) {
+			pullRequest(number: $prNumber) {
+				comments(last: 100) {
+					nodes { id url body viewerDidAuthor }
+				}
+			}
+		}
+	}`
+	responseData, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+		"owner":    c.Owner,
+		"repo":     c.Repo,
+		"prNumber": prNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository struct {
+				PullRequest *struct {
+					Comments struct {
+						Nodes []struct {
+							ID              string `json:"id"`
+							URL             string `json:"url"`
+							Body            string `json:"body"`
+							ViewerDidAuthor bool   `json:"viewerDidAuthor"`
+						} `json:"nodes"`
+					} `json:"comments"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse PR comments: %w", err)
+	}
+	if response.Data.Repository.PullRequest == nil {
+		return nil, fmt.Errorf("PR #%d not found", prNumber)
+	}
+
+	nodes := response.Data.Repository.PullRequest.Comments.Nodes
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if nodes[i].ViewerDidAuthor && strings.HasPrefix(nodes[i].Body, marker) {
+			return &markedComment{ID: nodes[i].ID, URL: nodes[i].URL, Body: nodes[i].Body}, nil
+		}
+	}
+	return nil, nil
+}
+
+// upsertComment updates commentID with body, or adds a comment to subjectID
+// when commentID is empty, and returns the comment URL
+func (c *GitHubClient) upsertComment(subjectID, commentID, body string) (string, error) {
+	mutation := `
+	mutation($subjectId: ID!, $body: String!) {
+	  addComment(input: {subjectId: $subjectId, body: $body}) {
+	    commentEdge { node { url } }
+	  }
+	}`
+	variables := map[string]interface{}{"subjectId": subjectID, "body": body}
+	if commentID != "" {
+		mutation = `
+	mutation($id: ID!, $body: String!) {
+	  updateIssueComment(input: {id: $id, body: $body}) {
+	    issueComment { url }
+	  }
+	}`
+		variables = map[string]interface{}{"id": commentID, "body": body}
+	}
+
+	responseData, err := c.RunGraphQLQueryWithVariables(mutation, variables)
+	if err != nil {
+		return "", err
+	}
+	var response struct {
+		Data struct {
+			AddComment *struct {
+				CommentEdge struct {
+					Node struct {
+						URL string `json:"url"`
+					} `json:"node"`
+				} `json:"commentEdge"`
+			} `json:"addComment"`
+			UpdateIssueComment *struct {
+				IssueComment struct {
+					URL string `json:"url"`
+				} `json:"issueComment"`
+			} `json:"updateIssueComment"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return "", fmt.Errorf("failed to parse comment response: %w", err)
+	}
+	switch {
+	case response.Data.AddComment != nil:
+		return response.Data.AddComment.CommentEdge.Node.URL, nil
+	case response.Data.UpdateIssueComment != nil:
+		return response.Data.UpdateIssueComment.IssueComment.URL, nil
+	}
+	return "", nil
+}
+
+func postStatusSummary(cmd *cobra.Command, args []string) error {
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get 'dry-run' flag: %w", err)
+	}
+	threadLimit, err := cmd.Flags().GetInt("thread-limit")
+	if err != nil {
+		return fmt.Errorf("failed to get 'thread-limit' flag: %w", err)
+	}
+	isSnoozed, err := snoozeFilter(cmd)
+	if err != nil {
+		return err
+	}
+
+	client := newCommandClient(cmd)
+	prNumber, err := resolvePRNumberFromArgs(args, client)
+	if err != nil {
+		return err
+	}
+	number, err := strconv.Atoi(prNumber)
+	if err != nil {
+		return fmt.Errorf("invalid PR number %q: %w", prNumber, err)
+	}
+
+	state, err := client.GetPRMergeState(number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch PR state: %w", err)
+	}
+	if !dryRun {
+		if err := checkFreshTargets(cmd, func() ([]prTarget, error) {
+			return []prTarget{{Ref: prNumber, Number: state.Number, State: state.State}}, nil
+		}); err != nil {
+			return err
+		}
+	}
+	reviewers, err := client.getPRReviewerState(number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch reviewers: %w", err)
+	}
+	data, err := client.GetUnifiedReviewData(prNumber, UnifiedReviewOptions{
+		IncludeThreads: true,
+		ThreadLimit:    threadLimit,
+		ReviewLimit:    1,
+		UnresolvedOnly: true,
+		ExcludeURLs:    true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch review threads: %w", err)
+	}
+	data.Threads = dropSnoozedThreads(data.Threads, isSnoozed)
+
+	summary := buildStatusSummary(state, reviewers, data)
+	body := renderStatusSummary(summary)
+	result := map[string]interface{}{
+		"pr":      number,
+		"summary": summary,
+	}
+
+	existing, err := client.findMarkedComment(number, statusSummaryMarker)
+	if err != nil {
+		return fmt.Errorf("failed to look up the status comment: %w", err)
+	}
+	switch {
+	case existing != nil && existing.Body == body:
+		result["action"] = "unchanged"
+		result["url"] = existing.URL
+	case dryRun:
+		result["action"] = "create"
+		if existing != nil {
+			result["action"] = "update"
+		}
+		result["body"] = body
+	default:
+		commentID := ""
+		result["action"] = "created"
+		if existing != nil {
+			commentID = existing.ID
+			result["action"] = "updated"
+		}
+		url, err := client.upsertComment(state.ID, commentID, body)
+		if err != nil {
+			return fmt.Errorf("failed to post the status comment: %w", err)
+		}
+		result["url"] = url
+	}
+	result["dryRun"] = dryRun
+
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"statusSummary": result})
+}
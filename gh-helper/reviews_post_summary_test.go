@@ -0,0 +1,69 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildStatusSummary(t *testing.T) {
+	state := &PRMergeState{Number: 306, HeadOID: "abcdef123456", Mergeable: "MERGEABLE", ChecksState: "SUCCESS", Approvals: []string{"alice"}}
+	reviewers := &prReviewerState{Author: "author", Requested: []string{"bob"}}
+	data := &UnifiedReviewData{Threads: []ThreadData{
+		{Path: "a.go", LastReplier: "alice"},
+		{Path: "a.go", LastReplier: "author"},
+		{Path: "b.go", LastReplier: "bob"},
+		{Path: "c.go", IsResolved: true, LastReplier: "alice"},
+	}}
+
+	summary := buildStatusSummary(state, reviewers, data)
+	if summary.UnresolvedThreads != 3 || summary.AwaitingReply != 2 {
+		t.Errorf("unresolved = %d, awaiting = %d, want 3, 2", summary.UnresolvedThreads, summary.AwaitingReply)
+	}
+	if want := map[string]int{"a.go": 2, "b.go": 1}; !reflect.DeepEqual(summary.UnresolvedByFile, want) {
+		t.Errorf("UnresolvedByFile = %v, want %v", summary.UnresolvedByFile, want)
+	}
+	want := []string{
+		"Reply to 2 review thread(s) awaiting a response",
+		"Resolve 1 answered review thread(s) or follow up",
+		"Wait for review from `bob`",
+	}
+	if got := summary.nextSteps(); !reflect.DeepEqual(got, want) {
+		t.Errorf("nextSteps() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderStatusSummary(t *testing.T) {
+	summary := StatusSummary{
+		HeadOID:           "abcdef123456",
+		Mergeable:         "CONFLICTING",
+		ChecksState:       "FAILURE",
+		ChangesRequested:  []string{"carol"},
+		UnresolvedByFile:  map[string]int{"a.go": 1, "b.go": 2},
+		UnresolvedThreads: 3,
+		AwaitingReply:     3,
+	}
+	body := renderStatusSummary(summary)
+	if !strings.HasPrefix(body, statusSummaryMarker+"\n") {
+		t.Errorf("body does not start with the marker:\n%s", body)
+	}
+	for _, want := range []string{
+		"- **CI:** ❌ failing",
+		"no approvals yet; changes requested by `carol`",
+		"| `b.go` | 2 |\n| `a.go` | 1 |",
+		"- Resolve merge conflicts with the base branch\n- Address changes requested by `carol`\n- Fix failing checks",
+		"for abcdef1.",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q:\n%s", want, body)
+		}
+	}
+	if body != renderStatusSummary(summary) {
+		t.Error("renderStatusSummary() is not deterministic")
+	}
+
+	ready := renderStatusSummary(StatusSummary{ChecksState: "SUCCESS", Approvals: []string{"alice"}, UnresolvedByFile: map[string]int{}})
+	if !strings.Contains(ready, "- **Threads:** all resolved") || !strings.Contains(ready, "- Ready to merge") {
+		t.Errorf("ready PR body:\n%s", ready)
+	}
+}
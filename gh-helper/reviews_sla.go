@@ -0,0 +1,382 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var slaReviewsCmd = NewOperationalCommand(
+	"sla [pr-number...] [flags]",
+	"Report review threads and reviews waiting longer than an SLA",
+	`List unresolved review threads and unanswered reviews older than --max-age,
+with the owner expected to act on each, and exit with code 4 when any
+violation exists. Suitable for scheduled jobs that keep review debt visible.
+
+Checks the given PRs, or all open PRs when none are given.
+
+Violations:
+  thread  Unresolved thread whose last comment is older than --max-age.
+          Owner: the PR author if a reviewer commented last, otherwise the
+          thread's first commenter (the author is waiting on the reviewer).
+  review  CHANGES_REQUESTED or commented review older than --max-age with no
+          later commit, comment, or review from the PR author. Owner: the PR author.
+
+Threads snoozed with 'threads snooze' are not violations until the snooze
+expires, unless --include-snoozed is given.
+
+Examples:
+  # All open PRs with a 48 hour SLA
+  gh-helper reviews sla --max-age 48h
+
+  # Specific PRs, owners only
+  gh-helper reviews sla 306 307 --max-age 24h --jq '[.reviewSLA.violations[].owner] | unique'`,
+	reviewsSLA,
+)
+
+func init() {
+	slaReviewsCmd.Flags().Duration("max-age", 48*time.Hour, "Maximum time a thread or review may wait for a response")
+	slaReviewsCmd.Flags().Int("limit", 50, "Maximum open PRs to check when no PR numbers are given")
+	addIncludeSnoozedFlag(slaReviewsCmd)
+
+	reviewsCmd.AddCommand(slaReviewsCmd)
+}
+
+// SLAViolation is a thread or review waiting longer than the SLA
+type SLAViolation struct {
+	PR       int    `json:"pr"`
+	Kind     string `json:"kind"` // "thread" or "review"
+	ID       string `json:"id"`
+	Owner    string `json:"owner"`
+	Since    string `json:"since"`
+	Age      string `json:"age"`
+	Path     string `json:"path,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	State    string `json:"state,omitempty"`
+	URL      string `json:"url,omitempty"`
+	ageHours float64
+}
+
+// slaPRNode is the per-PR data needed to evaluate review SLAs
+type slaPRNode struct {
+	Number        int       `json:"number"`
+	Title         string    `json:"title"`
+	Author        *actorRef `json:"author"`
+	ReviewThreads struct {
+		Nodes []struct {
+			ID         string `json:"id"`
+			IsResolved bool   `json:"isResolved"`
+			Path       string `json:"path"`
+			Line       int    `json:"line"`
+			First      struct {
+				Nodes []struct {
+					Author *actorRef `json:"author"`
+				} `json:"nodes"`
+			} `json:"first"`
+			Last struct {
+				Nodes []struct {
+					Author    *actorRef `json:"author"`
+					CreatedAt string    `json:"createdAt"`
+					URL       string    `json:"url"`
+				} `json:"nodes"`
+			} `json:"last"`
+		} `json:"nodes"`
+	} `json:"reviewThreads"`
+	Reviews struct {
+		Nodes []struct {
+			ID          string    `json:"id"`
+			Author      *actorRef `json:"author"`
+			State       string    `json:"state"`
+			Body        string    `json:"body"`
+			SubmittedAt string    `json:"submittedAt"`
+			URL         string    `json:"url"`
+		} `json:"nodes"`
+	} `json:"reviews"`
+	Comments struct {
+		Nodes []struct {
+			Author    *actorRef `json:"author"`
+			CreatedAt string    `json:"createdAt"`
+		} `json:"nodes"`
+	} `json:"comments"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				CommittedDate string `json:"committedDate"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+const slaPRFields = `
+	number
+	title
+	author { login }
+	reviewThreads(first: 100) {
+		nodes {
+			id
+			isResolved
+			path
+			line
+			first: comments(first: 1) { nodes { author { login } } }
+			last: comments(last: 1) { nodes { author { login } createdAt url } }
+		}
+	}
+	reviews(last: 50) {
+		nodes {
+			id
+			author { login }
+			state
+			body
+			submittedAt
+			url
+		}
+	}
+	comments(last: 30) {
+		nodes {
+			author { login }
+			createdAt
+		}
+	}
+	commits(last: 1) {
+		nodes {
+			commit { committedDate }
+		}
+	}`
+
+// lastAuthorActivity returns the latest time the PR author commented, reviewed, or committed
+func (pr slaPRNode) lastAuthorActivity() time.Time {
+	author := pr.Author.login()
+	var latest time.Time
+	consider := func(timestamp string) {
+		if t, err := time.Parse(time.RFC3339, timestamp); err == nil && t.After(latest) {
+			latest = t
+		}
+	}
+
+	for _, comment := range pr.Comments.Nodes {
+		if comment.Author.login() == author {
+			consider(comment.CreatedAt)
+		}
+	}
+	for _, review := range pr.Reviews.Nodes {
+		if review.Author.login() == author {
+			consider(review.SubmittedAt)
+		}
+	}
+	for _, thread := range pr.ReviewThreads.Nodes {
+		for _, comment := range thread.Last.Nodes {
+			if comment.Author.login() == author {
+				consider(comment.CreatedAt)
+			}
+		}
+	}
+	for _, commit := range pr.Commits.Nodes {
+		consider(commit.Commit.CommittedDate)
+	}
+	return latest
+}
+
+// findSLAViolations returns the threads and reviews of a PR waiting longer than maxAge
+func findSLAViolations(pr slaPRNode, maxAge time.Duration, now time.Time) []SLAViolation {
+	var violations []SLAViolation
+	author := pr.Author.login()
+
+	newViolation := func(kind, id, owner string, since time.Time) SLAViolation {
+		age := now.Sub(since)
+		return SLAViolation{
+			PR:       pr.Number,
+			Kind:     kind,
+			ID:       id,
+			Owner:    owner,
+			Since:    since.Format(time.RFC3339),
+			Age:      age.Truncate(time.Minute).String(),
+			ageHours: age.Hours(),
+		}
+	}
+
+	for _, thread := range pr.ReviewThreads.Nodes {
+		if thread.IsResolved || len(thread.Last.Nodes) == 0 {
+			continue
+		}
+		last := thread.Last.Nodes[0]
+		lastAt, err := time.Parse(time.RFC3339, last.CreatedAt)
+		if err != nil || now.Sub(lastAt) <= maxAge {
+			continue
+		}
+
+		owner := author
+		if last.Author.login() == author && len(thread.First.Nodes) > 0 {
+			owner = thread.First.Nodes[0].Author.login()
+		}
+
+		violation := newViolation("thread", thread.ID, owner, lastAt)
+		violation.Path = thread.Path
+		violation.Line = thread.Line
+		violation.URL = last.URL
+		violations = append(violations, violation)
+	}
+
+	authorActivity := pr.lastAuthorActivity()
+	for _, review := range pr.Reviews.Nodes {
+		reviewer := review.Author.login()
+		if reviewer == author {
+			continue
+		}
+		if review.State != "CHANGES_REQUESTED" && !(review.State == "COMMENTED" && strings.TrimSpace(review.Body) != "") {
+			continue
+		}
+		submittedAt, err := time.Parse(time.RFC3339, review.SubmittedAt)
+		if err != nil || now.Sub(submittedAt) <= maxAge || authorActivity.After(submittedAt) {
+			continue
+		}
+
+		violation := newViolation("review", review.ID, author, submittedAt)
+		violation.State = review.State
+		violation.URL = review.URL
+		violations = append(violations, violation)
+	}
+
+	return violations
+}
+
+// fetchSLAPRs fetches SLA data for the given PRs, or for open PRs when none are given
+func (c *GitHubClient) fetchSLAPRs(prNumbers []int, limit int) ([]slaPRNode, error) {
+	variables := map[string]interface{}{
+		"owner": c.Owner,
+		"repo":  c.Repo,
+	}
+
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString("query($owner: String!, $repo: String!")
+	if len(prNumbers) == 0 {
+		queryBuilder.WriteString(", $limit: Int!")
+		variables["limit"] = limit
+	}
+	queryBuilder.WriteString(") {\n  repository(owner: $owner, name: $repo) {\n")
+	if len(prNumbers) == 0 {
+		queryBuilder.WriteString("    pullRequests(first: $limit, states: OPEN, orderBy: {field: UPDATED_AT, direction: DESC}) {\n      nodes {")
+		queryBuilder.WriteString(slaPRFields)
+		queryBuilder.WriteString("\n      }\n    }\n")
+	} else {
+		for i, number := range prNumbers {
+			fmt.Fprintf(&queryBuilder, "    pr%d: pullRequest(number: %d) {%s\n    }\n", i, number, slaPRFields)
+		}
+	}
+	queryBuilder.WriteString("  }\n}")
+
+	responseData, err := c.RunGraphQLQueryWithVariables(queryBuilder.String(), variables)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prNumbers) == 0 {
+		var response struct {
+			Data struct {
+				Repository struct {
+					PullRequests struct {
+						Nodes []slaPRNode `json:"nodes"`
+					} `json:"pullRequests"`
+				} `json:"repository"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return nil, err
+		}
+		return response.Data.Repository.PullRequests.Nodes, nil
+	}
+
+	var response struct {
+		Data struct {
+			Repository map[string]*slaPRNode `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil, err
+	}
+
+	prs := make([]slaPRNode, 0, len(prNumbers))
+	for i, number := range prNumbers {
+		pr := response.Data.Repository[fmt.Sprintf("pr%d", i)]
+		if pr == nil {
+			return nil, fmt.Errorf("PR #%d not found", number)
+		}
+		prs = append(prs, *pr)
+	}
+	return prs, nil
+}
+
+func reviewsSLA(cmd *cobra.Command, args []string) error {
+	maxAge, err := cmd.Flags().GetDuration("max-age")
+	if err != nil {
+		return fmt.Errorf("failed to get 'max-age' flag: %w", err)
+	}
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return fmt.Errorf("failed to get 'limit' flag: %w", err)
+	}
+	if maxAge <= 0 {
+		return fmt.Errorf("--max-age must be positive")
+	}
+	isSnoozed, err := snoozeFilter(cmd)
+	if err != nil {
+		return err
+	}
+
+	var prNumbers []int
+	for _, arg := range args {
+		number, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+		if err != nil {
+			return fmt.Errorf("invalid PR number: %s", arg)
+		}
+		prNumbers = append(prNumbers, number)
+	}
+
+	client := newCommandClient(cmd)
+	prs, err := client.fetchSLAPRs(prNumbers, limit)
+	if err != nil {
+		return fmt.Errorf("failed to fetch pull requests: %w", err)
+	}
+
+	now := time.Now()
+	violations := []SLAViolation{}
+	byOwner := map[string]int{}
+	for _, pr := range prs {
+		for _, violation := range findSLAViolations(pr, maxAge, now) {
+			if violation.Kind == "thread" && isSnoozed != nil && isSnoozed(violation.ID) {
+				continue
+			}
+			violations = append(violations, violation)
+			byOwner[violation.Owner]++
+		}
+	}
+
+	// Oldest first so the worst debt is at the top
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].ageHours > violations[j].ageHours
+	})
+
+	output := map[string]interface{}{
+		"reviewSLA": map[string]interface{}{
+			"maxAge":     maxAge.String(),
+			"checkedPRs": len(prs),
+			"violations": violations,
+			"byOwner":    byOwner,
+		},
+	}
+	if err := EncodeOutputWithCmd(cmd, output); err != nil {
+		return err
+	}
+
+	if len(violations) > 0 {
+		return &ExitError{
+			Code: ExitCodeSLAViolation,
+			Err:  fmt.Errorf("%d review SLA violation(s) older than %v", len(violations), maxAge),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestFindSLAViolations(t *testing.T) {
+	prJSON := `{
+		"number": 42,
+		"author": {"login": "alice"},
+		"reviewThreads": {"nodes": [
+			{"id": "T1", "isResolved": false, "path": "a.go", "line": 3,
+			 "first": {"nodes": [{"author": {"login": "bob"}}]},
+			 "last": {"nodes": [{"author": {"login": "bob"}, "createdAt": "2025-01-01T00:00:00Z"}]}},
+			{"id": "T2", "isResolved": false,
+			 "first": {"nodes": [{"author": {"login": "carol"}}]},
+			 "last": {"nodes": [{"author": {"login": "alice"}, "createdAt": "2025-01-01T01:00:00Z"}]}},
+			{"id": "T3", "isResolved": true,
+			 "first": {"nodes": [{"author": {"login": "bob"}}]},
+			 "last": {"nodes": [{"author": {"login": "bob"}, "createdAt": "2025-01-01T00:00:00Z"}]}},
+			{"id": "T4", "isResolved": false,
+			 "first": {"nodes": [{"author": {"login": "bob"}}]},
+			 "last": {"nodes": [{"author": {"login": "bob"}, "createdAt": "2025-01-04T00:00:00Z"}]}}
+		]},
+		"reviews": {"nodes": [
+			{"id": "R1", "author": {"login": "bob"}, "state": "CHANGES_REQUESTED", "submittedAt": "2025-01-01T00:00:00Z"},
+			{"id": "R2", "author": {"login": "carol"}, "state": "COMMENTED", "body": "", "submittedAt": "2025-01-01T00:00:00Z"},
+			{"id": "R3", "author": {"login": "dave"}, "state": "APPROVED", "submittedAt": "2025-01-01T00:00:00Z"}
+		]},
+		"comments": {"nodes": []},
+		"commits": {"nodes": [{"commit": {"committedDate": "2024-12-31T00:00:00Z"}}]}
+	}`
+
+	var pr slaPRNode
+	if err := json.Unmarshal([]byte(prJSON), &pr); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC)
+	violations := findSLAViolations(pr, 48*time.Hour, now)
+
+	// T2's last comment is alice's reply after R1, which also counts as author activity after R1,
+	// so R1 is answered; T1 is owned by alice, T2 by carol
+	got := map[string]string{}
+	for _, v := range violations {
+		got[v.ID] = v.Owner
+	}
+	want := map[string]string{"T1": "alice", "T2": "carol"}
+	if len(got) != len(want) {
+		t.Fatalf("violations = %+v, want %v", violations, want)
+	}
+	for id, owner := range want {
+		if got[id] != owner {
+			t.Errorf("violation %s owner = %q, want %q", id, got[id], owner)
+		}
+	}
+
+	// Without author activity, the CHANGES_REQUESTED review becomes a violation
+	pr.ReviewThreads.Nodes = pr.ReviewThreads.Nodes[:1]
+	violations = findSLAViolations(pr, 48*time.Hour, now)
+	if len(violations) != 2 || violations[1].ID != "R1" || violations[1].Owner != "alice" {
+		t.Errorf("violations = %+v, want T1 and R1 owned by alice", violations)
+	}
+}
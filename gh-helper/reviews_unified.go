@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -30,7 +31,21 @@ Examples:
 
   # Custom limits and pagination
   gh-helper reviews fetch 306 --review-limit 10 --thread-limit 30
-  gh-helper reviews fetch 306 --reviews-after CURSOR`,
+  gh-helper reviews fetch 306 --reviews-after CURSOR
+
+  # Include the diff hunk of each thread, trimmed to its last 10 lines
+  gh-helper reviews fetch 306 --include-diff-hunks
+  gh-helper reviews fetch 306 --max-hunk-lines 10
+
+  # Incremental: only reviews and thread comments created since this user's
+  # previous --since-last-fetch on this machine (watermark in .cache/reviews/)
+  gh-helper reviews fetch 306 --since-last-fetch
+
+Threads snoozed with 'gh-helper threads snooze' are left out until the snooze
+expires; --include-snoozed shows them.
+
+Incremental fetches filter what the query returns, so raise --review-limit and
+--thread-limit on busy PRs to avoid missing activity beyond the limits.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: fetchReviews,
 }
@@ -64,10 +79,21 @@ func init() {
 	fetchReviewsCmd.Flags().Bool("no-threads", false, "Exclude threads (shorthand for --threads=false)")
 	fetchReviewsCmd.Flags().Bool("no-bodies", false, "Exclude bodies (shorthand for --bodies=false)")
 	fetchReviewsCmd.Flags().Bool("exclude-urls", false, "Exclude URLs from output")
+
+	// Diff hunk size controls
+	fetchReviewsCmd.Flags().Bool("include-diff-hunks", false, "Include the diff hunk of each thread")
+	fetchReviewsCmd.Flags().Int("max-hunk-lines", 0, "Trim thread diff hunks to the last N lines (0: unlimited; implies --include-diff-hunks)")
+	fetchReviewsCmd.Flags().Bool("omit-diff-hunks", false, "Omit thread diff hunks (default)")
+	_ = fetchReviewsCmd.Flags().MarkDeprecated("omit-diff-hunks", "diff hunks are omitted unless --include-diff-hunks or --max-hunk-lines is given")
+
+	// Incremental fetch
+	fetchReviewsCmd.Flags().Bool("since-last-fetch", false, "Return only reviews and thread comments newer than the previous --since-last-fetch, then advance the watermark")
+
+	addIncludeSnoozedFlag(fetchReviewsCmd)
 }
 
 func fetchReviews(cmd *cobra.Command, args []string) error {
-	client := NewGitHubClient(owner, repo)
+	client := newCommandClient(cmd)
 	prNumber, err := resolvePRNumberFromArgs(args, client)
 	if err != nil {
 		return err
@@ -93,6 +119,23 @@ func fetchReviews(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read 'exclude-urls' flag: %w", err)
 	}
 	
+	includeDiffHunks, err := cmd.Flags().GetBool("include-diff-hunks")
+	if err != nil {
+		return fmt.Errorf("failed to read 'include-diff-hunks' flag: %w", err)
+	}
+	maxHunkLines, err := cmd.Flags().GetInt("max-hunk-lines")
+	if err != nil {
+		return fmt.Errorf("failed to read 'max-hunk-lines' flag: %w", err)
+	}
+	sinceLastFetch, err := cmd.Flags().GetBool("since-last-fetch")
+	if err != nil {
+		return fmt.Errorf("failed to read 'since-last-fetch' flag: %w", err)
+	}
+	isSnoozed, err := snoozeFilter(cmd)
+	if err != nil {
+		return err
+	}
+	
 	// Adjust flags for thread-focused modes
 	if listThreads || threadsOnly {
 		// Force JSON for thread-focused modes by programmatically setting the flag.
@@ -116,6 +159,8 @@ func fetchReviews(cmd *cobra.Command, args []string) error {
 		ReviewLimit:         reviewLimit,
 		UnresolvedOnly:      unresolvedOnly,  // Use the clearer name
 		ExcludeURLs:         excludeURLs,
+		IncludeDiffHunks:    includeDiffHunks || maxHunkLines > 0,
+		MaxHunkLines:        maxHunkLines,
 	}
 
 	// Use structured logging (slog) for consistent format with JSON/YAML output
@@ -134,6 +179,30 @@ func fetchReviews(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to fetch unified data: %w", err)
 	}
 
+	// Snoozed threads are deferred on purpose; drop them before any output mode
+	data.Threads = dropSnoozedThreads(data.Threads, isSnoozed)
+
+	// Keep only activity newer than the watermark; it advances only after output succeeds
+	var watermarkPath string
+	var nextWatermark FetchWatermark
+	if sinceLastFetch {
+		watermarkPath = fetchWatermarkPath(prNumber, data.CurrentUser)
+		previous, err := loadFetchWatermark(watermarkPath)
+		if err != nil {
+			return err
+		}
+		var info IncrementalFetchInfo
+		nextWatermark, info = applyFetchWatermark(data, previous)
+		nextWatermark.FetchedAt = data.FetchedAt.UTC().Format(time.RFC3339)
+		data.Incremental = &info
+	}
+	saveWatermark := func() error {
+		if !sinceLastFetch {
+			return nil
+		}
+		return saveFetchWatermark(watermarkPath, nextWatermark)
+	}
+
 	// Handle specialized modes
 	if listThreads {
 		// Simple list mode: thread IDs based on filter
@@ -142,17 +211,23 @@ func fetchReviews(cmd *cobra.Command, args []string) error {
 			// Otherwise, show all threads
 			fmt.Println(thread.ID)
 		}
-		return nil
+		return saveWatermark()
 	}
 	
 	if threadsOnly {
 		// Output threads based on filter
 		// If unresolvedOnly was set, data.Threads already contains only unresolved threads
-		return EncodeOutputWithCmd(cmd, data.Threads)
+		if err := EncodeOutputWithCmd(cmd, data.Threads); err != nil {
+			return err
+		}
+		return saveWatermark()
 	}
 	
 	// Use specialized output function to create a consistent structure for both YAML and JSON
-	return outputFetch(cmd, data, includeReviewBodies, includeThreads)
+	if err := outputFetch(cmd, data, includeReviewBodies, includeThreads); err != nil {
+		return err
+	}
+	return saveWatermark()
 }
 
 // outputFetch creates unified fetch output using GitHub GraphQL API types
@@ -170,6 +245,9 @@ func outputFetch(cmd *cobra.Command, data *UnifiedReviewData, includeReviewBodie
 		"currentUser": data.CurrentUser,
 		"fetchedAt":   data.FetchedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
+	if data.Incremental != nil {
+		output["sinceLastFetch"] = data.Incremental
+	}
 	
 	// Reviews section using GitHub GraphQL Review structure
 	if includeReviewBodies {
@@ -258,6 +336,13 @@ func outputFetch(cmd *cobra.Command, data *UnifiedReviewData, includeReviewBodie
 							commentData["url"] = comment.URL
 						}
 						
+						if comment.DiffHunk != "" {
+							commentData["diffHunk"] = comment.DiffHunk
+						}
+						if comment.DiffHunkOmittedLines > 0 {
+							commentData["diffHunkOmittedLines"] = comment.DiffHunkOmittedLines
+						}
+						
 						comments = append(comments, commentData)
 					}
 					threadData["comments"] = comments
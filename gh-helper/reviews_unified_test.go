@@ -191,4 +191,41 @@ func TestOutputFetchThreadFiltering(t *testing.T) {
 
 func intPtr(i int) *int {
 	return &i
-}
\ No newline at end of file
+}
+
+func TestTrimDiffHunk(t *testing.T) {
+	hunk := "@@ -1,5 +1,6 @@\n line1\n line2\n-line3\n+line3b\n line4"
+
+	tests := []struct {
+		name        string
+		maxLines    int
+		wantHunk    string
+		wantOmitted int
+	}{
+		{
+			name:     "unlimited",
+			maxLines: 0,
+			wantHunk: hunk,
+		},
+		{
+			name:     "within limit",
+			maxLines: 5,
+			wantHunk: hunk,
+		},
+		{
+			name:        "keeps header and tail",
+			maxLines:    2,
+			wantHunk:    "@@ -1,5 +1,6 @@\n... (3 lines omitted)\n+line3b\n line4",
+			wantOmitted: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, omitted := trimDiffHunk(hunk, tt.maxLines)
+			if got != tt.wantHunk || omitted != tt.wantOmitted {
+				t.Errorf("trimDiffHunk() = (%q, %d), want (%q, %d)", got, omitted, tt.wantHunk, tt.wantOmitted)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// FetchWatermark records the newest review activity returned by the previous
+// 'reviews fetch --since-last-fetch' for one PR and user
+type FetchWatermark struct {
+	// CreatedAt is the newest createdAt among reviews and thread comments seen
+	CreatedAt string `json:"createdAt"`
+	// IDs are the items seen with exactly CreatedAt, so same-second items are not lost
+	IDs []string `json:"ids,omitempty"`
+	// FetchedAt is when the watermark was written
+	FetchedAt string `json:"fetchedAt"`
+}
+
+// IncrementalFetchInfo summarizes what --since-last-fetch filtered
+type IncrementalFetchInfo struct {
+	Since          string `json:"since,omitempty"` // previous watermark; empty on the first fetch
+	NewReviews     int    `json:"newReviews"`
+	NewComments    int    `json:"newComments"`
+	OmittedThreads int    `json:"omittedThreads"` // threads without new comments
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// fetchWatermarkPath returns the watermark file for a PR and user in the state store
+func fetchWatermarkPath(prNumber, user string) string {
+	if user == "" {
+		user = "unknown"
+	}
+	name := fmt.Sprintf("pr-%s-fetch-%s.json", prNumber, unsafeFileNameChars.ReplaceAllString(user, "_"))
+	return filepath.Join(GetCacheDir(), "reviews", name)
+}
+
+// loadFetchWatermark reads a watermark; a missing file returns nil without error
+func loadFetchWatermark(path string) (*FetchWatermark, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read fetch watermark: %w", err)
+	}
+	var watermark FetchWatermark
+	if err := json.Unmarshal(data, &watermark); err != nil {
+		return nil, fmt.Errorf("failed to parse fetch watermark %s: %w", path, err)
+	}
+	return &watermark, nil
+}
+
+// saveFetchWatermark writes a watermark with writeFileAtomic
+func saveFetchWatermark(path string, watermark FetchWatermark) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	data, err := json.MarshalIndent(watermark, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal fetch watermark: %w", err)
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to save fetch watermark: %w", err)
+	}
+	return nil
+}
+
+// isAfter reports whether an item is newer than the watermark
+func (w *FetchWatermark) isAfter(id, createdAt string) bool {
+	if w == nil || createdAt > w.CreatedAt {
+		return true
+	}
+	if createdAt < w.CreatedAt {
+		return false
+	}
+	for _, seen := range w.IDs {
+		if seen == id {
+			return false
+		}
+	}
+	return true
+}
+
+// applyFetchWatermark filters data down to reviews and thread comments newer
+// than the previous watermark and returns the advanced watermark. Threads keep
+// only their new comments; threads without any are dropped.
+func applyFetchWatermark(data *UnifiedReviewData, previous *FetchWatermark) (FetchWatermark, IncrementalFetchInfo) {
+	next := FetchWatermark{}
+	if previous != nil {
+		next.CreatedAt = previous.CreatedAt
+		next.IDs = append(next.IDs, previous.IDs...)
+	}
+	advance := func(id, createdAt string) {
+		switch {
+		case createdAt > next.CreatedAt:
+			next.CreatedAt = createdAt
+			next.IDs = []string{id}
+		case createdAt == next.CreatedAt:
+			next.IDs = append(next.IDs, id)
+		}
+	}
+
+	info := IncrementalFetchInfo{}
+	if previous != nil {
+		info.Since = previous.CreatedAt
+	}
+
+	reviews := []ReviewData{}
+	for _, review := range data.Reviews {
+		if previous.isAfter(review.ID, review.CreatedAt) {
+			reviews = append(reviews, review)
+			advance(review.ID, review.CreatedAt)
+		}
+	}
+	data.Reviews = reviews
+	info.NewReviews = len(reviews)
+
+	threads := []ThreadData{}
+	for _, thread := range data.Threads {
+		var comments []ThreadComment
+		for _, comment := range thread.Comments {
+			if previous.isAfter(comment.ID, comment.CreatedAt) {
+				comments = append(comments, comment)
+				advance(comment.ID, comment.CreatedAt)
+			}
+		}
+		if len(comments) == 0 {
+			info.OmittedThreads++
+			continue
+		}
+		thread.Comments = comments
+		threads = append(threads, thread)
+		info.NewComments += len(comments)
+	}
+	data.Threads = threads
+
+	sort.Strings(next.IDs)
+	return next, info
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyFetchWatermark(t *testing.T) {
+	newData := func() *UnifiedReviewData {
+		return &UnifiedReviewData{
+			Reviews: []ReviewData{
+				{ID: "R1", CreatedAt: "2025-01-01T00:00:00Z"},
+				{ID: "R2", CreatedAt: "2025-01-02T00:00:00Z"},
+			},
+			Threads: []ThreadData{
+				{ID: "T1", Comments: []ThreadComment{
+					{ID: "C1", CreatedAt: "2025-01-01T00:00:00Z"},
+					{ID: "C2", CreatedAt: "2025-01-02T00:00:00Z"},
+				}},
+				{ID: "T2", Comments: []ThreadComment{{ID: "C3", CreatedAt: "2025-01-01T12:00:00Z"}}},
+			},
+		}
+	}
+
+	// First fetch returns everything
+	data := newData()
+	watermark, info := applyFetchWatermark(data, nil)
+	if info.NewReviews != 2 || info.NewComments != 3 || info.Since != "" {
+		t.Fatalf("first fetch info = %+v", info)
+	}
+	if watermark.CreatedAt != "2025-01-02T00:00:00Z" || len(watermark.IDs) != 2 {
+		t.Fatalf("watermark = %+v, want newest timestamp with R2 and C2", watermark)
+	}
+
+	// Second fetch: a review in the same second as the watermark and a new reply
+	data = newData()
+	data.Reviews = append(data.Reviews, ReviewData{ID: "R3", CreatedAt: "2025-01-02T00:00:00Z"})
+	data.Threads[0].Comments = append(data.Threads[0].Comments, ThreadComment{ID: "C4", CreatedAt: "2025-01-03T00:00:00Z"})
+	next, info := applyFetchWatermark(data, &watermark)
+
+	if len(data.Reviews) != 1 || data.Reviews[0].ID != "R3" {
+		t.Errorf("reviews = %+v, want only R3", data.Reviews)
+	}
+	if len(data.Threads) != 1 || len(data.Threads[0].Comments) != 1 || data.Threads[0].Comments[0].ID != "C4" {
+		t.Errorf("threads = %+v, want T1 with only C4", data.Threads)
+	}
+	if info.OmittedThreads != 1 || info.Since != "2025-01-02T00:00:00Z" {
+		t.Errorf("info = %+v", info)
+	}
+	if next.CreatedAt != "2025-01-03T00:00:00Z" || len(next.IDs) != 1 || next.IDs[0] != "C4" {
+		t.Errorf("next watermark = %+v", next)
+	}
+}
+
+func TestFetchWatermarkRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviews", "pr-1-fetch-alice.json")
+
+	if watermark, err := loadFetchWatermark(path); err != nil || watermark != nil {
+		t.Fatalf("missing watermark = %v, %v; want nil, nil", watermark, err)
+	}
+
+	want := FetchWatermark{CreatedAt: "2025-01-02T00:00:00Z", IDs: []string{"R2"}, FetchedAt: "2025-01-02T00:05:00Z"}
+	if err := saveFetchWatermark(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadFetchWatermark(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.CreatedAt != want.CreatedAt || len(got.IDs) != 1 || got.FetchedAt != want.FetchedAt {
+		t.Errorf("loaded = %+v, want %+v", got, want)
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "*.tmp"))
+	if len(matches) != 0 {
+		t.Errorf("temporary files left behind: %v", matches)
+	}
+}
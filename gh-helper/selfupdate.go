@@ -0,0 +1,469 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/apstndb/gh-dev-tools/pkg/ghclient"
+	"github.com/spf13/cobra"
+)
+
+// version is set at release build time (-X main.version=...)
+var version = "dev"
+
+// The repository gh-helper itself is released from
+const (
+	selfUpdateOwner = "apstndb"
+	selfUpdateRepo  = "gh-dev-tools"
+)
+
+var versionCmd = NewOperationalCommand(
+	"version [flags]",
+	"Show the gh-helper version",
+	`Show the version of this gh-helper binary. With --check, also look up the
+latest release and report whether an update is available.
+
+Examples:
+  gh-helper version
+  gh-helper version --check`,
+	showVersion,
+)
+
+var selfUpdateCmd = NewOperationalCommand(
+	"self-update [flags]",
+	"Replace this binary with the latest (or a pinned) release",
+	`Download a gh-helper release for this OS and architecture, verify it, and
+replace the running executable.
+
+The release archive is verified against the SHA-256 checksums.txt published
+with the release before anything is replaced. Releases are not signed, so no
+signature is checked; checksums.txt is only as trustworthy as the TLS download
+from GitHub. The downloads are bounded by --timeout.
+
+Without --pin, nothing is downloaded when this binary is already the latest
+release. --pin installs the given release even if it is older (downgrade).
+Binaries installed with 'go install' or the go tool directive are better
+updated through Go itself.
+
+Examples:
+  gh-helper self-update
+  gh-helper self-update --pin v1.4.0
+  gh-helper self-update --dry-run`,
+	selfUpdate,
+)
+
+func init() {
+	versionCmd.Flags().Bool("check", false, "Look up the latest release")
+	selfUpdateCmd.Flags().String("pin", "", "Install this release tag instead of the latest (e.g., v1.4.0)")
+	selfUpdateCmd.Flags().Bool("dry-run", false, "Show the release that would be installed without downloading it")
+
+	rootCmd.AddCommand(versionCmd, selfUpdateCmd)
+}
+
+// currentVersion returns the release version, or the module version for
+// 'go install'ed binaries, or "dev"
+func currentVersion() string {
+	if version != "dev" {
+		return "v" + strings.TrimPrefix(version, "v")
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return version
+}
+
+// parseSemver parses vMAJOR.MINOR.PATCH[-prerelease]
+func parseSemver(v string) (parts [3]int, prerelease string, ok bool) {
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.Index(v, "+"); i >= 0 {
+		v = v[:i]
+	}
+	if i := strings.Index(v, "-"); i >= 0 {
+		v, prerelease = v[:i], v[i+1:]
+	}
+	fields := strings.Split(v, ".")
+	if len(fields) != 3 {
+		return parts, "", false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, "", false
+		}
+		parts[i] = n
+	}
+	return parts, prerelease, true
+}
+
+// compareVersions compares two semantic versions (-1, 0, 1). Unparsable
+// versions (e.g., "dev") sort before every release.
+func compareVersions(a, b string) int {
+	pa, prea, oka := parseSemver(a)
+	pb, preb, okb := parseSemver(b)
+	switch {
+	case !oka && !okb:
+		return 0
+	case !oka:
+		return -1
+	case !okb:
+		return 1
+	}
+	for i := range pa {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case prea == preb:
+		return 0
+	case prea == "": // a release sorts after its prereleases
+		return 1
+	case preb == "":
+		return -1
+	}
+	return comparePrereleases(prea, preb)
+}
+
+// comparePrereleases compares prerelease versions by their dot-separated
+// identifiers: numeric ones numerically and before alphanumeric ones, others
+// in ASCII order, and a shorter list of equal identifiers first (semver 11.4)
+func comparePrereleases(a, b string) int {
+	ia, ib := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(ia) && i < len(ib); i++ {
+		na, errA := strconv.ParseUint(ia[i], 10, 64)
+		nb, errB := strconv.ParseUint(ib[i], 10, 64)
+		switch {
+		case errA == nil && errB == nil:
+			if c := cmp.Compare(na, nb); c != 0 {
+				return c
+			}
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(ia[i], ib[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmp.Compare(len(ia), len(ib))
+}
+
+// selfUpdateRelease is the part of a REST release used by self-update
+type selfUpdateRelease struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+	Assets  []struct {
+		Name               string `json:"name"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// assetURL returns the download URL of the named asset
+func (r *selfUpdateRelease) assetURL(name string) (string, error) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset.BrowserDownloadURL, nil
+		}
+	}
+	return "", fmt.Errorf("release %s has no asset %s", r.TagName, name)
+}
+
+// releaseArchiveName returns the archive name of a release for an OS and
+// architecture, following the goreleaser name template
+func releaseArchiveName(tag, goos, goarch string) string {
+	ext := "tar.gz"
+	if goos == "windows" {
+		ext = "zip"
+	}
+	return fmt.Sprintf("%s_%s_%s_%s.%s", selfUpdateRepo, strings.TrimPrefix(tag, "v"), goos, goarch, ext)
+}
+
+// parseChecksums parses a "sha256  filename" list
+func parseChecksums(data []byte) map[string]string {
+	checksums := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			checksums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+		}
+	}
+	return checksums
+}
+
+// verifyChecksum checks data against the checksum of name
+func verifyChecksum(data []byte, name string, checksums map[string]string) error {
+	want, ok := checksums[name]
+	if !ok {
+		return fmt.Errorf("checksums.txt has no entry for %s", name)
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("checksum mismatch for %s: got %s, want %s", name, got, want)
+	}
+	return nil
+}
+
+// extractBinary returns the named file from a .tar.gz or .zip archive
+func extractBinary(archive []byte, archiveName, binary string) ([]byte, error) {
+	if strings.HasSuffix(archiveName, ".zip") {
+		reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", archiveName, err)
+		}
+		for _, file := range reader.File {
+			if filepath.Base(file.Name) != binary {
+				continue
+			}
+			rc, err := file.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			return io.ReadAll(rc)
+		}
+		return nil, fmt.Errorf("%s not found in %s", binary, archiveName)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", archiveName, err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s not found in %s", binary, archiveName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", archiveName, err)
+		}
+		if header.Typeflag == tar.TypeReg && filepath.Base(header.Name) == binary {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// replaceExecutable atomically replaces the file at path with data. The new
+// file is written next to it and renamed over it; on Windows, where a running
+// executable cannot be overwritten, the old one is moved aside first.
+func replaceExecutable(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.new")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file (is the directory writable?): %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return fmt.Errorf("failed to make new binary executable: %w", err)
+	}
+
+	if runtime.GOOS == "windows" {
+		old := path + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(path, old); err != nil {
+			return fmt.Errorf("failed to move the running binary aside: %w", err)
+		}
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
+
+// getSelfUpdateRelease fetches the latest release, or the release of tag
+func (c *GitHubClient) getSelfUpdateRelease(tag string) (*selfUpdateRelease, error) {
+	path := fmt.Sprintf("/repos/%s/%s/releases/latest", c.Owner, c.Repo)
+	if tag != "" {
+		path = fmt.Sprintf("/repos/%s/%s/releases/tags/%s", c.Owner, c.Repo, tag)
+	}
+	responseData, err := c.RunRESTRequest("GET", path, nil)
+	if err != nil {
+		if tag != "" {
+			return nil, NotFoundError("release "+tag, err)
+		}
+		return nil, err
+	}
+	var release selfUpdateRelease
+	if err := json.Unmarshal(responseData, &release); err != nil {
+		return nil, fmt.Errorf("failed to parse release: %w", err)
+	}
+	return &release, nil
+}
+
+// assetHTTPClient returns the client for release asset downloads. It reuses
+// the API transport without the API client's 30s overall timeout, which would
+// abort large archives on slow links; the request context bounds the download.
+func assetHTTPClient() *http.Client {
+	return &http.Client{Transport: ghclient.SharedHTTPClient().Transport}
+}
+
+// downloadAsset downloads a release asset within the deadline of ctx
+func downloadAsset(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	resp, err := assetHTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// newSelfUpdateClient returns a client for the repository gh-helper is released from
+func newSelfUpdateClient(cmd *cobra.Command) *GitHubClient {
+	client := newCommandClient(cmd)
+	client.Owner, client.Repo = selfUpdateOwner, selfUpdateRepo
+	return client
+}
+
+func showVersion(cmd *cobra.Command, args []string) error {
+	check, err := cmd.Flags().GetBool("check")
+	if err != nil {
+		return fmt.Errorf("failed to get 'check' flag: %w", err)
+	}
+
+	current := currentVersion()
+	result := map[string]interface{}{
+		"current": current,
+		"goos":    runtime.GOOS,
+		"goarch":  runtime.GOARCH,
+	}
+	if check {
+		release, err := newSelfUpdateClient(cmd).getSelfUpdateRelease("")
+		if err != nil {
+			return fmt.Errorf("failed to look up the latest release: %w", err)
+		}
+		result["latest"] = release.TagName
+		result["updateAvailable"] = compareVersions(current, release.TagName) < 0
+		result["url"] = release.HTMLURL
+	}
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"version": result})
+}
+
+func selfUpdate(cmd *cobra.Command, args []string) error {
+	pin, err := cmd.Flags().GetString("pin")
+	if err != nil {
+		return fmt.Errorf("failed to get 'pin' flag: %w", err)
+	}
+	if pin != "" && !strings.HasPrefix(pin, "v") {
+		pin = "v" + pin
+	}
+	dryRun := commandContext(cmd).DryRun
+
+	release, err := newSelfUpdateClient(cmd).getSelfUpdateRelease(pin)
+	if err != nil {
+		return fmt.Errorf("failed to look up the release: %w", err)
+	}
+
+	current := currentVersion()
+	result := map[string]interface{}{
+		"from": current,
+		"to":   release.TagName,
+	}
+	if pin == "" && compareVersions(current, release.TagName) >= 0 {
+		result["updated"] = false
+		result["reason"] = "already up to date"
+		return EncodeOutputWithCmd(cmd, map[string]interface{}{"selfUpdate": result})
+	}
+
+	archiveName := releaseArchiveName(release.TagName, runtime.GOOS, runtime.GOARCH)
+	archiveURL, err := release.assetURL(archiveName)
+	if err != nil {
+		return err
+	}
+	checksumsURL, err := release.assetURL("checksums.txt")
+	if err != nil {
+		return err
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the running executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+	result["asset"] = archiveName
+	result["path"] = executable
+
+	if dryRun {
+		result["updated"] = false
+		result["dryRun"] = true
+		return EncodeOutputWithCmd(cmd, map[string]interface{}{"selfUpdate": result})
+	}
+
+	// The downloads may take longer than an API call; --timeout bounds them
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timeout, err := ParseTimeout(commandContext(cmd).Timeout)
+	if err != nil {
+		return err
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	checksums, err := downloadAsset(ctx, checksumsURL)
+	if err != nil {
+		return err
+	}
+	archive, err := downloadAsset(ctx, archiveURL)
+	if err != nil {
+		return err
+	}
+	if err := verifyChecksum(archive, archiveName, parseChecksums(checksums)); err != nil {
+		return fmt.Errorf("refusing to install %s: %w", release.TagName, err)
+	}
+
+	binary := "gh-helper"
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+	data, err := extractBinary(archive, archiveName, binary)
+	if err != nil {
+		return err
+	}
+	if err := replaceExecutable(executable, data); err != nil {
+		return err
+	}
+
+	result["updated"] = true
+	result["checksum"] = "sha256 verified"
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"selfUpdate": result})
+}
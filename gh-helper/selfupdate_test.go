@@ -0,0 +1,114 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.4.0", "v1.4.0", 0},
+		{"v1.4.0", "1.4.0", 0},
+		{"v1.3.9", "v1.4.0", -1},
+		{"v1.10.0", "v1.9.0", 1},
+		{"v1.4.0-rc.1", "v1.4.0", -1},
+		{"v1.4.0-rc.2", "v1.4.0-rc.1", 1},
+		{"v1.4.0-rc.10", "v1.4.0-rc.9", 1},
+		{"v1.4.0-rc.1", "v1.4.0-rc.1.1", -1},
+		{"v1.4.0-alpha.1", "v1.4.0-alpha.beta", -1},
+		{"v1.4.0-beta", "v1.4.0-alpha.1", 1},
+		{"dev", "v0.0.1", -1},
+		{"v0.0.1", "dev", 1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestReleaseArchiveName(t *testing.T) {
+	if got := releaseArchiveName("v1.4.0", "linux", "amd64"); got != "gh-dev-tools_1.4.0_linux_amd64.tar.gz" {
+		t.Errorf("releaseArchiveName(linux) = %q", got)
+	}
+	if got := releaseArchiveName("v1.4.0", "windows", "arm64"); got != "gh-dev-tools_1.4.0_windows_arm64.zip" {
+		t.Errorf("releaseArchiveName(windows) = %q", got)
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("archive")
+	sum := sha256.Sum256(data)
+	checksums := parseChecksums([]byte(hex.EncodeToString(sum[:]) + "  a.tar.gz\n0000  b.tar.gz\n"))
+	if err := verifyChecksum(data, "a.tar.gz", checksums); err != nil {
+		t.Errorf("verifyChecksum(a) = %v", err)
+	}
+	if err := verifyChecksum(data, "b.tar.gz", checksums); err == nil || !strings.Contains(err.Error(), "mismatch") {
+		t.Errorf("verifyChecksum(b) = %v, want mismatch", err)
+	}
+	if err := verifyChecksum(data, "c.tar.gz", checksums); err == nil {
+		t.Error("verifyChecksum(c) succeeded without an entry")
+	}
+}
+
+func TestExtractBinary(t *testing.T) {
+	var tgz bytes.Buffer
+	gz := gzip.NewWriter(&tgz)
+	tw := tar.NewWriter(gz)
+	for name, content := range map[string]string{"README.md": "readme", "gh-helper": "binary"} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gz.Close()
+	if got, err := extractBinary(tgz.Bytes(), "x.tar.gz", "gh-helper"); err != nil || string(got) != "binary" {
+		t.Errorf("extractBinary(tar.gz) = %q, %v", got, err)
+	}
+
+	var zipped bytes.Buffer
+	zw := zip.NewWriter(&zipped)
+	w, err := zw.Create("gh-helper.exe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("exe"))
+	zw.Close()
+	if got, err := extractBinary(zipped.Bytes(), "x.zip", "gh-helper.exe"); err != nil || string(got) != "exe" {
+		t.Errorf("extractBinary(zip) = %q, %v", got, err)
+	}
+	if _, err := extractBinary(zipped.Bytes(), "x.zip", "gh-helper"); err == nil {
+		t.Error("extractBinary() found a missing binary")
+	}
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gh-helper")
+	if err := os.WriteFile(path, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := replaceExecutable(path, []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil || string(got) != "new" {
+		t.Errorf("replaced content = %q, %v", got, err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm()&0100 == 0 {
+		t.Errorf("replaced binary is not executable: %v", err)
+	}
+}
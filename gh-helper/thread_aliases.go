@@ -0,0 +1,446 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+)
+
+var listThreadsCmd = NewOperationalCommand(
+	"list [pr-number]",
+	"List review threads with short aliases (#1, #2, ...)",
+	`List review threads of a pull request with short per-PR aliases.
+
+Aliases are assigned in thread order and stored in the local cache, so they
+stay stable across runs. Other thread commands accept an alias in place of
+a PRRT_ thread ID (with --pr, or the current branch's PR).
+
+`+prNumberArgsHelp+`
+
+Examples:
+  # List unresolved threads with aliases
+  gh-helper threads list 306 --unresolved-only
+
+  # Then refer to threads by alias
+  gh-helper threads show 2 --pr 306
+  gh-helper threads reply 3 --pr 306 --message "Fixed" --resolve
+  gh-helper threads resolve '#1' '#4' --pr 306
+
+  # Cleanup session: stale unresolved threads, oldest first
+  gh-helper threads list 306 --stale-only
+
+Each thread includes "age" (since its last comment) and a "staleness" bucket:
+fresh (younger than --aging-after), aging, or stale (older than --stale-after).
+Unresolved threads also include "daysUnresolved" (since the first comment).
+
+Threads snoozed with 'threads snooze' are left out (counted in
+"snoozedCount") unless --include-snoozed is given.`,
+	listThreads,
+)
+
+func init() {
+	listThreadsCmd.Args = cobra.MaximumNArgs(1)
+
+	listThreadsCmd.Flags().Bool("unresolved-only", false, "Show only unresolved threads")
+	listThreadsCmd.Flags().Int("limit", 100, "Maximum threads to fetch")
+	listThreadsCmd.Flags().Bool("exclude-urls", false, "Exclude URLs from output")
+	listThreadsCmd.Flags().Bool("stale-only", false, "Show only stale unresolved threads, oldest first")
+	listThreadsCmd.Flags().Duration("aging-after", 48*time.Hour, "Age since last comment after which a thread is aging")
+	listThreadsCmd.Flags().Duration("stale-after", 7*24*time.Hour, "Age since last comment after which a thread is stale")
+	addIncludeSnoozedFlag(listThreadsCmd)
+
+	threadsCmd.PersistentFlags().String("pr", "", "PR number used to resolve thread aliases (default: current branch's PR)")
+	threadsCmd.AddCommand(listThreadsCmd)
+}
+
+// ThreadAliases maps short per-PR aliases to review thread IDs.
+// Alias N refers to Threads[N-1]; new threads are appended so existing aliases never change.
+type ThreadAliases struct {
+	PR        string    `json:"pr"`
+	Threads   []string  `json:"threads"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// threadAliasPattern matches "3" or "#3"
+var threadAliasPattern = regexp.MustCompile(`^#?(\d+)$`)
+
+// parseThreadAlias returns the alias number if s is an alias rather than a thread ID
+func parseThreadAlias(s string) (int, bool) {
+	matches := threadAliasPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if matches == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(matches[1])
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+func threadAliasFile(prNumber string) string {
+	return filepath.Join(GetCacheDir(), "threads", fmt.Sprintf("pr-%s-aliases.json", prNumber))
+}
+
+// loadThreadAliases loads the alias map for a PR, returning an empty map if none exists
+func loadThreadAliases(prNumber string) (*ThreadAliases, error) {
+	data, err := os.ReadFile(threadAliasFile(prNumber))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ThreadAliases{PR: prNumber}, nil
+		}
+		return nil, err
+	}
+
+	var aliases ThreadAliases
+	if err := Unmarshal(data, &aliases); err != nil {
+		return nil, err
+	}
+	return &aliases, nil
+}
+
+// saveThreadAliases saves the alias map for a PR to cache
+func saveThreadAliases(aliases *ThreadAliases) error {
+	file := threadAliasFile(aliases.PR)
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return fmt.Errorf("failed to create alias directory: %w", err)
+	}
+
+	aliases.UpdatedAt = time.Now()
+	data, err := yaml.MarshalWithOptions(aliases, yaml.UseJSONMarshaler())
+	if err != nil {
+		return fmt.Errorf("failed to marshal aliases: %w", err)
+	}
+
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("failed to write alias file: %w", err)
+	}
+	return nil
+}
+
+// Assign appends unknown thread IDs in order and reports whether the map changed
+func (a *ThreadAliases) Assign(threadIDs []string) bool {
+	known := make(map[string]bool, len(a.Threads))
+	for _, id := range a.Threads {
+		known[id] = true
+	}
+
+	changed := false
+	for _, id := range threadIDs {
+		if !known[id] {
+			a.Threads = append(a.Threads, id)
+			known[id] = true
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Lookup returns the thread ID for an alias number
+func (a *ThreadAliases) Lookup(n int) (string, bool) {
+	if n <= 0 || n > len(a.Threads) {
+		return "", false
+	}
+	return a.Threads[n-1], true
+}
+
+// AliasOf returns the alias number for a thread ID, or 0 if none is assigned
+func (a *ThreadAliases) AliasOf(threadID string) int {
+	for i, id := range a.Threads {
+		if id == threadID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// refreshThreadAliases fetches the PR's threads and assigns aliases to new ones
+func refreshThreadAliases(cmd *cobra.Command, client *GitHubClient, prNumber string, limit int, excludeURLs bool) (*ThreadAliases, *BatchThreadsResponse, error) {
+	aliases, err := loadThreadAliases(prNumber)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load thread aliases: %w", err)
+	}
+
+	threads, err := client.ListReviewThreads(prNumber, false, false, limit, excludeURLs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]string, 0, len(threads.Threads))
+	for _, thread := range threads.Threads {
+		ids = append(ids, thread.ID)
+	}
+	if aliases.Assign(ids) {
+		if err := saveThreadAliases(aliases); err != nil {
+			WarningMsg("Failed to save thread aliases: %v", err).Print(cmd)
+		}
+	}
+
+	return aliases, threads, nil
+}
+
+// resolveThreadIDs replaces alias arguments (3, #3) with thread IDs.
+// Arguments that are not aliases are returned unchanged.
+func resolveThreadIDs(cmd *cobra.Command, client *GitHubClient, ids []string) ([]string, error) {
+	hasAlias := false
+	for _, id := range ids {
+		if _, ok := parseThreadAlias(id); ok {
+			hasAlias = true
+			break
+		}
+	}
+	if !hasAlias {
+		return ids, nil
+	}
+
+	prArg, _ := cmd.Flags().GetString("pr")
+	var prArgs []string
+	if prArg != "" {
+		prArgs = []string{prArg}
+	}
+	prNumber, err := resolvePRNumberFromArgs(prArgs, client)
+	if err != nil {
+		return nil, fmt.Errorf("thread aliases need a PR (use --pr): %w", err)
+	}
+
+	aliases, err := loadThreadAliases(prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load thread aliases: %w", err)
+	}
+
+	resolved := make([]string, len(ids))
+	refreshed := false
+	for i, id := range ids {
+		n, ok := parseThreadAlias(id)
+		if !ok {
+			resolved[i] = id
+			continue
+		}
+		threadID, found := aliases.Lookup(n)
+		if !found && !refreshed {
+			// Unknown alias: the cache may be missing or stale, so assign from the current thread list
+			aliases, _, err = refreshThreadAliases(cmd, client, prNumber, 100, true)
+			if err != nil {
+				return nil, fmt.Errorf("failed to refresh thread aliases: %w", err)
+			}
+			refreshed = true
+			threadID, found = aliases.Lookup(n)
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown thread alias #%d for PR #%s (run 'gh-helper threads list %s')", n, prNumber, prNumber)
+		}
+		resolved[i] = threadID
+	}
+	return resolved, nil
+}
+
+// Staleness buckets for review threads, by time since the last comment
+const (
+	stalenessFresh = "fresh"
+	stalenessAging = "aging"
+	stalenessStale = "stale"
+)
+
+// ThreadStaleness describes how long a thread has been waiting
+type ThreadStaleness struct {
+	Age            time.Duration // since the last comment
+	DaysUnresolved int           // since the first comment; 0 for resolved threads
+	Bucket         string
+}
+
+// threadStaleness computes thread age and staleness from its comments.
+// ok is false when no comment has a parseable timestamp.
+func threadStaleness(thread ThreadInfo, now time.Time, agingAfter, staleAfter time.Duration) (ThreadStaleness, bool) {
+	var first, last time.Time
+	for _, comment := range thread.Comments {
+		createdAt, err := time.Parse(time.RFC3339, comment.CreatedAt)
+		if err != nil {
+			continue
+		}
+		if first.IsZero() || createdAt.Before(first) {
+			first = createdAt
+		}
+		if createdAt.After(last) {
+			last = createdAt
+		}
+	}
+	if last.IsZero() {
+		return ThreadStaleness{}, false
+	}
+
+	staleness := ThreadStaleness{Age: now.Sub(last)}
+	if !thread.IsResolved {
+		staleness.DaysUnresolved = int(now.Sub(first).Hours() / 24)
+	}
+	switch {
+	case staleness.Age >= staleAfter:
+		staleness.Bucket = stalenessStale
+	case staleness.Age >= agingAfter:
+		staleness.Bucket = stalenessAging
+	default:
+		staleness.Bucket = stalenessFresh
+	}
+	return staleness, true
+}
+
+// listedThreadComments is the number of comments ListReviewThreads fetches per thread
+const listedThreadComments = 20
+
+// withLatestComments returns thread with the latest comments appended to its
+// first ones, so staleness covers both the first and the last comment
+func withLatestComments(thread ThreadInfo, latest []CommentInfo) ThreadInfo {
+	if len(latest) == 0 {
+		return thread
+	}
+	thread.Comments = append(slices.Clip(thread.Comments), latest...)
+	return thread
+}
+
+func listThreads(cmd *cobra.Command, args []string) error {
+	client := newCommandClient(cmd)
+	prNumber, err := resolvePRNumberFromArgs(args, client)
+	if err != nil {
+		return err
+	}
+
+	unresolvedOnly, err := cmd.Flags().GetBool("unresolved-only")
+	if err != nil {
+		return fmt.Errorf("failed to get 'unresolved-only' flag: %w", err)
+	}
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return fmt.Errorf("failed to get 'limit' flag: %w", err)
+	}
+	excludeURLs, err := cmd.Flags().GetBool("exclude-urls")
+	if err != nil {
+		return fmt.Errorf("failed to get 'exclude-urls' flag: %w", err)
+	}
+	staleOnly, err := cmd.Flags().GetBool("stale-only")
+	if err != nil {
+		return fmt.Errorf("failed to get 'stale-only' flag: %w", err)
+	}
+	agingAfter, err := cmd.Flags().GetDuration("aging-after")
+	if err != nil {
+		return fmt.Errorf("failed to get 'aging-after' flag: %w", err)
+	}
+	staleAfter, err := cmd.Flags().GetDuration("stale-after")
+	if err != nil {
+		return fmt.Errorf("failed to get 'stale-after' flag: %w", err)
+	}
+	if agingAfter <= 0 || staleAfter < agingAfter {
+		return fmt.Errorf("--aging-after must be positive and not greater than --stale-after")
+	}
+	isSnoozed, err := snoozeFilter(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Aliases are assigned over all threads so they stay stable regardless of filters
+	aliases, threads, err := refreshThreadAliases(cmd, client, prNumber, limit, excludeURLs)
+	if err != nil {
+		return fmt.Errorf("failed to list threads: %w", err)
+	}
+
+	// ListReviewThreads returns the first comments of each thread; for threads
+	// at that cap, age and last author come from the latest comments instead
+	var longThreadIDs []string
+	for _, thread := range threads.Threads {
+		if len(thread.Comments) >= listedThreadComments {
+			longThreadIDs = append(longThreadIDs, thread.ID)
+		}
+	}
+	latestComments := map[string][]CommentInfo{}
+	if len(longThreadIDs) > 0 {
+		if latestComments, _, err = client.getLatestThreadComments(longThreadIDs); err != nil {
+			return fmt.Errorf("failed to fetch latest thread comments: %w", err)
+		}
+	}
+
+	now := time.Now()
+	results := []map[string]interface{}{}
+	ages := map[string]time.Duration{}
+	unresolvedCount := 0
+	staleCount := 0
+	snoozedCount := 0
+	for _, thread := range threads.Threads {
+		if isSnoozed != nil && isSnoozed(thread.ID) {
+			snoozedCount++
+			continue
+		}
+		latest := latestComments[thread.ID]
+		staleness, hasStaleness := threadStaleness(withLatestComments(thread, latest), now, agingAfter, staleAfter)
+		isStale := hasStaleness && !thread.IsResolved && staleness.Bucket == stalenessStale
+		if !thread.IsResolved {
+			unresolvedCount++
+		}
+		if isStale {
+			staleCount++
+		}
+		if unresolvedOnly && thread.IsResolved {
+			continue
+		}
+		if staleOnly && !isStale {
+			continue
+		}
+
+		threadData := map[string]interface{}{
+			"alias":      fmt.Sprintf("#%d", aliases.AliasOf(thread.ID)),
+			"id":         thread.ID,
+			"path":       thread.Path,
+			"line":       thread.Line,
+			"isResolved": thread.IsResolved,
+		}
+		if thread.URL != "" {
+			threadData["url"] = thread.URL
+		}
+		if len(thread.Comments) > 0 {
+			first := thread.Comments[0]
+			last := thread.Comments[len(thread.Comments)-1]
+			if len(latest) > 0 {
+				last = latest[len(latest)-1]
+			}
+			threadData["author"] = first.Author
+			threadData["lastCommentBy"] = last.Author
+			threadData["comments"] = len(thread.Comments)
+			summary, _, _ := strings.Cut(strings.TrimSpace(first.Body), "\n")
+			threadData["summary"] = summary
+		}
+		if hasStaleness {
+			threadData["age"] = staleness.Age.Truncate(time.Minute).String()
+			threadData["staleness"] = staleness.Bucket
+			if !thread.IsResolved {
+				threadData["daysUnresolved"] = staleness.DaysUnresolved
+			}
+			ages[thread.ID] = staleness.Age
+		}
+		results = append(results, threadData)
+	}
+
+	// Oldest debt first when targeting stale threads
+	if staleOnly {
+		sort.SliceStable(results, func(i, j int) bool {
+			return ages[results[i]["id"].(string)] > ages[results[j]["id"].(string)]
+		})
+	}
+
+	output := map[string]interface{}{
+		"threads": map[string]interface{}{
+			"pr":              prNumber,
+			"totalCount":      threads.TotalCount,
+			"unresolvedCount": unresolvedCount,
+			"staleCount":      staleCount,
+			"snoozedCount":    snoozedCount,
+			"nodes":           results,
+		},
+	}
+
+	return EncodeOutputWithCmd(cmd, output)
+}
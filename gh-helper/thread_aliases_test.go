@@ -0,0 +1,135 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseThreadAlias(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   int
+		wantOK bool
+	}{
+		{"3", 3, true},
+		{"#12", 12, true},
+		{"0", 0, false},
+		{"PRRT_kwDONC6gMM5SgXT2", 0, false},
+		{"#", 0, false},
+		{"3a", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := parseThreadAlias(tt.input)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseThreadAlias(%q) = (%d, %v), want (%d, %v)", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestThreadAliasesAssignIsStable(t *testing.T) {
+	aliases := &ThreadAliases{PR: "306"}
+
+	if !aliases.Assign([]string{"PRRT_a", "PRRT_b"}) {
+		t.Fatal("expected first assignment to change the map")
+	}
+	if aliases.Assign([]string{"PRRT_a", "PRRT_b"}) {
+		t.Error("expected re-assigning known threads to be a no-op")
+	}
+
+	// A new thread gets the next alias; existing aliases do not shift
+	aliases.Assign([]string{"PRRT_c", "PRRT_a", "PRRT_b"})
+
+	for n, want := range map[int]string{1: "PRRT_a", 2: "PRRT_b", 3: "PRRT_c"} {
+		if got, ok := aliases.Lookup(n); !ok || got != want {
+			t.Errorf("Lookup(%d) = (%q, %v), want %q", n, got, ok, want)
+		}
+	}
+	if _, ok := aliases.Lookup(4); ok {
+		t.Error("Lookup(4) should fail")
+	}
+	if got := aliases.AliasOf("PRRT_c"); got != 3 {
+		t.Errorf("AliasOf(PRRT_c) = %d, want 3", got)
+	}
+}
+
+func TestThreadStaleness(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	comments := func(times ...string) []CommentInfo {
+		var result []CommentInfo
+		for _, createdAt := range times {
+			result = append(result, CommentInfo{CreatedAt: createdAt})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name               string
+		thread             ThreadInfo
+		wantOK             bool
+		wantBucket         string
+		wantAge            time.Duration
+		wantDaysUnresolved int
+	}{
+		{
+			name:               "fresh",
+			thread:             ThreadInfo{Comments: comments("2025-06-09T12:00:00Z", "2025-06-10T10:00:00Z")},
+			wantOK:             true,
+			wantBucket:         stalenessFresh,
+			wantAge:            2 * time.Hour,
+			wantDaysUnresolved: 1,
+		},
+		{
+			name:               "aging uses last comment",
+			thread:             ThreadInfo{Comments: comments("2025-06-01T12:00:00Z", "2025-06-07T12:00:00Z")},
+			wantOK:             true,
+			wantBucket:         stalenessAging,
+			wantAge:            72 * time.Hour,
+			wantDaysUnresolved: 9,
+		},
+		{
+			name:               "stale",
+			thread:             ThreadInfo{Comments: comments("2025-05-31T12:00:00Z")},
+			wantOK:             true,
+			wantBucket:         stalenessStale,
+			wantAge:            240 * time.Hour,
+			wantDaysUnresolved: 10,
+		},
+		{
+			name:       "resolved has no days unresolved",
+			thread:     ThreadInfo{IsResolved: true, Comments: comments("2025-05-31T12:00:00Z")},
+			wantOK:     true,
+			wantBucket: stalenessStale,
+			wantAge:    240 * time.Hour,
+		},
+		{
+			name:   "no timestamps",
+			thread: ThreadInfo{Comments: comments("")},
+		},
+		{
+			name:               "latest comments beyond the listed ones",
+			thread:             withLatestComments(ThreadInfo{Comments: comments("2025-05-31T12:00:00Z")}, comments("2025-06-10T06:00:00Z")),
+			wantOK:             true,
+			wantBucket:         stalenessFresh,
+			wantAge:            6 * time.Hour,
+			wantDaysUnresolved: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := threadStaleness(tt.thread, now, 48*time.Hour, 7*24*time.Hour)
+			if ok != tt.wantOK {
+				t.Fatalf("threadStaleness() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if got.Bucket != tt.wantBucket || got.Age != tt.wantAge || got.DaysUnresolved != tt.wantDaysUnresolved {
+				t.Errorf("threadStaleness() = %+v, want bucket %s, age %v, days %d", got, tt.wantBucket, tt.wantAge, tt.wantDaysUnresolved)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// QuoteOptions controls quoting the answered comment in 'threads reply'
+type QuoteOptions struct {
+	MaxLines int // Trim the quote to the first N lines (0: unlimited); ignored with a selection
+	Start    int // 1-based first line of --quote-selection (0: from the first line)
+	End      int // 1-based last line of --quote-selection (0: to the last line)
+	Selected bool
+}
+
+// parseQuoteSelection parses --quote-selection "start:end" (1-based, inclusive;
+// either side may be omitted, e.g. "3:" or ":5")
+func parseQuoteSelection(spec string) (start, end int, err error) {
+	startStr, endStr, ok := strings.Cut(spec, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid --quote-selection %q (expected start:end)", spec)
+	}
+	if startStr = strings.TrimSpace(startStr); startStr != "" {
+		if start, err = strconv.Atoi(startStr); err != nil || start < 1 {
+			return 0, 0, fmt.Errorf("invalid --quote-selection start %q", startStr)
+		}
+	}
+	if endStr = strings.TrimSpace(endStr); endStr != "" {
+		if end, err = strconv.Atoi(endStr); err != nil || end < 1 {
+			return 0, 0, fmt.Errorf("invalid --quote-selection end %q", endStr)
+		}
+	}
+	if start > 0 && end > 0 && start > end {
+		return 0, 0, fmt.Errorf("invalid --quote-selection %q (start after end)", spec)
+	}
+	return start, end, nil
+}
+
+// lastReviewerComment returns the latest comment not written by the current user,
+// falling back to the latest comment when all are the user's own
+func lastReviewerComment(comments []CommentInfo, currentUser string) (CommentInfo, bool) {
+	for i := len(comments) - 1; i >= 0; i-- {
+		if !strings.EqualFold(comments[i].Author, currentUser) {
+			return comments[i], true
+		}
+	}
+	if len(comments) > 0 {
+		return comments[len(comments)-1], true
+	}
+	return CommentInfo{}, false
+}
+
+// quoteComment renders a comment body as a Markdown blockquote
+func quoteComment(body string, opts QuoteOptions) (string, error) {
+	lines := strings.Split(strings.Trim(strings.ReplaceAll(body, "\r\n", "\n"), "\n"), "\n")
+
+	truncated := false
+	if opts.Selected {
+		start, end := opts.Start, opts.End
+		if start == 0 {
+			start = 1
+		}
+		if start > len(lines) {
+			return "", fmt.Errorf("--quote-selection starts at line %d but the comment has %d line(s)", start, len(lines))
+		}
+		if end == 0 || end > len(lines) {
+			end = len(lines)
+		}
+		lines = lines[start-1 : end]
+	} else if opts.MaxLines > 0 && len(lines) > opts.MaxLines {
+		lines = lines[:opts.MaxLines]
+		truncated = true
+	}
+
+	var b strings.Builder
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			b.WriteString(">\n")
+			continue
+		}
+		b.WriteString("> " + line + "\n")
+	}
+	if truncated {
+		b.WriteString("> …\n")
+	}
+	return b.String(), nil
+}
+
+// buildThreadQuotes fetches the threads being answered and renders a quote of
+// the last reviewer comment for each; per-thread problems are returned as errors
+func buildThreadQuotes(client *GitHubClient, threadIDs []string, opts QuoteOptions) (map[string]string, map[string]string, error) {
+	currentUser, err := client.GetCurrentUser()
+	if err != nil {
+		return nil, nil, err
+	}
+	threads, failures, err := client.getLatestThreadComments(threadIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	quotes := make(map[string]string, len(threadIDs))
+	for _, id := range threadIDs {
+		if _, failed := failures[id]; failed {
+			continue
+		}
+		comments, ok := threads[id]
+		if !ok {
+			failures[id] = "thread not found"
+			continue
+		}
+		comment, ok := lastReviewerComment(comments, currentUser)
+		if !ok {
+			failures[id] = "thread has no comments to quote"
+			continue
+		}
+		quote, err := quoteComment(comment.Body, opts)
+		if err != nil {
+			failures[id] = err.Error()
+			continue
+		}
+		quotes[id] = quote
+	}
+	return quotes, failures, nil
+}
+
+// quoteCommentWindow is how many of the latest comments are searched for the
+// comment to quote
+const quoteCommentWindow = 20
+
+// getLatestThreadComments fetches the latest comments of each thread, oldest
+// first; per-thread GraphQL errors are returned as failures like GetThreadBatch
+func (c *GitHubClient) getLatestThreadComments(threadIDs []string) (map[string][]CommentInfo, map[string]string, error) {
+	query := `
+	query($ids: [ID!]!, $last: Int!) {
+	  nodes(ids: $ids) {
+	    id
+	    ... on PullRequestReviewThread {
+	      comments(last: $last) { nodes { id body author { login } createdAt } }
+	    }
+	  }
+	}`
+
+	result, graphqlErrors, err := c.RunGraphQLQueryPartial(query, map[string]interface{}{
+		"ids":  threadIDs,
+		"last": quoteCommentWindow,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch threads: %w", err)
+	}
+
+	failures := make(map[string]string)
+	for _, graphqlErr := range graphqlErrors {
+		index, ok := graphqlErr.PathIndex(1)
+		if graphqlErr.Alias() != "nodes" || !ok || index < 0 || index >= len(threadIDs) {
+			return nil, nil, fmt.Errorf("failed to fetch threads: GraphQL error: %s", graphqlErr.Message)
+		}
+		failures[threadIDs[index]] = graphqlErr.Message
+	}
+
+	var response struct {
+		Data struct {
+			Nodes []*struct {
+				ID       string `json:"id"`
+				Comments struct {
+					Nodes []struct {
+						ID        string    `json:"id"`
+						Body      string    `json:"body"`
+						Author    *actorRef `json:"author"`
+						CreatedAt string    `json:"createdAt"`
+					} `json:"nodes"`
+				} `json:"comments"`
+			} `json:"nodes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse threads response: %w", err)
+	}
+
+	threads := make(map[string][]CommentInfo, len(response.Data.Nodes))
+	for _, node := range response.Data.Nodes {
+		if node == nil || node.ID == "" {
+			continue
+		}
+		comments := []CommentInfo{}
+		for _, comment := range node.Comments.Nodes {
+			comments = append(comments, CommentInfo{
+				ID:        comment.ID,
+				Body:      comment.Body,
+				Author:    comment.Author.login(),
+				CreatedAt: comment.CreatedAt,
+			})
+		}
+		threads[node.ID] = comments
+	}
+	return threads, failures, nil
+}
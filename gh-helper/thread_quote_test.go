@@ -0,0 +1,64 @@
+package main
+
+import "testing"
+
+func TestQuoteComment(t *testing.T) {
+	body := "First line\r\n\r\nThird line\nFourth line\n"
+
+	tests := []struct {
+		name string
+		opts QuoteOptions
+		want string
+	}{
+		{"all lines", QuoteOptions{}, "> First line\n>\n> Third line\n> Fourth line\n"},
+		{"trimmed", QuoteOptions{MaxLines: 2}, "> First line\n>\n> …\n"},
+		{"selection", QuoteOptions{MaxLines: 1, Start: 3, End: 4, Selected: true}, "> Third line\n> Fourth line\n"},
+		{"open-ended selection", QuoteOptions{Start: 4, Selected: true}, "> Fourth line\n"},
+		{"selection past end is clamped", QuoteOptions{End: 10, Selected: true}, "> First line\n>\n> Third line\n> Fourth line\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := quoteComment(body, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("quoteComment() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := quoteComment(body, QuoteOptions{Start: 5, Selected: true}); err == nil {
+		t.Error("expected error for selection starting past the last line")
+	}
+}
+
+func TestParseQuoteSelection(t *testing.T) {
+	for spec, want := range map[string][2]int{"2:4": {2, 4}, "3:": {3, 0}, ":5": {0, 5}} {
+		start, end, err := parseQuoteSelection(spec)
+		if err != nil || start != want[0] || end != want[1] {
+			t.Errorf("parseQuoteSelection(%q) = %d, %d, %v; want %v", spec, start, end, err, want)
+		}
+	}
+	for _, spec := range []string{"4", "4:2", "0:3", "a:b"} {
+		if _, _, err := parseQuoteSelection(spec); err == nil {
+			t.Errorf("parseQuoteSelection(%q) expected error", spec)
+		}
+	}
+}
+
+func TestLastReviewerComment(t *testing.T) {
+	comments := []CommentInfo{
+		{ID: "1", Author: "reviewer"},
+		{ID: "2", Author: "me"},
+	}
+	if got, _ := lastReviewerComment(comments, "Me"); got.ID != "1" {
+		t.Errorf("got comment %s, want 1", got.ID)
+	}
+	if got, _ := lastReviewerComment(comments[1:], "me"); got.ID != "2" {
+		t.Errorf("own-only thread: got comment %s, want 2", got.ID)
+	}
+	if _, ok := lastReviewerComment(nil, "me"); ok {
+		t.Error("expected no comment for empty thread")
+	}
+}
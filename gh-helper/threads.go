@@ -197,9 +197,12 @@ query($owner: String!, $repo: String!, $prNumber: Int!, $limit: Int!, $excludeUr
 // - Uses GraphQL's multi-node query to fetch multiple threads simultaneously
 // - Eliminates N API calls for N threads (O(N) → O(1) optimization)
 // - Maintains thread order and provides error context for invalid IDs
-func (c *GitHubClient) GetThreadBatch(threadIDs []string, excludeURLs bool) (map[string]*ThreadInfo, error) {
+//
+// Invalid or inaccessible IDs do not fail the batch: their GraphQL errors are
+// returned in the failures map keyed by thread ID.
+func (c *GitHubClient) GetThreadBatch(threadIDs []string, excludeURLs bool) (map[string]*ThreadInfo, map[string]string, error) {
 	if len(threadIDs) == 0 {
-		return map[string]*ThreadInfo{}, nil
+		return map[string]*ThreadInfo{}, map[string]string{}, nil
 	}
 
 	// Construct nodes query for multiple threads
@@ -238,9 +241,19 @@ query($ids: [ID!]!, $excludeUrls: Boolean!) {
 		"excludeUrls": excludeURLs,
 	}
 
-	result, err := c.RunGraphQLQueryWithVariables(query, variables)
+	result, graphqlErrors, err := c.RunGraphQLQueryPartial(query, variables)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch thread batch: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch thread batch: %w", err)
+	}
+
+	// Errors for individual IDs have the path ["nodes", index]
+	failures := make(map[string]string)
+	for _, graphqlErr := range graphqlErrors {
+		index, ok := graphqlErr.PathIndex(1)
+		if graphqlErr.Alias() != "nodes" || !ok || index < 0 || index >= len(threadIDs) {
+			return nil, nil, fmt.Errorf("failed to fetch thread batch: GraphQL error: %s", graphqlErr.Message)
+		}
+		failures[threadIDs[index]] = graphqlErr.Message
 	}
 
 	var response struct {
@@ -272,7 +285,7 @@ query($ids: [ID!]!, $excludeUrls: Boolean!) {
 	}
 
 	if err := Unmarshal(result, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse thread batch response: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse thread batch response: %w", err)
 	}
 
 	threads := make(map[string]*ThreadInfo)
@@ -311,7 +324,7 @@ query($ids: [ID!]!, $excludeUrls: Boolean!) {
 		}
 	}
 
-	return threads, nil
+	return threads, failures, nil
 }
 
 // ReplyToThread adds a reply to a review thread using GraphQL mutation
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var promoteThreadCmd = NewOperationalCommand(
+	"promote <thread-id> [flags]",
+	"Convert a review thread into a tracked sub-issue",
+	`Turn review feedback that will not be addressed in the current PR into a
+tracked issue, then close the loop on the thread:
+
+  1. Create an issue titled from the first comment's summary line, with the
+     thread link, file location, diff hunk, and quoted comment in the body
+  2. Link it as a sub-issue of --parent (when given)
+  3. Reply to the thread with the new issue link
+  4. Resolve the thread (unless --no-resolve)
+
+Accepts a thread ID or an alias assigned by 'threads list'. Threads of a
+merged or closed PR are refused (exit code 6) unless --force is given.
+
+Examples:
+  # Track a thread under epic #248
+  gh-helper threads promote PRRT_kwDONC6gMM5SgXT2 --parent 248
+
+  # By alias, with an explicit title and label
+  gh-helper threads promote 3 --pr 306 --parent 248 --title "Refactor retry loop" --label enhancement
+
+  # Keep the thread open
+  gh-helper threads promote 3 --pr 306 --no-resolve`,
+	promoteThread,
+)
+
+func init() {
+	promoteThreadCmd.Args = cobra.ExactArgs(1)
+
+	promoteThreadCmd.Flags().Int("parent", 0, "Parent issue number to link the new issue under")
+	promoteThreadCmd.Flags().String("title", "", "Issue title (default: summary of the first comment)")
+	promoteThreadCmd.Flags().StringSlice("label", []string{}, "Labels for the new issue")
+	promoteThreadCmd.Flags().Int("max-hunk-lines", 30, "Trim the diff hunk in the issue body to the last N lines (0: unlimited)")
+	promoteThreadCmd.Flags().Bool("no-resolve", false, "Reply with the issue link but leave the thread unresolved")
+	addForceFlag(promoteThreadCmd)
+
+	threadsCmd.AddCommand(promoteThreadCmd)
+}
+
+// maxPromotedTitleLength keeps generated issue titles within a readable length
+const maxPromotedTitleLength = 80
+
+// markdownDecorationPattern matches leading Markdown decoration such as "### ", "> ", "- ", or "**"
+var markdownDecorationPattern = regexp.MustCompile(`^(?:[#>*_\-\s]|!\[[^\]]*\]\([^)]*\))+`)
+
+// promotedIssueTitle derives an issue title from the first meaningful line of a comment
+func promotedIssueTitle(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(markdownDecorationPattern.ReplaceAllString(line, ""))
+		line = strings.TrimRight(strings.ReplaceAll(line, "**", ""), "*_ ")
+		if line == "" || strings.HasPrefix(line, "```") {
+			continue
+		}
+		if len([]rune(line)) > maxPromotedTitleLength {
+			line = strings.TrimSpace(string([]rune(line)[:maxPromotedTitleLength-3])) + "..."
+		}
+		return line
+	}
+	return ""
+}
+
+// promotedIssueBody builds the issue body linking back to the review thread
+func promotedIssueBody(thread *ThreadInfo, maxHunkLines int) string {
+	var b strings.Builder
+	first := thread.Comments[0]
+
+	if thread.URL != "" {
+		fmt.Fprintf(&b, "Promoted from review thread: %s\n\n", thread.URL)
+	} else {
+		fmt.Fprintf(&b, "Promoted from review thread `%s`\n\n", thread.ID)
+	}
+
+	if thread.Path != "" {
+		location := thread.Path
+		if thread.Line != nil {
+			location = fmt.Sprintf("%s:%d", thread.Path, *thread.Line)
+		}
+		fmt.Fprintf(&b, "`%s`\n\n", location)
+	}
+
+	if first.DiffHunk != "" {
+		hunk, _ := trimDiffHunk(first.DiffHunk, maxHunkLines)
+		fmt.Fprintf(&b, "```diff\n%s\n```\n\n", strings.TrimSuffix(hunk, "\n"))
+	}
+
+	fmt.Fprintf(&b, "@%s commented:\n\n", first.Author)
+	for _, line := range strings.Split(strings.TrimSpace(first.Body), "\n") {
+		fmt.Fprintf(&b, "> %s\n", line)
+	}
+	return b.String()
+}
+
+func promoteThread(cmd *cobra.Command, args []string) error {
+	parentNumber, err := cmd.Flags().GetInt("parent")
+	if err != nil {
+		return fmt.Errorf("failed to get 'parent' flag: %w", err)
+	}
+	title, err := cmd.Flags().GetString("title")
+	if err != nil {
+		return fmt.Errorf("failed to get 'title' flag: %w", err)
+	}
+	labels, err := cmd.Flags().GetStringSlice("label")
+	if err != nil {
+		return fmt.Errorf("failed to get 'label' flag: %w", err)
+	}
+	maxHunkLines, err := cmd.Flags().GetInt("max-hunk-lines")
+	if err != nil {
+		return fmt.Errorf("failed to get 'max-hunk-lines' flag: %w", err)
+	}
+	noResolve, err := cmd.Flags().GetBool("no-resolve")
+	if err != nil {
+		return fmt.Errorf("failed to get 'no-resolve' flag: %w", err)
+	}
+
+	client := newCommandClient(cmd)
+
+	// Resolve short aliases (#1, #2, ...) to thread IDs
+	threadIDs, err := resolveThreadIDs(cmd, client, args)
+	if err != nil {
+		return err
+	}
+	threadID := threadIDs[0]
+
+	threadsMap, failures, err := client.GetThreadBatch(threadIDs, false)
+	if err != nil {
+		return fmt.Errorf("failed to fetch thread: %w", err)
+	}
+	thread, exists := threadsMap[threadID]
+	if !exists {
+		if message, ok := failures[threadID]; ok {
+			return fmt.Errorf("failed to fetch thread %s: %s", threadID, message)
+		}
+		return fmt.Errorf("thread not found: %s", threadID)
+	}
+	if len(thread.Comments) == 0 {
+		return fmt.Errorf("thread %s has no comments to promote", threadID)
+	}
+
+	if title == "" {
+		title = promotedIssueTitle(thread.Comments[0].Body)
+	}
+	if title == "" {
+		return fmt.Errorf("could not derive an issue title from the thread; use --title")
+	}
+
+	// Nothing is created for a thread on a merged or closed PR unless --force
+	if err := checkFreshTargets(cmd, func() ([]prTarget, error) { return client.getPRTargetsForNodes([]string{threadID}) }); err != nil {
+		return err
+	}
+
+	repositoryID, err := client.GetRepositoryID()
+	if err != nil {
+		return fmt.Errorf("failed to get repository ID: %w", err)
+	}
+
+	var labelIDs []string
+	if len(labels) > 0 {
+		labelMap, err := client.GetLabelIDs(labels)
+		if err != nil {
+			return fmt.Errorf("failed to get label IDs: %w", err)
+		}
+		for _, labelName := range labels {
+			id, ok := labelMap[labelName]
+			if !ok {
+				return fmt.Errorf("label not found: %s", labelName)
+			}
+			labelIDs = append(labelIDs, id)
+		}
+	}
+
+	issueID, issue, err := client.CreateIssue(IssueCreateParams{
+		RepositoryID: repositoryID,
+		Title:        title,
+		Body:         promotedIssueBody(thread, maxHunkLines),
+		LabelIDs:     labelIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	result := map[string]interface{}{
+		"thread": threadID,
+		"issue":  issue,
+	}
+
+	// The issue exists from here on, so report partial progress instead of failing
+	var problems []string
+	if parentNumber > 0 {
+		issue.Parent, err = client.AddSubIssue(issueID, parentNumber)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("failed to link under #%d: %v", parentNumber, err))
+		}
+	}
+
+	replyBody := fmt.Sprintf("Tracked in #%d", issue.Number)
+	if issue.Parent != nil {
+		replyBody = fmt.Sprintf("Tracked in #%d (sub-issue of #%d)", issue.Number, issue.Parent.Number)
+	}
+	if err := client.ReplyToThread(threadID, replyBody); err != nil {
+		problems = append(problems, fmt.Sprintf("failed to reply to thread: %v", err))
+	} else {
+		result["replied"] = true
+	}
+
+	if !noResolve {
+		if err := client.ResolveThread(threadID); err != nil {
+			problems = append(problems, fmt.Sprintf("failed to resolve thread: %v", err))
+		} else {
+			result["resolved"] = true
+		}
+	}
+	if len(problems) > 0 {
+		result["errors"] = problems
+	}
+
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"promote": result})
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPromotedIssueTitle(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"plain", "Consider caching this lookup.\n\nIt runs per request.", "Consider caching this lookup."},
+		{"gemini header", "![medium](https://example.com/medium.svg)\n\n**The retry loop never backs off**\n\nDetails", "The retry loop never backs off"},
+		{"heading and quote", "### Nit\n> quoted", "Nit"},
+		{"fence markers skipped", "```go\nx := 1\n```", "x := 1"},
+		{"truncated", strings.Repeat("word ", 30), strings.TrimSpace(strings.Repeat("word ", 16)[:77]) + "..."},
+		{"empty", "\n\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := promotedIssueTitle(tt.body); got != tt.want {
+				t.Errorf("promotedIssueTitle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromotedIssueBody(t *testing.T) {
+	line := 42
+	thread := &ThreadInfo{
+		ID:   "PRRT_test",
+		URL:  "https://github.com/o/r/pull/1#discussion_r1",
+		Path: "main.go",
+		Line: &line,
+		Comments: []CommentInfo{{
+			Author:   "reviewer",
+			Body:     "First line\nSecond line",
+			DiffHunk: "@@ -1,3 +1,3 @@\n a\n-b\n+c",
+		}},
+	}
+
+	body := promotedIssueBody(thread, 0)
+	for _, want := range []string{
+		"Promoted from review thread: https://github.com/o/r/pull/1#discussion_r1",
+		"`main.go:42`",
+		"```diff\n@@ -1,3 +1,3 @@\n a\n-b\n+c\n```",
+		"@reviewer commented:\n\n> First line\n> Second line\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("promotedIssueBody() missing %q in:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+var searchThreadsCmd = NewOperationalCommand(
+	"search [flags]",
+	"Search review thread comments across the repository",
+	`Search the comments of review threads on PRs updated since --since, to find
+concerns raised before about a topic. GitHub search does not index review
+comments, so PRs are fetched in batches with their threads and matched
+locally.
+
+--query matches case-insensitively as plain text, or as a regular expression
+with --regex. Each matching thread is reported once with the snippet of its
+first matching comment.
+
+Examples:
+  # Unresolved TODOs raised in the last 90 days
+  gh-helper threads search --query "TODO" --state unresolved --since 90d
+
+  # Earlier discussion of a topic, as thread IDs for 'threads show'
+  gh-helper threads search --query 'retr(y|ies)' --regex --jq '[.threadSearch.matches[].threadId]'`,
+	searchThreads,
+)
+
+func init() {
+	searchThreadsCmd.Flags().String("query", "", "Text to search for (required)")
+	searchThreadsCmd.Flags().Bool("regex", false, "Treat --query as a regular expression")
+	searchThreadsCmd.Flags().String("state", "all", "Thread state: unresolved, resolved, or all")
+	searchThreadsCmd.Flags().String("since", "90d", "Search PRs updated since (days like 90d, YYYY-MM-DD, RFC 3339, or duration)")
+	searchThreadsCmd.Flags().Int("limit", 200, "Maximum PRs to search")
+	_ = searchThreadsCmd.MarkFlagRequired("query")
+
+	threadsCmd.AddCommand(searchThreadsCmd)
+}
+
+// ThreadSearchMatch is a review thread with a comment matching the query
+type ThreadSearchMatch struct {
+	ThreadID string `json:"threadId"`
+	PR       int    `json:"pr"`
+	PRTitle  string `json:"prTitle"`
+	Path     string `json:"path"`
+	Line     int    `json:"line,omitempty"`
+	Resolved bool   `json:"resolved"`
+	Outdated bool   `json:"outdated,omitempty"`
+	Author   string `json:"author"` // author of the matching comment
+	URL      string `json:"url"`    // URL of the matching comment
+	Snippet  string `json:"snippet"`
+	Matches  int    `json:"matches"` // matching comments in the thread
+}
+
+// searchPR is a PR with its review threads as fetched for 'threads search'
+type searchPR struct {
+	Number        int    `json:"number"`
+	Title         string `json:"title"`
+	ReviewThreads struct {
+		Nodes []struct {
+			ID         string `json:"id"`
+			Path       string `json:"path"`
+			Line       int    `json:"line"`
+			IsResolved bool   `json:"isResolved"`
+			IsOutdated bool   `json:"isOutdated"`
+			Comments   struct {
+				Nodes []struct {
+					Author *actorRef `json:"author"`
+					Body   string    `json:"body"`
+					URL    string    `json:"url"`
+				} `json:"nodes"`
+			} `json:"comments"`
+		} `json:"nodes"`
+	} `json:"reviewThreads"`
+}
+
+// threadSearchSnippetRadius is the context kept on each side of a match
+const threadSearchSnippetRadius = 60
+
+// compileThreadQuery returns the matcher for --query: case-insensitive text, or a regexp
+func compileThreadQuery(query string, isRegex bool) (*regexp.Regexp, error) {
+	if !isRegex {
+		query = regexp.QuoteMeta(query)
+	}
+	re, err := regexp.Compile("(?i)" + query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --query regular expression: %w", err)
+	}
+	return re, nil
+}
+
+// matchSnippet returns the text around the first match on one line, with
+// ellipses where it was cut, or "" when the body does not match
+func matchSnippet(body string, re *regexp.Regexp) string {
+	loc := re.FindStringIndex(body)
+	if loc == nil {
+		return ""
+	}
+	start, end := loc[0], loc[1]
+	from, to := max(0, start-threadSearchSnippetRadius), min(len(body), end+threadSearchSnippetRadius)
+	// Avoid cutting a multi-byte character
+	for from > 0 && !utf8.RuneStart(body[from]) {
+		from--
+	}
+	for to < len(body) && !utf8.RuneStart(body[to]) {
+		to++
+	}
+	snippet := strings.Join(strings.Fields(body[from:to]), " ")
+	if from > 0 {
+		snippet = "…" + snippet
+	}
+	if to < len(body) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// searchPRThreads matches the review threads of the PRs; state is unresolved, resolved, or all
+func searchPRThreads(prs []searchPR, re *regexp.Regexp, state string) []ThreadSearchMatch {
+	matches := []ThreadSearchMatch{}
+	for _, pr := range prs {
+		for _, thread := range pr.ReviewThreads.Nodes {
+			if (state == "unresolved" && thread.IsResolved) || (state == "resolved" && !thread.IsResolved) {
+				continue
+			}
+			var match *ThreadSearchMatch
+			for _, comment := range thread.Comments.Nodes {
+				snippet := matchSnippet(comment.Body, re)
+				if snippet == "" {
+					continue
+				}
+				if match == nil {
+					match = &ThreadSearchMatch{
+						ThreadID: thread.ID,
+						PR:       pr.Number,
+						PRTitle:  pr.Title,
+						Path:     thread.Path,
+						Line:     thread.Line,
+						Resolved: thread.IsResolved,
+						Outdated: thread.IsOutdated,
+						Author:   comment.Author.login(),
+						URL:      comment.URL,
+						Snippet:  snippet,
+					}
+				}
+				match.Matches++
+			}
+			if match != nil {
+				matches = append(matches, *match)
+			}
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].PR != matches[j].PR {
+			return matches[i].PR > matches[j].PR
+		}
+		if matches[i].Path != matches[j].Path {
+			return matches[i].Path < matches[j].Path
+		}
+		return matches[i].Line < matches[j].Line
+	})
+	return matches
+}
+
+// fetchSearchPRs fetches up to limit PRs updated since the given time with
+// their review threads, newest first, a page of PRs per query
+func (c *GitHubClient) fetchSearchPRs(since time.Time, limit int) ([]searchPR, error) {
+	searchQuery := fmt.Sprintf("repo:%s/%s is:pr updated:>=%s sort:updated-desc", c.Owner, c.Repo, since.UTC().Format("2006-01-02"))
+	query := `
+	query($searchQuery: String!, $first: Int!, $after: String) {
+		search(query: $searchQuery, type: ISSUE, first: $first, after: $after) {
+			nodes {
+				... on PullRequest {
+					number title
+					reviewThreads(first: 100) {
+						nodes {
+							id path line isResolved isOutdated
+							comments(first: 30) { nodes { author { login } body url } }
+						}
+					}
+				}
+			}
+			pageInfo { hasNextPage endCursor }
+		}
+	}`
+
+	var prs []searchPR
+	var after *string
+	for len(prs) < limit {
+		responseData, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+			"searchQuery": searchQuery,
+			"first":       min(limit-len(prs), 20),
+			"after":       after,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Data struct {
+				Search struct {
+					Nodes    []searchPR `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"search"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(responseData, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse search response: %w", err)
+		}
+
+		prs = append(prs, response.Data.Search.Nodes...)
+		if !response.Data.Search.PageInfo.HasNextPage {
+			break
+		}
+		cursor := response.Data.Search.PageInfo.EndCursor
+		after = &cursor
+	}
+	return prs, nil
+}
+
+func searchThreads(cmd *cobra.Command, args []string) error {
+	query, err := cmd.Flags().GetString("query")
+	if err != nil {
+		return fmt.Errorf("failed to get 'query' flag: %w", err)
+	}
+	isRegex, err := cmd.Flags().GetBool("regex")
+	if err != nil {
+		return fmt.Errorf("failed to get 'regex' flag: %w", err)
+	}
+	state, err := cmd.Flags().GetString("state")
+	if err != nil {
+		return fmt.Errorf("failed to get 'state' flag: %w", err)
+	}
+	sinceValue, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return fmt.Errorf("failed to get 'since' flag: %w", err)
+	}
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return fmt.Errorf("failed to get 'limit' flag: %w", err)
+	}
+
+	if state != "unresolved" && state != "resolved" && state != "all" {
+		return fmt.Errorf("invalid --state %q (expected unresolved, resolved, or all)", state)
+	}
+	if strings.TrimSpace(query) == "" {
+		return fmt.Errorf("--query must not be empty")
+	}
+	re, err := compileThreadQuery(query, isRegex)
+	if err != nil {
+		return err
+	}
+	since, err := parseSince(sinceValue, time.Now())
+	if err != nil {
+		return err
+	}
+
+	client := newCommandClient(cmd)
+	prs, err := client.fetchSearchPRs(since, limit)
+	if err != nil {
+		return fmt.Errorf("failed to fetch review threads: %w", err)
+	}
+
+	matches := searchPRThreads(prs, re, state)
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{
+		"threadSearch": map[string]interface{}{
+			"query":       query,
+			"state":       state,
+			"since":       since.UTC().Format(time.RFC3339),
+			"prsSearched": len(prs),
+			"matches":     matches,
+		},
+	})
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMatchSnippet(t *testing.T) {
+	re, err := compileThreadQuery("todo", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := matchSnippet("Please add a\nTODO here", re); got != "Please add a TODO here" {
+		t.Errorf("short body snippet = %q", got)
+	}
+	long := strings.Repeat("a", 100) + " TODO: handle retries " + strings.Repeat("b", 100)
+	got := matchSnippet(long, re)
+	if !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") || !strings.Contains(got, "TODO: handle retries") {
+		t.Errorf("long body snippet = %q", got)
+	}
+	if matchSnippet("nothing to see", re) != "" {
+		t.Error("non-matching body returned a snippet")
+	}
+	if _, err := compileThreadQuery("(", true); err == nil {
+		t.Error("expected an error for an invalid regexp")
+	}
+}
+
+func TestSearchPRThreads(t *testing.T) {
+	var prs []searchPR
+	data := `[
+	  {"number": 10, "title": "Add cache", "reviewThreads": {"nodes": [
+	    {"id": "T1", "path": "cache.go", "line": 5, "isResolved": false, "comments": {"nodes": [
+	      {"author": {"login": "alice"}, "body": "Retry on timeout?", "url": "u1"},
+	      {"author": {"login": "bob"}, "body": "Will add retries later", "url": "u2"}]}},
+	    {"id": "T2", "path": "cache.go", "line": 9, "isResolved": true, "comments": {"nodes": [
+	      {"author": {"login": "alice"}, "body": "retry here too", "url": "u3"}]}},
+	    {"id": "T3", "path": "main.go", "line": 1, "isResolved": false, "comments": {"nodes": [
+	      {"author": null, "body": "unrelated", "url": "u4"}]}}]}},
+	  {"number": 12, "title": "Fix client", "reviewThreads": {"nodes": [
+	    {"id": "T4", "path": "client.go", "line": 3, "isResolved": false, "comments": {"nodes": [
+	      {"author": {"login": "carol"}, "body": "no retry budget", "url": "u5"}]}}]}}
+	]`
+	if err := json.Unmarshal([]byte(data), &prs); err != nil {
+		t.Fatal(err)
+	}
+	re, _ := compileThreadQuery(`retr(y|ies)`, true)
+
+	matches := searchPRThreads(prs, re, "unresolved")
+	if len(matches) != 2 {
+		t.Fatalf("got %d matches, want 2: %+v", len(matches), matches)
+	}
+	if matches[0].ThreadID != "T4" || matches[1].ThreadID != "T1" {
+		t.Errorf("order = %s, %s; want T4 (newer PR) then T1", matches[0].ThreadID, matches[1].ThreadID)
+	}
+	if m := matches[1]; m.Matches != 2 || m.Author != "alice" || m.URL != "u1" || m.PRTitle != "Add cache" {
+		t.Errorf("T1 match = %+v", m)
+	}
+
+	if all := searchPRThreads(prs, re, "all"); len(all) != 3 {
+		t.Errorf("all states: got %d matches, want 3", len(all))
+	}
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+)
+
+var snoozeThreadCmd = NewOperationalCommand(
+	"snooze <thread-id...> [flags]",
+	"Defer review threads until a date without resolving them",
+	`Snooze review threads that are deliberately deferred. Until the snooze
+expires, the threads are left out of 'threads list', the threads of
+'reviews fetch', and 'reviews sla' violations (use --include-snoozed there to
+show them anyway). The threads stay unresolved on GitHub.
+
+Snoozes are recorded locally in .cache/threads/snoozes.json and are not
+shared with other clones. Accepts thread IDs or aliases assigned by
+'threads list'.
+
+--until accepts a date (YYYY-MM-DD, UTC midnight), an RFC 3339 time, or a
+duration from now (48h, 7d).
+
+Examples:
+  # Defer until after the release
+  gh-helper threads snooze PRRT_kwDONC6gMM5SgXT2 --until 2025-02-01 --reason "after v1.0"
+
+  # By alias, for a week
+  gh-helper threads snooze 3 4 --pr 306 --until 7d
+
+  # Wake a thread up early
+  gh-helper threads snooze 3 --pr 306 --clear`,
+	snoozeThreads,
+)
+
+var snoozedThreadsCmd = NewOperationalCommand(
+	"snoozed [flags]",
+	"List snoozed review threads",
+	`List the review threads snoozed with 'threads snooze' whose snooze has not
+expired yet, soonest expiry first. Expired snoozes are dropped from the store.
+
+Examples:
+  gh-helper threads snoozed
+  gh-helper threads snoozed --pr 306`,
+	listSnoozedThreads,
+)
+
+func init() {
+	snoozeThreadCmd.Args = cobra.MinimumNArgs(1)
+	snoozeThreadCmd.Flags().String("until", "", "When the snooze expires (YYYY-MM-DD, RFC 3339, or duration like 7d)")
+	snoozeThreadCmd.Flags().String("reason", "", "Why the thread is deferred")
+	snoozeThreadCmd.Flags().Bool("clear", false, "Remove the snooze instead of setting one")
+	snoozeThreadCmd.MarkFlagsMutuallyExclusive("until", "clear")
+
+	threadsCmd.AddCommand(snoozeThreadCmd, snoozedThreadsCmd)
+}
+
+// ThreadSnooze is a locally recorded deferral of a review thread
+type ThreadSnooze struct {
+	ThreadID  string    `json:"threadId"`
+	PR        int       `json:"pr,omitempty"`
+	Until     time.Time `json:"until"`
+	Reason    string    `json:"reason,omitempty"`
+	SnoozedAt time.Time `json:"snoozedAt"`
+}
+
+// ThreadSnoozes is the snooze store, keyed by thread ID
+type ThreadSnoozes struct {
+	Snoozes map[string]ThreadSnooze `json:"snoozes"`
+}
+
+func threadSnoozeFile() string {
+	return filepath.Join(GetCacheDir(), "threads", "snoozes.json")
+}
+
+// loadThreadSnoozes loads the snooze store, returning an empty store if none exists
+func loadThreadSnoozes() (*ThreadSnoozes, error) {
+	snoozes := &ThreadSnoozes{Snoozes: map[string]ThreadSnooze{}}
+	data, err := os.ReadFile(threadSnoozeFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return snoozes, nil
+		}
+		return nil, fmt.Errorf("failed to read thread snoozes: %w", err)
+	}
+	if err := Unmarshal(data, snoozes); err != nil {
+		return nil, fmt.Errorf("failed to parse thread snoozes: %w", err)
+	}
+	if snoozes.Snoozes == nil {
+		snoozes.Snoozes = map[string]ThreadSnooze{}
+	}
+	return snoozes, nil
+}
+
+// saveThreadSnoozes writes the snooze store to cache
+func saveThreadSnoozes(snoozes *ThreadSnoozes) error {
+	file := threadSnoozeFile()
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return fmt.Errorf("failed to create snooze directory: %w", err)
+	}
+	data, err := yaml.MarshalWithOptions(snoozes, yaml.UseJSONMarshaler())
+	if err != nil {
+		return fmt.Errorf("failed to marshal thread snoozes: %w", err)
+	}
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("failed to write snooze file: %w", err)
+	}
+	return nil
+}
+
+// IsSnoozed reports whether the thread has a snooze that has not expired
+func (s *ThreadSnoozes) IsSnoozed(threadID string, now time.Time) bool {
+	snooze, ok := s.Snoozes[threadID]
+	return ok && now.Before(snooze.Until)
+}
+
+// Prune drops expired snoozes and reports whether any were dropped
+func (s *ThreadSnoozes) Prune(now time.Time) bool {
+	changed := false
+	for id, snooze := range s.Snoozes {
+		if !now.Before(snooze.Until) {
+			delete(s.Snoozes, id)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// activeThreadSnoozes loads the snooze store for filtering listings. A store
+// that cannot be read filters nothing, with a warning.
+func activeThreadSnoozes(cmd *cobra.Command) *ThreadSnoozes {
+	snoozes, err := loadThreadSnoozes()
+	if err != nil {
+		WarningMsg("Ignoring thread snoozes: %v", err).Print(cmd)
+		return &ThreadSnoozes{Snoozes: map[string]ThreadSnooze{}}
+	}
+	return snoozes
+}
+
+// addIncludeSnoozedFlag registers --include-snoozed on a listing command
+func addIncludeSnoozedFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("include-snoozed", false, "Include threads snoozed with 'threads snooze'")
+}
+
+// snoozeFilter returns a predicate matching threads to leave out of a
+// listing, or nil when --include-snoozed is set
+func snoozeFilter(cmd *cobra.Command) (func(threadID string) bool, error) {
+	includeSnoozed, err := cmd.Flags().GetBool("include-snoozed")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get 'include-snoozed' flag: %w", err)
+	}
+	if includeSnoozed {
+		return nil, nil
+	}
+	snoozes := activeThreadSnoozes(cmd)
+	now := time.Now()
+	return func(threadID string) bool {
+		return snoozes.IsSnoozed(threadID, now)
+	}, nil
+}
+
+// dropSnoozedThreads removes the threads matched by isSnoozed (a nil filter keeps all)
+func dropSnoozedThreads(threads []ThreadData, isSnoozed func(threadID string) bool) []ThreadData {
+	if isSnoozed == nil {
+		return threads
+	}
+	kept := threads[:0]
+	for _, thread := range threads {
+		if !isSnoozed(thread.ID) {
+			kept = append(kept, thread)
+		}
+	}
+	return kept
+}
+
+// parseSnoozeUntil parses --until as a date, an RFC 3339 time, or a duration from now
+func parseSnoozeUntil(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	if m := sinceDaysPattern.FindStringSubmatch(value); m != nil {
+		days, _ := strconv.Atoi(m[1])
+		return now.AddDate(0, 0, days), nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return now.Add(d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --until %q (expected YYYY-MM-DD, RFC 3339 time, or duration like 7d)", value)
+}
+
+func snoozeThreads(cmd *cobra.Command, args []string) error {
+	until, err := cmd.Flags().GetString("until")
+	if err != nil {
+		return fmt.Errorf("failed to get 'until' flag: %w", err)
+	}
+	reason, err := cmd.Flags().GetString("reason")
+	if err != nil {
+		return fmt.Errorf("failed to get 'reason' flag: %w", err)
+	}
+	clearSnooze, err := cmd.Flags().GetBool("clear")
+	if err != nil {
+		return fmt.Errorf("failed to get 'clear' flag: %w", err)
+	}
+	if !clearSnooze && until == "" {
+		return fmt.Errorf("--until is required (or --clear to remove a snooze)")
+	}
+
+	now := time.Now()
+	var untilTime time.Time
+	if !clearSnooze {
+		if untilTime, err = parseSnoozeUntil(until, now); err != nil {
+			return err
+		}
+		if !untilTime.After(now) {
+			return fmt.Errorf("--until %s is not in the future", until)
+		}
+	}
+
+	client := newCommandClient(cmd)
+	threadIDs, err := resolveThreadIDs(cmd, client, args)
+	if err != nil {
+		return err
+	}
+
+	snoozes, err := loadThreadSnoozes()
+	if err != nil {
+		return err
+	}
+	snoozes.Prune(now)
+
+	if clearSnooze {
+		cleared := []string{}
+		for _, id := range threadIDs {
+			if _, ok := snoozes.Snoozes[id]; ok {
+				delete(snoozes.Snoozes, id)
+				cleared = append(cleared, id)
+			}
+		}
+		if err := saveThreadSnoozes(snoozes); err != nil {
+			return err
+		}
+		return EncodeOutputWithCmd(cmd, map[string]interface{}{"cleared": cleared})
+	}
+
+	// Looking up the PRs validates the thread IDs and labels the snoozed list
+	targets, err := client.getPRTargetsForNodes(threadIDs)
+	if err != nil {
+		return fmt.Errorf("failed to look up threads: %w", err)
+	}
+	prs := map[string]int{}
+	for _, target := range targets {
+		prs[target.Ref] = target.Number
+	}
+
+	snoozed := []ThreadSnooze{}
+	for _, id := range threadIDs {
+		pr, ok := prs[id]
+		if !ok {
+			return fmt.Errorf("review thread %s not found", id)
+		}
+		snooze := ThreadSnooze{ThreadID: id, PR: pr, Until: untilTime, Reason: reason, SnoozedAt: now}
+		snoozes.Snoozes[id] = snooze
+		snoozed = append(snoozed, snooze)
+	}
+	if err := saveThreadSnoozes(snoozes); err != nil {
+		return err
+	}
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"snoozed": snoozed})
+}
+
+func listSnoozedThreads(cmd *cobra.Command, args []string) error {
+	prArg, err := cmd.Flags().GetString("pr")
+	if err != nil {
+		return fmt.Errorf("failed to get 'pr' flag: %w", err)
+	}
+	pr := 0
+	if prArg != "" {
+		if pr, err = strconv.Atoi(prArg); err != nil {
+			return fmt.Errorf("invalid --pr %q: %w", prArg, err)
+		}
+	}
+
+	snoozes, err := loadThreadSnoozes()
+	if err != nil {
+		return err
+	}
+	if snoozes.Prune(time.Now()) {
+		if err := saveThreadSnoozes(snoozes); err != nil {
+			WarningMsg("Failed to drop expired snoozes: %v", err).Print(cmd)
+		}
+	}
+
+	list := []ThreadSnooze{}
+	for _, snooze := range snoozes.Snoozes {
+		if pr == 0 || snooze.PR == pr {
+			list = append(list, snooze)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].Until.Equal(list[j].Until) {
+			return list[i].Until.Before(list[j].Until)
+		}
+		return list[i].ThreadID < list[j].ThreadID
+	})
+
+	return EncodeOutputWithCmd(cmd, map[string]interface{}{"snoozedThreads": list})
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSnoozeUntil(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2025-02-01", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"2025-02-01T09:00:00Z", time.Date(2025, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{"7d", time.Date(2025, 1, 17, 12, 0, 0, 0, time.UTC)},
+		{"48h", time.Date(2025, 1, 12, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseSnoozeUntil(tt.value, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseSnoozeUntil(%q) = %v, %v, want %v", tt.value, got, err, tt.want)
+		}
+	}
+	if _, err := parseSnoozeUntil("next week", now); err == nil {
+		t.Error("parseSnoozeUntil(\"next week\") succeeded, want error")
+	}
+}
+
+func TestThreadSnoozesStore(t *testing.T) {
+	t.Chdir(t.TempDir())
+	now := time.Now()
+
+	snoozes, err := loadThreadSnoozes()
+	if err != nil || len(snoozes.Snoozes) != 0 {
+		t.Fatalf("loadThreadSnoozes() on empty store = %+v, %v", snoozes, err)
+	}
+	snoozes.Snoozes["PRRT_active"] = ThreadSnooze{ThreadID: "PRRT_active", PR: 306, Until: now.Add(time.Hour)}
+	snoozes.Snoozes["PRRT_expired"] = ThreadSnooze{ThreadID: "PRRT_expired", PR: 306, Until: now.Add(-time.Hour)}
+	if err := saveThreadSnoozes(snoozes); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadThreadSnoozes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.IsSnoozed("PRRT_active", now) {
+		t.Error("IsSnoozed(active) = false, want true")
+	}
+	if loaded.IsSnoozed("PRRT_expired", now) || loaded.IsSnoozed("PRRT_unknown", now) {
+		t.Error("IsSnoozed() = true for an expired or unknown thread")
+	}
+	if !loaded.Prune(now) || len(loaded.Snoozes) != 1 {
+		t.Errorf("Prune() left %+v, want only the active snooze", loaded.Snoozes)
+	}
+	if loaded.Prune(now) {
+		t.Error("second Prune() reported changes")
+	}
+}
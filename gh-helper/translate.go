@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Translator translates comment bodies using an external command or an HTTP endpoint.
+//
+// Command mode runs the command via 'sh -c' with the text on stdin and the
+// translation on stdout. "{lang}" in the command is replaced with the target
+// language, which is also exported as GH_HELPER_TRANSLATE_TARGET.
+//
+// Endpoint mode POSTs a LibreTranslate-compatible request
+// ({"q", "source": "auto", "target", "format": "text", "api_key"}) and reads
+// "translatedText" from the response.
+type Translator struct {
+	Command    string
+	Endpoint   string
+	APIKey     string
+	Target     string
+	httpClient *http.Client
+}
+
+// translateLangPattern accepts language codes like "ja", "en", "pt-BR", "zh-Hant"
+var translateLangPattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z]{2,4})?$`)
+
+// addTranslateFlags registers translation flags on a command
+func addTranslateFlags(cmd *cobra.Command) {
+	cmd.Flags().String("translate", "", "Translate comment bodies to this language (e.g., ja, en)")
+	cmd.Flags().String("translate-cmd", "", "Translation command reading stdin ({lang} is replaced; default: $GH_HELPER_TRANSLATE_CMD)")
+	cmd.Flags().String("translate-endpoint", "", "LibreTranslate-compatible endpoint URL (default: $GH_HELPER_TRANSLATE_ENDPOINT)")
+}
+
+// newTranslatorFromCmd returns a Translator configured from flags and environment,
+// or nil if --translate is not set
+func newTranslatorFromCmd(cmd *cobra.Command) (*Translator, error) {
+	target, err := cmd.Flags().GetString("translate")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read 'translate' flag: %w", err)
+	}
+	if target == "" {
+		return nil, nil
+	}
+	if !translateLangPattern.MatchString(target) {
+		return nil, fmt.Errorf("invalid --translate language %q (expected a code like ja or en)", target)
+	}
+
+	command, err := cmd.Flags().GetString("translate-cmd")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read 'translate-cmd' flag: %w", err)
+	}
+	endpoint, err := cmd.Flags().GetString("translate-endpoint")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read 'translate-endpoint' flag: %w", err)
+	}
+	if command == "" && endpoint == "" {
+		command = os.Getenv("GH_HELPER_TRANSLATE_CMD")
+		endpoint = os.Getenv("GH_HELPER_TRANSLATE_ENDPOINT")
+	}
+	if command != "" && endpoint != "" {
+		return nil, fmt.Errorf("configure either a translation command or an endpoint, not both")
+	}
+	if command == "" && endpoint == "" {
+		return nil, fmt.Errorf("--translate requires a translator: set --translate-cmd / GH_HELPER_TRANSLATE_CMD or --translate-endpoint / GH_HELPER_TRANSLATE_ENDPOINT")
+	}
+
+	return &Translator{
+		Command:    command,
+		Endpoint:   endpoint,
+		APIKey:     os.Getenv("GH_HELPER_TRANSLATE_API_KEY"),
+		Target:     target,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Translate returns text translated to the target language
+func (t *Translator) Translate(text string) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return text, nil
+	}
+	if t.Command != "" {
+		return t.translateWithCommand(text)
+	}
+	return t.translateWithEndpoint(text)
+}
+
+func (t *Translator) translateWithCommand(text string) (string, error) {
+	command := strings.ReplaceAll(t.Command, "{lang}", t.Target)
+	translateCmd := exec.Command("sh", "-c", command)
+	translateCmd.Stdin = strings.NewReader(text)
+	translateCmd.Env = append(os.Environ(), "GH_HELPER_TRANSLATE_TARGET="+t.Target)
+
+	var stdout, stderr bytes.Buffer
+	translateCmd.Stdout = &stdout
+	translateCmd.Stderr = &stderr
+	if err := translateCmd.Run(); err != nil {
+		return "", fmt.Errorf("translation command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimRight(stdout.String(), "\n"), nil
+}
+
+func (t *Translator) translateWithEndpoint(text string) (string, error) {
+	payload := map[string]string{
+		"q":      text,
+		"source": "auto",
+		"target": t.Target,
+		"format": "text",
+	}
+	if t.APIKey != "" {
+		payload["api_key"] = t.APIKey
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal translation request: %w", err)
+	}
+
+	resp, err := t.httpClient.Post(t.Endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("translation request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return "", fmt.Errorf("failed to read translation response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("translation endpoint returned status %d: %s", resp.StatusCode, buf.String())
+	}
+
+	var response struct {
+		TranslatedText string `json:"translatedText"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &response); err != nil {
+		return "", fmt.Errorf("failed to parse translation response: %w", err)
+	}
+	return response.TranslatedText, nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTranslatorCommand(t *testing.T) {
+	translator := &Translator{Command: `tr a-z A-Z; printf ' [{lang}/%s]' "$GH_HELPER_TRANSLATE_TARGET"`, Target: "ja"}
+
+	got, err := translator.Translate("looks good")
+	if err != nil {
+		t.Fatalf("Translate() error = %v", err)
+	}
+	if want := "LOOKS GOOD [ja/ja]"; got != want {
+		t.Errorf("Translate() = %q, want %q", got, want)
+	}
+
+	if got, err := translator.Translate("  "); err != nil || got != "  " {
+		t.Errorf("Translate(blank) = (%q, %v), want unchanged", got, err)
+	}
+}
+
+func TestTranslatorEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		if req["target"] != "en" || req["source"] != "auto" {
+			t.Errorf("unexpected request: %v", req)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"translatedText": "translated: " + req["q"]})
+	}))
+	defer server.Close()
+
+	translator := &Translator{Endpoint: server.URL, Target: "en", httpClient: server.Client()}
+	got, err := translator.Translate("よさそう")
+	if err != nil {
+		t.Fatalf("Translate() error = %v", err)
+	}
+	if want := "translated: よさそう"; got != want {
+		t.Errorf("Translate() = %q, want %q", got, want)
+	}
+}
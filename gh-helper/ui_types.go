@@ -2,7 +2,10 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"time"
+
+	"github.com/spf13/cobra"
 )
 
 // Typed message system for consistent user interface across dev-tools.
@@ -58,16 +61,39 @@ func (m *Message) String() string {
 	return fmt.Sprintf("%s %s", style.Prefix, formatted)
 }
 
-// Print outputs the message to stdout
-func (m *Message) Print() {
-	fmt.Println(m.String())
+// messageWriter returns where informational messages of cmd go: stdout, or
+// nowhere with --quiet
+func messageWriter(cmd *cobra.Command) io.Writer {
+	if w := commandContext(cmd).Messages; w != nil {
+		return w
+	}
+	return cmd.OutOrStdout()
+}
+
+// stderrMessageWriter returns where informational messages of cmd go when its
+// stdout carries a data stream: stderr, or nowhere with --quiet
+func stderrMessageWriter(cmd *cobra.Command) io.Writer {
+	if commandContext(cmd).Quiet {
+		return io.Discard
+	}
+	return cmd.ErrOrStderr()
+}
+
+// Print outputs the message to the command's message writer
+func (m *Message) Print(cmd *cobra.Command) {
+	m.Fprint(messageWriter(cmd))
+}
+
+// Fprint writes the message to w, e.g. stderr when stdout carries the output
+func (m *Message) Fprint(w io.Writer) {
+	fmt.Fprintln(w, m.String())
 }
 
 // Printf outputs the message with additional formatting
-func (m *Message) Printf(format string, args ...interface{}) {
+func (m *Message) Printf(cmd *cobra.Command, format string, args ...interface{}) {
 	content := fmt.Sprintf(format, args...)
 	style := messageStyles[m.Type]
-	fmt.Printf("%s %s", style.Prefix, content)
+	fmt.Fprintf(messageWriter(cmd), "%s %s", style.Prefix, content)
 }
 
 // Common message constructors
@@ -120,8 +146,8 @@ func (rs *ReviewStatus) String() string {
 }
 
 // Print outputs the review status
-func (rs *ReviewStatus) Print() {
-	fmt.Println(rs.String())
+func (rs *ReviewStatus) Print(cmd *cobra.Command) {
+	fmt.Fprintln(messageWriter(cmd), rs.String())
 }
 
 // ReviewSummary represents a summary of found reviews
@@ -140,8 +166,8 @@ func (rs *ReviewSummary) String() string {
 }
 
 // Print outputs the review summary
-func (rs *ReviewSummary) Print() {
-	fmt.Println(rs.String())
+func (rs *ReviewSummary) Print(cmd *cobra.Command) {
+	fmt.Fprintln(messageWriter(cmd), rs.String())
 }
 
 // TimeoutInfo represents timeout-related information
@@ -221,8 +247,8 @@ func (gm *GuidanceMessage) String() string {
 }
 
 // Print outputs the guidance message
-func (gm *GuidanceMessage) Print() {
-	fmt.Println(gm.String())
+func (gm *GuidanceMessage) Print(cmd *cobra.Command) {
+	fmt.Fprintln(messageWriter(cmd), gm.String())
 }
 
 // Common guidance messages
@@ -269,14 +295,15 @@ func (mg *MessageGroup) Add(msg *Message) {
 }
 
 // Print outputs all messages in the group
-func (mg *MessageGroup) Print() {
+func (mg *MessageGroup) Print(cmd *cobra.Command) {
+	w := messageWriter(cmd)
 	if mg.Title != "" {
-		fmt.Printf("\n=== %s ===\n", mg.Title)
+		fmt.Fprintf(w, "\n=== %s ===\n", mg.Title)
 	}
 	for _, msg := range mg.Messages {
-		msg.Print()
+		msg.Fprint(w)
 	}
 	if mg.Title != "" {
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 }
\ No newline at end of file
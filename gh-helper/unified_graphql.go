@@ -237,6 +237,7 @@ query UniversalPRQuery(
       mergeable @include(if: $includeMetadata)
       mergeStateStatus @include(if: $includeMetadata)
       createdAt @include(if: $includeMetadata)
+      headRefOid @include(if: $includeMetadata)
       
       # Last push date
       timelineItems(last: 1, itemTypes: [HEAD_REF_FORCE_PUSHED_EVENT, PULL_REQUEST_COMMIT]) @include(if: $includeMetadata) {
@@ -311,6 +312,7 @@ type UniversalPRResponse struct {
 				Mergeable        *string `json:"mergeable,omitempty"`
 				MergeStateStatus *string `json:"mergeStateStatus,omitempty"`
 				CreatedAt        *string `json:"createdAt,omitempty"`
+				HeadRefOid       *string `json:"headRefOid,omitempty"`
 				
 				TimelineItems *struct {
 					Nodes []interface{} `json:"nodes,omitempty"`
@@ -437,6 +439,31 @@ func (r *UniversalPRResponse) GetCreatedAt() string {
 	return ""
 }
 
+// GetHeadRefOid returns the PR head commit SHA if included
+func (r *UniversalPRResponse) GetHeadRefOid() string {
+	if r.Data.Repository.PullRequest.HeadRefOid != nil {
+		return *r.Data.Repository.PullRequest.HeadRefOid
+	}
+	return ""
+}
+
+// GetLastPushType returns the __typename of the latest push timeline item
+// (HeadRefForcePushedEvent or PullRequestCommit) if included
+func (r *UniversalPRResponse) GetLastPushType() string {
+	if r.Data.Repository.PullRequest.TimelineItems == nil {
+		return ""
+	}
+	nodes := r.Data.Repository.PullRequest.TimelineItems.Nodes
+	if len(nodes) > 0 {
+		if nodeMap, ok := nodes[0].(map[string]interface{}); ok {
+			if typename, ok := nodeMap["__typename"].(string); ok {
+				return typename
+			}
+		}
+	}
+	return ""
+}
+
 // GetLastPushAt returns the last push time if included
 func (r *UniversalPRResponse) GetLastPushAt() string {
 	if r.Data.Repository.PullRequest.TimelineItems == nil {
@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/apstndb/gh-dev-tools/pkg/reviewwait"
 )
 
 // UnifiedReviewData represents all review-related data fetched in a single GraphQL query
@@ -16,6 +18,9 @@ type UnifiedReviewData struct {
 	FetchedAt         time.Time        `json:"fetchedAt"`
 	ReviewPageInfo    PageInfo         `json:"reviewPageInfo"`
 	ThreadPageInfo    PageInfo         `json:"threadPageInfo"`
+
+	// Incremental is set when reviews and threads were filtered by --since-last-fetch
+	Incremental *IncrementalFetchInfo `json:"incremental,omitempty"`
 }
 
 // PageInfo contains pagination metadata following GitHub's GraphQL Relay spec
@@ -77,6 +82,10 @@ type ThreadComment struct {
 	Author    string `json:"author"`
 	Body      string `json:"body"`
 	CreatedAt string `json:"createdAt"`
+	DiffHunk  string `json:"diffHunk,omitempty"`
+
+	// DiffHunkOmittedLines is the number of leading hunk lines elided by --max-hunk-lines
+	DiffHunkOmittedLines int `json:"diffHunkOmittedLines,omitempty"`
 }
 
 // UnifiedReviewOptions controls what data to fetch
@@ -90,6 +99,8 @@ type UnifiedReviewOptions struct {
 	ThreadAfterCursor    string // Pagination cursor for threads
 	UnresolvedOnly       bool   // Filter to only unresolved threads
 	ExcludeURLs          bool   // Exclude URLs from GraphQL query
+	IncludeDiffHunks     bool   // Include diffHunk fields in thread comments
+	MaxHunkLines         int    // Trim diff hunks to the last N lines (0: unlimited)
 }
 
 // DefaultUnifiedReviewOptions returns sensible defaults
@@ -138,7 +149,7 @@ query($owner: String!, $repo: String!, $prNumber: Int!,
       $useReviewsBefore: Boolean!, $reviewBeforeCursor: String!,
       $useDefaultThreads: Boolean!,
       $useThreadsAfter: Boolean!, $threadAfterCursor: String!,
-      $excludeUrls: Boolean!) {
+      $excludeUrls: Boolean!, $includeDiffHunks: Boolean!) {
   viewer {
     login
   }
@@ -253,6 +264,7 @@ query($owner: String!, $repo: String!, $prNumber: Int!,
               author { login }
               body
               createdAt
+              diffHunk @include(if: $includeDiffHunks)
             }
           }
         }
@@ -280,6 +292,7 @@ query($owner: String!, $repo: String!, $prNumber: Int!,
               author { login }
               body
               createdAt
+              diffHunk @include(if: $includeDiffHunks)
             }
           }
         }
@@ -304,6 +317,7 @@ query($owner: String!, $repo: String!, $prNumber: Int!,
 		"useThreadsAfter":     useThreadsAfter,
 		"threadAfterCursor":   opts.ThreadAfterCursor,
 		"excludeUrls":         opts.ExcludeURLs,
+		"includeDiffHunks":    opts.IncludeDiffHunks,
 	}
 
 	result, err := c.RunGraphQLQueryWithVariables(query, variables)
@@ -408,6 +422,7 @@ query($owner: String!, $repo: String!, $prNumber: Int!,
 									} `json:"author"`
 									Body      string `json:"body"`
 									CreatedAt string `json:"createdAt"`
+									DiffHunk  string `json:"diffHunk"`
 								} `json:"nodes"`
 							} `json:"comments"`
 						} `json:"nodes"`
@@ -430,6 +445,7 @@ query($owner: String!, $repo: String!, $prNumber: Int!,
 									} `json:"author"`
 									Body      string `json:"body"`
 									CreatedAt string `json:"createdAt"`
+									DiffHunk  string `json:"diffHunk"`
 								} `json:"nodes"`
 							} `json:"comments"`
 						} `json:"nodes"`
@@ -543,15 +559,21 @@ query($owner: String!, $repo: String!, $prNumber: Int!,
 		var comments []ThreadComment
 		lastReplier := ""
 
-		for _, comment := range thread.Comments.Nodes {
-			comments = append(comments, ThreadComment{
+		for i, comment := range thread.Comments.Nodes {
+			threadComment := ThreadComment{
 				ID:        comment.ID,
 				URL:       comment.URL,
 				Author:    comment.Author.Login,
 				Body:      comment.Body,
 				CreatedAt: comment.CreatedAt,
-			})
+			}
 
+			// Replies share the first comment's hunk, so keep it only once per thread
+			if i == 0 && comment.DiffHunk != "" {
+				threadComment.DiffHunk, threadComment.DiffHunkOmittedLines = trimDiffHunk(comment.DiffHunk, opts.MaxHunkLines)
+			}
+
+			comments = append(comments, threadComment)
 			lastReplier = comment.Author.Login
 		}
 
@@ -610,43 +632,34 @@ query($owner: String!, $repo: String!, $prNumber: Int!,
 	}, nil
 }
 
-// analyzeReviewSeverity determines the severity of review feedback
-func analyzeReviewSeverity(body string) ReviewSeverity {
-	bodyLower := strings.ToLower(body)
-
-	// Check explicit severity markers (like Gemini uses)
-	if strings.Contains(body, "![critical]") || strings.Contains(bodyLower, "critical") {
-		return SeverityCritical
-	}
-	if strings.Contains(body, "![high]") || strings.Contains(bodyLower, "high-severity") ||
-		strings.Contains(bodyLower, "high-priority") {
-		return SeverityHigh
+// trimDiffHunk keeps the "@@" header and the last maxLines lines of a diff hunk.
+// GitHub hunks end at the commented line, so the tail carries the relevant context.
+// Returns the trimmed hunk and the number of omitted lines.
+func trimDiffHunk(hunk string, maxLines int) (string, int) {
+	if maxLines <= 0 {
+		return hunk, 0
 	}
-	// Removed medium/low levels for simplification
 
-	// Check for critical keywords
-	criticalPatterns := []string{
-		"panic", "crash", "security", "vulnerability", "injection",
-		"leak", "exposed", "hardcoded.*password", "hardcoded.*credential",
+	lines := strings.Split(strings.TrimSuffix(hunk, "\n"), "\n")
+	var header []string
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "@@") {
+		header, lines = lines[:1], lines[1:]
 	}
-	for _, pattern := range criticalPatterns {
-		if strings.Contains(bodyLower, pattern) {
-			return SeverityCritical
-		}
+	if len(lines) <= maxLines {
+		return hunk, 0
 	}
 
-	// Check for high priority keywords
-	highPatterns := []string{
-		"bug", "error", "broken", "incorrect", "wrong",
-		"fail", "nil.*handling", "null.*reference",
-	}
-	for _, pattern := range highPatterns {
-		if strings.Contains(bodyLower, pattern) {
-			return SeverityHigh
-		}
-	}
+	omitted := len(lines) - maxLines
+	trimmed := make([]string, 0, len(header)+1+maxLines)
+	trimmed = append(trimmed, header...)
+	trimmed = append(trimmed, fmt.Sprintf("... (%d lines omitted)", omitted))
+	trimmed = append(trimmed, lines[omitted:]...)
+	return strings.Join(trimmed, "\n"), omitted
+}
 
-	return SeverityInfo
+// analyzeReviewSeverity determines the severity of review feedback
+func analyzeReviewSeverity(body string) ReviewSeverity {
+	return reviewwait.AnalyzeSeverity(body)
 }
 
 // extractActionItems finds specific issues mentioned in review
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = NewOperationalCommand(
+	"validate [flags]",
+	"Check that labels, users, milestones, projects, and teams exist",
+	`Pre-flight check for names used by later mutations. All names are resolved
+in a single GraphQL query, and every unknown name is reported at once, so a
+script can stop before creating or editing anything.
+
+Exits with an error when any name is unknown.
+
+Examples:
+  # Before creating issues
+  gh-helper validate --labels bug,enhancement --assignees @me,octocat --milestone v0.20.0
+
+  # Before requesting team reviews
+  gh-helper validate --teams backend,docs
+
+  # Unknown names only
+  gh-helper validate --labels bug,bgu --jq .validate.unknown`,
+	validateNames,
+)
+
+func init() {
+	validateCmd.Flags().StringSlice("labels", []string{}, "Label names")
+	validateCmd.Flags().StringSlice("assignees", []string{}, "User logins (@me for the authenticated user)")
+	validateCmd.Flags().StringSlice("milestone", []string{}, "Milestone titles")
+	validateCmd.Flags().StringSlice("project", []string{}, "Project (v2) titles linked to the repository")
+	validateCmd.Flags().StringSlice("teams", []string{}, "Team slugs in the repository owner's organization")
+
+	rootCmd.AddCommand(validateCmd)
+}
+
+func validateNames(cmd *cobra.Command, args []string) error {
+	var req ResolveRequest
+	var err error
+	if req.Labels, err = cmd.Flags().GetStringSlice("labels"); err != nil {
+		return fmt.Errorf("failed to get 'labels' flag: %w", err)
+	}
+	if req.Users, err = cmd.Flags().GetStringSlice("assignees"); err != nil {
+		return fmt.Errorf("failed to get 'assignees' flag: %w", err)
+	}
+	if req.Milestones, err = cmd.Flags().GetStringSlice("milestone"); err != nil {
+		return fmt.Errorf("failed to get 'milestone' flag: %w", err)
+	}
+	if req.Projects, err = cmd.Flags().GetStringSlice("project"); err != nil {
+		return fmt.Errorf("failed to get 'project' flag: %w", err)
+	}
+	if req.Teams, err = cmd.Flags().GetStringSlice("teams"); err != nil {
+		return fmt.Errorf("failed to get 'teams' flag: %w", err)
+	}
+	if len(req.Labels)+len(req.Users)+len(req.Milestones)+len(req.Projects)+len(req.Teams) == 0 {
+		return fmt.Errorf("nothing to validate: specify --labels, --assignees, --milestone, --project, or --teams")
+	}
+
+	client := newCommandClient(cmd)
+	result, err := client.Resolver().Resolve(req)
+	if err != nil {
+		return err
+	}
+
+	validationErr := result.Err()
+	output := map[string]interface{}{
+		"validate": map[string]interface{}{
+			"valid":    validationErr == nil,
+			"resolved": result.IDs,
+			"unknown":  result.Unknown,
+		},
+	}
+	if err := EncodeOutputWithCmd(cmd, output); err != nil {
+		return err
+	}
+	return validationErr
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/apstndb/gh-dev-tools/pkg/reviewwait"
+)
+
+// waitConditions are the condition names available to 'reviews wait --wait-for'.
+// A new gate is a new entry here (and a reviewwait.Condition), not a new wait loop.
+var waitConditions = reviewwait.DefaultBuiltins(geminiSummaryHeader)
+
+// getHeadDeployments returns the deployments of the PR head commit with the
+// state of their latest status, newest last
+func (c *GitHubClient) getHeadDeployments(prNumber int) ([]reviewwait.Deployment, error) {
+	query := `
+	query($owner: String!, $repo: String!, $number: Int!) {
+		repository(owner: $owner, name: $repo) {
+			pullRequest(number: $number) {
+				commits(last: 1) {
+					nodes {
+						commit {
+							deployments(last: 20) {
+								nodes {
+									environment
+									latestStatus { state }
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+	responseData, err := c.RunGraphQLQueryWithVariables(query, map[string]interface{}{
+		"owner":  c.Owner,
+		"repo":   c.Repo,
+		"number": prNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data struct {
+			Repository struct {
+				PullRequest *struct {
+					Commits struct {
+						Nodes []struct {
+							Commit struct {
+								Deployments struct {
+									Nodes []struct {
+										Environment  string `json:"environment"`
+										LatestStatus *struct {
+											State string `json:"state"`
+										} `json:"latestStatus"`
+									} `json:"nodes"`
+								} `json:"deployments"`
+							} `json:"commit"`
+						} `json:"nodes"`
+					} `json:"commits"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse deployments: %w", err)
+	}
+	if response.Data.Repository.PullRequest == nil {
+		return nil, fmt.Errorf("PR #%d not found", prNumber)
+	}
+
+	var deployments []reviewwait.Deployment
+	for _, node := range response.Data.Repository.PullRequest.Commits.Nodes {
+		for _, deployment := range node.Commit.Deployments.Nodes {
+			state := "PENDING"
+			if deployment.LatestStatus != nil {
+				state = deployment.LatestStatus.State
+			}
+			deployments = append(deployments, reviewwait.Deployment{Environment: deployment.Environment, State: state})
+		}
+	}
+	return deployments, nil
+}
+
+// prWaitFetcher is the reviewwait.Fetcher of 'reviews wait': one unified PR
+// query per poll, plus the head deployments when the condition reads them
+type prWaitFetcher struct {
+	client   *GitHubClient
+	prNumber string
+	number   int
+	// reviewsOnly fetches reviews without the check and merge state
+	reviewsOnly bool
+
+	// reviewsReady stays set once new reviews were seen during the wait
+	reviewsReady bool
+	// response is the PR data of the latest successful poll
+	response *UniversalPRResponse
+}
+
+func (f *prWaitFetcher) Fetch(needs reviewwait.Requirements) (reviewwait.Poll, error) {
+	config := NewPRQueryConfig(f.client.Owner, f.client.Repo, f.number)
+	if f.reviewsOnly {
+		config.ForReviewsOnly()
+	} else {
+		config.ForReviewsAndStatus()
+	}
+	if needs.Comments {
+		config.WithComments()
+	}
+	response, err := f.client.FetchPRData(config)
+	if err != nil {
+		return reviewwait.Poll{}, fmt.Errorf("failed to fetch PR data: %w", err)
+	}
+	var deployments []reviewwait.Deployment
+	if needs.Deployments {
+		if deployments, err = f.client.getHeadDeployments(f.number); err != nil {
+			return reviewwait.Poll{}, fmt.Errorf("failed to fetch deployments: %w", err)
+		}
+	}
+	f.response = response
+
+	reviews := response.GetReviews()
+	lastState, _ := loadReviewState(f.prNumber)
+	if len(reviews) > 0 && !f.reviewsReady {
+		f.reviewsReady = hasNewReviews(reviews, lastState)
+	}
+	mergeable, mergeStatus := response.GetMergeStatus()
+	snapshot := reviewwait.Snapshot{
+		NewReviews:       f.reviewsReady,
+		ChangesRequested: len(findChangesRequested(reviews, lastState)) > 0,
+		Checks:           reviewwait.CheckState{Mergeable: mergeable, MergeStateStatus: mergeStatus},
+		LastPush:         parseTimestamp(response.GetLastPushAt()),
+		Deployments:      deployments,
+	}
+	if rollup := response.GetStatusCheckRollup(); rollup != nil {
+		snapshot.Checks.RollupState = rollup.State
+	}
+	if needs.Comments {
+		snapshot.Comments = summaryComments(response.GetComments())
+	}
+	return reviewwait.Poll{
+		HeadOid:      response.GetHeadRefOid(),
+		LastPushType: response.GetLastPushType(),
+		Snapshot:     snapshot,
+	}, nil
+}
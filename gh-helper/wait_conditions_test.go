@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apstndb/gh-dev-tools/pkg/reviewwait"
+	"github.com/spf13/cobra"
+)
+
+func newWaitOptionsTestCommand(t *testing.T, waitFor string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "wait"}
+	for _, name := range []string{"request-review", "exclude-reviews", "exclude-checks", "async", "detailed", "request-summary", "fail-on-changes-requested", "require-fresh-summary"} {
+		cmd.Flags().Bool(name, false, "")
+	}
+	cmd.Flags().String("wait-for", "", "")
+	if err := cmd.Flags().Set("wait-for", waitFor); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestReadWaitOptionsWaitFor(t *testing.T) {
+	opts, err := readWaitOptions(newWaitOptionsTestCommand(t, `reviews && checks && !changes-requested`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Condition == nil {
+		t.Fatal("Condition = nil, want the parsed --wait-for")
+	}
+	snapshot := reviewwait.Snapshot{NewReviews: true, Checks: reviewwait.CheckState{RollupState: "SUCCESS"}}
+	if ok, _ := opts.Condition.Satisfied(snapshot); !ok {
+		t.Error("condition not satisfied by new reviews and complete checks")
+	}
+	snapshot.ChangesRequested = true
+	if ok, reason := opts.Condition.Satisfied(snapshot); ok || reason != "changes requested" {
+		t.Errorf("Satisfied() with changes requested = %v, %q", ok, reason)
+	}
+
+	if _, err := readWaitOptions(newWaitOptionsTestCommand(t, "reviews && approvals")); err == nil || !strings.Contains(err.Error(), "invalid --wait-for") {
+		t.Errorf("readWaitOptions() = %v, want invalid --wait-for", err)
+	}
+}
@@ -0,0 +1,243 @@
+// Package ghclient is the GitHub API transport shared by gh-helper and other
+// tools: GraphQL and REST requests over a shared HTTP/2 client, authenticated
+// with the gh CLI token, with SAML SSO failures reported as *SSOError and
+// GitHub incident responses as *OutageError.
+//
+// It knows nothing about repositories or commands; gh-helper's GitHubClient
+// builds its queries on top of it.
+package ghclient
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+const (
+	// DefaultBaseURL is the GitHub API endpoint
+	DefaultBaseURL = "https://api.github.com"
+	// DefaultUserAgent identifies requests from these tools
+	DefaultUserAgent = "spanner-mycli-dev-tools/1.0"
+)
+
+// Client executes GitHub API requests
+type Client struct {
+	// HTTPClient sends requests; New uses SharedHTTPClient
+	HTTPClient *http.Client
+	// Token returns the bearer token for each request; New uses GHToken
+	Token func() (string, error)
+	// BaseURL is the API endpoint without a trailing slash (tests point it at a local server)
+	BaseURL string
+	// UserAgent is sent with every request
+	UserAgent string
+	// Usage, when set, aggregates request counts and GraphQL query costs
+	Usage *Usage
+}
+
+// New returns a Client for api.github.com authenticated via the gh CLI
+func New() *Client {
+	return &Client{
+		HTTPClient: SharedHTTPClient(),
+		Token:      GHToken,
+		BaseURL:    DefaultBaseURL,
+		UserAgent:  DefaultUserAgent,
+	}
+}
+
+// Global shared client for HTTP/2 connection reuse and keep-alive optimization
+var (
+	sharedHTTPClient *http.Client
+	clientOnce       sync.Once
+)
+
+// SharedHTTPClient returns a shared HTTP client optimized for GitHub API
+// Features: HTTP/2, connection pooling, keep-alive, proper timeouts
+func SharedHTTPClient() *http.Client {
+	clientOnce.Do(func() {
+		// Create transport with HTTP/2 support and connection pooling
+		transport := &http.Transport{
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 10,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+			TLSClientConfig: &tls.Config{
+				NextProtos: []string{"h2", "http/1.1"}, // Prefer HTTP/2
+			},
+		}
+
+		// Configure HTTP/2 (ignore errors - falls back to HTTP/1.1 gracefully)
+		_ = http2.ConfigureTransport(transport)
+
+		sharedHTTPClient = &http.Client{
+			Transport: transport,
+			Timeout:   30 * time.Second,
+		}
+	})
+	return sharedHTTPClient
+}
+
+// GHToken retrieves GitHub token from gh CLI
+// No caching needed - auth tokens don't invalidate during single command execution
+func GHToken() (string, error) {
+	cmd := exec.Command("gh", "auth", "token")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get GitHub token: %w\n💡 Tip: Run 'gh auth login' to authenticate", err)
+	}
+
+	token := strings.TrimSpace(string(output))
+	if token == "" {
+		return "", fmt.Errorf("empty token returned from gh auth token")
+	}
+
+	return token, nil
+}
+
+// GraphQL executes a GraphQL query and fails on the first GraphQL error
+func (c *Client) GraphQL(query string, variables map[string]interface{}) ([]byte, error) {
+	data, graphqlErrors, err := c.GraphQLPartial(query, variables)
+	if err != nil {
+		return nil, err
+	}
+	if len(graphqlErrors) > 0 {
+		return nil, fmt.Errorf("GraphQL error: %s", graphqlErrors[0].Message)
+	}
+	return data, nil
+}
+
+// GraphQLPartial executes a GraphQL query and returns the response body together
+// with any GraphQL errors instead of failing on the first one. Batched alias queries and
+// mutations use this to report per-alias success and failure from partial data.
+// Transport, HTTP status, and SSO authorization failures are still returned as err,
+// as are outages (*OutageError), including serviceUnavailable GraphQL errors.
+func (c *Client) GraphQLPartial(query string, variables map[string]interface{}) ([]byte, []GraphQLError, error) {
+	var mutation, injected bool
+	if c.Usage != nil {
+		query, mutation, injected = injectRateLimit(query)
+	}
+
+	jsonData, err := json.Marshal(GraphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal GraphQL request: %w", err)
+	}
+
+	resp, body, err := c.do("POST", "/graphql", jsonData)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.Usage != nil {
+		var rateLimit *rateLimitInfo
+		if injected && resp.StatusCode == http.StatusOK {
+			body, rateLimit = stripRateLimit(body)
+		}
+		c.Usage.recordGraphQL(mutation, rateLimit, resp.Header)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if ssoErr := detectSSOError(resp.Header, apiErrorMessage(body)); ssoErr != nil {
+			return nil, nil, ssoErr
+		}
+		if outage := detectOutage(resp.StatusCode, body); outage != nil {
+			return nil, nil, outage
+		}
+		return nil, nil, fmt.Errorf("GraphQL request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var graphqlResp GraphQLResponse
+	if err := json.Unmarshal(body, &graphqlResp); err == nil {
+		// SAML enforcement errors are reported per-resource, so check all errors, not just the first
+		for _, gqlErr := range graphqlResp.Errors {
+			if ssoErr := detectSSOError(resp.Header, gqlErr.Message); ssoErr != nil {
+				return nil, nil, ssoErr
+			}
+		}
+		if outage := graphQLOutage(graphqlResp.Errors); outage != nil {
+			return nil, nil, outage
+		}
+		return body, graphqlResp.Errors, nil
+	}
+
+	return body, nil, nil
+}
+
+// REST executes a GitHub REST API request.
+// path is relative to the API endpoint (e.g., "/repos/owner/repo/compare/a...b").
+// body is marshaled as JSON when non-nil.
+func (c *Client) REST(method, path string, body interface{}) ([]byte, error) {
+	var jsonData []byte
+	if body != nil {
+		var err error
+		jsonData, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal REST request: %w", err)
+		}
+	}
+
+	resp, respBody, err := c.do(method, path, jsonData)
+	if err != nil {
+		return nil, err
+	}
+	if c.Usage != nil {
+		c.Usage.recordREST()
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if ssoErr := detectSSOError(resp.Header, apiErrorMessage(respBody)); ssoErr != nil {
+			return nil, ssoErr
+		}
+		if outage := detectOutage(resp.StatusCode, respBody); outage != nil {
+			return nil, outage
+		}
+		return nil, fmt.Errorf("REST request %s %s failed with status %d: %s", method, path, resp.StatusCode, string(respBody))
+	}
+
+	return respBody, nil
+}
+
+// do sends an authenticated request and reads the whole response body
+func (c *Client) do(method, path string, jsonData []byte) (*http.Response, []byte, error) {
+	token, err := c.Token()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get GitHub token: %w", err)
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+path, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("User-Agent", c.UserAgent)
+	if path != "/graphql" {
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	}
+	if jsonData != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to execute %s %s: %w", method, path, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Warn("failed to close response body", "url", resp.Request.URL.String(), "error", err)
+		}
+	}()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return resp, buf.Bytes(), nil
+}
@@ -0,0 +1,65 @@
+package ghclient
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &Client{
+		HTTPClient: server.Client(),
+		Token:      func() (string, error) { return "test-token", nil },
+		BaseURL:    server.URL,
+		UserAgent:  "test",
+	}
+}
+
+func TestGraphQLPartial(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" || r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("unexpected request %s %s (Authorization: %q)", r.Method, r.URL.Path, r.Header.Get("Authorization"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"number":1`) {
+			t.Errorf("variables not sent: %s", body)
+		}
+		_, _ = io.WriteString(w, `{"data": {"a0": {"id": "I_1"}, "a1": null},
+  "errors": [{"type": "NOT_FOUND", "path": ["a1"], "message": "Could not resolve"}]}`)
+	})
+
+	data, graphqlErrors, err := client.GraphQLPartial("query { ... }", map[string]interface{}{"number": 1})
+	if err != nil {
+		t.Fatalf("GraphQLPartial() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"I_1"`) {
+		t.Errorf("partial data missing: %s", data)
+	}
+	if len(graphqlErrors) != 1 || graphqlErrors[0].Alias() != "a1" {
+		t.Fatalf("errors = %+v", graphqlErrors)
+	}
+
+	if _, err := client.GraphQL("query { ... }", map[string]interface{}{"number": 1}); err == nil || !strings.Contains(err.Error(), "Could not resolve") {
+		t.Errorf("GraphQL() error = %v, want first GraphQL error", err)
+	}
+}
+
+func TestSSOErrorFromResponse(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-GitHub-SSO", "required; url=https://github.com/orgs/example/sso?authorization_request=abc")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, `{"message": "Resource protected by organization SAML enforcement."}`)
+	})
+
+	_, err := client.REST("GET", "/repos/example/repo", nil)
+	var ssoErr *SSOError
+	if !errors.As(err, &ssoErr) || ssoErr.Organization != "example" {
+		t.Fatalf("REST() error = %v, want *SSOError for example", err)
+	}
+}
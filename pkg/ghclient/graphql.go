@@ -0,0 +1,63 @@
+package ghclient
+
+import "encoding/json"
+
+// GraphQLRequest represents a GraphQL request payload
+type GraphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// GraphQLResponse represents a GraphQL response
+type GraphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []GraphQLError  `json:"errors"`
+}
+
+// GraphQLError is a single entry of a GraphQL "errors" array
+type GraphQLError struct {
+	Message string        `json:"message"`
+	Type    string        `json:"type,omitempty"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// Alias returns the top-level field (or alias) the error belongs to, e.g. "add3"
+// for an error in a batched "add3: addSubIssue(...)" mutation
+func (e GraphQLError) Alias() string {
+	if len(e.Path) == 0 {
+		return ""
+	}
+	alias, _ := e.Path[0].(string)
+	return alias
+}
+
+// PathIndex returns the list index at the given path position, e.g. 1 for
+// path ["nodes", 1] of a nodes(ids:) query
+func (e GraphQLError) PathIndex(position int) (int, bool) {
+	if position >= len(e.Path) {
+		return 0, false
+	}
+	// The numeric type depends on the decoder (encoding/json: float64, YAML-based: int/uint64)
+	switch index := e.Path[position].(type) {
+	case float64:
+		return int(index), true
+	case int:
+		return index, true
+	case int64:
+		return int(index), true
+	case uint64:
+		return int(index), true
+	default:
+		return 0, false
+	}
+}
+
+// HasPathElement reports whether the error path contains the given field name
+func (e GraphQLError) HasPathElement(field string) bool {
+	for _, element := range e.Path {
+		if name, ok := element.(string); ok && name == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGraphQLErrorPath(t *testing.T) {
+	body := []byte(`{
+  "data": {"add0": {"issue": {"number": 1}}, "add1": null},
+  "errors": [
+    {"type": "NOT_FOUND", "path": ["add1"], "message": "Could not resolve to a node"},
+    {"type": "NOT_FOUND", "path": ["nodes", 2], "message": "Could not resolve to a node with the global id of 'X'"},
+    {"message": "Something went wrong"}
+  ]
+}`)
+
+	var resp GraphQLResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if len(resp.Errors) != 3 {
+		t.Fatalf("got %d errors, want 3", len(resp.Errors))
+	}
+
+	tests := []struct {
+		name      string
+		err       GraphQLError
+		wantAlias string
+		wantIndex int
+		wantOK    bool
+	}{
+		{"alias only", resp.Errors[0], "add1", 0, false},
+		{"nodes index", resp.Errors[1], "nodes", 2, true},
+		{"no path", resp.Errors[2], "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Alias(); got != tt.wantAlias {
+				t.Errorf("Alias() = %q, want %q", got, tt.wantAlias)
+			}
+			index, ok := tt.err.PathIndex(1)
+			if index != tt.wantIndex || ok != tt.wantOK {
+				t.Errorf("PathIndex(1) = (%d, %v), want (%d, %v)", index, ok, tt.wantIndex, tt.wantOK)
+			}
+		})
+	}
+}
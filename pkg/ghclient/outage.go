@@ -0,0 +1,65 @@
+package ghclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// OutageError is returned when GitHub answers with an incident response rather
+// than a verdict on the request: a 5xx status, a scheduled maintenance
+// payload, or a GraphQL serviceUnavailable error. Retrying later may succeed.
+type OutageError struct {
+	StatusCode  int    `json:"statusCode,omitempty"` // 0 for GraphQL errors in a 200 response
+	Maintenance bool   `json:"maintenance,omitempty"`
+	Message     string `json:"message"`
+}
+
+func (e *OutageError) Error() string {
+	kind := "GitHub API unavailable"
+	if e.Maintenance {
+		kind = "GitHub API under maintenance"
+	}
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("%s (status %d): %s", kind, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", kind, e.Message)
+}
+
+// IsOutage reports whether err is or wraps an *OutageError
+func IsOutage(err error) bool {
+	var outage *OutageError
+	return errors.As(err, &outage)
+}
+
+// maxOutageMessage bounds the message kept from an error body; incident pages are HTML
+const maxOutageMessage = 200
+
+// detectOutage returns an OutageError for incident responses, nil otherwise.
+// body is the response body of a non-success status.
+func detectOutage(statusCode int, body []byte) *OutageError {
+	message := strings.TrimSpace(apiErrorMessage(body))
+	maintenance := strings.Contains(strings.ToLower(message), "maintenance")
+	if statusCode < http.StatusInternalServerError && !maintenance {
+		return nil
+	}
+	if strings.HasPrefix(message, "<") || message == "" {
+		message = http.StatusText(statusCode)
+	}
+	if runes := []rune(message); len(runes) > maxOutageMessage {
+		message = string(runes[:maxOutageMessage]) + "…"
+	}
+	return &OutageError{StatusCode: statusCode, Maintenance: maintenance, Message: message}
+}
+
+// graphQLOutage returns an OutageError for the first serviceUnavailable error
+// (reported as type SERVICE_UNAVAILABLE or serviceUnavailable), nil otherwise
+func graphQLOutage(graphqlErrors []GraphQLError) *OutageError {
+	for _, gqlErr := range graphqlErrors {
+		if strings.EqualFold(strings.ReplaceAll(gqlErr.Type, "_", ""), "serviceunavailable") {
+			return &OutageError{Message: gqlErr.Message}
+		}
+	}
+	return nil
+}
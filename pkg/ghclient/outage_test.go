@@ -0,0 +1,63 @@
+package ghclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestDetectOutage(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		body            string
+		wantNil         bool
+		wantMaintenance bool
+		wantMessage     string
+	}{
+		{name: "server error", status: 502, body: "<html>Bad gateway</html>", wantMessage: "Bad Gateway"},
+		{name: "JSON message", status: 503, body: `{"message": "Service unavailable"}`, wantMessage: "Service unavailable"},
+		{name: "maintenance", status: 503, body: `{"message": "GitHub is undergoing scheduled maintenance"}`,
+			wantMaintenance: true, wantMessage: "GitHub is undergoing scheduled maintenance"},
+		{name: "not found", status: 404, body: `{"message": "Not Found"}`, wantNil: true},
+		{name: "rate limited", status: 403, body: `{"message": "API rate limit exceeded"}`, wantNil: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectOutage(tt.status, []byte(tt.body))
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("detectOutage() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("detectOutage() = nil, want outage")
+			}
+			if got.Maintenance != tt.wantMaintenance || got.Message != tt.wantMessage || got.StatusCode != tt.status {
+				t.Errorf("detectOutage() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestOutageErrorFromResponse(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"data": null,
+  "errors": [{"type": "SERVICE_UNAVAILABLE", "message": "Something went wrong while executing your query"}]}`)
+	})
+
+	_, _, err := client.GraphQLPartial("query { ... }", nil)
+	var outage *OutageError
+	if !errors.As(err, &outage) || outage.StatusCode != 0 {
+		t.Fatalf("GraphQLPartial() error = %v, want *OutageError", err)
+	}
+	if !IsOutage(fmt.Errorf("failed to fetch: %w", err)) {
+		t.Error("IsOutage() = false for a wrapped outage")
+	}
+	if IsOutage(errors.New("GraphQL error: Could not resolve")) {
+		t.Error("IsOutage() = true for an ordinary error")
+	}
+}
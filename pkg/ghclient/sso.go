@@ -0,0 +1,96 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SSOError is returned when the token lacks SAML SSO authorization for an organization.
+// GitHub signals this with the X-GitHub-SSO response header and/or a FORBIDDEN
+// GraphQL error mentioning SAML enforcement.
+type SSOError struct {
+	Organization string `json:"organization,omitempty"`
+	AuthorizeURL string `json:"authorizeUrl,omitempty"`
+	Message      string `json:"message"`
+}
+
+func (e *SSOError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("SAML SSO authorization required")
+	if e.Organization != "" {
+		fmt.Fprintf(&sb, " for organization %q", e.Organization)
+	}
+	fmt.Fprintf(&sb, ": %s\n", e.Message)
+	sb.WriteString("💡 Remediation:\n")
+	if e.AuthorizeURL != "" {
+		fmt.Fprintf(&sb, "  1. Authorize your token for SSO: %s\n", e.AuthorizeURL)
+	} else {
+		sb.WriteString("  1. Authorize your token for SSO at https://github.com/settings/tokens (Configure SSO)\n")
+	}
+	sb.WriteString("  2. If using gh CLI OAuth: gh auth refresh -h github.com -s read:org\n")
+	sb.WriteString("     then approve the organization in the browser prompt")
+	return sb.String()
+}
+
+// ssoProtectedMessage is the substring GitHub uses in SAML enforcement errors
+const ssoProtectedMessage = "SAML enforcement"
+
+// parseSSOHeader extracts the authorization URL from an X-GitHub-SSO header value.
+// Format: "required; url=https://github.com/orgs/ORG/sso?authorization_request=..."
+// or "partial-results; organizations=123,456".
+func parseSSOHeader(value string) (required bool, authorizeURL string) {
+	if value == "" {
+		return false, ""
+	}
+	parts := strings.Split(value, ";")
+	required = strings.TrimSpace(parts[0]) == "required"
+	for _, part := range parts[1:] {
+		if url, ok := strings.CutPrefix(strings.TrimSpace(part), "url="); ok {
+			authorizeURL = url
+		}
+	}
+	return required, authorizeURL
+}
+
+// organizationFromSSOURL extracts ORG from https://github.com/orgs/ORG/sso?...
+func organizationFromSSOURL(authorizeURL string) string {
+	rest, ok := strings.CutPrefix(authorizeURL, "https://github.com/orgs/")
+	if !ok {
+		return ""
+	}
+	org, _, _ := strings.Cut(rest, "/")
+	return org
+}
+
+// detectSSOError returns an SSOError if the response indicates missing SSO authorization.
+// errorMessage is the API error message (GraphQL error or REST message), if any.
+func detectSSOError(header http.Header, errorMessage string) *SSOError {
+	required, authorizeURL := parseSSOHeader(header.Get("X-GitHub-SSO"))
+	if !required && !strings.Contains(errorMessage, ssoProtectedMessage) {
+		return nil
+	}
+
+	message := errorMessage
+	if message == "" {
+		message = "Resource protected by organization SAML enforcement"
+	}
+
+	return &SSOError{
+		Organization: organizationFromSSOURL(authorizeURL),
+		AuthorizeURL: authorizeURL,
+		Message:      message,
+	}
+}
+
+// apiErrorMessage returns the "message" field of a REST error body, or the raw body
+func apiErrorMessage(body []byte) string {
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+		return apiErr.Message
+	}
+	return string(body)
+}
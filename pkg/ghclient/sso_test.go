@@ -0,0 +1,69 @@
+package ghclient
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDetectSSOError(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		message string
+		wantNil bool
+		wantOrg string
+		wantURL string
+	}{
+		{
+			name:    "required header with URL",
+			header:  "required; url=https://github.com/orgs/example/sso?authorization_request=abc",
+			message: "Resource protected by organization SAML enforcement. You must grant your Personal Access token access to this organization.",
+			wantOrg: "example",
+			wantURL: "https://github.com/orgs/example/sso?authorization_request=abc",
+		},
+		{
+			name:    "GraphQL message without header",
+			message: "Resource protected by organization SAML enforcement. You must grant your OAuth token access to this organization.",
+		},
+		{
+			name:    "partial results header only",
+			header:  "partial-results; organizations=21955855,20582480",
+			message: "Could not resolve to a Repository",
+			wantNil: true,
+		},
+		{
+			name:    "unrelated error",
+			message: "Bad credentials",
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.header != "" {
+				header.Set("X-GitHub-SSO", tt.header)
+			}
+			got := detectSSOError(header, tt.message)
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("detectSSOError() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("detectSSOError() = nil, want error")
+			}
+			if got.Organization != tt.wantOrg {
+				t.Errorf("Organization = %q, want %q", got.Organization, tt.wantOrg)
+			}
+			if got.AuthorizeURL != tt.wantURL {
+				t.Errorf("AuthorizeURL = %q, want %q", got.AuthorizeURL, tt.wantURL)
+			}
+			if !strings.Contains(got.Error(), "gh auth refresh") {
+				t.Errorf("Error() missing gh auth refresh remediation: %s", got.Error())
+			}
+		})
+	}
+}
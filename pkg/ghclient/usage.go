@@ -0,0 +1,185 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// rateLimitAlias is the alias of the rateLimit field added to tracked queries;
+// it is removed from the data returned to callers
+const rateLimitAlias = "ghclientRateLimit"
+
+// Usage aggregates the API usage of the clients sharing it. While a Client has
+// a Usage, GraphQL queries also request rateLimit { cost remaining }, so the
+// cost of each query is known. rateLimit is a Query field, so mutations are
+// counted without a cost. Usage is safe for concurrent use.
+type Usage struct {
+	mu        sync.Mutex
+	queries   int
+	mutations int
+	rest      int
+	totalCost int
+	remaining int
+	known     bool // remaining has been observed
+}
+
+// UsageReport is a snapshot of Usage
+type UsageReport struct {
+	Queries   int  `json:"queries"`
+	Mutations int  `json:"mutations"`
+	REST      int  `json:"rest,omitempty"`
+	TotalCost int  `json:"totalCost"`           // sum of GraphQL query costs
+	Remaining *int `json:"remaining,omitempty"` // GraphQL points left in the current rate limit window
+}
+
+// Report returns the usage so far
+func (u *Usage) Report() UsageReport {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	report := UsageReport{
+		Queries:   u.queries,
+		Mutations: u.mutations,
+		REST:      u.rest,
+		TotalCost: u.totalCost,
+	}
+	if u.known {
+		remaining := u.remaining
+		report.Remaining = &remaining
+	}
+	return report
+}
+
+// recordGraphQL counts one GraphQL request. cost and remaining come from the
+// injected rateLimit field (nil when absent); the response header is the
+// fallback for remaining.
+func (u *Usage) recordGraphQL(mutation bool, rateLimit *rateLimitInfo, header http.Header) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if mutation {
+		u.mutations++
+	} else {
+		u.queries++
+	}
+	switch {
+	case rateLimit != nil:
+		u.totalCost += rateLimit.Cost
+		u.remaining, u.known = rateLimit.Remaining, true
+	case header.Get("X-RateLimit-Resource") == "graphql":
+		if remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining")); err == nil {
+			u.remaining, u.known = remaining, true
+		}
+	}
+}
+
+// recordREST counts one REST request
+func (u *Usage) recordREST() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.rest++
+}
+
+// rateLimitInfo is the injected rateLimit field
+type rateLimitInfo struct {
+	Cost      int `json:"cost"`
+	Remaining int `json:"remaining"`
+}
+
+// injectRateLimit adds "ghclientRateLimit: rateLimit { cost remaining }" to the
+// selection set of the first operation in doc when it is a query. It reports
+// whether the operation is a mutation and whether the field was added.
+func injectRateLimit(doc string) (injected string, mutation, ok bool) {
+	braceDepth, parenDepth := 0, 0
+	kind := "" // keyword of the current top-level definition
+	for i := 0; i < len(doc); i++ {
+		switch ch := doc[i]; {
+		case ch == '#':
+			for i < len(doc) && doc[i] != '\n' {
+				i++
+			}
+		case ch == '"':
+			if len(doc) >= i+3 && doc[i:i+3] == `"""` {
+				end := strings.Index(doc[i+3:], `"""`)
+				if end < 0 {
+					return doc, false, false
+				}
+				i += end + 5
+				continue
+			}
+			for i++; i < len(doc) && doc[i] != '"'; i++ {
+				if doc[i] == '\\' {
+					i++
+				}
+			}
+		case ch == '(':
+			parenDepth++
+		case ch == ')':
+			parenDepth--
+		case ch == '{':
+			if braceDepth == 0 && parenDepth == 0 {
+				switch kind {
+				case "", "query":
+					field := " " + rateLimitAlias + ": rateLimit { cost remaining }"
+					return doc[:i+1] + field + doc[i+1:], false, true
+				case "mutation", "subscription":
+					return doc, kind == "mutation", false
+				}
+			}
+			braceDepth++
+		case ch == '}':
+			braceDepth--
+			if braceDepth == 0 && parenDepth == 0 {
+				kind = ""
+			}
+		case braceDepth == 0 && parenDepth == 0 && kind == "" && isNameStart(ch):
+			start := i
+			for i < len(doc) && isNameChar(doc[i]) {
+				i++
+			}
+			kind = doc[start:i]
+			i--
+		}
+	}
+	return doc, false, false
+}
+
+// stripRateLimit removes the injected field from a GraphQL response body and returns it
+func stripRateLimit(body []byte) ([]byte, *rateLimitInfo) {
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(body, &response); err != nil {
+		return body, nil
+	}
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(response["data"], &data); err != nil || data == nil {
+		return body, nil
+	}
+	raw, found := data[rateLimitAlias]
+	if !found {
+		return body, nil
+	}
+
+	var info *rateLimitInfo
+	_ = json.Unmarshal(raw, &info)
+	delete(data, rateLimitAlias)
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return body, info
+	}
+	response["data"] = dataJSON
+	stripped, err := json.Marshal(response)
+	if err != nil {
+		return body, info
+	}
+	return stripped, info
+}
+
+func isNameStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isNameChar(ch byte) bool {
+	return isNameStart(ch) || (ch >= '0' && ch <= '9')
+}
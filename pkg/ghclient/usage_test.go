@@ -0,0 +1,86 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestInjectRateLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		doc          string
+		wantInjected bool
+		wantMutation bool
+		wantPrefix   string
+	}{
+		{name: "anonymous query", doc: "{ viewer { login } }", wantInjected: true, wantPrefix: "{ ghclientRateLimit: rateLimit"},
+		{name: "named query with defaults", doc: `query Q($a: Input = {x: 1}, $s: String = "{") { node { id } }`, wantInjected: true, wantPrefix: `query Q($a: Input = {x: 1}, $s: String = "{") { ghclientRateLimit`},
+		{name: "fragment first", doc: "fragment F on Issue { id }\n# query {\nquery { node { ...F } }", wantInjected: true, wantPrefix: "fragment F on Issue { id }\n# query {\nquery { ghclientRateLimit"},
+		{name: "mutation", doc: "mutation { addComment(input: {}) { clientMutationId } }", wantMutation: true},
+		{name: "block string", doc: `query { a(b: """ { """) { id } }`, wantInjected: true, wantPrefix: "query { ghclientRateLimit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, mutation, injected := injectRateLimit(tt.doc)
+			if injected != tt.wantInjected || mutation != tt.wantMutation {
+				t.Fatalf("injectRateLimit() injected = %v, mutation = %v; want %v, %v", injected, mutation, tt.wantInjected, tt.wantMutation)
+			}
+			if !injected && got != tt.doc {
+				t.Errorf("document changed without injection: %q", got)
+			}
+			if !strings.HasPrefix(got, tt.wantPrefix) {
+				t.Errorf("injectRateLimit() = %q, want prefix %q", got, tt.wantPrefix)
+			}
+		})
+	}
+}
+
+func TestUsage(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" {
+			w.Header().Set("X-RateLimit-Resource", "core")
+			_, _ = io.WriteString(w, `{}`)
+			return
+		}
+		var request GraphQLRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		w.Header().Set("X-RateLimit-Resource", "graphql")
+		w.Header().Set("X-RateLimit-Remaining", "4000")
+		if strings.HasPrefix(request.Query, "mutation") {
+			_, _ = io.WriteString(w, `{"data": {"addComment": null}}`)
+			return
+		}
+		if !strings.Contains(request.Query, "ghclientRateLimit: rateLimit") {
+			t.Errorf("rateLimit not requested: %s", request.Query)
+		}
+		_, _ = io.WriteString(w, `{"data": {"viewer": {"login": "octocat"}, "ghclientRateLimit": {"cost": 3, "remaining": 4990}}}`)
+	})
+	client.Usage = &Usage{}
+
+	for range 2 {
+		data, err := client.GraphQL("query { viewer { login } }", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "ghclientRateLimit") || !strings.Contains(string(data), "octocat") {
+			t.Errorf("GraphQL() data = %s, want rateLimit stripped", data)
+		}
+	}
+	if _, err := client.GraphQL("mutation { addComment(input: {}) { clientMutationId } }", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.REST("GET", "/rate_limit", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	report := client.Usage.Report()
+	if report.Queries != 2 || report.Mutations != 1 || report.REST != 1 || report.TotalCost != 6 {
+		t.Errorf("Report() = %+v, want 2 queries, 1 mutation, 1 REST, cost 6", report)
+	}
+	if report.Remaining == nil || *report.Remaining != 4000 {
+		t.Errorf("Report().Remaining = %v, want 4000 from the last GraphQL response header", report.Remaining)
+	}
+}
@@ -0,0 +1,39 @@
+// Package reviewwait holds the engine of gh-helper's 'reviews wait': whether
+// PR checks are complete, whether the PR head moved between polls, and whether
+// the Gemini summary still describes the current head. Conditions combine
+// these decisions into the gate of a wait (see ParseCondition), and Waiter
+// polls a Fetcher until the gate opens, degrading during GitHub outages.
+// AnalyzeSeverity classifies review feedback. It has no API access; callers
+// supply a Fetcher that returns the state of each poll.
+package reviewwait
+
+// CheckState is the check-related PR state observed in one poll
+type CheckState struct {
+	// RollupState is statusCheckRollup.state; empty when the rollup is null
+	RollupState string
+	// Mergeable is the PR mergeable field (MERGEABLE, CONFLICTING, UNKNOWN)
+	Mergeable string
+	// MergeStateStatus is the PR mergeStateStatus field (CLEAN, BLOCKED, HAS_HOOKS, ...)
+	MergeStateStatus string
+}
+
+// ChecksTerminal reports whether checks have reached a terminal state.
+//
+// A null statusCheckRollup is ambiguous: no checks are configured, checks have
+// not started yet, or merge conflicts prevent CI from running. Only CLEAN and
+// HAS_HOOKS merge states (or conflicts, where waiting is pointless) count as
+// complete in that case.
+func ChecksTerminal(state CheckState) bool {
+	if state.RollupState != "" {
+		switch state.RollupState {
+		case "SUCCESS", "FAILURE", "ERROR":
+			return true
+		}
+		return false
+	}
+
+	if state.Mergeable == "CONFLICTING" {
+		return true
+	}
+	return state.MergeStateStatus == "CLEAN" || state.MergeStateStatus == "HAS_HOOKS"
+}
@@ -0,0 +1,27 @@
+package reviewwait
+
+import "testing"
+
+func TestChecksTerminal(t *testing.T) {
+	tests := []struct {
+		name  string
+		state CheckState
+		want  bool
+	}{
+		{"rollup success", CheckState{RollupState: "SUCCESS", MergeStateStatus: "BLOCKED"}, true},
+		{"rollup failure", CheckState{RollupState: "FAILURE"}, true},
+		{"rollup pending", CheckState{RollupState: "PENDING", MergeStateStatus: "CLEAN"}, false},
+		{"no rollup, no checks configured", CheckState{Mergeable: "MERGEABLE", MergeStateStatus: "CLEAN"}, true},
+		{"no rollup, merge hooks only", CheckState{Mergeable: "MERGEABLE", MergeStateStatus: "HAS_HOOKS"}, true},
+		{"no rollup, conflicts", CheckState{Mergeable: "CONFLICTING", MergeStateStatus: "DIRTY"}, true},
+		{"no rollup, checks not started", CheckState{Mergeable: "MERGEABLE", MergeStateStatus: "BLOCKED"}, false},
+		{"no rollup, unknown", CheckState{Mergeable: "UNKNOWN", MergeStateStatus: "UNKNOWN"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ChecksTerminal(tt.state); got != tt.want {
+				t.Errorf("ChecksTerminal(%+v) = %v, want %v", tt.state, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package reviewwait
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Snapshot is the PR state observed in one poll, as seen by conditions
+type Snapshot struct {
+	// NewReviews is true once reviews newer than the last acknowledged state arrived
+	NewReviews bool
+	// ChangesRequested is true when a new review requests changes
+	ChangesRequested bool
+	Checks           CheckState
+	// HeadSettling is true in the poll where the head moved; checks and merge
+	// state may still describe the previous head
+	HeadSettling bool
+	// Comments are the PR conversation comments (only fetched when a condition needs them)
+	Comments []Comment
+	// LastPush is when the current head was pushed; zero when unknown
+	LastPush time.Time
+	// Deployments of the head commit (only fetched when a condition needs them)
+	Deployments []Deployment
+}
+
+// Deployment is a deployment of the PR head and the state of its latest status
+type Deployment struct {
+	Environment string
+	State       string // DeploymentStatusState, e.g. SUCCESS, IN_PROGRESS, FAILURE
+}
+
+// Condition is a wait gate evaluated on every poll. reason describes the
+// observed state, for progress and timeout output.
+type Condition interface {
+	Satisfied(s Snapshot) (ok bool, reason string)
+}
+
+// Requirements lists the optional data a condition reads from the Snapshot,
+// so callers only fetch it when needed
+type Requirements struct {
+	Comments    bool
+	Deployments bool
+}
+
+// requirer is implemented by built-ins that read optional Snapshot data
+type requirer interface {
+	requirements() Requirements
+}
+
+// RequirementsOf returns the combined requirements of c and its operands
+func RequirementsOf(c Condition) Requirements {
+	switch c := c.(type) {
+	case allCondition:
+		return combinedRequirements(c)
+	case anyCondition:
+		return combinedRequirements(c)
+	case notCondition:
+		return RequirementsOf(c.operand)
+	case requirer:
+		return c.requirements()
+	}
+	return Requirements{}
+}
+
+func combinedRequirements(operands []Condition) Requirements {
+	var r Requirements
+	for _, operand := range operands {
+		sub := RequirementsOf(operand)
+		r.Comments = r.Comments || sub.Comments
+		r.Deployments = r.Deployments || sub.Deployments
+	}
+	return r
+}
+
+// conditionFunc adapts a function to a Condition
+type conditionFunc func(s Snapshot) (bool, string)
+
+func (f conditionFunc) Satisfied(s Snapshot) (bool, string) {
+	return f(s)
+}
+
+// NewReview is satisfied once new reviews have arrived
+func NewReview() Condition {
+	return conditionFunc(func(s Snapshot) (bool, string) {
+		if s.NewReviews {
+			return true, "new reviews available"
+		}
+		return false, "no new reviews"
+	})
+}
+
+// ChecksComplete is satisfied when checks reach a terminal state (see
+// ChecksTerminal), except in the poll right after the head moved
+func ChecksComplete() Condition {
+	return conditionFunc(func(s Snapshot) (bool, string) {
+		if s.HeadSettling {
+			return false, "checks restarting on the new head"
+		}
+		if ChecksTerminal(s.Checks) {
+			return true, "checks complete"
+		}
+		if s.Checks.RollupState != "" {
+			return false, fmt.Sprintf("checks %s", strings.ToLower(s.Checks.RollupState))
+		}
+		return false, "checks not reported yet"
+	})
+}
+
+// Mergeable is satisfied when GitHub reports the PR as mergeable
+func Mergeable() Condition {
+	return conditionFunc(func(s Snapshot) (bool, string) {
+		if s.Checks.Mergeable == "MERGEABLE" {
+			return true, "mergeable"
+		}
+		return false, fmt.Sprintf("mergeable is %s", s.Checks.Mergeable)
+	})
+}
+
+// ChangesRequested is satisfied when a new review requests changes; negate
+// it to require that nobody has
+func ChangesRequested() Condition {
+	return conditionFunc(func(s Snapshot) (bool, string) {
+		if s.ChangesRequested {
+			return true, "changes requested"
+		}
+		return false, "no changes requested"
+	})
+}
+
+type commentCondition struct {
+	pattern *regexp.Regexp
+}
+
+// CommentMatching is satisfied when a PR comment posted after the latest push
+// matches pattern (any comment when the push time is unknown)
+func CommentMatching(pattern *regexp.Regexp) Condition {
+	return commentCondition{pattern: pattern}
+}
+
+func (c commentCondition) Satisfied(s Snapshot) (bool, string) {
+	for _, comment := range s.Comments {
+		if !comment.CreatedAt.Before(s.LastPush) && c.pattern.MatchString(comment.Body) {
+			return true, fmt.Sprintf("comment matching %q", c.pattern)
+		}
+	}
+	return false, fmt.Sprintf("no comment matching %q since the latest push", c.pattern)
+}
+
+func (commentCondition) requirements() Requirements {
+	return Requirements{Comments: true}
+}
+
+type freshSummaryCondition struct {
+	header string
+}
+
+// FreshSummary is satisfied when the newest comment containing header
+// postdates the latest push (see SummaryFresh)
+func FreshSummary(header string) Condition {
+	return freshSummaryCondition{header: header}
+}
+
+func (c freshSummaryCondition) Satisfied(s Snapshot) (bool, string) {
+	if SummaryFresh(s.Comments, c.header, s.LastPush) {
+		return true, "summary up to date"
+	}
+	return false, "summary predates the latest push"
+}
+
+func (freshSummaryCondition) requirements() Requirements {
+	return Requirements{Comments: true}
+}
+
+type deploymentCondition struct {
+	environment string
+}
+
+// DeploymentReady is satisfied when a deployment of the head to environment
+// (any environment when empty) has succeeded
+func DeploymentReady(environment string) Condition {
+	return deploymentCondition{environment: environment}
+}
+
+func (c deploymentCondition) Satisfied(s Snapshot) (bool, string) {
+	target := "any environment"
+	if c.environment != "" {
+		target = c.environment
+	}
+	state := ""
+	for _, deployment := range s.Deployments {
+		if c.environment != "" && !strings.EqualFold(deployment.Environment, c.environment) {
+			continue
+		}
+		if deployment.State == "SUCCESS" {
+			return true, fmt.Sprintf("deployed to %s", deployment.Environment)
+		}
+		state = deployment.State
+	}
+	if state == "" {
+		return false, fmt.Sprintf("no deployment to %s", target)
+	}
+	return false, fmt.Sprintf("deployment to %s is %s", target, strings.ToLower(state))
+}
+
+func (deploymentCondition) requirements() Requirements {
+	return Requirements{Deployments: true}
+}
+
+type allCondition []Condition
+
+// All is satisfied when every operand is; the reason names the first unmet one
+func All(operands ...Condition) Condition {
+	return allCondition(operands)
+}
+
+func (c allCondition) Satisfied(s Snapshot) (bool, string) {
+	reasons := make([]string, 0, len(c))
+	for _, operand := range c {
+		ok, reason := operand.Satisfied(s)
+		if !ok {
+			return false, reason
+		}
+		reasons = append(reasons, reason)
+	}
+	return true, strings.Join(reasons, ", ")
+}
+
+type anyCondition []Condition
+
+// Any is satisfied when at least one operand is
+func Any(operands ...Condition) Condition {
+	return anyCondition(operands)
+}
+
+func (c anyCondition) Satisfied(s Snapshot) (bool, string) {
+	reasons := make([]string, 0, len(c))
+	for _, operand := range c {
+		ok, reason := operand.Satisfied(s)
+		if ok {
+			return true, reason
+		}
+		reasons = append(reasons, reason)
+	}
+	return false, strings.Join(reasons, " and ")
+}
+
+type notCondition struct {
+	operand Condition
+}
+
+// Not negates a condition
+func Not(operand Condition) Condition {
+	return notCondition{operand: operand}
+}
+
+func (c notCondition) Satisfied(s Snapshot) (bool, string) {
+	ok, reason := c.operand.Satisfied(s)
+	return !ok, reason
+}
@@ -0,0 +1,63 @@
+package reviewwait
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestConditions(t *testing.T) {
+	push := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	snapshot := Snapshot{
+		NewReviews: true,
+		Checks:     CheckState{RollupState: "SUCCESS", Mergeable: "MERGEABLE", MergeStateStatus: "CLEAN"},
+		Comments: []Comment{
+			{Body: "/deploy done", CreatedAt: push.Add(-time.Hour)},
+			{Body: "LGTM", CreatedAt: push.Add(time.Minute)},
+		},
+		LastPush:    push,
+		Deployments: []Deployment{{Environment: "staging", State: "SUCCESS"}, {Environment: "production", State: "IN_PROGRESS"}},
+	}
+
+	tests := []struct {
+		name       string
+		condition  Condition
+		want       bool
+		wantReason string
+	}{
+		{"reviews", NewReview(), true, "new reviews available"},
+		{"checks", ChecksComplete(), true, "checks complete"},
+		{"mergeable", Mergeable(), true, "mergeable"},
+		{"no changes requested", Not(ChangesRequested()), true, "no changes requested"},
+		{"comment after push", CommentMatching(regexp.MustCompile("LGTM")), true, `comment matching "LGTM"`},
+		{"comment before push", CommentMatching(regexp.MustCompile("deploy")), false, `no comment matching "deploy" since the latest push`},
+		{"staging deployed", DeploymentReady("staging"), true, "deployed to staging"},
+		{"production in progress", DeploymentReady("production"), false, "deployment to production is in_progress"},
+		{"all names first unmet", All(NewReview(), DeploymentReady("production"), Mergeable()), false, "deployment to production is in_progress"},
+		{"any", Any(DeploymentReady("production"), DeploymentReady("staging")), true, "deployed to staging"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, reason := tt.condition.Satisfied(snapshot)
+			if ok != tt.want || reason != tt.wantReason {
+				t.Errorf("Satisfied() = %v, %q; want %v, %q", ok, reason, tt.want, tt.wantReason)
+			}
+		})
+	}
+
+	settling := snapshot
+	settling.HeadSettling = true
+	if ok, _ := ChecksComplete().Satisfied(settling); ok {
+		t.Error("ChecksComplete() satisfied while the head is settling")
+	}
+}
+
+func TestRequirementsOf(t *testing.T) {
+	c := All(NewReview(), Not(Any(CommentMatching(regexp.MustCompile("x")), Mergeable())))
+	if r := RequirementsOf(c); !r.Comments || r.Deployments {
+		t.Errorf("RequirementsOf() = %+v, want comments only", r)
+	}
+	if r := RequirementsOf(DeploymentReady("")); !r.Deployments {
+		t.Errorf("RequirementsOf(DeploymentReady) = %+v, want deployments", r)
+	}
+}
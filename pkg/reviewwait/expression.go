@@ -0,0 +1,206 @@
+package reviewwait
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Builtin constructs a named condition; arg is the quoted argument of
+// name("arg"), or "" when the name is used bare
+type Builtin func(arg string) (Condition, error)
+
+// DefaultBuiltins returns the built-in condition names. summaryHeader
+// identifies summary comments for summary-fresh.
+//
+//	reviews            new reviews arrived
+//	checks             checks reached a terminal state
+//	mergeable          the PR is mergeable
+//	changes-requested  a new review requests changes
+//	summary-fresh      the summary comment postdates the latest push
+//	comment("re")      a comment since the latest push matches the regexp
+//	deployment("env")  a deployment of the head succeeded (any environment without an argument)
+func DefaultBuiltins(summaryHeader string) map[string]Builtin {
+	noArg := func(name string, c Condition) Builtin {
+		return func(arg string) (Condition, error) {
+			if arg != "" {
+				return nil, fmt.Errorf("%s takes no argument", name)
+			}
+			return c, nil
+		}
+	}
+	return map[string]Builtin{
+		"reviews":           noArg("reviews", NewReview()),
+		"checks":            noArg("checks", ChecksComplete()),
+		"mergeable":         noArg("mergeable", Mergeable()),
+		"changes-requested": noArg("changes-requested", ChangesRequested()),
+		"summary-fresh":     noArg("summary-fresh", FreshSummary(summaryHeader)),
+		"comment": func(arg string) (Condition, error) {
+			if arg == "" {
+				return nil, fmt.Errorf(`comment requires a pattern, e.g. comment("LGTM")`)
+			}
+			pattern, err := regexp.Compile(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid comment pattern: %w", err)
+			}
+			return CommentMatching(pattern), nil
+		},
+		"deployment": func(arg string) (Condition, error) {
+			return DeploymentReady(arg), nil
+		},
+	}
+}
+
+// ParseCondition parses a condition expression such as
+// "reviews && checks && !changes-requested". Operators are ! (not), && (and),
+// and || (or), in decreasing precedence; parentheses group. Names resolve
+// through builtins.
+func ParseCondition(expr string, builtins map[string]Builtin) (Condition, error) {
+	p := &conditionParser{input: expr, builtins: builtins}
+	c, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos:])
+	}
+	return c, nil
+}
+
+type conditionParser struct {
+	input    string
+	pos      int
+	builtins map[string]Builtin
+}
+
+func (p *conditionParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid condition %q at offset %d: %s", p.input, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *conditionParser) skipSpace() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume skips token if it is next
+func (p *conditionParser) consume(token string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.input[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *conditionParser) parseOr() (Condition, error) {
+	operands := []Condition{}
+	for {
+		c, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, c)
+		if !p.consume("||") {
+			break
+		}
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return Any(operands...), nil
+}
+
+func (p *conditionParser) parseAnd() (Condition, error) {
+	operands := []Condition{}
+	for {
+		c, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, c)
+		if !p.consume("&&") {
+			break
+		}
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return All(operands...), nil
+}
+
+func (p *conditionParser) parseUnary() (Condition, error) {
+	if p.consume("!") {
+		c, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(c), nil
+	}
+	if p.consume("(") {
+		c, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.errorf("missing )")
+		}
+		return c, nil
+	}
+	return p.parseName()
+}
+
+func (p *conditionParser) parseName() (Condition, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.input) {
+		ch := rune(p.input[p.pos])
+		if !unicode.IsLetter(ch) && !unicode.IsDigit(ch) && ch != '-' && ch != '_' {
+			break
+		}
+		p.pos++
+	}
+	name := p.input[start:p.pos]
+	if name == "" {
+		if p.pos == len(p.input) {
+			return nil, p.errorf("expected a condition name")
+		}
+		return nil, p.errorf("unexpected %q", p.input[p.pos:p.pos+1])
+	}
+
+	builtin, ok := p.builtins[name]
+	if !ok {
+		names := make([]string, 0, len(p.builtins))
+		for known := range p.builtins {
+			names = append(names, known)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown condition %q (available: %s)", name, strings.Join(names, ", "))
+	}
+
+	arg := ""
+	if p.pos < len(p.input) && p.input[p.pos] == '(' {
+		p.pos++
+		p.skipSpace()
+		quoted, err := strconv.QuotedPrefix(p.input[p.pos:])
+		if err != nil {
+			return nil, p.errorf("%s(...) takes a quoted string", name)
+		}
+		p.pos += len(quoted)
+		if arg, err = strconv.Unquote(quoted); err != nil {
+			return nil, p.errorf("%s(...) takes a quoted string", name)
+		}
+		if !p.consume(")") {
+			return nil, p.errorf("missing ) after %s argument", name)
+		}
+	}
+
+	c, err := builtin(arg)
+	if err != nil {
+		return nil, fmt.Errorf("condition %s: %w", name, err)
+	}
+	return c, nil
+}
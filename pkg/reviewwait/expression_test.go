@@ -0,0 +1,59 @@
+package reviewwait
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCondition(t *testing.T) {
+	builtins := DefaultBuiltins("## Summary")
+	ready := Snapshot{NewReviews: true, Checks: CheckState{RollupState: "SUCCESS", Mergeable: "MERGEABLE"}}
+	changes := ready
+	changes.ChangesRequested = true
+
+	tests := []struct {
+		expr         string
+		ready, other bool // results on ready and on changes
+	}{
+		{"reviews && checks", true, true},
+		{"reviews && checks && !changes-requested", true, false},
+		{"!changes-requested || mergeable", true, true},
+		{"changes-requested || !(reviews && checks)", false, true},
+		{`deployment("production") || reviews`, true, true},
+		{"  reviews&&!  changes-requested ", true, false},
+	}
+	for _, tt := range tests {
+		c, err := ParseCondition(tt.expr, builtins)
+		if err != nil {
+			t.Errorf("ParseCondition(%q) error: %v", tt.expr, err)
+			continue
+		}
+		if ok, _ := c.Satisfied(ready); ok != tt.ready {
+			t.Errorf("%q on ready = %v, want %v", tt.expr, ok, tt.ready)
+		}
+		if ok, _ := c.Satisfied(changes); ok != tt.other {
+			t.Errorf("%q on changes requested = %v, want %v", tt.expr, ok, tt.other)
+		}
+	}
+}
+
+func TestParseConditionErrors(t *testing.T) {
+	builtins := DefaultBuiltins("## Summary")
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"reviews &&", "expected a condition name"},
+		{"reviewz", `unknown condition "reviewz"`},
+		{"(reviews", "missing )"},
+		{"reviews checks", `unexpected "checks"`},
+		{"comment(LGTM)", "takes a quoted string"},
+		{`comment("(")`, "invalid comment pattern"},
+		{`checks("x")`, "takes no argument"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseCondition(tt.expr, builtins); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseCondition(%q) = %v, want error containing %q", tt.expr, err, tt.want)
+		}
+	}
+}
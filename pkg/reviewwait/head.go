@@ -0,0 +1,79 @@
+package reviewwait
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HeadChange records the PR head moving while waiting
+type HeadChange struct {
+	From string    `json:"from"`
+	To   string    `json:"to"`
+	Kind string    `json:"kind"` // "force-push" or "new-commit"
+	At   time.Time `json:"at"`
+}
+
+// HeadTracker detects force-pushes and new commits between polls so a wait
+// does not report completion based on checks of an obsolete commit.
+// The zero value is ready to use.
+type HeadTracker struct {
+	current string
+	changes []HeadChange
+}
+
+// Observe records the head SHA seen in a poll and returns the change, if any.
+// lastPushType is the __typename of the latest push timeline item.
+func (t *HeadTracker) Observe(oid, lastPushType string, now time.Time) *HeadChange {
+	if oid == "" {
+		return nil
+	}
+	if t.current == "" || t.current == oid {
+		t.current = oid
+		return nil
+	}
+
+	change := HeadChange{From: t.current, To: oid, Kind: "new-commit", At: now}
+	if lastPushType == "HeadRefForcePushedEvent" {
+		change.Kind = "force-push"
+	}
+	t.current = oid
+	t.changes = append(t.changes, change)
+	return &t.changes[len(t.changes)-1]
+}
+
+// Current returns the latest observed head SHA
+func (t *HeadTracker) Current() string {
+	return t.current
+}
+
+// Changes returns the head changes observed so far
+func (t *HeadTracker) Changes() []HeadChange {
+	return t.changes
+}
+
+// String formats a head change for progress output
+func (c HeadChange) String() string {
+	return fmt.Sprintf("%s → %s (%s)", ShortSHA(c.From), ShortSHA(c.To), c.Kind)
+}
+
+// Summary describes all head changes seen during the wait, or "" if none
+func (t *HeadTracker) Summary() string {
+	if len(t.changes) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(t.changes))
+	for _, change := range t.changes {
+		parts = append(parts, fmt.Sprintf("[%s] %s", change.At.Format("15:04:05"), change))
+	}
+	return fmt.Sprintf("🔀 Head changed %d time(s) during wait; checks reflect %s:\n   %s",
+		len(t.changes), ShortSHA(t.current), strings.Join(parts, "\n   "))
+}
+
+// ShortSHA abbreviates a commit SHA to 7 characters
+func ShortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,46 @@
+package reviewwait
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeadTrackerObserve(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	var tracker HeadTracker
+
+	if change := tracker.Observe("", "", now); change != nil {
+		t.Fatalf("empty SHA reported a change: %+v", change)
+	}
+	if change := tracker.Observe("aaaaaaaaaaaa", "PullRequestCommit", now); change != nil {
+		t.Fatalf("first observation reported a change: %+v", change)
+	}
+	if change := tracker.Observe("aaaaaaaaaaaa", "PullRequestCommit", now); change != nil {
+		t.Fatalf("unchanged head reported a change: %+v", change)
+	}
+
+	change := tracker.Observe("bbbbbbbbbbbb", "HeadRefForcePushedEvent", now)
+	if change == nil || change.Kind != "force-push" || change.From != "aaaaaaaaaaaa" {
+		t.Fatalf("force-push change = %+v", change)
+	}
+	change = tracker.Observe("cccccccccccc", "PullRequestCommit", now.Add(time.Minute))
+	if change == nil || change.Kind != "new-commit" {
+		t.Fatalf("new commit change = %+v", change)
+	}
+
+	summary := tracker.Summary()
+	for _, want := range []string{
+		"Head changed 2 time(s)",
+		"checks reflect ccccccc",
+		"[12:00:00] aaaaaaa → bbbbbbb (force-push)",
+		"[12:01:00] bbbbbbb → ccccccc (new-commit)",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Summary() missing %q in:\n%s", want, summary)
+		}
+	}
+	if (&HeadTracker{}).Summary() != "" {
+		t.Error("Summary() without changes should be empty")
+	}
+}
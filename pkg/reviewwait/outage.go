@@ -0,0 +1,86 @@
+package reviewwait
+
+import (
+	"fmt"
+	"time"
+)
+
+// DegradedInterval is the minimum poll interval while the GitHub API is in an
+// outage, so an incident is not hammered with requests
+const DegradedInterval = 2 * time.Minute
+
+// OutageTracker switches a poll loop into a degraded mode while polls fail
+// with GitHub incident responses, and totals the time spent degraded for the
+// final summary. The zero value is ready to use.
+type OutageTracker struct {
+	since     time.Time // start of the current outage; zero when healthy
+	lastError string
+	failures  int // failed polls in the current outage
+	outages   int
+	total     time.Duration // length of the outages that ended
+}
+
+// Fail records a poll that failed with an outage and reports whether the
+// failure is worth printing: the first of an outage, or a different error
+// than the previous one. Repeats of the same error are counted silently.
+func (t *OutageTracker) Fail(message string, now time.Time) bool {
+	t.failures++
+	if t.since.IsZero() {
+		t.since = now
+		t.outages++
+		t.lastError = message
+		return true
+	}
+	if message != t.lastError {
+		t.lastError = message
+		return true
+	}
+	return false
+}
+
+// Recover records a successful poll. It returns how long the outage lasted
+// and the number of failed polls when the poll ends one.
+func (t *OutageTracker) Recover(now time.Time) (time.Duration, int, bool) {
+	if t.since.IsZero() {
+		return 0, 0, false
+	}
+	duration, failures := now.Sub(t.since), t.failures
+	t.total += duration
+	t.since, t.lastError, t.failures = time.Time{}, "", 0
+	return duration, failures, true
+}
+
+// Degraded reports whether an outage is in progress
+func (t *OutageTracker) Degraded() bool {
+	return !t.since.IsZero()
+}
+
+// Interval returns the delay before the next poll: normal when healthy, at
+// least DegradedInterval during an outage
+func (t *OutageTracker) Interval(normal time.Duration) time.Duration {
+	if t.Degraded() && normal < DegradedInterval {
+		return DegradedInterval
+	}
+	return normal
+}
+
+// Total returns the time spent in outages, including one still in progress
+func (t *OutageTracker) Total(now time.Time) time.Duration {
+	if t.Degraded() {
+		return t.total + now.Sub(t.since)
+	}
+	return t.total
+}
+
+// Summary describes the outages seen during the wait, or "" if none
+func (t *OutageTracker) Summary(now time.Time) string {
+	if t.outages == 0 {
+		return ""
+	}
+	minutes := t.Total(now).Round(time.Minute).Minutes()
+	summary := fmt.Sprintf("🌩️  GitHub API outage: %.0f minute(s) degraded over %d outage(s)", minutes, t.outages)
+	if t.Degraded() {
+		summary += fmt.Sprintf("; still failing: %s", t.lastError)
+	}
+	return summary
+}
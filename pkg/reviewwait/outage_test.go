@@ -0,0 +1,45 @@
+package reviewwait
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOutageTracker(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	var tracker OutageTracker
+
+	if tracker.Degraded() || tracker.Interval(30*time.Second) != 30*time.Second || tracker.Summary(now) != "" {
+		t.Fatal("zero tracker should be healthy without a summary")
+	}
+	if _, _, recovered := tracker.Recover(now); recovered {
+		t.Fatal("Recover() without an outage reported a recovery")
+	}
+
+	if !tracker.Fail("status 502", now) {
+		t.Error("first failure of an outage should be printed")
+	}
+	if tracker.Fail("status 502", now.Add(2*time.Minute)) {
+		t.Error("repeated failure should be silent")
+	}
+	if !tracker.Fail("scheduled maintenance", now.Add(4*time.Minute)) {
+		t.Error("changed failure should be printed")
+	}
+	if !tracker.Degraded() || tracker.Interval(30*time.Second) != DegradedInterval {
+		t.Errorf("degraded interval = %v, want %v", tracker.Interval(30*time.Second), DegradedInterval)
+	}
+
+	duration, failures, recovered := tracker.Recover(now.Add(6 * time.Minute))
+	if !recovered || duration != 6*time.Minute || failures != 3 {
+		t.Fatalf("Recover() = %v, %d, %v; want 6m, 3, true", duration, failures, recovered)
+	}
+
+	tracker.Fail("status 500", now.Add(10*time.Minute))
+	summary := tracker.Summary(now.Add(13 * time.Minute))
+	for _, want := range []string{"9 minute(s) degraded over 2 outage(s)", "still failing: status 500"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Summary() missing %q in %q", want, summary)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package reviewwait
+
+import "strings"
+
+// Severity is the priority of review feedback
+type Severity string
+
+const (
+	SeverityCritical Severity = "CRITICAL"
+	SeverityHigh     Severity = "HIGH"
+	SeverityInfo     Severity = "INFO"
+)
+
+var (
+	criticalKeywords = []string{
+		"panic", "crash", "security", "vulnerability", "injection",
+		"leak", "exposed", "hardcoded.*password", "hardcoded.*credential",
+	}
+	highKeywords = []string{
+		"bug", "error", "broken", "incorrect", "wrong",
+		"fail", "nil.*handling", "null.*reference",
+	}
+)
+
+// AnalyzeSeverity classifies a review body by Gemini's explicit severity
+// markers, falling back to keywords that usually signal a defect
+func AnalyzeSeverity(body string) Severity {
+	bodyLower := strings.ToLower(body)
+
+	// Check explicit severity markers (like Gemini uses)
+	if strings.Contains(body, "![critical]") || strings.Contains(bodyLower, "critical") {
+		return SeverityCritical
+	}
+	if strings.Contains(body, "![high]") || strings.Contains(bodyLower, "high-severity") ||
+		strings.Contains(bodyLower, "high-priority") {
+		return SeverityHigh
+	}
+
+	for _, keyword := range criticalKeywords {
+		if strings.Contains(bodyLower, keyword) {
+			return SeverityCritical
+		}
+	}
+	for _, keyword := range highKeywords {
+		if strings.Contains(bodyLower, keyword) {
+			return SeverityHigh
+		}
+	}
+	return SeverityInfo
+}
+
+// MarkedSeverity recognizes only Gemini's explicit severity markers
+func MarkedSeverity(body string) Severity {
+	if strings.Contains(body, "![critical]") {
+		return SeverityCritical
+	}
+	if strings.Contains(body, "![high]") {
+		return SeverityHigh
+	}
+	return SeverityInfo
+}
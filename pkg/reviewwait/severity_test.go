@@ -0,0 +1,25 @@
+package reviewwait
+
+import "testing"
+
+func TestAnalyzeSeverity(t *testing.T) {
+	tests := []struct {
+		body       string
+		want       Severity
+		wantMarked Severity
+	}{
+		{"![critical] SQL built by concatenation", SeverityCritical, SeverityCritical},
+		{"![high] missing error check", SeverityHigh, SeverityHigh},
+		{"This may panic on an empty slice", SeverityCritical, SeverityInfo},
+		{"The error is dropped here", SeverityHigh, SeverityInfo},
+		{"Consider renaming this variable", SeverityInfo, SeverityInfo},
+	}
+	for _, tt := range tests {
+		if got := AnalyzeSeverity(tt.body); got != tt.want {
+			t.Errorf("AnalyzeSeverity(%q) = %s, want %s", tt.body, got, tt.want)
+		}
+		if got := MarkedSeverity(tt.body); got != tt.wantMarked {
+			t.Errorf("MarkedSeverity(%q) = %s, want %s", tt.body, got, tt.wantMarked)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package reviewwait
+
+import (
+	"strings"
+	"time"
+)
+
+// Comment is a PR conversation comment observed in one poll
+type Comment struct {
+	Body      string
+	CreatedAt time.Time
+}
+
+// LatestSummaryAt returns when the newest comment containing header was posted
+func LatestSummaryAt(comments []Comment, header string) (time.Time, bool) {
+	var latest time.Time
+	found := false
+	for _, comment := range comments {
+		if strings.Contains(comment.Body, header) && (!found || comment.CreatedAt.After(latest)) {
+			latest = comment.CreatedAt
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// SummaryFresh reports whether the newest summary comment postdates the latest
+// push, i.e. describes the current head. A zero lastPush (unknown) accepts any
+// summary.
+func SummaryFresh(comments []Comment, header string, lastPush time.Time) bool {
+	summaryAt, found := LatestSummaryAt(comments, header)
+	return found && !summaryAt.Before(lastPush)
+}
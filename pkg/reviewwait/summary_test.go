@@ -0,0 +1,38 @@
+package reviewwait
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummaryFresh(t *testing.T) {
+	const header = "## Summary of Changes"
+	at := func(hour int) time.Time {
+		return time.Date(2025, 1, 1, hour, 0, 0, 0, time.UTC)
+	}
+	comments := []Comment{
+		{Body: header + "\n\nold", CreatedAt: at(1)},
+		{Body: "/gemini summary", CreatedAt: at(3)},
+		{Body: header + "\n\nnew", CreatedAt: at(4)},
+		{Body: "LGTM", CreatedAt: at(5)},
+	}
+
+	tests := []struct {
+		name     string
+		comments []Comment
+		lastPush time.Time
+		want     bool
+	}{
+		{"summary after push", comments, at(2), true},
+		{"summary before push", comments, at(5), false},
+		{"push time unknown", comments, time.Time{}, true},
+		{"no summary", comments[1:2], time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SummaryFresh(tt.comments, header, tt.lastPush); got != tt.want {
+				t.Errorf("SummaryFresh() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package reviewwait
+
+import (
+	"time"
+)
+
+// DefaultInterval is the delay between polls when Waiter.Interval is unset
+const DefaultInterval = 30 * time.Second
+
+// Poll is the PR state a Fetcher observed in one poll
+type Poll struct {
+	// HeadOid is the PR head SHA; LastPushType is the __typename of the latest
+	// push timeline item. Both feed the Waiter's HeadTracker.
+	HeadOid      string
+	LastPushType string
+	// Snapshot is evaluated by the condition; the Waiter sets HeadSettling
+	Snapshot Snapshot
+}
+
+// Fetcher fetches the PR state of one poll. needs lists the optional Snapshot
+// data the condition reads, so implementations only query it when needed.
+type Fetcher interface {
+	Fetch(needs Requirements) (Poll, error)
+}
+
+// FetcherFunc adapts a function to a Fetcher
+type FetcherFunc func(needs Requirements) (Poll, error)
+
+// Fetch calls f
+func (f FetcherFunc) Fetch(needs Requirements) (Poll, error) {
+	return f(needs)
+}
+
+// Progress is the outcome of one successful poll
+type Progress struct {
+	Poll Poll
+	// First is true for the first successful poll of the wait
+	First bool
+	// HeadChange is set when the head moved since the previous poll
+	HeadChange *HeadChange
+	// Met and Reason are the condition's verdict on Poll.Snapshot
+	Met    bool
+	Reason string
+	// Remaining is the time left before the timeout
+	Remaining time.Duration
+}
+
+// PollFailure describes a failed poll
+type PollFailure struct {
+	Err error
+	// Outage is true when Waiter.IsOutage classified Err; polling then slows
+	// down to DegradedInterval until a poll succeeds
+	Outage bool
+	// Continuing is true when the outage started in an earlier poll
+	Continuing bool
+	// Notify is false for a repeated outage error, which callers usually do not
+	// report again; other failures are always notified
+	Notify bool
+	// Next is the delay before the next poll
+	Next time.Duration
+}
+
+// Result is the final state of a wait
+type Result struct {
+	Met      bool
+	Reason   string
+	TimedOut bool
+	// Last is the last successful poll; nil when none completed
+	Last *Progress
+}
+
+// Waiter polls a Fetcher until its Condition is satisfied or Timeout passes.
+// It tracks head changes (checks are not trusted in the poll where the head
+// moved) and degrades polling during GitHub outages. Optional fields may be
+// left zero.
+type Waiter struct {
+	Fetcher   Fetcher
+	Condition Condition
+	Timeout   time.Duration
+	// Interval is the delay between polls (default DefaultInterval)
+	Interval time.Duration
+	// IsOutage classifies fetch errors as GitHub outages, e.g. ghclient.IsOutage;
+	// nil treats every error as an ordinary failure
+	IsOutage func(error) bool
+
+	// OnPoll is called after each successful poll; a non-nil error ends the
+	// wait with that error
+	OnPoll func(Progress) error
+	// OnFailure is called after each failed poll
+	OnFailure func(PollFailure)
+	// OnRecover is called on the first successful poll after an outage
+	OnRecover func(duration time.Duration, failures int)
+
+	// Head and Outage record head changes and outages; read their summaries
+	// after Wait returns
+	Head   HeadTracker
+	Outage OutageTracker
+
+	// Now and Sleep default to time.Now and time.Sleep; tests replace them
+	Now   func() time.Time
+	Sleep func(time.Duration)
+}
+
+// Wait polls until the condition is met (Result.Met), the timeout passes
+// (Result.TimedOut), or OnPoll returns an error
+func (w *Waiter) Wait() (Result, error) {
+	now, sleep := w.Now, w.Sleep
+	if now == nil {
+		now = time.Now
+	}
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	needs := RequirementsOf(w.Condition)
+
+	start := now()
+	remaining := func() time.Duration {
+		return w.Timeout - now().Sub(start)
+	}
+	var result Result
+	for {
+		if remaining() <= 0 {
+			result.TimedOut = true
+			return result, nil
+		}
+
+		poll, err := w.Fetcher.Fetch(needs)
+		if err != nil {
+			failure := PollFailure{Err: err, Notify: true, Next: interval}
+			if w.IsOutage != nil && w.IsOutage(err) {
+				failure.Outage = true
+				failure.Continuing = w.Outage.Degraded()
+				failure.Notify = w.Outage.Fail(err.Error(), now())
+				failure.Next = w.Outage.Interval(interval)
+			}
+			if w.OnFailure != nil {
+				w.OnFailure(failure)
+			}
+			sleep(max(min(failure.Next, remaining()), 0))
+			continue
+		}
+		if duration, failures, recovered := w.Outage.Recover(now()); recovered && w.OnRecover != nil {
+			w.OnRecover(duration, failures)
+		}
+
+		progress := Progress{
+			Poll:       poll,
+			First:      result.Last == nil,
+			HeadChange: w.Head.Observe(poll.HeadOid, poll.LastPushType, now()),
+		}
+		// Right after a push, checks for the new head may not all be registered
+		// yet and the merge state may still describe the previous head
+		progress.Poll.Snapshot.HeadSettling = progress.HeadChange != nil
+		progress.Met, progress.Reason = w.Condition.Satisfied(progress.Poll.Snapshot)
+		progress.Remaining = remaining()
+		result.Met, result.Reason, result.Last = progress.Met, progress.Reason, &progress
+
+		if w.OnPoll != nil {
+			if err := w.OnPoll(progress); err != nil {
+				return result, err
+			}
+		}
+		if progress.Met {
+			return result, nil
+		}
+		sleep(max(min(interval, remaining()), 0))
+	}
+}
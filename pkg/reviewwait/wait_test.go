@@ -0,0 +1,105 @@
+package reviewwait
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock advances only when the Waiter sleeps
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+}
+
+func TestWaiter(t *testing.T) {
+	errOutage := errors.New("status 502")
+	clock := &fakeClock{now: time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)}
+	// An outage, then checks pending on head a, then complete on a new head b
+	// (settling, so not trusted), then complete on b
+	polls := []func() (Poll, error){
+		func() (Poll, error) { return Poll{}, errOutage },
+		func() (Poll, error) {
+			return Poll{HeadOid: "a", Snapshot: Snapshot{Checks: CheckState{RollupState: "PENDING"}}}, nil
+		},
+		func() (Poll, error) {
+			return Poll{HeadOid: "b", Snapshot: Snapshot{Checks: CheckState{RollupState: "SUCCESS"}}}, nil
+		},
+		func() (Poll, error) {
+			return Poll{HeadOid: "b", Snapshot: Snapshot{Checks: CheckState{RollupState: "SUCCESS"}}}, nil
+		},
+	}
+	var failures []PollFailure
+	var progress []Progress
+	recovered := false
+	waiter := &Waiter{
+		Fetcher: FetcherFunc(func(needs Requirements) (Poll, error) {
+			next := polls[0]
+			polls = polls[1:]
+			return next()
+		}),
+		Condition: ChecksComplete(),
+		Timeout:   10 * time.Minute,
+		IsOutage:  func(err error) bool { return errors.Is(err, errOutage) },
+		OnPoll: func(p Progress) error {
+			progress = append(progress, p)
+			return nil
+		},
+		OnFailure: func(f PollFailure) { failures = append(failures, f) },
+		OnRecover: func(time.Duration, int) { recovered = true },
+		Now:       clock.Now,
+		Sleep:     clock.Sleep,
+	}
+
+	result, err := waiter.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Met || result.TimedOut || len(progress) != 3 {
+		t.Fatalf("result = %+v after %d polls; want met after 3 polls", result, len(progress))
+	}
+	if len(failures) != 1 || !failures[0].Outage || !failures[0].Notify || failures[0].Next != DegradedInterval || !recovered {
+		t.Errorf("failures = %+v, recovered = %v; want one notified outage", failures, recovered)
+	}
+	if !progress[0].First || progress[1].HeadChange == nil || progress[1].Met || !progress[2].Met {
+		t.Errorf("progress = %+v; want the head change poll not to meet the condition", progress)
+	}
+	if want := []time.Duration{DegradedInterval, DefaultInterval, DefaultInterval}; len(clock.sleeps) != len(want) || clock.sleeps[0] != want[0] {
+		t.Errorf("sleeps = %v, want %v", clock.sleeps, want)
+	}
+}
+
+func TestWaiterTimeout(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)}
+	stop := errors.New("stop")
+	waiter := &Waiter{
+		Fetcher: FetcherFunc(func(needs Requirements) (Poll, error) {
+			return Poll{}, nil
+		}),
+		Condition: NewReview(),
+		Timeout:   time.Minute + 10*time.Second,
+		Now:       clock.Now,
+		Sleep:     clock.Sleep,
+	}
+
+	result, err := waiter.Wait()
+	if err != nil || !result.TimedOut || result.Met || result.Reason != "no new reviews" {
+		t.Fatalf("Wait() = %+v, %v; want a timeout", result, err)
+	}
+	// The last sleep is cut to the remaining time
+	if last := clock.sleeps[len(clock.sleeps)-1]; last != 10*time.Second {
+		t.Errorf("sleeps = %v, want the last one capped at 10s", clock.sleeps)
+	}
+
+	waiter.OnPoll = func(Progress) error { return stop }
+	if _, err := waiter.Wait(); !errors.Is(err, stop) {
+		t.Errorf("OnPoll error = %v, want it returned", err)
+	}
+}